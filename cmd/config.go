@@ -16,13 +16,16 @@ import (
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/router"
 	"github.com/traPtitech/traQ/router/auth"
+	"github.com/traPtitech/traQ/service/bot/event"
 	"github.com/traPtitech/traQ/service/channel"
 	"github.com/traPtitech/traQ/service/counter"
+	"github.com/traPtitech/traQ/service/digest"
 	"github.com/traPtitech/traQ/service/fcm"
 	"github.com/traPtitech/traQ/service/imaging"
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/search"
 	"github.com/traPtitech/traQ/service/variable"
+	"github.com/traPtitech/traQ/service/webpush"
 	"github.com/traPtitech/traQ/utils/storage"
 )
 
@@ -45,6 +48,9 @@ type Config struct {
 	// AllowSignUp ユーザーが自分自身で登録できるかどうか（default: false）
 	AllowSignUp bool `mapstructure:"allowSignUp" yaml:"allowSignUp"`
 
+	// HideDMMessageHistory DMのメッセージ編集履歴を投稿者以外に非公開にするかどうか (default: false)
+	HideDMMessageHistory bool `mapstructure:"hideDMMessageHistory" yaml:"hideDMMessageHistory"`
+
 	// AccessLog HTTPアクセスログ設定
 	AccessLog struct {
 		// Enabled 有効かどうか (default: true)
@@ -90,6 +96,15 @@ type Config struct {
 		URL string `mapstructure:"url" yaml:"url"`
 	} `mapstructure:"es" yaml:"es"`
 
+	// Bleve 組み込み検索エンジン設定
+	// es.urlが設定されている場合はElasticsearchが優先されます
+	Bleve struct {
+		// Enabled 組み込み検索エンジンを使用するかどうか (default: false)
+		Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+		// Path インデックスの保存先ディレクトリ. 空の場合はメモリ上に保持します (default: "")
+		Path string `mapstructure:"path" yaml:"path"`
+	} `mapstructure:"bleve" yaml:"bleve"`
+
 	// Storage ファイルストレージ設定
 	Storage struct {
 		// Type ストレージタイプ (default: local)
@@ -154,6 +169,46 @@ type Config struct {
 		} `mapstructure:"serviceAccount" yaml:"serviceAccount"`
 	} `mapstructure:"firebase" yaml:"firebase"`
 
+	// WebPush Web Push設定
+	WebPush struct {
+		// VAPID VAPID鍵設定 (公開鍵と秘密鍵の両方を設定すると有効になります)
+		VAPID struct {
+			// PublicKey base64urlでエンコードされたP-256公開鍵
+			PublicKey string `mapstructure:"publicKey" yaml:"publicKey"`
+			// PrivateKey base64urlでエンコードされたP-256秘密鍵
+			PrivateKey string `mapstructure:"privateKey" yaml:"privateKey"`
+		} `mapstructure:"vapid" yaml:"vapid"`
+		// Subject プッシュサービスに通知する連絡先 (mailto:またはhttps:のURL) (default: Origin)
+		Subject string `mapstructure:"subject" yaml:"subject"`
+	} `mapstructure:"webPush" yaml:"webPush"`
+
+	// PushNotification プッシュ通知(FCM・Web Push)の送信設定
+	PushNotification struct {
+		// CoalesceWindow 同じユーザー・チャンネルへの通知を1件にまとめる間隔(秒). 0はまとめない (default: 5)
+		CoalesceWindow int `mapstructure:"coalesceWindow" yaml:"coalesceWindow"`
+		// DeviceRateLimit デバイスごとの送信レート制限
+		DeviceRateLimit struct {
+			// Count Period秒あたりに1デバイスへ送信できる通知の最大数. 0は制限しない (default: 0)
+			Count int `mapstructure:"count" yaml:"count"`
+			// Period レート制限の期間(秒) (default: 60)
+			Period int `mapstructure:"period" yaml:"period"`
+		} `mapstructure:"deviceRateLimit" yaml:"deviceRateLimit"`
+	} `mapstructure:"pushNotification" yaml:"pushNotification"`
+
+	// SMTP 未読通知メールダイジェストの送信設定
+	SMTP struct {
+		// Host SMTPサーバーのホスト (空の場合はメール送信無効)
+		Host string `mapstructure:"host" yaml:"host"`
+		// Port SMTPサーバーのポート (default: 587)
+		Port int `mapstructure:"port" yaml:"port"`
+		// Username SMTP認証のユーザー名 (空の場合は認証しない)
+		Username string `mapstructure:"username" yaml:"username"`
+		// Password SMTP認証のパスワード
+		Password string `mapstructure:"password" yaml:"password"`
+		// From 送信元メールアドレス
+		From string `mapstructure:"from" yaml:"from"`
+	} `mapstructure:"smtp" yaml:"smtp"`
+
 	// OAuth2 OAuth2認可サーバー設定
 	OAuth2 struct {
 		// IsRefreshEnabled リフレッシュトークンを有効にするかどうか (default: false)
@@ -162,6 +217,26 @@ type Config struct {
 		AccessTokenExpire int `mapstructure:"accessTokenExp" yaml:"accessTokenExp"`
 	} `mapstructure:"oauth2" yaml:"oauth2"`
 
+	// Bot BOT設定
+	Bot struct {
+		// EventRetry HTTP ModeのBOTへのイベント再送設定
+		EventRetry struct {
+			// MaxRetries 最大再送回数. 0は再送しない (default: 3)
+			MaxRetries int `mapstructure:"maxRetries" yaml:"maxRetries"`
+			// InitialBackoff 初回の再送までの待機時間(秒). 以降の再送毎に2倍になります (default: 1)
+			InitialBackoff int `mapstructure:"initialBackoff" yaml:"initialBackoff"`
+			// MaxBackoff 再送までの最大待機時間(秒) (default: 60)
+			MaxBackoff int `mapstructure:"maxBackoff" yaml:"maxBackoff"`
+		} `mapstructure:"eventRetry" yaml:"eventRetry"`
+		// EventBacklog WebSocket ModeのBOTへのイベントのバックログ設定
+		EventBacklog struct {
+			// Size BOT毎に保持する最大イベント数. 0は保持しない (default: 100)
+			Size int `mapstructure:"size" yaml:"size"`
+			// Retention イベントを保持する期間(秒). 0は期間で破棄しない (default: 600)
+			Retention int `mapstructure:"retention" yaml:"retention"`
+		} `mapstructure:"eventBacklog" yaml:"eventBacklog"`
+	} `mapstructure:"bot" yaml:"bot"`
+
 	// ExternalAuthentication 外部認証設定
 	ExternalAuthentication struct {
 		// Enabled 有効かどうか (default: false)
@@ -235,6 +310,7 @@ func init() {
 	viper.SetDefault("port", 3000)
 	viper.SetDefault("gzip", true)
 	viper.SetDefault("allowSignUp", false)
+	viper.SetDefault("hideDMMessageHistory", false)
 	viper.SetDefault("accessLog.enabled", true)
 	viper.SetDefault("imagemagick", "")
 	viper.SetDefault("imaging.maxPixels", 2560*1600)
@@ -248,6 +324,8 @@ func init() {
 	viper.SetDefault("mariadb.connection.maxIdle", 2)
 	viper.SetDefault("mariadb.connection.lifetime", 0)
 	viper.SetDefault("es.url", "")
+	viper.SetDefault("bleve.enabled", false)
+	viper.SetDefault("bleve.path", "")
 	viper.SetDefault("storage.type", "local")
 	viper.SetDefault("storage.local.dir", "./storage")
 	viper.SetDefault("storage.swift.username", "")
@@ -262,8 +340,24 @@ func init() {
 	viper.SetDefault("gcp.serviceAccount.file", "")
 	viper.SetDefault("gcp.stackdriver.profiler.enabled", false)
 	viper.SetDefault("firebase.serviceAccount.file", "")
+	viper.SetDefault("webPush.vapid.publicKey", "")
+	viper.SetDefault("webPush.vapid.privateKey", "")
+	viper.SetDefault("webPush.subject", "")
+	viper.SetDefault("pushNotification.coalesceWindow", 5)
+	viper.SetDefault("pushNotification.deviceRateLimit.count", 0)
+	viper.SetDefault("pushNotification.deviceRateLimit.period", 60)
+	viper.SetDefault("smtp.host", "")
+	viper.SetDefault("smtp.port", 587)
+	viper.SetDefault("smtp.username", "")
+	viper.SetDefault("smtp.password", "")
+	viper.SetDefault("smtp.from", "")
 	viper.SetDefault("oauth2.isRefreshEnabled", false)
 	viper.SetDefault("oauth2.accessTokenExp", 60*60*24*365)
+	viper.SetDefault("bot.eventRetry.maxRetries", 3)
+	viper.SetDefault("bot.eventRetry.initialBackoff", 1)
+	viper.SetDefault("bot.eventRetry.maxBackoff", 60)
+	viper.SetDefault("bot.eventBacklog.size", 100)
+	viper.SetDefault("bot.eventBacklog.retention", 600)
 	viper.SetDefault("externalAuthentication.enabled", false)
 	viper.SetDefault("externalAuthentication.authPost.url", "")
 	viper.SetDefault("externalAuthentication.authPost.successfulCode", 0)
@@ -368,17 +462,35 @@ func initStackdriverProfiler(c *Config) error {
 	}, option.WithCredentialsFile(c.GCP.ServiceAccount.File))
 }
 
-func newFCMClientIfAvailable(repo repository.Repository, logger *zap.Logger, unreadCounter counter.UnreadMessageCounter, file variable.FirebaseCredentialsFilePathString) (fcm.Client, error) {
+func newFCMClientIfAvailable(repo repository.Repository, logger *zap.Logger, unreadCounter counter.UnreadMessageCounter, file variable.FirebaseCredentialsFilePathString, webPushConfig webpush.Config, fcmConfig fcm.Config) (fcm.Client, error) {
+	var clients []fcm.Client
 	if len(file) > 0 {
-		return fcm.NewClientWithCredentialsFile(repo, logger, unreadCounter, file)
+		c, err := fcm.NewClientWithCredentialsFile(repo, logger, unreadCounter, file, fcmConfig.DeviceRateLimit)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, c)
+	}
+	if webPushConfig.Valid() {
+		c, err := webpush.NewClient(repo, logger, unreadCounter, webPushConfig)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, c)
 	}
-	return fcm.NewNullClient(), nil
+	if len(clients) == 0 {
+		return fcm.NewNullClient(), nil
+	}
+	return fcm.NewCoalescingClient(fcm.NewMultiClient(clients...), fcmConfig.CoalesceWindow), nil
 }
 
-func initSearchServiceIfAvailable(mm message.Manager, cm channel.Manager, repo repository.Repository, logger *zap.Logger, config search.ESEngineConfig) (search.Engine, error) {
+func initSearchServiceIfAvailable(mm message.Manager, cm channel.Manager, repo repository.Repository, logger *zap.Logger, config search.ESEngineConfig, bleveConfig search.BleveEngineConfig) (search.Engine, error) {
 	if len(config.URL) > 0 {
 		return search.NewESEngine(mm, cm, repo, logger, config)
 	}
+	if bleveConfig.Enabled {
+		return search.NewBleveEngine(mm, cm, repo, logger, bleveConfig)
+	}
 	return search.NewNullEngine(), nil
 }
 
@@ -390,12 +502,67 @@ func provideFirebaseCredentialsFilePathString(c *Config) variable.FirebaseCreden
 	return variable.FirebaseCredentialsFilePathString(c.Firebase.ServiceAccount.File)
 }
 
+func provideWebPushConfig(c *Config) webpush.Config {
+	subject := c.WebPush.Subject
+	if len(subject) == 0 {
+		subject = c.Origin
+	}
+	return webpush.Config{
+		VAPIDPublicKey:  c.WebPush.VAPID.PublicKey,
+		VAPIDPrivateKey: c.WebPush.VAPID.PrivateKey,
+		Subject:         subject,
+		DeviceRateLimit: provideFCMConfig(c).DeviceRateLimit,
+	}
+}
+
+func provideFCMConfig(c *Config) fcm.Config {
+	return fcm.Config{
+		CoalesceWindow: time.Duration(c.PushNotification.CoalesceWindow) * time.Second,
+		DeviceRateLimit: fcm.RateLimit{
+			Count:  c.PushNotification.DeviceRateLimit.Count,
+			Period: time.Duration(c.PushNotification.DeviceRateLimit.Period) * time.Second,
+		},
+	}
+}
+
+func provideEmailDigestConfig(c *Config) digest.Config {
+	return digest.Config{
+		Host:     c.SMTP.Host,
+		Port:     c.SMTP.Port,
+		Username: c.SMTP.Username,
+		Password: c.SMTP.Password,
+		From:     c.SMTP.From,
+	}
+}
+
 func provideESEngineConfig(c *Config) search.ESEngineConfig {
 	return search.ESEngineConfig{
 		URL: c.ES.URL,
 	}
 }
 
+func provideBleveEngineConfig(c *Config) search.BleveEngineConfig {
+	return search.BleveEngineConfig{
+		Enabled: c.Bleve.Enabled,
+		Path:    c.Bleve.Path,
+	}
+}
+
+func provideBotEventRetryConfig(c *Config) event.RetryConfig {
+	return event.RetryConfig{
+		MaxRetries:     c.Bot.EventRetry.MaxRetries,
+		InitialBackoff: time.Duration(c.Bot.EventRetry.InitialBackoff) * time.Second,
+		MaxBackoff:     time.Duration(c.Bot.EventRetry.MaxBackoff) * time.Second,
+	}
+}
+
+func provideBotEventBacklogConfig(c *Config) event.BacklogConfig {
+	return event.BacklogConfig{
+		Size:      c.Bot.EventBacklog.Size,
+		Retention: time.Duration(c.Bot.EventBacklog.Retention) * time.Second,
+	}
+}
+
 func provideImageProcessorConfig(c *Config) imaging.Config {
 	return imaging.Config{
 		MaxPixels:        c.Imaging.MaxPixels,
@@ -466,16 +633,18 @@ func provideRouterExternalAuthConfig(c *Config) router.ExternalAuthConfig {
 
 func provideRouterConfig(c *Config) *router.Config {
 	return &router.Config{
-		Development:      c.DevMode,
-		Version:          Version,
-		Revision:         Revision,
-		AccessLogging:    c.AccessLog.Enabled,
-		Gzipped:          c.Gzip,
-		AllowSignUp:      c.AllowSignUp,
-		AccessTokenExp:   c.OAuth2.AccessTokenExpire,
-		IsRefreshEnabled: c.OAuth2.IsRefreshEnabled,
-		WebRTCSecretKey:  c.WebRTC.SecretKey,
-		WebRTCAPIKey:     c.WebRTC.APIKey,
-		ExternalAuth:     provideRouterExternalAuthConfig(c),
+		Development:           c.DevMode,
+		Version:               Version,
+		Revision:              Revision,
+		AccessLogging:         c.AccessLog.Enabled,
+		Gzipped:               c.Gzip,
+		AllowSignUp:           c.AllowSignUp,
+		HideDMMessageHistory:  c.HideDMMessageHistory,
+		AccessTokenExp:        c.OAuth2.AccessTokenExpire,
+		IsRefreshEnabled:      c.OAuth2.IsRefreshEnabled,
+		WebRTCSecretKey:       c.WebRTC.SecretKey,
+		WebRTCAPIKey:          c.WebRTC.APIKey,
+		WebPushVAPIDPublicKey: provideWebPushConfig(c).VAPIDPublicKey,
+		ExternalAuth:          provideRouterExternalAuthConfig(c),
 	}
 }
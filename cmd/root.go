@@ -75,6 +75,7 @@ func init() {
 		stampCommand(),
 		versionCommand(),
 		healthcheckCommand(),
+		webPushKeygenCommand(),
 	)
 
 	flags := rootCommand.PersistentFlags()
@@ -193,6 +193,9 @@ func (s *Server) Start(address string) error {
 		}
 	}()
 	s.SS.StampThrottler.Start()
+	s.SS.MessageScheduler.Start()
+	s.SS.OutgoingWebhook.Start()
+	s.SS.EmailDigest.Start()
 	return s.Router.Start(address)
 }
 
@@ -233,6 +236,21 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		s.L.Info("Channel manager shutdown")
 		return nil
 	})
+	eg.Go(func() error {
+		s.SS.MessageScheduler.Shutdown()
+		s.L.Info("Message scheduler shutdown")
+		return nil
+	})
+	eg.Go(func() error {
+		s.SS.OutgoingWebhook.Shutdown()
+		s.L.Info("Outgoing webhook dispatcher shutdown")
+		return nil
+	})
+	eg.Go(func() error {
+		s.SS.EmailDigest.Shutdown()
+		s.L.Info("Email digest notifier shutdown")
+		return nil
+	})
 	eg.Go(func() error {
 		err := s.SS.MessageManager.Wait(ctx)
 		s.L.Info("Message manager shutdown")
@@ -16,6 +16,7 @@ import (
 	botWS "github.com/traPtitech/traQ/service/bot/ws"
 	"github.com/traPtitech/traQ/service/channel"
 	"github.com/traPtitech/traQ/service/counter"
+	"github.com/traPtitech/traQ/service/digest"
 	"github.com/traPtitech/traQ/service/exevent"
 	"github.com/traPtitech/traQ/service/file"
 	"github.com/traPtitech/traQ/service/imaging"
@@ -24,6 +25,7 @@ import (
 	"github.com/traPtitech/traQ/service/ogp"
 	rbac2 "github.com/traPtitech/traQ/service/rbac"
 	"github.com/traPtitech/traQ/service/viewer"
+	"github.com/traPtitech/traQ/service/webhook"
 	"github.com/traPtitech/traQ/service/webrtcv3"
 	"github.com/traPtitech/traQ/service/ws"
 	"github.com/traPtitech/traQ/utils/storage"
@@ -35,6 +37,9 @@ func newServer(hub *hub.Hub, db *gorm.DB, repo repository.Repository, fs storage
 		channel.InitChannelManager,
 		file.InitFileManager,
 		message.NewMessageManager,
+		message.NewScheduler,
+		webhook.NewOutgoingDispatcher,
+		digest.NewNotifier,
 		counter.NewOnlineCounter,
 		counter.NewUnreadMessageCounter,
 		counter.NewMessageCounter,
@@ -53,9 +58,15 @@ func newServer(hub *hub.Hub, db *gorm.DB, repo repository.Repository, fs storage
 		initSearchServiceIfAvailable,
 		provideServerOriginString,
 		provideFirebaseCredentialsFilePathString,
+		provideWebPushConfig,
+		provideFCMConfig,
+		provideEmailDigestConfig,
 		provideImageProcessorConfig,
 		provideRouterConfig,
 		provideESEngineConfig,
+		provideBleveEngineConfig,
+		provideBotEventRetryConfig,
+		provideBotEventBacklogConfig,
 		wire.Struct(new(service.Services), "*"),
 		wire.Struct(new(Server), "*"),
 		wire.Bind(new(repository.ChannelRepository), new(repository.Repository)),
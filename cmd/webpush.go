@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/traPtitech/traQ/service/webpush"
+)
+
+// webPushKeygenCommand VAPID鍵生成コマンド
+func webPushKeygenCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "webpush-keygen",
+		Short: "Generate a VAPID key pair for Web Push",
+		Run: func(cmd *cobra.Command, args []string) {
+			pub, priv, err := webpush.GenerateVAPIDKeys()
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("webPush:\n  vapid:\n    publicKey: %s\n    privateKey: %s\n", pub, priv)
+		},
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/traPtitech/traQ/service/bot/ws"
 	"github.com/traPtitech/traQ/service/channel"
 	"github.com/traPtitech/traQ/service/counter"
+	"github.com/traPtitech/traQ/service/digest"
 	"github.com/traPtitech/traQ/service/exevent"
 	"github.com/traPtitech/traQ/service/file"
 	"github.com/traPtitech/traQ/service/imaging"
@@ -23,6 +24,7 @@ import (
 	"github.com/traPtitech/traQ/service/ogp"
 	"github.com/traPtitech/traQ/service/rbac"
 	"github.com/traPtitech/traQ/service/viewer"
+	"github.com/traPtitech/traQ/service/webhook"
 	"github.com/traPtitech/traQ/service/webrtcv3"
 	ws2 "github.com/traPtitech/traQ/service/ws"
 	"github.com/traPtitech/traQ/utils/storage"
@@ -42,8 +44,18 @@ func newServer(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, fs storag
 		return nil, err
 	}
 	webrtcv3Manager := webrtcv3.NewManager(hub2)
-	streamer := ws.NewStreamer(hub2, webrtcv3Manager, logger)
-	botService := bot.NewService(repo, manager, hub2, streamer, logger)
+	messageManager, err := message.NewMessageManager(repo, manager, logger)
+	if err != nil {
+		return nil, err
+	}
+	rbacRBAC, err := rbac.New(repo)
+	if err != nil {
+		return nil, err
+	}
+	streamer := ws.NewStreamer(hub2, webrtcv3Manager, repo, manager, messageManager, rbacRBAC, logger)
+	retryConfig := provideBotEventRetryConfig(c2)
+	backlogConfig := provideBotEventBacklogConfig(c2)
+	botService := bot.NewService(repo, manager, hub2, streamer, logger, retryConfig, backlogConfig)
 	onlineCounter := counter.NewOnlineCounter(hub2)
 	unreadMessageCounter, err := counter.NewUnreadMessageCounter(db, hub2)
 	if err != nil {
@@ -57,13 +69,13 @@ func newServer(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, fs storag
 	if err != nil {
 		return nil, err
 	}
-	messageManager, err := message.NewMessageManager(repo, manager, logger)
-	if err != nil {
-		return nil, err
-	}
 	stampThrottler := exevent.NewStampThrottler(hub2, messageManager)
+	scheduler := message.NewScheduler(repo, messageManager, manager, rbacRBAC, logger)
+	outgoingDispatcher := webhook.NewOutgoingDispatcher(repo, manager, messageManager, hub2, logger)
 	firebaseCredentialsFilePathString := provideFirebaseCredentialsFilePathString(c2)
-	client, err := newFCMClientIfAvailable(repo, logger, unreadMessageCounter, firebaseCredentialsFilePathString)
+	webpushConfig := provideWebPushConfig(c2)
+	fcmConfig := provideFCMConfig(c2)
+	client, err := newFCMClientIfAvailable(repo, logger, unreadMessageCounter, firebaseCredentialsFilePathString, webpushConfig, fcmConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -77,16 +89,18 @@ func newServer(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, fs storag
 	wsStreamer := ws2.NewStreamer(hub2, viewerManager, webrtcv3Manager, logger)
 	serverOriginString := provideServerOriginString(c2)
 	notificationService := notification.NewService(repo, manager, messageManager, fileManager, hub2, logger, client, wsStreamer, viewerManager, serverOriginString)
-	ogpService, err := ogp.NewServiceImpl(repo, logger)
+	digestConfig := provideEmailDigestConfig(c2)
+	notifier, err := digest.NewNotifier(repo, manager, hub2, onlineCounter, logger, digestConfig, serverOriginString)
 	if err != nil {
 		return nil, err
 	}
-	rbacRBAC, err := rbac.New(repo)
+	ogpService, err := ogp.NewServiceImpl(repo, logger)
 	if err != nil {
 		return nil, err
 	}
 	esEngineConfig := provideESEngineConfig(c2)
-	engine, err := initSearchServiceIfAvailable(messageManager, manager, repo, logger, esEngineConfig)
+	bleveEngineConfig := provideBleveEngineConfig(c2)
+	engine, err := initSearchServiceIfAvailable(messageManager, manager, repo, logger, esEngineConfig, bleveEngineConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -97,16 +111,19 @@ func newServer(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, fs storag
 		UnreadMessageCounter: unreadMessageCounter,
 		MessageCounter:       messageCounter,
 		ChannelCounter:       channelCounter,
+		EmailDigest:          notifier,
 		StampThrottler:       stampThrottler,
 		FCM:                  client,
 		FileManager:          fileManager,
 		Imaging:              processor,
 		MessageManager:       messageManager,
+		MessageScheduler:     scheduler,
 		Notification:         notificationService,
 		OGP:                  ogpService,
 		RBAC:                 rbacRBAC,
 		Search:               engine,
 		ViewerManager:        viewerManager,
+		OutgoingWebhook:      outgoingDispatcher,
 		WebRTCv3:             webrtcv3Manager,
 		WS:                   wsStreamer,
 		BotWS:                streamer,
@@ -139,6 +139,17 @@ const (
 	// 		message: *model.Message
 	// 		cited_ids: []uuid.UUID	引用されたメッセージのIDの配列
 	MessageCited = "message.cited"
+	// MessageHidden メッセージがモデレーターにより非表示にされた
+	// 	Fields:
+	// 		message_id: uuid.UUID
+	// 		message: *model.Message
+	MessageHidden = "message.hidden"
+	// MessageReported メッセージが通報された
+	// 	Fields:
+	// 		report_id: uuid.UUID
+	// 		message_id: uuid.UUID
+	// 		report: *model.MessageReport
+	MessageReported = "message.reported"
 
 	// ChannelCreated チャンネルが作成された
 	// 	Fields:
@@ -157,6 +168,28 @@ const (
 	// 		topic: string
 	// 		updater_id: uuid.UUID
 	ChannelTopicUpdated = "channel.topic.updated"
+	// ChannelNameUpdated チャンネル名が変更された
+	// 	Fields:
+	// 		channel_id: uuid.UUID
+	// 		old_name: string
+	// 		name: string
+	// 		updater_id: uuid.UUID
+	ChannelNameUpdated = "channel.name.updated"
+	// ChannelParentUpdated チャンネルの親チャンネルが変更された
+	// 	Fields:
+	// 		channel_id: uuid.UUID
+	// 		old_parent_id: uuid.UUID
+	// 		parent_id: uuid.UUID
+	// 		updater_id: uuid.UUID
+	ChannelParentUpdated = "channel.parent.updated"
+	// ChannelArchived チャンネルがアーカイブされた
+	// 	Fields:
+	// 		channel_id: uuid.UUID
+	ChannelArchived = "channel.archived"
+	// ChannelUnarchived チャンネルのアーカイブが解除された
+	// 	Fields:
+	// 		channel_id: uuid.UUID
+	ChannelUnarchived = "channel.unarchived"
 	// ChannelDeleted チャンネルが削除された
 	// 	Fields:
 	// 		channel_id: uuid.UUID
@@ -256,6 +289,24 @@ const (
 	// 		bot_id: uuid.UUID
 	// 		bot: *model.Bot
 	BotPingRequest = "bot.ping"
+	// BotCommandInvoked Botのスラッシュコマンドが実行された
+	// 	Fields:
+	// 		bot_id: uuid.UUID
+	// 		command: *model.BotCommand
+	// 		user_id: uuid.UUID
+	// 		channel_id: uuid.UUID
+	// 		arguments: map[string]interface{}
+	// 		text: string
+	BotCommandInvoked = "bot.command_invoked"
+	// BotMessageActionInvoked Botのメッセージのアクションが実行された
+	// 	Fields:
+	// 		bot_id: uuid.UUID
+	// 		message_id: uuid.UUID
+	// 		channel_id: uuid.UUID
+	// 		action_id: string
+	// 		value: string
+	// 		user_id: uuid.UUID
+	BotMessageActionInvoked = "bot.message_action_invoked"
 	// BotJoined Botがチャンネルに参加した
 	// 	Fields:
 	// 		bot_id: uuid.UUID
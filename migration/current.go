@@ -41,6 +41,23 @@ func Migrations() []*gormigrate.Migration {
 		v28(), // v28 ユーザーグループにアイコンを追加
 		v29(), // BotにModeを追加、WebSocket Modeを追加
 		v30(), // bot_event_logsにresultを追加
+		v31(), // メッセージにスレッドを追加
+		v32(), // 予約投稿メッセージの追加
+		v33(), // メッセージ通報のモデレーション対応
+		v34(), // Botイベントのデッドレター
+		v35(), // Botイベントの署名用シークレットを追加
+		v36(), // Botのスラッシュコマンドを追加
+		v37(), // BOTメッセージのアクションを追加
+		v38(), // Botの購読イベントのフィルタを追加
+		v39(), // 送信Webhookを追加
+		v40(), // Webhookの外部サービスアダプターのイベントタイプを追加
+		v41(), // Webhookの受信ログを追加
+		v42(), // Web Pushの購読情報を追加
+		v43(), // メールアドレスと未読通知メールダイジェスト設定を追加
+		v44(), // おやすみモード設定を追加
+		v45(), // キーワード通知を追加
+		v46(), // 予約投稿メッセージに送信状態を追加
+		v47(), // Botのスラッシュコマンド名の一意性をBOT毎に変更
 	}
 }
 
@@ -55,19 +72,28 @@ func AllTables() []interface{} {
 		&model.DMChannelMapping{},
 		&model.ChannelLatestMessage{},
 		&model.BotEventLog{},
+		&model.BotEventDeadLetter{},
 		&model.BotJoinChannel{},
+		&model.BotCommand{},
 		&model.Bot{},
 		&model.OAuth2Client{},
 		&model.OAuth2Authorize{},
 		&model.OAuth2Token{},
 		&model.MessageReport{},
+		&model.OutgoingWebhookLog{},
+		&model.OutgoingWebhookChannel{},
+		&model.OutgoingWebhook{},
+		&model.WebhookLog{},
 		&model.WebhookBot{},
 		&model.Stamp{},
 		&model.UsersTag{},
 		&model.Unread{},
 		&model.Star{},
 		&model.Device{},
+		&model.WebPushSubscription{},
+		&model.NotificationKeyword{},
 		&model.Pin{},
+		&model.MessageAttachment{},
 		&model.FileACLEntry{},
 		&model.FileThumbnail{},
 		&model.FileMeta{},
@@ -90,5 +116,6 @@ func AllTables() []interface{} {
 		&model.MessageStamp{},
 		&model.SessionRecord{},
 		&model.OgpCache{},
+		&model.ScheduledMessage{},
 	}
 }
@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v31 メッセージにスレッドを追加
+func v31() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "31",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v31Message{})
+		},
+	}
+}
+
+type v31Message struct {
+	ID        uuid.UUID      `gorm:"type:char(36);not null;primaryKey"`
+	UserID    uuid.UUID      `gorm:"type:char(36);not null;"`
+	ChannelID uuid.UUID      `gorm:"type:char(36);not null;index:idx_messages_channel_id_deleted_at_created_at,priority:1"`
+	Text      string         `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	ThreadID  optional.UUID  `gorm:"type:char(36);index:idx_messages_thread_id_created_at,priority:1"` // 追加
+	CreatedAt time.Time      `gorm:"precision:6;index;index:idx_messages_channel_id_deleted_at_created_at,priority:3;index:idx_messages_deleted_at_created_at,priority:2;index:idx_messages_thread_id_created_at,priority:2"`
+	UpdatedAt time.Time      `gorm:"precision:6;index:idx_messages_deleted_at_updated_at,priority:2"`
+	DeletedAt gorm.DeletedAt `gorm:"precision:6;index:idx_messages_channel_id_deleted_at_created_at,priority:2;index:idx_messages_deleted_at_created_at,priority:1;index:idx_messages_deleted_at_updated_at,priority:1"`
+}
+
+func (*v31Message) TableName() string {
+	return "messages"
+}
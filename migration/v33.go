@@ -0,0 +1,54 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v33 メッセージ通報のモデレーション対応
+func v33() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "33",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v33Message{}, &v33MessageReport{})
+		},
+	}
+}
+
+type v33Message struct {
+	ID        uuid.UUID      `gorm:"type:char(36);not null;primaryKey"`
+	UserID    uuid.UUID      `gorm:"type:char(36);not null;"`
+	ChannelID uuid.UUID      `gorm:"type:char(36);not null;index:idx_messages_channel_id_deleted_at_created_at,priority:1"`
+	Text      string         `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	ThreadID  optional.UUID  `gorm:"type:char(36);index:idx_messages_thread_id_created_at,priority:1"`
+	Hidden    bool           `gorm:"type:boolean;not null;default:false"` // 追加
+	CreatedAt time.Time      `gorm:"precision:6;index;index:idx_messages_channel_id_deleted_at_created_at,priority:3;index:idx_messages_deleted_at_created_at,priority:2;index:idx_messages_thread_id_created_at,priority:2"`
+	UpdatedAt time.Time      `gorm:"precision:6;index:idx_messages_deleted_at_updated_at,priority:2"`
+	DeletedAt gorm.DeletedAt `gorm:"precision:6;index:idx_messages_channel_id_deleted_at_created_at,priority:2;index:idx_messages_deleted_at_created_at,priority:1;index:idx_messages_deleted_at_updated_at,priority:1"`
+}
+
+func (*v33Message) TableName() string {
+	return "messages"
+}
+
+type v33MessageReport struct {
+	ID        uuid.UUID      `gorm:"type:char(36);not null;primaryKey"`
+	MessageID uuid.UUID      `gorm:"type:char(36);not null;uniqueIndex:message_reporter"`
+	Reporter  uuid.UUID      `gorm:"type:char(36);not null;uniqueIndex:message_reporter"`
+	Reason    string         `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	State     string         `gorm:"type:varchar(20);not null;default:'open';index"` // 追加
+	Action    string         `gorm:"type:varchar(20);not null;default:''"`           // 追加
+	HandlerID optional.UUID  `gorm:"type:char(36)"`                                  // 追加
+	HandledAt optional.Time  `gorm:"precision:6"`                                    // 追加
+	CreatedAt time.Time      `gorm:"precision:6;index"`
+	DeletedAt gorm.DeletedAt `gorm:"precision:6"`
+}
+
+func (*v33MessageReport) TableName() string {
+	return "message_reports"
+}
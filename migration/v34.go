@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v34 Botイベントのデッドレター
+func v34() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "34",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v34BotEventDeadLetter{})
+		},
+	}
+}
+
+type v34BotEventDeadLetter struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	BotID     uuid.UUID `gorm:"type:char(36);not null;index:bot_id_created_at_idx"`
+	Event     string    `gorm:"type:varchar(30);not null"`
+	Body      string    `gorm:"type:text"`
+	RequestID uuid.UUID `gorm:"type:char(36);not null"`
+	Attempts  int       `gorm:"not null;default:0"`
+	Result    string    `gorm:"type:char(2);not null"`
+	Error     string    `gorm:"type:text"`
+	Code      int       `gorm:"not null;default:0"`
+	CreatedAt time.Time `gorm:"precision:6;index:bot_id_created_at_idx"`
+}
+
+func (*v34BotEventDeadLetter) TableName() string {
+	return "bot_event_dead_letters"
+}
@@ -0,0 +1,58 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/random"
+)
+
+// v35 Botイベントの署名用シークレットを追加
+func v35() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "35",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v35Bot{}); err != nil {
+				return err
+			}
+
+			// 既存のBotにシークレットを発行
+			var ids []uuid.UUID
+			if err := db.Model(&v35Bot{}).Where("signing_secret = ''").Pluck("id", &ids).Error; err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if err := db.Model(&v35Bot{ID: id}).Update("signing_secret", random.SecureAlphaNumeric(40)).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v35Bot struct {
+	ID                uuid.UUID      `gorm:"type:char(36);not null;primaryKey"`
+	BotUserID         uuid.UUID      `gorm:"type:char(36);not null;unique"`
+	Description       string         `gorm:"type:text;not null"`
+	VerificationToken string         `gorm:"type:varchar(30);not null"`
+	SigningSecret     string         `gorm:"type:varchar(64);not null;default:''"` // 追加
+	AccessTokenID     uuid.UUID      `gorm:"type:char(36);not null"`
+	PostURL           string         `gorm:"type:text;not null"`
+	SubscribeEvents   string         `gorm:"type:text;not null"`
+	Privileged        bool           `gorm:"type:boolean;not null;default:false"`
+	Mode              string         `gorm:"type:varchar(30);not null"`
+	State             int            `gorm:"type:tinyint;not null;default:0"`
+	BotCode           string         `gorm:"type:varchar(30);not null;unique"`
+	CreatorID         uuid.UUID      `gorm:"type:char(36);not null"`
+	CreatedAt         time.Time      `gorm:"precision:6"`
+	UpdatedAt         time.Time      `gorm:"precision:6"`
+	DeletedAt         gorm.DeletedAt `gorm:"precision:6"`
+}
+
+func (*v35Bot) TableName() string {
+	return "bots"
+}
@@ -0,0 +1,35 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v36 Botのスラッシュコマンドを追加
+func v36() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "36",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v36BotCommand{})
+		},
+	}
+}
+
+type v36BotCommand struct {
+	ID          uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	BotID       uuid.UUID `gorm:"type:char(36);not null;index"`
+	Name        string    `gorm:"type:varchar(32);not null;unique"`
+	Description string    `gorm:"type:text;not null"`
+	Arguments   string    `gorm:"type:text;not null"`
+	CreatedAt   time.Time `gorm:"precision:6"`
+	UpdatedAt   time.Time `gorm:"precision:6"`
+
+	Bot *v35Bot `gorm:"constraint:bot_commands_bot_id_bots_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:BotID"`
+}
+
+func (*v36BotCommand) TableName() string {
+	return "bot_commands"
+}
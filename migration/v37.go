@@ -0,0 +1,44 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v37 BOTメッセージのアクションを追加
+func v37() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "37",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v37MessageAttachment{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"message_attachments", "message_attachments_message_id_messages_id_foreign", "message_id", "messages(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v37MessageAttachment struct {
+	MessageID uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	Actions   string    `gorm:"type:text;not null"`
+	CreatedAt time.Time `gorm:"precision:6"`
+	UpdatedAt time.Time `gorm:"precision:6"`
+}
+
+func (*v37MessageAttachment) TableName() string {
+	return "message_attachments"
+}
@@ -0,0 +1,43 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v38 Botの購読イベントのフィルタを追加
+func v38() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "38",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v38Bot{})
+		},
+	}
+}
+
+type v38Bot struct {
+	ID                uuid.UUID      `gorm:"type:char(36);not null;primaryKey"`
+	BotUserID         uuid.UUID      `gorm:"type:char(36);not null;unique"`
+	Description       string         `gorm:"type:text;not null"`
+	VerificationToken string         `gorm:"type:varchar(30);not null"`
+	SigningSecret     string         `gorm:"type:varchar(64);not null;default:''"`
+	AccessTokenID     uuid.UUID      `gorm:"type:char(36);not null"`
+	PostURL           string         `gorm:"type:text;not null"`
+	SubscribeEvents   string         `gorm:"type:text;not null"`
+	EventFilter       string         `gorm:"type:text"` // 追加
+	Privileged        bool           `gorm:"type:boolean;not null;default:false"`
+	Mode              string         `gorm:"type:varchar(30);not null"`
+	State             int            `gorm:"type:tinyint;not null;default:0"`
+	BotCode           string         `gorm:"type:varchar(30);not null;unique"`
+	CreatorID         uuid.UUID      `gorm:"type:char(36);not null"`
+	CreatedAt         time.Time      `gorm:"precision:6"`
+	UpdatedAt         time.Time      `gorm:"precision:6"`
+	DeletedAt         gorm.DeletedAt `gorm:"precision:6"`
+}
+
+func (*v38Bot) TableName() string {
+	return "bots"
+}
@@ -0,0 +1,74 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v39 送信Webhookを追加
+func v39() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "39",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v39OutgoingWebhook{}, &v39OutgoingWebhookChannel{}, &v39OutgoingWebhookLog{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"outgoing_webhooks", "outgoing_webhooks_webhook_id_webhook_bots_id_foreign", "webhook_id", "webhook_bots(id)", "CASCADE", "CASCADE"},
+				{"outgoing_webhook_channels", "outgoing_webhook_channels_webhook_id_outgoing_webhooks_foreign", "webhook_id", "outgoing_webhooks(webhook_id)", "CASCADE", "CASCADE"},
+				{"outgoing_webhook_channels", "outgoing_webhook_channels_channel_id_channels_id_foreign", "channel_id", "channels(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v39OutgoingWebhook struct {
+	WebhookID     uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	URL           string    `gorm:"type:text;not null"`
+	TriggerWords  string    `gorm:"type:text;not null"`
+	SigningSecret string    `gorm:"type:varchar(64);not null"`
+	Reply         bool      `gorm:"type:boolean;not null;default:false"`
+	CreatedAt     time.Time `gorm:"precision:6"`
+	UpdatedAt     time.Time `gorm:"precision:6"`
+}
+
+func (*v39OutgoingWebhook) TableName() string {
+	return "outgoing_webhooks"
+}
+
+type v39OutgoingWebhookChannel struct {
+	WebhookID uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	ChannelID uuid.UUID `gorm:"type:char(36);not null;primaryKey;index"`
+}
+
+func (*v39OutgoingWebhookChannel) TableName() string {
+	return "outgoing_webhook_channels"
+}
+
+type v39OutgoingWebhookLog struct {
+	RequestID uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	WebhookID uuid.UUID `gorm:"type:char(36);not null;index:webhook_id_date_time_idx"`
+	MessageID uuid.UUID `gorm:"type:char(36);not null"`
+	Body      string    `gorm:"type:text"`
+	Result    string    `gorm:"type:char(2);not null"`
+	Error     string    `gorm:"type:text"`
+	Code      int       `gorm:"not null;default:0"`
+	Latency   int64     `gorm:"not null;default:0"`
+	DateTime  time.Time `gorm:"precision:6;index:webhook_id_date_time_idx"`
+}
+
+func (*v39OutgoingWebhookLog) TableName() string {
+	return "outgoing_webhook_logs"
+}
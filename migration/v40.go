@@ -0,0 +1,43 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v40 Webhookの外部サービスアダプターのイベントタイプを追加
+func v40() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "40",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v40WebhookBot{}); err != nil {
+				return err
+			}
+			// 既存のWebhookは全てのイベントを投稿する
+			return db.Unscoped().Model(&v40WebhookBot{}).
+				Session(&gorm.Session{AllowGlobalUpdate: true}).
+				Update("event_types", "issue pull_request push release").
+				Error
+		},
+	}
+}
+
+type v40WebhookBot struct {
+	ID          uuid.UUID      `gorm:"type:char(36);not null;primaryKey"`
+	BotUserID   uuid.UUID      `gorm:"type:char(36);not null;unique"`
+	Description string         `gorm:"type:text;not null"`
+	Secret      string         `gorm:"type:text;not null"`
+	ChannelID   uuid.UUID      `gorm:"type:char(36);not null"`
+	CreatorID   uuid.UUID      `gorm:"type:char(36);not null"`
+	EventTypes  string         `gorm:"type:text;not null"` // 追加
+	CreatedAt   time.Time      `gorm:"precision:6"`
+	UpdatedAt   time.Time      `gorm:"precision:6"`
+	DeletedAt   gorm.DeletedAt `gorm:"precision:6"`
+}
+
+func (*v40WebhookBot) TableName() string {
+	return "webhook_bots"
+}
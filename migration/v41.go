@@ -0,0 +1,35 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v41 Webhookの受信ログを追加
+func v41() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "41",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v41WebhookLog{})
+		},
+	}
+}
+
+type v41WebhookLog struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	WebhookID uuid.UUID `gorm:"type:char(36);not null;index:webhook_id_date_time_idx"`
+	Adapter   string    `gorm:"type:varchar(10);not null;default:''"`
+	IP        string    `gorm:"type:varchar(45);not null;default:''"`
+	Body      string    `gorm:"type:text"`
+	Result    string    `gorm:"type:char(2);not null"`
+	Error     string    `gorm:"type:text"`
+	Code      int       `gorm:"not null;default:0"`
+	DateTime  time.Time `gorm:"precision:6;index:webhook_id_date_time_idx"`
+}
+
+func (*v41WebhookLog) TableName() string {
+	return "webhook_logs"
+}
@@ -0,0 +1,46 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v42 Web Pushの購読情報を追加
+func v42() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "42",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v42WebPushSubscription{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"web_push_subscriptions", "web_push_subscriptions_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v42WebPushSubscription struct {
+	Endpoint  string    `gorm:"type:varchar(512);not null;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;index"`
+	P256dh    string    `gorm:"type:varchar(100);not null"`
+	Auth      string    `gorm:"type:varchar(50);not null"`
+	CreatedAt time.Time `gorm:"precision:6"`
+	UpdatedAt time.Time `gorm:"precision:6"`
+}
+
+func (*v42WebPushSubscription) TableName() string {
+	return "web_push_subscriptions"
+}
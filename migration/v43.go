@@ -0,0 +1,46 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v43 メールアドレスと未読通知メールダイジェスト設定を追加
+func v43() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "43",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v43UserProfile{}, &v43UserSettings{})
+		},
+	}
+}
+
+type v43UserProfile struct {
+	UserID      uuid.UUID     `gorm:"type:char(36);not null;primaryKey"`
+	Bio         string        `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	TwitterID   string        `gorm:"type:varchar(15);not null;default:''"`
+	Email       string        `gorm:"type:varchar(254);not null;default:''"` // 追加
+	LastOnline  optional.Time `gorm:"precision:6"`
+	HomeChannel optional.UUID `gorm:"type:char(36)"`
+	UpdatedAt   time.Time     `gorm:"precision:6"`
+}
+
+func (*v43UserProfile) TableName() string {
+	return "user_profiles"
+}
+
+type v43UserSettings struct {
+	UserID               uuid.UUID     `gorm:"type:char(36);not null;primaryKey;"`
+	NotifyCitation       bool          `gorm:"type:boolean"`
+	EmailDigestFrequency string        `gorm:"type:varchar(10);not null;default:'daily'"` // 追加
+	EmailDigestSentAt    optional.Time `gorm:"precision:6"`                               // 追加
+}
+
+func (*v43UserSettings) TableName() string {
+	return "user_settings"
+}
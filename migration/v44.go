@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v44 おやすみモード設定を追加
+func v44() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "44",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v44UserSettings{})
+		},
+	}
+}
+
+type v44UserSettings struct {
+	UserID               uuid.UUID     `gorm:"type:char(36);not null;primaryKey;"`
+	NotifyCitation       bool          `gorm:"type:boolean"`
+	EmailDigestFrequency string        `gorm:"type:varchar(10);not null;default:'daily'"`
+	EmailDigestSentAt    optional.Time `gorm:"precision:6"`
+	DNDUntil             optional.Time `gorm:"precision:6"`                          // 追加
+	DNDMuteForceChannels bool          `gorm:"type:boolean;not null;default:false"`  // 追加
+	QuietHoursEnabled    bool          `gorm:"type:boolean;not null;default:false"`  // 追加
+	QuietHoursStart      string        `gorm:"type:varchar(5);not null;default:''"`  // 追加
+	QuietHoursEnd        string        `gorm:"type:varchar(5);not null;default:''"`  // 追加
+	QuietHoursTimezone   string        `gorm:"type:varchar(64);not null;default:''"` // 追加
+}
+
+func (*v44UserSettings) TableName() string {
+	return "user_settings"
+}
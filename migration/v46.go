@@ -0,0 +1,53 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v46 予約投稿メッセージに送信状態を追加
+func v46() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "46",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v46ScheduledMessage{}); err != nil {
+				return err
+			}
+			// 送信中状態はerrorに"sending"を入れて表していた
+			if err := db.Model(&v46ScheduledMessage{}).
+				Where("error = 'sending'").
+				Updates(map[string]interface{}{"state": "sending", "error": "", "claimed_at": time.Now()}).
+				Error; err != nil {
+				return err
+			}
+			return db.Model(&v46ScheduledMessage{}).
+				Where("error <> ''").
+				Update("state", "failed").
+				Error
+		},
+	}
+}
+
+type v46ScheduledMessage struct {
+	ID          uuid.UUID     `gorm:"type:char(36);not null;primaryKey"`
+	UserID      uuid.UUID     `gorm:"type:char(36);not null;index"`
+	ChannelID   uuid.UUID     `gorm:"type:char(36);not null"`
+	Text        string        `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	ScheduledAt time.Time     `gorm:"precision:6;index"`
+	State       string        `gorm:"type:varchar(10);not null;default:'pending'"` // 追加
+	Error       string        `gorm:"type:varchar(100);not null;default:''"`
+	ClaimedAt   optional.Time `gorm:"precision:6"`                 // 追加
+	Attempts    int           `gorm:"type:int;not null;default:0"` // 追加
+	RetryAt     optional.Time `gorm:"precision:6"`                 // 追加
+	CreatedAt   time.Time     `gorm:"precision:6"`
+	UpdatedAt   time.Time     `gorm:"precision:6"`
+}
+
+func (*v46ScheduledMessage) TableName() string {
+	return "scheduled_messages"
+}
@@ -0,0 +1,38 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v47 Botのスラッシュコマンド名の一意性をBOT毎に変更
+func v47() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "47",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.Migrator().DropIndex("bot_commands", "name"); err != nil {
+				return err
+			}
+			return db.AutoMigrate(&v47BotCommand{})
+		},
+	}
+}
+
+type v47BotCommand struct {
+	ID          uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	BotID       uuid.UUID `gorm:"type:char(36);not null;index;uniqueIndex:bot_name"`    // 変更
+	Name        string    `gorm:"type:varchar(32);not null;uniqueIndex:bot_name;index"` // 変更
+	Description string    `gorm:"type:text;not null"`
+	Arguments   string    `gorm:"type:text;not null"`
+	CreatedAt   time.Time `gorm:"precision:6"`
+	UpdatedAt   time.Time `gorm:"precision:6"`
+
+	Bot *v35Bot `gorm:"constraint:bot_commands_bot_id_bots_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:BotID"`
+}
+
+func (*v47BotCommand) TableName() string {
+	return "bot_commands"
+}
@@ -43,9 +43,11 @@ type Bot struct {
 	BotUserID         uuid.UUID      `gorm:"type:char(36);not null;unique"`
 	Description       string         `gorm:"type:text;not null"`
 	VerificationToken string         `gorm:"type:varchar(30);not null"`
+	SigningSecret     string         `gorm:"type:varchar(64);not null;default:''"`
 	AccessTokenID     uuid.UUID      `gorm:"type:char(36);not null"`
 	PostURL           string         `gorm:"type:text;not null"`
 	SubscribeEvents   BotEventTypes  `gorm:"type:text;not null"`
+	EventFilter       BotEventFilter `gorm:"type:text"`
 	Privileged        bool           `gorm:"type:boolean;not null;default:false"`
 	Mode              BotMode        `gorm:"type:varchar(30);not null"`
 	State             BotState       `gorm:"type:tinyint;not null;default:0"`
@@ -93,6 +95,25 @@ func (*BotEventLog) TableName() string {
 	return "bot_event_logs"
 }
 
+// BotEventDeadLetter 再送を諦めたBotイベント
+type BotEventDeadLetter struct {
+	ID        uuid.UUID    `gorm:"type:char(36);not null;primaryKey"`
+	BotID     uuid.UUID    `gorm:"type:char(36);not null;index:bot_id_created_at_idx"`
+	Event     BotEventType `gorm:"type:varchar(30);not null"`
+	Body      string       `gorm:"type:text"`
+	RequestID uuid.UUID    `gorm:"type:char(36);not null"` // 最後に試行したリクエストのID
+	Attempts  int          `gorm:"not null;default:0"`
+	Result    string       `gorm:"type:char(2);not null"` // 最後の試行の結果
+	Error     string       `gorm:"type:text"`             // 最後の試行のエラー
+	Code      int          `gorm:"not null;default:0"`    // 最後の試行のステータスコード
+	CreatedAt time.Time    `gorm:"precision:6;index:bot_id_created_at_idx"`
+}
+
+// TableName BotEventDeadLetterのテーブル名
+func (*BotEventDeadLetter) TableName() string {
+	return "bot_event_dead_letters"
+}
+
 // BotEventType Botイベントタイプ
 type BotEventType string
 
@@ -0,0 +1,174 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gofrs/uuid"
+)
+
+// BotCommandArgumentType スラッシュコマンドの引数の型
+type BotCommandArgumentType string
+
+const (
+	// BotCommandArgumentString 文字列
+	BotCommandArgumentString BotCommandArgumentType = "string"
+	// BotCommandArgumentInteger 整数
+	BotCommandArgumentInteger BotCommandArgumentType = "integer"
+	// BotCommandArgumentBoolean 真偽値
+	BotCommandArgumentBoolean BotCommandArgumentType = "boolean"
+)
+
+// Valid 有効な引数の型かどうか
+func (t BotCommandArgumentType) Valid() bool {
+	switch t {
+	case BotCommandArgumentString, BotCommandArgumentInteger, BotCommandArgumentBoolean:
+		return true
+	default:
+		return false
+	}
+}
+
+// BotCommandArgument スラッシュコマンドの引数定義
+type BotCommandArgument struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Type        BotCommandArgumentType `json:"type"`
+	Required    bool                   `json:"required"`
+}
+
+// BotCommandArguments スラッシュコマンドの引数定義の配列
+type BotCommandArguments []*BotCommandArgument
+
+// Value database/sql/driver.Valuer 実装
+func (args BotCommandArguments) Value() (driver.Value, error) {
+	if args == nil {
+		return "[]", nil
+	}
+	return json.MarshalToString(args)
+}
+
+// Scan database/sql.Scanner 実装
+func (args *BotCommandArguments) Scan(src interface{}) error {
+	*args = BotCommandArguments{}
+	switch s := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return json.Unmarshal([]byte(s), args)
+	case []byte:
+		return json.Unmarshal(s, args)
+	default:
+		return errors.New("failed to scan BotCommandArguments")
+	}
+}
+
+// BotCommand Botが登録したスラッシュコマンド
+type BotCommand struct {
+	ID          uuid.UUID           `gorm:"type:char(36);not null;primaryKey"`
+	BotID       uuid.UUID           `gorm:"type:char(36);not null;index;uniqueIndex:bot_name"`
+	Name        string              `gorm:"type:varchar(32);not null;uniqueIndex:bot_name;index"`
+	Description string              `gorm:"type:text;not null"`
+	Arguments   BotCommandArguments `gorm:"type:text;not null"`
+	CreatedAt   time.Time           `gorm:"precision:6"`
+	UpdatedAt   time.Time           `gorm:"precision:6"`
+
+	Bot *Bot `gorm:"constraint:bot_commands_bot_id_bots_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:BotID"`
+}
+
+// TableName BotCommandのテーブル名
+func (*BotCommand) TableName() string {
+	return "bot_commands"
+}
+
+// ParseBotCommandInvocation `/name arg1 arg2`形式のテキストをコマンド名と引数部分に分割します
+//
+// コマンドの形式でない場合はokにfalseを返します。
+func ParseBotCommandInvocation(text string) (name string, args string, ok bool) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "/") {
+		return "", "", false
+	}
+	name, args, _ = strings.Cut(text[1:], " ")
+	if len(name) == 0 {
+		return "", "", false
+	}
+	return name, strings.TrimSpace(args), true
+}
+
+// ParseArguments 引数部分のテキストを引数定義に従って解析します
+//
+// 引数は空白区切りで、ダブルクォートで囲むことで空白を含めることができます。
+// 最後の引数が文字列型の場合、余った部分は全てその引数に含まれます。
+func (c *BotCommand) ParseArguments(text string) (map[string]interface{}, error) {
+	tokens := splitBotCommandArguments(text)
+	result := make(map[string]interface{}, len(c.Arguments))
+	for i, arg := range c.Arguments {
+		if i >= len(tokens) {
+			if arg.Required {
+				return nil, fmt.Errorf("argument %s is required", arg.Name)
+			}
+			continue
+		}
+
+		token := tokens[i]
+		if i == len(c.Arguments)-1 && arg.Type == BotCommandArgumentString && len(tokens) > len(c.Arguments) {
+			token = strings.Join(tokens[i:], " ")
+		}
+
+		switch arg.Type {
+		case BotCommandArgumentInteger:
+			v, err := strconv.ParseInt(token, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("argument %s must be an integer", arg.Name)
+			}
+			result[arg.Name] = v
+		case BotCommandArgumentBoolean:
+			v, err := strconv.ParseBool(token)
+			if err != nil {
+				return nil, fmt.Errorf("argument %s must be a boolean", arg.Name)
+			}
+			result[arg.Name] = v
+		default:
+			result[arg.Name] = token
+		}
+	}
+	if len(tokens) > len(c.Arguments) && (len(c.Arguments) == 0 || c.Arguments[len(c.Arguments)-1].Type != BotCommandArgumentString) {
+		return nil, errors.New("too many arguments")
+	}
+	return result, nil
+}
+
+func splitBotCommandArguments(s string) []string {
+	var (
+		tokens  []string
+		current strings.Builder
+		quoted  bool
+		started bool
+	)
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			started = true
+		case unicode.IsSpace(r) && !quoted:
+			if started {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				started = false
+			}
+		default:
+			current.WriteRune(r)
+			started = true
+		}
+	}
+	if started {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
@@ -0,0 +1,142 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBotCommand_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "bot_commands", (&BotCommand{}).TableName())
+}
+
+func TestBotCommandArgumentType_Valid(t *testing.T) {
+	t.Parallel()
+	assert.True(t, BotCommandArgumentString.Valid())
+	assert.True(t, BotCommandArgumentInteger.Valid())
+	assert.True(t, BotCommandArgumentBoolean.Valid())
+	assert.False(t, BotCommandArgumentType("user").Valid())
+}
+
+func TestBotCommandArguments_Value(t *testing.T) {
+	t.Parallel()
+	v, err := BotCommandArguments(nil).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", v)
+
+	v, err = BotCommandArguments{{Name: "a", Type: BotCommandArgumentString, Required: true}}.Value()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"name":"a","description":"","type":"string","required":true}]`, v.(string))
+}
+
+func TestBotCommandArguments_Scan(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		var args BotCommandArguments
+		assert.NoError(t, args.Scan(nil))
+		assert.Len(t, args, 0)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+		var args BotCommandArguments
+		assert.NoError(t, args.Scan(`[{"name":"a","type":"integer"}]`))
+		if assert.Len(t, args, 1) {
+			assert.Equal(t, "a", args[0].Name)
+			assert.Equal(t, BotCommandArgumentInteger, args[0].Type)
+		}
+	})
+
+	t.Run("[]byte", func(t *testing.T) {
+		t.Parallel()
+		var args BotCommandArguments
+		assert.NoError(t, args.Scan([]byte(`[{"name":"a","type":"boolean"}]`)))
+		assert.Len(t, args, 1)
+	})
+
+	t.Run("other", func(t *testing.T) {
+		t.Parallel()
+		var args BotCommandArguments
+		assert.Error(t, args.Scan(1))
+	})
+}
+
+func TestParseBotCommandInvocation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text string
+		name string
+		args string
+		ok   bool
+	}{
+		{"/deploy", "deploy", "", true},
+		{"  /deploy prod  now ", "deploy", "prod  now", true},
+		{"deploy prod", "", "", false},
+		{"/", "", "", false},
+		{"/ deploy", "", "", false},
+	}
+	for _, tt := range tests {
+		name, args, ok := ParseBotCommandInvocation(tt.text)
+		assert.Equal(t, tt.name, name, tt.text)
+		assert.Equal(t, tt.args, args, tt.text)
+		assert.Equal(t, tt.ok, ok, tt.text)
+	}
+}
+
+func TestBotCommand_ParseArguments(t *testing.T) {
+	t.Parallel()
+
+	c := &BotCommand{
+		Name: "deploy",
+		Arguments: BotCommandArguments{
+			{Name: "replicas", Type: BotCommandArgumentInteger, Required: true},
+			{Name: "force", Type: BotCommandArgumentBoolean},
+			{Name: "message", Type: BotCommandArgumentString},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		text    string
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{"required only", "3", map[string]interface{}{"replicas": int64(3)}, false},
+		{"all", "3 true hello", map[string]interface{}{"replicas": int64(3), "force": true, "message": "hello"}, false},
+		{"rest of text", "3 false hello  world", map[string]interface{}{"replicas": int64(3), "force": false, "message": "hello world"}, false},
+		{"quoted", `3 true "hello  world"`, map[string]interface{}{"replicas": int64(3), "force": true, "message": "hello  world"}, false},
+		{"empty quoted", `3 true ""`, map[string]interface{}{"replicas": int64(3), "force": true, "message": ""}, false},
+		{"missing required", "", nil, true},
+		{"invalid integer", "three", nil, true},
+		{"invalid boolean", "3 maybe", nil, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := c.ParseArguments(tt.text)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+
+	t.Run("too many arguments", func(t *testing.T) {
+		t.Parallel()
+		c := &BotCommand{
+			Name:      "ping",
+			Arguments: BotCommandArguments{{Name: "n", Type: BotCommandArgumentInteger}},
+		}
+		_, err := c.ParseArguments("1 2")
+		assert.Error(t, err)
+
+		_, err = (&BotCommand{Name: "ping"}).ParseArguments("1")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,141 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+// BotChannelFilter Botがイベントを受け取るチャンネルの指定
+type BotChannelFilter struct {
+	ChannelID uuid.UUID `json:"channelId"`
+	// IncludeDescendants 子孫チャンネルも対象にするかどうか
+	IncludeDescendants bool `json:"includeDescendants"`
+}
+
+// BotEventFilter Botの購読イベントのフィルタ
+//
+// 各条件は未指定の場合は全てに一致します
+type BotEventFilter struct {
+	// Channels イベントを受け取るチャンネル
+	Channels []*BotChannelFilter `json:"channels"`
+	// MessagePrefixes メッセージイベントを受け取るメッセージ本文の接頭辞 (いずれかに一致)
+	MessagePrefixes []string `json:"messagePrefixes"`
+	// MessagePattern メッセージイベントを受け取るメッセージ本文の正規表現
+	MessagePattern string `json:"messagePattern"`
+}
+
+// botEventFilterPatterns コンパイル済み正規表現のキャッシュ
+var botEventFilterPatterns sync.Map
+
+// HasChannelFilter チャンネルの条件が指定されているかどうか
+func (f *BotEventFilter) HasChannelFilter() bool {
+	return len(f.Channels) > 0
+}
+
+// HasMessageFilter メッセージ本文の条件が指定されているかどうか
+func (f *BotEventFilter) HasMessageFilter() bool {
+	return len(f.MessagePrefixes) > 0 || len(f.MessagePattern) > 0
+}
+
+// MatchChannel 指定したチャンネルがチャンネルの条件に一致するかどうか
+//
+// ascendantIDsには指定したチャンネルの祖先チャンネルのIDを渡してください
+func (f *BotEventFilter) MatchChannel(channelID uuid.UUID, ascendantIDs []uuid.UUID) bool {
+	if !f.HasChannelFilter() {
+		return true
+	}
+	for _, c := range f.Channels {
+		if c.ChannelID == channelID {
+			return true
+		}
+		if c.IncludeDescendants {
+			for _, id := range ascendantIDs {
+				if c.ChannelID == id {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// MatchMessage 指定したメッセージ本文がメッセージ本文の条件に一致するかどうか
+//
+// 接頭辞と正規表現の両方が指定されている場合は両方に一致する必要があります
+func (f *BotEventFilter) MatchMessage(text string) bool {
+	if len(f.MessagePrefixes) > 0 {
+		matched := false
+		for _, p := range f.MessagePrefixes {
+			if strings.HasPrefix(text, p) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(f.MessagePattern) > 0 {
+		re, err := compileBotEventFilterPattern(f.MessagePattern)
+		if err != nil || !re.MatchString(text) {
+			return false
+		}
+	}
+	return true
+}
+
+func compileBotEventFilterPattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := botEventFilterPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	botEventFilterPatterns.Store(pattern, re)
+	return re, nil
+}
+
+// MarshalJSON encoding/json.Marshaler 実装
+func (f BotEventFilter) MarshalJSON() ([]byte, error) {
+	type alias BotEventFilter
+	a := alias(f)
+	if a.Channels == nil {
+		a.Channels = []*BotChannelFilter{}
+	}
+	if a.MessagePrefixes == nil {
+		a.MessagePrefixes = []string{}
+	}
+	return json.Marshal(a)
+}
+
+// Value database/sql/driver.Valuer 実装
+func (f BotEventFilter) Value() (driver.Value, error) {
+	return json.MarshalToString(f)
+}
+
+// Scan database/sql.Scanner 実装
+func (f *BotEventFilter) Scan(src interface{}) error {
+	*f = BotEventFilter{}
+	switch s := src.(type) {
+	case nil:
+		return nil
+	case string:
+		if len(s) == 0 {
+			return nil
+		}
+		return json.Unmarshal([]byte(s), f)
+	case []byte:
+		if len(s) == 0 {
+			return nil
+		}
+		return json.Unmarshal(s, f)
+	default:
+		return errors.New("failed to scan BotEventFilter")
+	}
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBotEventFilter_MatchChannel(t *testing.T) {
+	t.Parallel()
+
+	parent := uuid.NewV3(uuid.Nil, "parent")
+	child := uuid.NewV3(uuid.Nil, "child")
+	other := uuid.NewV3(uuid.Nil, "other")
+
+	tests := []struct {
+		name       string
+		filter     BotEventFilter
+		channelID  uuid.UUID
+		ascendants []uuid.UUID
+		want       bool
+	}{
+		{"no filter", BotEventFilter{}, other, nil, true},
+		{"exact", BotEventFilter{Channels: []*BotChannelFilter{{ChannelID: child}}}, child, []uuid.UUID{parent}, true},
+		{"descendant excluded", BotEventFilter{Channels: []*BotChannelFilter{{ChannelID: parent}}}, child, []uuid.UUID{parent}, false},
+		{"descendant included", BotEventFilter{Channels: []*BotChannelFilter{{ChannelID: parent, IncludeDescendants: true}}}, child, []uuid.UUID{parent}, true},
+		{"not matched", BotEventFilter{Channels: []*BotChannelFilter{{ChannelID: parent, IncludeDescendants: true}}}, other, nil, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.filter.MatchChannel(tt.channelID, tt.ascendants))
+		})
+	}
+}
+
+func TestBotEventFilter_MatchMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		filter BotEventFilter
+		text   string
+		want   bool
+	}{
+		{"no filter", BotEventFilter{}, "hello", true},
+		{"prefix matched", BotEventFilter{MessagePrefixes: []string{"!", "/"}}, "/help", true},
+		{"prefix not matched", BotEventFilter{MessagePrefixes: []string{"!", "/"}}, "help", false},
+		{"pattern matched", BotEventFilter{MessagePattern: `^deploy\s+\w+$`}, "deploy traq", true},
+		{"pattern not matched", BotEventFilter{MessagePattern: `^deploy\s+\w+$`}, "deploy", false},
+		{"invalid pattern", BotEventFilter{MessagePattern: `(`}, "(", false},
+		{"both matched", BotEventFilter{MessagePrefixes: []string{"!"}, MessagePattern: `ping$`}, "!ping", true},
+		{"only prefix matched", BotEventFilter{MessagePrefixes: []string{"!"}, MessagePattern: `ping$`}, "!pong", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.filter.MatchMessage(tt.text))
+		})
+	}
+}
+
+func TestBotEventFilter_Scan(t *testing.T) {
+	t.Parallel()
+
+	var f BotEventFilter
+	assert.NoError(t, f.Scan(nil))
+	assert.False(t, f.HasChannelFilter())
+	assert.False(t, f.HasMessageFilter())
+
+	id := uuid.NewV3(uuid.Nil, "c")
+	v, err := BotEventFilter{
+		Channels:        []*BotChannelFilter{{ChannelID: id, IncludeDescendants: true}},
+		MessagePrefixes: []string{"!"},
+	}.Value()
+	if assert.NoError(t, err) {
+		assert.NoError(t, f.Scan(v))
+		assert.True(t, f.HasChannelFilter())
+		assert.True(t, f.HasMessageFilter())
+		assert.Equal(t, id, f.Channels[0].ChannelID)
+		assert.True(t, f.Channels[0].IncludeDescendants)
+	}
+
+	assert.Error(t, f.Scan(1))
+}
@@ -19,3 +19,22 @@ type Device struct {
 func (*Device) TableName() string {
 	return "devices"
 }
+
+// WebPushSubscription Web Pushの購読情報の構造体
+//
+// ブラウザのPushSubscriptionに対応します
+type WebPushSubscription struct {
+	Endpoint  string    `gorm:"type:varchar(512);not null;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;index"`
+	P256dh    string    `gorm:"type:varchar(100);not null"`
+	Auth      string    `gorm:"type:varchar(50);not null"`
+	CreatedAt time.Time `gorm:"precision:6"`
+	UpdatedAt time.Time `gorm:"precision:6"`
+
+	User *User `gorm:"constraint:web_push_subscriptions_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName WebPushSubscription構造体のテーブル名
+func (*WebPushSubscription) TableName() string {
+	return "web_push_subscriptions"
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// MessageActionType メッセージアクションの種類
+type MessageActionType string
+
+const (
+	// MessageActionButton ボタン
+	MessageActionButton MessageActionType = "button"
+	// MessageActionSelect セレクトメニュー
+	MessageActionSelect MessageActionType = "select"
+)
+
+// Valid 有効なアクションの種類かどうか
+func (t MessageActionType) Valid() bool {
+	switch t {
+	case MessageActionButton, MessageActionSelect:
+		return true
+	default:
+		return false
+	}
+}
+
+// MessageActionOption セレクトメニューの選択肢
+type MessageActionOption struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// MessageAction BOTがメッセージに付与するボタンやセレクトメニュー
+type MessageAction struct {
+	// ID メッセージ内で一意なアクションID
+	ID    string            `json:"id"`
+	Type  MessageActionType `json:"type"`
+	Label string            `json:"label"`
+	// Value ボタンが押された時にBOTに送信される値
+	Value string `json:"value,omitempty"`
+	// Options セレクトメニューの選択肢
+	Options []*MessageActionOption `json:"options,omitempty"`
+}
+
+// HasOption 指定した値の選択肢が存在するかどうか
+func (a *MessageAction) HasOption(value string) bool {
+	for _, o := range a.Options {
+		if o.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// MessageActions メッセージアクションの配列
+type MessageActions []*MessageAction
+
+// Find 指定したIDのアクションを返します。存在しない場合はnilを返します。
+func (actions MessageActions) Find(id string) *MessageAction {
+	for _, a := range actions {
+		if a.ID == id {
+			return a
+		}
+	}
+	return nil
+}
+
+// Value database/sql/driver.Valuer 実装
+func (actions MessageActions) Value() (driver.Value, error) {
+	if actions == nil {
+		return "[]", nil
+	}
+	return json.MarshalToString(actions)
+}
+
+// Scan database/sql.Scanner 実装
+func (actions *MessageActions) Scan(src interface{}) error {
+	*actions = MessageActions{}
+	switch s := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return json.Unmarshal([]byte(s), actions)
+	case []byte:
+		return json.Unmarshal(s, actions)
+	default:
+		return errors.New("failed to scan MessageActions")
+	}
+}
+
+// MessageAttachment BOTがメッセージに付与したアクションのレコード
+type MessageAttachment struct {
+	MessageID uuid.UUID      `gorm:"type:char(36);not null;primaryKey"`
+	Actions   MessageActions `gorm:"type:text;not null"`
+	CreatedAt time.Time      `gorm:"precision:6"`
+	UpdatedAt time.Time      `gorm:"precision:6"`
+}
+
+// TableName MessageAttachmentのテーブル名
+func (*MessageAttachment) TableName() string {
+	return "message_attachments"
+}
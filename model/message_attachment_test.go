@@ -0,0 +1,62 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageAttachment_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "message_attachments", (&MessageAttachment{}).TableName())
+}
+
+func TestMessageActionType_Valid(t *testing.T) {
+	t.Parallel()
+	assert.True(t, MessageActionButton.Valid())
+	assert.True(t, MessageActionSelect.Valid())
+	assert.False(t, MessageActionType("link").Valid())
+}
+
+func TestMessageActions_Find(t *testing.T) {
+	t.Parallel()
+	actions := MessageActions{
+		{ID: "a", Type: MessageActionButton},
+		{ID: "b", Type: MessageActionSelect, Options: []*MessageActionOption{{Label: "X", Value: "x"}}},
+	}
+	if a := actions.Find("b"); assert.NotNil(t, a) {
+		assert.True(t, a.HasOption("x"))
+		assert.False(t, a.HasOption("y"))
+	}
+	assert.Nil(t, actions.Find("c"))
+	assert.Nil(t, MessageActions(nil).Find("a"))
+}
+
+func TestMessageActions_Value(t *testing.T) {
+	t.Parallel()
+	v, err := MessageActions(nil).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", v)
+
+	v, err = MessageActions{{ID: "a", Type: MessageActionButton, Label: "A"}}.Value()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"id":"a","type":"button","label":"A"}]`, v.(string))
+}
+
+func TestMessageActions_Scan(t *testing.T) {
+	t.Parallel()
+
+	var actions MessageActions
+	assert.NoError(t, actions.Scan(nil))
+	assert.Len(t, actions, 0)
+
+	assert.NoError(t, actions.Scan(`[{"id":"a","type":"button","label":"A"}]`))
+	assert.Len(t, actions, 1)
+
+	assert.NoError(t, actions.Scan([]byte(`[{"id":"a","type":"select","label":"A","options":[{"label":"X","value":"x"}]}]`)))
+	if assert.Len(t, actions, 1) {
+		assert.Len(t, actions[0].Options, 1)
+	}
+
+	assert.Error(t, actions.Scan(1))
+}
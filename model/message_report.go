@@ -5,6 +5,24 @@ import (
 
 	"github.com/gofrs/uuid"
 	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+const (
+	// MessageReportStateOpen 未対応
+	MessageReportStateOpen = "open"
+	// MessageReportStateResolved 対応済み
+	MessageReportStateResolved = "resolved"
+	// MessageReportStateDismissed 却下
+	MessageReportStateDismissed = "dismissed"
+
+	// MessageReportActionNone メッセージに対して何もしない
+	MessageReportActionNone = "none"
+	// MessageReportActionHide メッセージを非表示にする
+	MessageReportActionHide = "hide"
+	// MessageReportActionDelete メッセージを削除する
+	MessageReportActionDelete = "delete"
 )
 
 // MessageReport メッセージレポート構造体
@@ -13,6 +31,10 @@ type MessageReport struct {
 	MessageID uuid.UUID      `gorm:"type:char(36);not null;uniqueIndex:message_reporter" json:"messageId"`
 	Reporter  uuid.UUID      `gorm:"type:char(36);not null;uniqueIndex:message_reporter" json:"reporter"`
 	Reason    string         `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"                json:"reason"`
+	State     string         `gorm:"type:varchar(20);not null;default:'open';index"       json:"state"`
+	Action    string         `gorm:"type:varchar(20);not null;default:''"                 json:"action"`
+	HandlerID optional.UUID  `gorm:"type:char(36)"                                        json:"handlerId"`
+	HandledAt optional.Time  `gorm:"precision:6"                                          json:"handledAt"`
 	CreatedAt time.Time      `gorm:"precision:6;index"                                    json:"createdAt"`
 	DeletedAt gorm.DeletedAt `gorm:"precision:6"                                          json:"-"`
 }
@@ -21,3 +43,8 @@ type MessageReport struct {
 func (*MessageReport) TableName() string {
 	return "message_reports"
 }
+
+// IsOpen 未対応の通報かどうか
+func (r *MessageReport) IsOpen() bool {
+	return r.State == MessageReportStateOpen
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/gofrs/uuid"
 	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 // Message データベースに格納するmessageの構造体
@@ -13,7 +15,9 @@ type Message struct {
 	UserID    uuid.UUID      `gorm:"type:char(36);not null;"`
 	ChannelID uuid.UUID      `gorm:"type:char(36);not null;index:idx_messages_channel_id_deleted_at_created_at,priority:1"`
 	Text      string         `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
-	CreatedAt time.Time      `gorm:"precision:6;index;index:idx_messages_channel_id_deleted_at_created_at,priority:3;index:idx_messages_deleted_at_created_at,priority:2"`
+	ThreadID  optional.UUID  `gorm:"type:char(36);index:idx_messages_thread_id_created_at,priority:1"`
+	Hidden    bool           `gorm:"type:boolean;not null;default:false"`
+	CreatedAt time.Time      `gorm:"precision:6;index;index:idx_messages_channel_id_deleted_at_created_at,priority:3;index:idx_messages_deleted_at_created_at,priority:2;index:idx_messages_thread_id_created_at,priority:2"`
 	UpdatedAt time.Time      `gorm:"precision:6;index:idx_messages_deleted_at_updated_at,priority:2"`
 	DeletedAt gorm.DeletedAt `gorm:"precision:6;index:idx_messages_channel_id_deleted_at_created_at,priority:2;index:idx_messages_deleted_at_created_at,priority:1;index:idx_messages_deleted_at_updated_at,priority:1"`
 
@@ -21,6 +25,8 @@ type Message struct {
 	Channel *Channel       `gorm:"constraint:messages_channel_id_channels_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
 	Stamps  []MessageStamp `gorm:"constraint:messages_stamps_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignkey:MessageID"`
 	Pin     *Pin           `gorm:"constraint:pins_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+
+	Attachment *MessageAttachment `gorm:"constraint:message_attachments_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 // TableName DBの名前を指定するメソッド
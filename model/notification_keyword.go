@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// MaxNotificationKeywords ユーザーが登録できる通知キーワードの最大数
+	MaxNotificationKeywords = 50
+	// MaxNotificationKeywordLength 通知キーワードの最大文字数
+	MaxNotificationKeywordLength = 50
+)
+
+// NotificationKeyword キーワード通知の構造体
+type NotificationKeyword struct {
+	UserID    uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	Keyword   string    `gorm:"type:varchar(50);not null;primaryKey"`
+	CreatedAt time.Time `gorm:"precision:6"`
+
+	User *User `gorm:"constraint:user_notification_keywords_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName NotificationKeyword構造体のテーブル名
+func (*NotificationKeyword) TableName() string {
+	return "user_notification_keywords"
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// OutgoingWebhookTriggerWords 送信Webhookのトリガーワードの配列
+type OutgoingWebhookTriggerWords []string
+
+// Value database/sql/driver.Valuer 実装
+func (words OutgoingWebhookTriggerWords) Value() (driver.Value, error) {
+	if words == nil {
+		return "[]", nil
+	}
+	return json.MarshalToString(words)
+}
+
+// Scan database/sql.Scanner 実装
+func (words *OutgoingWebhookTriggerWords) Scan(src interface{}) error {
+	*words = OutgoingWebhookTriggerWords{}
+	switch s := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return json.Unmarshal([]byte(s), words)
+	case []byte:
+		return json.Unmarshal(s, words)
+	default:
+		return errors.New("failed to scan OutgoingWebhookTriggerWords")
+	}
+}
+
+// OutgoingWebhook 送信Webhook
+//
+// 指定したチャンネルに投稿されたメッセージを外部のURLに送信します
+type OutgoingWebhook struct {
+	WebhookID     uuid.UUID                   `gorm:"type:char(36);not null;primaryKey"`
+	URL           string                      `gorm:"type:text;not null"`
+	TriggerWords  OutgoingWebhookTriggerWords `gorm:"type:text;not null"`
+	SigningSecret string                      `gorm:"type:varchar(64);not null"`
+	Reply         bool                        `gorm:"type:boolean;not null;default:false"`
+	CreatedAt     time.Time                   `gorm:"precision:6"`
+	UpdatedAt     time.Time                   `gorm:"precision:6"`
+
+	Channels []*OutgoingWebhookChannel `gorm:"constraint:outgoing_webhook_channels_webhook_id_outgoing_webhooks_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:WebhookID;references:WebhookID"`
+	Webhook  *WebhookBot               `gorm:"constraint:outgoing_webhooks_webhook_id_webhook_bots_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:WebhookID"`
+}
+
+// TableName OutgoingWebhookのテーブル名
+func (*OutgoingWebhook) TableName() string {
+	return "outgoing_webhooks"
+}
+
+// ChannelIDs 送信対象のチャンネルのIDの配列を返します
+func (w *OutgoingWebhook) ChannelIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, len(w.Channels))
+	for i, c := range w.Channels {
+		ids[i] = c.ChannelID
+	}
+	return ids
+}
+
+// MatchTriggerWord 指定したメッセージ本文がトリガーワードで始まるかどうか
+//
+// 一致したトリガーワードを返します。トリガーワードが設定されていない場合は全ての本文に一致します。
+func (w *OutgoingWebhook) MatchTriggerWord(text string) (word string, ok bool) {
+	if len(w.TriggerWords) == 0 {
+		return "", true
+	}
+	for _, word := range w.TriggerWords {
+		if strings.HasPrefix(text, word) {
+			return word, true
+		}
+	}
+	return "", false
+}
+
+// OutgoingWebhookChannel 送信Webhookの送信対象チャンネル
+type OutgoingWebhookChannel struct {
+	WebhookID uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	ChannelID uuid.UUID `gorm:"type:char(36);not null;primaryKey;index"`
+
+	Channel *Channel `gorm:"constraint:outgoing_webhook_channels_channel_id_channels_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName OutgoingWebhookChannelのテーブル名
+func (*OutgoingWebhookChannel) TableName() string {
+	return "outgoing_webhook_channels"
+}
+
+// OutgoingWebhookLog 送信Webhookの配送ログ
+type OutgoingWebhookLog struct {
+	RequestID uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	WebhookID uuid.UUID `gorm:"type:char(36);not null;index:webhook_id_date_time_idx"`
+	MessageID uuid.UUID `gorm:"type:char(36);not null"`
+	Body      string    `gorm:"type:text"`
+	Result    string    `gorm:"type:char(2);not null"`
+	Error     string    `gorm:"type:text"`
+	Code      int       `gorm:"not null;default:0"`
+	Latency   int64     `gorm:"not null;default:0"`
+	DateTime  time.Time `gorm:"precision:6;index:webhook_id_date_time_idx"`
+}
+
+// TableName OutgoingWebhookLogのテーブル名
+func (*OutgoingWebhookLog) TableName() string {
+	return "outgoing_webhook_logs"
+}
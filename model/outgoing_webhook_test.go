@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutgoingWebhook_MatchTriggerWord(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		words    OutgoingWebhookTriggerWords
+		text     string
+		wantWord string
+		wantOK   bool
+	}{
+		{"no trigger words", OutgoingWebhookTriggerWords{}, "hello", "", true},
+		{"matched", OutgoingWebhookTriggerWords{"!build", "!deploy"}, "!deploy now", "!deploy", true},
+		{"not prefix", OutgoingWebhookTriggerWords{"!deploy"}, "please !deploy", "", false},
+		{"not matched", OutgoingWebhookTriggerWords{"!deploy"}, "hello", "", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			w := &OutgoingWebhook{TriggerWords: tt.words}
+			word, ok := w.MatchTriggerWord(tt.text)
+			assert.Equal(t, tt.wantWord, word)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestOutgoingWebhookTriggerWords_Scan(t *testing.T) {
+	t.Parallel()
+
+	var words OutgoingWebhookTriggerWords
+	assert.NoError(t, words.Scan(`["a","b"]`))
+	assert.Equal(t, OutgoingWebhookTriggerWords{"a", "b"}, words)
+	assert.NoError(t, words.Scan(nil))
+	assert.Equal(t, OutgoingWebhookTriggerWords{}, words)
+	assert.Error(t, words.Scan(1))
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// ScheduledMessage 予約投稿メッセージの構造体
+type ScheduledMessage struct {
+	ID          uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID      uuid.UUID `gorm:"type:char(36);not null;index"`
+	ChannelID   uuid.UUID `gorm:"type:char(36);not null"`
+	Text        string    `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	ScheduledAt time.Time `gorm:"precision:6;index"`
+	// State 送信状態
+	State ScheduledMessageState `gorm:"type:varchar(10);not null;default:'pending'"`
+	// Error 送信に失敗した理由 送信失敗状態でない場合は空文字列
+	Error string `gorm:"type:varchar(100);not null;default:''"`
+	// ClaimedAt 送信処理を開始した日時 送信中状態でない場合はNULL
+	ClaimedAt optional.Time `gorm:"precision:6"`
+	// Attempts 送信に失敗して再試行した回数
+	Attempts int `gorm:"type:int;not null;default:0"`
+	// RetryAt 次に送信を再試行する日時 再試行待ちでない場合はNULL
+	RetryAt   optional.Time `gorm:"precision:6"`
+	CreatedAt time.Time     `gorm:"precision:6"`
+	UpdatedAt time.Time     `gorm:"precision:6"`
+
+	User    *User    `gorm:"constraint:scheduled_messages_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Channel *Channel `gorm:"constraint:scheduled_messages_channel_id_channels_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// ScheduledMessageState 予約投稿メッセージの送信状態
+type ScheduledMessageState string
+
+const (
+	// ScheduledMessagePending 送信待ち
+	ScheduledMessagePending ScheduledMessageState = "pending"
+	// ScheduledMessageSending 送信中
+	//
+	// 送信処理を開始する前にこの状態にし、同じメッセージが二重に送信されないようにします
+	ScheduledMessageSending ScheduledMessageState = "sending"
+	// ScheduledMessageFailed 送信失敗
+	ScheduledMessageFailed ScheduledMessageState = "failed"
+)
+
+// TableName ScheduledMessage構造体のテーブル名
+func (*ScheduledMessage) TableName() string {
+	return "scheduled_messages"
+}
+
+// IsPending 送信待ちかどうか
+func (sm *ScheduledMessage) IsPending() bool {
+	return sm.State == ScheduledMessagePending
+}
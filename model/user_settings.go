@@ -1,11 +1,63 @@
 package model
 
-import "github.com/gofrs/uuid"
+import (
+	"errors"
+	"time"
+	_ "time/tzdata" // コンテナにタイムゾーンデータが無い場合でもLoadLocationできるように埋め込む
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// EmailDigestFrequency 未読通知メールダイジェストの送信頻度
+type EmailDigestFrequency string
+
+const (
+	// EmailDigestOff メールダイジェストを送信しない
+	EmailDigestOff EmailDigestFrequency = "off"
+	// EmailDigestHourly 1時間ごとにメールダイジェストを送信する
+	EmailDigestHourly EmailDigestFrequency = "hourly"
+	// EmailDigestDaily 1日ごとにメールダイジェストを送信する
+	EmailDigestDaily EmailDigestFrequency = "daily"
+)
+
+// Valid 有効な送信頻度かどうか
+func (f EmailDigestFrequency) Valid() bool {
+	switch f {
+	case EmailDigestOff, EmailDigestHourly, EmailDigestDaily:
+		return true
+	default:
+		return false
+	}
+}
+
+// Interval 送信間隔を返します
+//
+// 送信しない場合は0を返します
+func (f EmailDigestFrequency) Interval() time.Duration {
+	switch f {
+	case EmailDigestHourly:
+		return time.Hour
+	case EmailDigestDaily:
+		return 24 * time.Hour
+	default:
+		return 0
+	}
+}
 
 // UserSettings ユーザー設定の構造体
 type UserSettings struct {
-	UserID         uuid.UUID `gorm:"type:char(36);not null;primaryKey;" json:"id"`
-	NotifyCitation bool      `gorm:"type:boolean" json:"notifyCitation"`
+	UserID               uuid.UUID            `gorm:"type:char(36);not null;primaryKey;" json:"id"`
+	NotifyCitation       bool                 `gorm:"type:boolean" json:"notifyCitation"`
+	EmailDigestFrequency EmailDigestFrequency `gorm:"type:varchar(10);not null;default:'daily'" json:"emailDigestFrequency"`
+	EmailDigestSentAt    optional.Time        `gorm:"precision:6" json:"-"`
+	DNDUntil             optional.Time        `gorm:"precision:6" json:"dndUntil"`
+	DNDMuteForceChannels bool                 `gorm:"type:boolean;not null;default:false" json:"dndMuteForceChannels"`
+	QuietHoursEnabled    bool                 `gorm:"type:boolean;not null;default:false" json:"quietHoursEnabled"`
+	QuietHoursStart      string               `gorm:"type:varchar(5);not null;default:''" json:"quietHoursStart"`
+	QuietHoursEnd        string               `gorm:"type:varchar(5);not null;default:''" json:"quietHoursEnd"`
+	QuietHoursTimezone   string               `gorm:"type:varchar(64);not null;default:''" json:"quietHoursTimezone"`
 
 	User *User `gorm:"constraint:user_settings_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
@@ -19,3 +71,78 @@ func (us *UserSettings) TableName() string {
 func (us *UserSettings) IsNotifyCitationEnabled() bool {
 	return us.NotifyCitation
 }
+
+// IsDNDActive 指定した日時におやすみモード(DND)が有効かどうかを返します
+//
+// DNDUntilまでの手動おやすみモードか、QuietHoursTimezoneにおける
+// QuietHoursStartからQuietHoursEndまでの定期おやすみ時間帯の場合にtrueを返します
+func (us *UserSettings) IsDNDActive(now time.Time) bool {
+	if us.DNDUntil.Valid && now.Before(us.DNDUntil.Time) {
+		return true
+	}
+	if !us.QuietHoursEnabled {
+		return false
+	}
+	start, ok := parseClock(us.QuietHoursStart)
+	if !ok {
+		return false
+	}
+	end, ok := parseClock(us.QuietHoursEnd)
+	if !ok || start == end {
+		return false
+	}
+	loc, err := loadTimezone(us.QuietHoursTimezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	t := now.In(loc)
+	m := t.Hour()*60 + t.Minute()
+	if start < end {
+		return start <= m && m < end
+	}
+	// 日付をまたぐ場合 (例: 22:00-07:00)
+	return start <= m || m < end
+}
+
+// IsValidClock "HH:MM"形式の時刻として正しいかどうか
+func IsValidClock(s string) bool {
+	_, ok := parseClock(s)
+	return ok
+}
+
+// IsValidTimezone IANAタイムゾーン名として正しいかどうか
+//
+// 空文字列はUTCとして扱います
+func IsValidTimezone(name string) bool {
+	_, err := loadTimezone(name)
+	return err == nil
+}
+
+// loadTimezone IANAタイムゾーン名からLocationを取得します
+//
+// サーバーのローカルタイムゾーンに依存しないよう"Local"は受け付けません
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "Local" {
+		return nil, errors.New("invalid timezone")
+	}
+	return time.LoadLocation(name)
+}
+
+// parseClock "HH:MM"形式の時刻を0時からの経過分に変換します
+func parseClock(s string) (int, bool) {
+	if len(s) != 5 || s[2] != ':' {
+		return 0, false
+	}
+	for _, i := range []int{0, 1, 3, 4} {
+		if s[i] < '0' || '9' < s[i] {
+			return 0, false
+		}
+	}
+	h := int(s[0]-'0')*10 + int(s[1]-'0')
+	m := int(s[3]-'0')*10 + int(s[4]-'0')
+	if h > 23 || m > 59 {
+		return 0, false
+	}
+	return h*60 + m, true
+}
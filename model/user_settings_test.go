@@ -0,0 +1,130 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestUserSettings_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "user_settings", (&UserSettings{}).TableName())
+}
+
+func TestEmailDigestFrequency_Valid(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, EmailDigestOff.Valid())
+	assert.True(t, EmailDigestHourly.Valid())
+	assert.True(t, EmailDigestDaily.Valid())
+	assert.False(t, EmailDigestFrequency("").Valid())
+	assert.False(t, EmailDigestFrequency("weekly").Valid())
+}
+
+func TestEmailDigestFrequency_Interval(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, time.Duration(0), EmailDigestOff.Interval())
+	assert.Equal(t, time.Hour, EmailDigestHourly.Interval())
+	assert.Equal(t, 24*time.Hour, EmailDigestDaily.Interval())
+	assert.Equal(t, time.Duration(0), EmailDigestFrequency("weekly").Interval())
+}
+
+func TestUserSettings_IsDNDActive(t *testing.T) {
+	t.Parallel()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if !assert.NoError(t, err) {
+		return
+	}
+	now := time.Date(2022, 4, 1, 23, 30, 0, 0, tokyo)
+
+	tests := []struct {
+		name     string
+		settings UserSettings
+		want     bool
+	}{
+		{
+			name:     "default",
+			settings: UserSettings{},
+			want:     false,
+		},
+		{
+			name:     "snoozed",
+			settings: UserSettings{DNDUntil: optional.TimeFrom(now.Add(time.Hour))},
+			want:     true,
+		},
+		{
+			name:     "snooze expired",
+			settings: UserSettings{DNDUntil: optional.TimeFrom(now.Add(-time.Hour))},
+			want:     false,
+		},
+		{
+			name:     "quiet hours across midnight",
+			settings: UserSettings{QuietHoursEnabled: true, QuietHoursStart: "22:00", QuietHoursEnd: "07:00", QuietHoursTimezone: "Asia/Tokyo"},
+			want:     true,
+		},
+		{
+			name:     "quiet hours disabled",
+			settings: UserSettings{QuietHoursEnabled: false, QuietHoursStart: "22:00", QuietHoursEnd: "07:00", QuietHoursTimezone: "Asia/Tokyo"},
+			want:     false,
+		},
+		{
+			name:     "quiet hours in other timezone",
+			settings: UserSettings{QuietHoursEnabled: true, QuietHoursStart: "22:00", QuietHoursEnd: "07:00", QuietHoursTimezone: "UTC"},
+			want:     false, // UTCでは14:30
+		},
+		{
+			name:     "quiet hours within a day",
+			settings: UserSettings{QuietHoursEnabled: true, QuietHoursStart: "13:00", QuietHoursEnd: "15:00", QuietHoursTimezone: ""},
+			want:     true, // UTCでは14:30
+		},
+		{
+			name:     "quiet hours end is exclusive",
+			settings: UserSettings{QuietHoursEnabled: true, QuietHoursStart: "20:00", QuietHoursEnd: "23:30", QuietHoursTimezone: "Asia/Tokyo"},
+			want:     false,
+		},
+		{
+			name:     "empty range",
+			settings: UserSettings{QuietHoursEnabled: true, QuietHoursStart: "23:00", QuietHoursEnd: "23:00", QuietHoursTimezone: "Asia/Tokyo"},
+			want:     false,
+		},
+		{
+			name:     "invalid clock",
+			settings: UserSettings{QuietHoursEnabled: true, QuietHoursStart: "25:00", QuietHoursEnd: "07:00", QuietHoursTimezone: "Asia/Tokyo"},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.settings.IsDNDActive(now))
+		})
+	}
+}
+
+func TestIsValidClock(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsValidClock("00:00"))
+	assert.True(t, IsValidClock("23:59"))
+	assert.False(t, IsValidClock(""))
+	assert.False(t, IsValidClock("24:00"))
+	assert.False(t, IsValidClock("12:60"))
+	assert.False(t, IsValidClock("1:00"))
+	assert.False(t, IsValidClock("+1:00"))
+}
+
+func TestIsValidTimezone(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsValidTimezone(""))
+	assert.True(t, IsValidTimezone("UTC"))
+	assert.True(t, IsValidTimezone("Asia/Tokyo"))
+	assert.False(t, IsValidTimezone("Local"))
+	assert.False(t, IsValidTimezone("Asia/Nowhere"))
+}
@@ -76,6 +76,7 @@ type UserInfo interface {
 	GetBio() string
 	GetLastOnline() optional.Time
 	GetHomeChannel() optional.UUID
+	GetEmail() string
 
 	// IsActive ユーザーが有効かどうか
 	IsActive() bool
@@ -123,6 +124,7 @@ type UserProfile struct {
 	UserID      uuid.UUID     `gorm:"type:char(36);not null;primaryKey"`
 	Bio         string        `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
 	TwitterID   string        `gorm:"type:varchar(15);not null;default:''"`
+	Email       string        `gorm:"type:varchar(254);not null;default:''"`
 	LastOnline  optional.Time `gorm:"precision:6"`
 	HomeChannel optional.UUID `gorm:"type:char(36)"`
 	UpdatedAt   time.Time     `gorm:"precision:6"`
@@ -149,6 +151,22 @@ func (ExternalProviderUser) TableName() string {
 	return "external_provider_users"
 }
 
+// GetEmail 外部アカウントのメールアドレスを返します
+//
+// メールアドレスが不明な場合は空文字列を返します
+func (u *ExternalProviderUser) GetEmail() string {
+	if email, ok := u.Extra["email"].(string); ok {
+		return email
+	}
+	// Googleアカウントは外部アカウント名がメールアドレス
+	if u.ProviderName == "google" {
+		if name, ok := u.Extra["externalName"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
 // GetID implements UserInfo interface
 func (user *User) GetID() uuid.UUID {
 	return user.ID
@@ -231,6 +249,14 @@ func (user *User) GetHomeChannel() optional.UUID {
 	return user.Profile.HomeChannel
 }
 
+// GetEmail implements UserInfo interface
+func (user *User) GetEmail() string {
+	if user.Profile == nil {
+		panic("unexpected control flow")
+	}
+	return user.Profile.Email
+}
+
 // IsActive implements UserInfo interface
 func (user *User) IsActive() bool {
 	return user.GetState() == UserAccountStatusActive
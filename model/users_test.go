@@ -57,3 +57,12 @@ func TestUserAccountStatus_Valid(t *testing.T) {
 	assert.True(t, UserAccountStatusDeactivated.Valid())
 	assert.False(t, UserAccountStatus(-1).Valid())
 }
+
+func TestExternalProviderUser_GetEmail(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "a@example.com", (&ExternalProviderUser{ProviderName: "oidc", Extra: JSON{"email": "a@example.com"}}).GetEmail())
+	assert.Equal(t, "b@example.com", (&ExternalProviderUser{ProviderName: "google", Extra: JSON{"externalName": "b@example.com"}}).GetEmail())
+	assert.Equal(t, "", (&ExternalProviderUser{ProviderName: "github", Extra: JSON{"externalName": "octocat"}}).GetEmail())
+	assert.Equal(t, "", (&ExternalProviderUser{ProviderName: "traq"}).GetEmail())
+}
@@ -1,9 +1,14 @@
 package model
 
 import (
+	"database/sql/driver"
+	"errors"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
+	jsonIter "github.com/json-iterator/go"
 	"gorm.io/gorm"
 )
 
@@ -16,21 +21,23 @@ type Webhook interface {
 	GetSecret() string
 	GetChannelID() uuid.UUID
 	GetCreatorID() uuid.UUID
+	GetEventTypes() WebhookEventTypes
 	GetCreatedAt() time.Time
 	GetUpdatedAt() time.Time
 }
 
 // WebhookBot DB用WebhookBot構造体
 type WebhookBot struct {
-	ID          uuid.UUID      `gorm:"type:char(36);not null;primaryKey"`
-	BotUserID   uuid.UUID      `gorm:"type:char(36);not null;unique"`
-	Description string         `gorm:"type:text;not null"`
-	Secret      string         `gorm:"type:text;not null"`
-	ChannelID   uuid.UUID      `gorm:"type:char(36);not null"`
-	CreatorID   uuid.UUID      `gorm:"type:char(36);not null"`
-	CreatedAt   time.Time      `gorm:"precision:6"`
-	UpdatedAt   time.Time      `gorm:"precision:6"`
-	DeletedAt   gorm.DeletedAt `gorm:"precision:6"`
+	ID          uuid.UUID         `gorm:"type:char(36);not null;primaryKey"`
+	BotUserID   uuid.UUID         `gorm:"type:char(36);not null;unique"`
+	Description string            `gorm:"type:text;not null"`
+	Secret      string            `gorm:"type:text;not null"`
+	ChannelID   uuid.UUID         `gorm:"type:char(36);not null"`
+	CreatorID   uuid.UUID         `gorm:"type:char(36);not null"`
+	EventTypes  WebhookEventTypes `gorm:"type:text;not null"`
+	CreatedAt   time.Time         `gorm:"precision:6"`
+	UpdatedAt   time.Time         `gorm:"precision:6"`
+	DeletedAt   gorm.DeletedAt    `gorm:"precision:6"`
 
 	BotUser User     `gorm:"constraint:webhook_bots_bot_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignkey:BotUserID"`
 	Creator *User    `gorm:"constraint:webhook_bots_creator_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:CreatorID"`
@@ -77,6 +84,11 @@ func (w *WebhookBot) GetCreatorID() uuid.UUID {
 	return w.CreatorID
 }
 
+// GetEventTypes 外部サービスのアダプターで投稿するイベントの種類を返します
+func (w *WebhookBot) GetEventTypes() WebhookEventTypes {
+	return w.EventTypes
+}
+
 // GetCreatedAt Webhookの作成日時を返します
 func (w *WebhookBot) GetCreatedAt() time.Time {
 	return w.CreatedAt
@@ -86,3 +98,125 @@ func (w *WebhookBot) GetCreatedAt() time.Time {
 func (w *WebhookBot) GetUpdatedAt() time.Time {
 	return w.UpdatedAt
 }
+
+// WebhookEventType GitHub・GitLabなど外部サービスのWebhookイベントの種類
+type WebhookEventType string
+
+const (
+	// WebhookEventPush プッシュ
+	WebhookEventPush WebhookEventType = "push"
+	// WebhookEventPullRequest プルリクエスト(マージリクエスト)
+	WebhookEventPullRequest WebhookEventType = "pull_request"
+	// WebhookEventIssue イシュー
+	WebhookEventIssue WebhookEventType = "issue"
+	// WebhookEventRelease リリース
+	WebhookEventRelease WebhookEventType = "release"
+)
+
+// Valid 有効なイベントの種類かどうか
+func (t WebhookEventType) Valid() bool {
+	switch t {
+	case WebhookEventPush, WebhookEventPullRequest, WebhookEventIssue, WebhookEventRelease:
+		return true
+	default:
+		return false
+	}
+}
+
+// WebhookEventTypes WebhookイベントタイプのSet
+type WebhookEventTypes map[WebhookEventType]struct{}
+
+// AllWebhookEventTypes 全てのWebhookイベントタイプのSetを返します
+func AllWebhookEventTypes() WebhookEventTypes {
+	return WebhookEventTypes{
+		WebhookEventPush:        {},
+		WebhookEventPullRequest: {},
+		WebhookEventIssue:       {},
+		WebhookEventRelease:     {},
+	}
+}
+
+func WebhookEventTypesFromArray(arr []string) WebhookEventTypes {
+	res := WebhookEventTypes{}
+	for _, v := range arr {
+		if len(v) > 0 {
+			res[WebhookEventType(v)] = struct{}{}
+		}
+	}
+	return res
+}
+
+// Contains 指定したイベントタイプが含まれているかどうか
+func (set WebhookEventTypes) Contains(t WebhookEventType) bool {
+	_, ok := set[t]
+	return ok
+}
+
+// Array イベントタイプをソートされたstringの配列に変換します
+func (set WebhookEventTypes) Array() []string {
+	r := make([]string, 0, len(set))
+	for t := range set {
+		r = append(r, string(t))
+	}
+	sort.Strings(r)
+	return r
+}
+
+// String イベントタイプをスペース区切りで文字列に出力します
+func (set WebhookEventTypes) String() string {
+	return strings.Join(set.Array(), " ")
+}
+
+// MarshalJSON encoding/json.Marshaler 実装
+func (set WebhookEventTypes) MarshalJSON() ([]byte, error) {
+	return jsonIter.ConfigFastest.Marshal(set.Array())
+}
+
+// UnmarshalJSON encoding/json.Unmarshaler 実装
+func (set *WebhookEventTypes) UnmarshalJSON(data []byte) error {
+	var arr []string
+	if err := jsonIter.ConfigFastest.Unmarshal(data, &arr); err != nil {
+		return err
+	}
+	*set = WebhookEventTypesFromArray(arr)
+	return nil
+}
+
+// Value database/sql/driver.Valuer 実装
+func (set WebhookEventTypes) Value() (driver.Value, error) {
+	return set.String(), nil
+}
+
+// Scan database/sql.Scanner 実装
+func (set *WebhookEventTypes) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*set = WebhookEventTypes{}
+	case string:
+		*set = WebhookEventTypesFromArray(strings.Split(s, " "))
+	case []byte:
+		*set = WebhookEventTypesFromArray(strings.Split(string(s), " "))
+	default:
+		return errors.New("failed to scan WebhookEventTypes")
+	}
+	return nil
+}
+
+// WebhookLog Webhookの受信ログ
+type WebhookLog struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	WebhookID uuid.UUID `gorm:"type:char(36);not null;index:webhook_id_date_time_idx"`
+	// Adapter 受信したエンドポイントの外部サービスアダプター名 通常のエンドポイントの場合は空
+	Adapter  string    `gorm:"type:varchar(10);not null;default:''"`
+	IP       string    `gorm:"type:varchar(45);not null;default:''"`
+	Body     string    `gorm:"type:text"`
+	Result   string    `gorm:"type:char(2);not null"`
+	Error    string    `gorm:"type:text"`
+	Code     int       `gorm:"not null;default:0"`
+	DateTime time.Time `gorm:"precision:6;index:webhook_id_date_time_idx"`
+}
+
+// TableName WebhookLogのテーブル名
+func (*WebhookLog) TableName() string {
+	return "webhook_logs"
+}
@@ -66,3 +66,71 @@ func TestWebhookBot_GetUpdatedAt(t *testing.T) {
 	tm := time.Now()
 	assert.Equal(t, tm, (&WebhookBot{UpdatedAt: tm}).GetUpdatedAt())
 }
+
+func TestWebhookBot_GetEventTypes(t *testing.T) {
+	t.Parallel()
+	es := WebhookEventTypes{WebhookEventPush: struct{}{}}
+	assert.Equal(t, es, (&WebhookBot{EventTypes: es}).GetEventTypes())
+}
+
+func TestWebhookEventType_Valid(t *testing.T) {
+	t.Parallel()
+	for et := range AllWebhookEventTypes() {
+		assert.True(t, et.Valid())
+	}
+	assert.False(t, WebhookEventType("deployment").Valid())
+}
+
+func TestWebhookEventTypes_Value(t *testing.T) {
+	t.Parallel()
+	v, err := AllWebhookEventTypes().Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "issue pull_request push release", v)
+}
+
+func TestWebhookEventTypes_Scan(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		s := WebhookEventTypes{}
+		assert.NoError(t, s.Scan(nil))
+		assert.EqualValues(t, WebhookEventTypes{}, s)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+
+		s := WebhookEventTypes{}
+		assert.NoError(t, s.Scan("push release  "))
+		assert.EqualValues(t, []string{"push", "release"}, s.Array())
+	})
+
+	t.Run("[]byte", func(t *testing.T) {
+		t.Parallel()
+
+		s := WebhookEventTypes{}
+		assert.NoError(t, s.Scan([]byte("")))
+		assert.Empty(t, s)
+	})
+
+	t.Run("other", func(t *testing.T) {
+		t.Parallel()
+
+		s := WebhookEventTypes{}
+		assert.Error(t, s.Scan(123))
+	})
+}
+
+func TestWebhookEventTypes_JSON(t *testing.T) {
+	t.Parallel()
+	es := WebhookEventTypes{WebhookEventRelease: struct{}{}, WebhookEventIssue: struct{}{}}
+	b, err := es.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, `["issue","release"]`, string(b))
+
+	var es2 WebhookEventTypes
+	assert.NoError(t, es2.UnmarshalJSON(b))
+	assert.Equal(t, es, es2)
+}
@@ -19,6 +19,7 @@ type UpdateBotArgs struct {
 	Privileged      optional.Bool
 	CreatorID       optional.UUID
 	SubscribeEvents model.BotEventTypes
+	EventFilter     *model.BotEventFilter
 }
 
 // BotsQuery Bot情報取得用クエリ
@@ -173,4 +174,33 @@ type BotRepository interface {
 	// 成功した場合、nilを返します。
 	// DBによるエラーを返すことがあります。
 	PurgeBotEventLogs(before time.Time) error
+	// CreateBotEventDeadLetter 再送を諦めたBotイベントを保存します
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	CreateBotEventDeadLetter(letter *model.BotEventDeadLetter) error
+	// GetBotEventDeadLetter 指定したIDの再送を諦めたBotイベントを取得します
+	//
+	// 成功した場合、デッドレターとnilを返します。
+	// 存在しなかった場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetBotEventDeadLetter(id uuid.UUID) (*model.BotEventDeadLetter, error)
+	// GetBotEventDeadLetters 指定したBotの再送を諦めたイベントを新しい順に取得します
+	//
+	// 成功した場合、デッドレターの配列とnilを返します。負のoffset, limitは無視されます。
+	// 存在しないBotを指定した場合、空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetBotEventDeadLetters(botID uuid.UUID, limit, offset int) ([]*model.BotEventDeadLetter, error)
+	// DeleteBotEventDeadLetter 指定したIDの再送を諦めたBotイベントを削除します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しなかった場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteBotEventDeadLetter(id uuid.UUID) error
+	// PurgeBotEventDeadLetters 指定した時間以前の再送を諦めたBotイベントを全て消去します
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	PurgeBotEventDeadLetters(before time.Time) error
 }
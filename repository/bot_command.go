@@ -0,0 +1,57 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// BotCommandsQuery スラッシュコマンド取得用クエリ
+type BotCommandsQuery struct {
+	BotID       optional.UUID
+	Name        optional.String
+	IsActive    optional.Bool
+	IsCMemberOf optional.UUID
+}
+
+// Bot 指定したBotのコマンドである
+func (q BotCommandsQuery) Bot(botID uuid.UUID) BotCommandsQuery {
+	q.BotID = optional.UUIDFrom(botID)
+	return q
+}
+
+// Named 指定した名前のコマンドである
+func (q BotCommandsQuery) Named(name string) BotCommandsQuery {
+	q.Name = optional.StringFrom(name)
+	return q
+}
+
+// Active 有効なBotのコマンドである
+func (q BotCommandsQuery) Active() BotCommandsQuery {
+	q.IsActive = optional.BoolFrom(true)
+	return q
+}
+
+// CMemberOf channelIDに入っているBotのコマンドである
+func (q BotCommandsQuery) CMemberOf(channelID uuid.UUID) BotCommandsQuery {
+	q.IsCMemberOf = optional.UUIDFrom(channelID)
+	return q
+}
+
+// BotCommandRepository Botのスラッシュコマンドリポジトリ
+type BotCommandRepository interface {
+	// SetBotCommands 指定したBotのスラッシュコマンドを全て置き換えます
+	//
+	// 成功した場合、nilを返します。
+	// コマンド名はBot毎に一意です。同名のコマンドが含まれている場合、ErrAlreadyExistsを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	SetBotCommands(botID uuid.UUID, commands []*model.BotCommand) error
+	// GetBotCommands 指定した条件に一致するスラッシュコマンドを名前順に取得します
+	//
+	// 成功した場合、コマンドの配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetBotCommands(query BotCommandsQuery) ([]*model.BotCommand, error)
+}
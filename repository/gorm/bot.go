@@ -16,6 +16,9 @@ import (
 	"github.com/traPtitech/traQ/utils/random"
 )
 
+// botSigningSecretLength Botイベント署名用シークレットの長さ
+const botSigningSecretLength = 40
+
 // CreateBot implements BotRepository interface.
 func (repo *Repository) CreateBot(name, displayName, description string, iconFileID, creatorID uuid.UUID, mode model.BotMode, state model.BotState, webhookURL string) (*model.Bot, error) {
 	uid := uuid.Must(uuid.NewV4())
@@ -36,6 +39,7 @@ func (repo *Repository) CreateBot(name, displayName, description string, iconFil
 		BotUserID:         uid,
 		Description:       description,
 		VerificationToken: random.SecureAlphaNumeric(30),
+		SigningSecret:     random.SecureAlphaNumeric(botSigningSecretLength),
 		PostURL:           webhookURL,
 		AccessTokenID:     tid,
 		SubscribeEvents:   model.BotEventTypes{},
@@ -126,6 +130,9 @@ func (repo *Repository) UpdateBot(id uuid.UUID, args repository.UpdateBotArgs) e
 		if args.SubscribeEvents != nil {
 			changes["subscribe_events"] = args.SubscribeEvents
 		}
+		if args.EventFilter != nil {
+			changes["event_filter"] = *args.EventFilter
+		}
 
 		if len(changes) > 0 {
 			if err := tx.Model(&b).Updates(changes).Error; err != nil {
@@ -294,6 +301,7 @@ func (repo *Repository) ReissueBotTokens(id uuid.UUID) (*model.Bot, error) {
 		}
 		bot.BotCode = random.AlphaNumeric(30)
 		bot.VerificationToken = random.SecureAlphaNumeric(30)
+		bot.SigningSecret = random.SecureAlphaNumeric(botSigningSecretLength)
 
 		if err := tx.Delete(&model.OAuth2Token{ID: bot.AccessTokenID}).Error; err != nil {
 			return err
@@ -356,6 +364,9 @@ func (repo *Repository) DeleteBot(id uuid.UUID) error {
 		if err := tx.Delete(&model.BotJoinChannel{}, &model.BotJoinChannel{BotID: id}).Error; err != nil {
 			return err
 		}
+		if err := tx.Delete(&model.BotCommand{}, &model.BotCommand{BotID: id}).Error; err != nil {
+			return err
+		}
 		if err := tx.Delete(&model.OAuth2Token{}, &model.OAuth2Token{ID: b.AccessTokenID}).Error; err != nil {
 			return err
 		}
@@ -451,3 +462,56 @@ func (repo *Repository) GetBotEventLogs(botID uuid.UUID, limit, offset int) ([]*
 func (repo *Repository) PurgeBotEventLogs(before time.Time) error {
 	return repo.db.Delete(&model.BotEventLog{}, "date_time < ?", before).Error
 }
+
+// CreateBotEventDeadLetter implements BotRepository interface.
+func (repo *Repository) CreateBotEventDeadLetter(letter *model.BotEventDeadLetter) error {
+	if letter.ID == uuid.Nil {
+		letter.ID = uuid.Must(uuid.NewV4())
+	}
+	return repo.db.Create(letter).Error
+}
+
+// GetBotEventDeadLetter implements BotRepository interface.
+func (repo *Repository) GetBotEventDeadLetter(id uuid.UUID) (*model.BotEventDeadLetter, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var letter model.BotEventDeadLetter
+	if err := repo.db.First(&letter, &model.BotEventDeadLetter{ID: id}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &letter, nil
+}
+
+// GetBotEventDeadLetters implements BotRepository interface.
+func (repo *Repository) GetBotEventDeadLetters(botID uuid.UUID, limit, offset int) ([]*model.BotEventDeadLetter, error) {
+	letters := make([]*model.BotEventDeadLetter, 0)
+	if botID == uuid.Nil {
+		return letters, nil
+	}
+	return letters, repo.db.Where(&model.BotEventDeadLetter{BotID: botID}).
+		Order("created_at DESC").
+		Scopes(gormUtil.LimitAndOffset(limit, offset)).
+		Find(&letters).
+		Error
+}
+
+// DeleteBotEventDeadLetter implements BotRepository interface.
+func (repo *Repository) DeleteBotEventDeadLetter(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.Delete(&model.BotEventDeadLetter{ID: id})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// PurgeBotEventDeadLetters implements BotRepository interface.
+func (repo *Repository) PurgeBotEventDeadLetters(before time.Time) error {
+	return repo.db.Delete(&model.BotEventDeadLetter{}, "created_at < ?", before).Error
+}
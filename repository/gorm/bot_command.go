@@ -0,0 +1,61 @@
+package gorm
+
+import (
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormUtil"
+)
+
+// SetBotCommands implements BotCommandRepository interface.
+func (repo *Repository) SetBotCommands(botID uuid.UUID, commands []*model.BotCommand) error {
+	if botID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.BotCommand{}, &model.BotCommand{BotID: botID}).Error; err != nil {
+			return err
+		}
+		for _, c := range commands {
+			if c.ID == uuid.Nil {
+				c.ID = uuid.Must(uuid.NewV4())
+			}
+			c.BotID = botID
+			if err := tx.Create(c).Error; err != nil {
+				if gormUtil.IsMySQLDuplicatedRecordErr(err) {
+					return repository.ErrAlreadyExists
+				}
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetBotCommands implements BotCommandRepository interface.
+func (repo *Repository) GetBotCommands(query repository.BotCommandsQuery) ([]*model.BotCommand, error) {
+	commands := make([]*model.BotCommand, 0)
+	tx := repo.db.Table("bot_commands").Select("bot_commands.*")
+
+	if query.BotID.Valid {
+		tx = tx.Where("bot_commands.bot_id = ?", query.BotID.UUID)
+	}
+	if query.Name.Valid {
+		tx = tx.Where("bot_commands.name = ?", query.Name.String)
+	}
+	if query.IsActive.Valid {
+		tx = tx.Joins("INNER JOIN bots ON bots.id = bot_commands.bot_id AND bots.deleted_at IS NULL")
+		if query.IsActive.Bool {
+			tx = tx.Where("bots.state = ?", model.BotActive)
+		} else {
+			tx = tx.Where("bots.state != ?", model.BotActive)
+		}
+	}
+	if query.IsCMemberOf.Valid {
+		tx = tx.Joins("INNER JOIN bot_join_channels ON bot_join_channels.bot_id = bot_commands.bot_id AND bot_join_channels.channel_id = ?", query.IsCMemberOf.UUID)
+	}
+
+	return commands, tx.Order("bot_commands.name").Find(&commands).Error
+}
@@ -0,0 +1,146 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/random"
+)
+
+func TestRepositoryImpl_SetBotCommands(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user := setupWithUser(t, common3)
+
+	bot1 := mustMakeBot(t, repo, rand, user.GetID(), model.BotActive)
+	bot2 := mustMakeBot(t, repo, rand, user.GetID(), model.BotActive)
+	name1 := random.AlphaNumeric(20)
+	name2 := random.AlphaNumeric(20)
+
+	assert.EqualError(repo.SetBotCommands(uuid.Nil, nil), repository.ErrNilID.Error())
+
+	require.NoError(repo.SetBotCommands(bot1.ID, []*model.BotCommand{
+		{Name: name2, Description: "desc2"},
+		{Name: name1, Description: "desc1", Arguments: model.BotCommandArguments{
+			{Name: "count", Type: model.BotCommandArgumentInteger, Required: true},
+		}},
+	}))
+	commands, err := repo.GetBotCommands(repository.BotCommandsQuery{}.Bot(bot1.ID))
+	require.NoError(err)
+	if assert.Len(commands, 2) {
+		if commands[0].Name != name1 {
+			commands[0], commands[1] = commands[1], commands[0]
+		}
+		assert.NotEqual(uuid.Nil, commands[0].ID)
+		assert.Equal(bot1.ID, commands[0].BotID)
+		assert.Equal(name1, commands[0].Name)
+		assert.Equal("desc1", commands[0].Description)
+		if assert.Len(commands[0].Arguments, 1) {
+			assert.Equal("count", commands[0].Arguments[0].Name)
+			assert.Equal(model.BotCommandArgumentInteger, commands[0].Arguments[0].Type)
+			assert.True(commands[0].Arguments[0].Required)
+		}
+		assert.Equal(name2, commands[1].Name)
+		assert.Len(commands[1].Arguments, 0)
+	}
+
+	// 同じBotで同名のコマンドは登録できず、既存のコマンドは変わらない
+	assert.EqualError(repo.SetBotCommands(bot1.ID, []*model.BotCommand{{Name: name1}, {Name: name1}}), repository.ErrAlreadyExists.Error())
+	commands, err = repo.GetBotCommands(repository.BotCommandsQuery{}.Bot(bot1.ID))
+	require.NoError(err)
+	assert.Len(commands, 2)
+
+	// 他のBotと同名のコマンドは登録できる
+	require.NoError(repo.SetBotCommands(bot2.ID, []*model.BotCommand{{Name: name1}}))
+	commands, err = repo.GetBotCommands(repository.BotCommandsQuery{}.Named(name1))
+	require.NoError(err)
+	assert.Len(commands, 2)
+
+	// 全て置き換え
+	require.NoError(repo.SetBotCommands(bot1.ID, []*model.BotCommand{{Name: name1}}))
+	commands, err = repo.GetBotCommands(repository.BotCommandsQuery{}.Bot(bot1.ID))
+	require.NoError(err)
+	if assert.Len(commands, 1) {
+		assert.Equal(name1, commands[0].Name)
+		assert.Equal("", commands[0].Description)
+	}
+
+	// 空にすると全て削除
+	require.NoError(repo.SetBotCommands(bot1.ID, []*model.BotCommand{}))
+	commands, err = repo.GetBotCommands(repository.BotCommandsQuery{}.Bot(bot1.ID))
+	require.NoError(err)
+	assert.Len(commands, 0)
+}
+
+func TestRepositoryImpl_GetBotCommands(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+
+	activeBot := mustMakeBot(t, repo, rand, user.GetID(), model.BotActive)
+	inactiveBot := mustMakeBot(t, repo, rand, user.GetID(), model.BotInactive)
+	notJoinedBot := mustMakeBot(t, repo, rand, user.GetID(), model.BotActive)
+	require.NoError(repo.AddBotToChannel(activeBot.ID, channel.ID))
+	require.NoError(repo.AddBotToChannel(inactiveBot.ID, channel.ID))
+
+	mustSet := func(botID uuid.UUID) *model.BotCommand {
+		c := &model.BotCommand{Name: random.AlphaNumeric(20)}
+		require.NoError(repo.SetBotCommands(botID, []*model.BotCommand{c}))
+		return c
+	}
+	activeCmd := mustSet(activeBot.ID)
+	inactiveCmd := mustSet(inactiveBot.ID)
+	notJoinedCmd := mustSet(notJoinedBot.ID)
+
+	ids := func(commands []*model.BotCommand) []uuid.UUID {
+		res := make([]uuid.UUID, len(commands))
+		for i, c := range commands {
+			res[i] = c.ID
+		}
+		return res
+	}
+
+	t.Run("Bot", func(t *testing.T) {
+		t.Parallel()
+		commands, err := repo.GetBotCommands(repository.BotCommandsQuery{}.Bot(inactiveBot.ID))
+		if assert.NoError(err) {
+			assert.ElementsMatch([]uuid.UUID{inactiveCmd.ID}, ids(commands))
+		}
+	})
+
+	t.Run("CMemberOf", func(t *testing.T) {
+		t.Parallel()
+		commands, err := repo.GetBotCommands(repository.BotCommandsQuery{}.CMemberOf(channel.ID))
+		if assert.NoError(err) {
+			assert.ElementsMatch([]uuid.UUID{activeCmd.ID, inactiveCmd.ID}, ids(commands))
+		}
+	})
+
+	t.Run("Active CMemberOf", func(t *testing.T) {
+		t.Parallel()
+		commands, err := repo.GetBotCommands(repository.BotCommandsQuery{}.Active().CMemberOf(channel.ID))
+		if assert.NoError(err) {
+			assert.ElementsMatch([]uuid.UUID{activeCmd.ID}, ids(commands))
+		}
+	})
+
+	t.Run("Named", func(t *testing.T) {
+		t.Parallel()
+		commands, err := repo.GetBotCommands(repository.BotCommandsQuery{}.Named(activeCmd.Name))
+		if assert.NoError(err) {
+			assert.ElementsMatch([]uuid.UUID{activeCmd.ID}, ids(commands))
+		}
+	})
+
+	t.Run("Active", func(t *testing.T) {
+		t.Parallel()
+		commands, err := repo.GetBotCommands(repository.BotCommandsQuery{}.Active())
+		if assert.NoError(err) {
+			got := ids(commands)
+			assert.Contains(got, activeCmd.ID)
+			assert.Contains(got, notJoinedCmd.ID)
+			assert.NotContains(got, inactiveCmd.ID)
+		}
+	})
+}
@@ -95,11 +95,12 @@ func (repo *Repository) UpdateChannel(channelID uuid.UUID, args repository.Updat
 		return nil, repository.ErrNilID
 	}
 
-	var ch model.Channel
+	var ch, old model.Channel
 	err := repo.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.First(&ch, &model.Channel{ID: channelID}).Error; err != nil {
 			return convertError(err)
 		}
+		old = ch
 
 		data := map[string]interface{}{"updater_id": args.UpdaterID}
 		if args.Topic.Valid {
@@ -147,6 +148,40 @@ func (repo *Repository) UpdateChannel(channelID uuid.UUID, args repository.Updat
 			},
 		})
 	}
+	if args.Name.Valid && old.Name != ch.Name {
+		repo.hub.Publish(hub.Message{
+			Name: event.ChannelNameUpdated,
+			Fields: hub.Fields{
+				"channel_id": channelID,
+				"old_name":   old.Name,
+				"name":       ch.Name,
+				"updater_id": args.UpdaterID,
+			},
+		})
+	}
+	if args.Parent.Valid && old.ParentID != ch.ParentID {
+		repo.hub.Publish(hub.Message{
+			Name: event.ChannelParentUpdated,
+			Fields: hub.Fields{
+				"channel_id":    channelID,
+				"old_parent_id": old.ParentID,
+				"parent_id":     ch.ParentID,
+				"updater_id":    args.UpdaterID,
+			},
+		})
+	}
+	if args.Visibility.Valid && old.IsVisible != ch.IsVisible {
+		name := event.ChannelUnarchived
+		if !ch.IsVisible {
+			name = event.ChannelArchived
+		}
+		repo.hub.Publish(hub.Message{
+			Name: name,
+			Fields: hub.Fields{
+				"channel_id": channelID,
+			},
+		})
+	}
 	return &ch, nil
 }
 
@@ -185,6 +220,12 @@ func (repo *Repository) ArchiveChannels(ids []uuid.UUID) ([]*model.Channel, erro
 				"private":    !ch.IsPublic,
 			},
 		})
+		repo.hub.Publish(hub.Message{
+			Name: event.ChannelArchived,
+			Fields: hub.Fields{
+				"channel_id": ch.ID,
+			},
+		})
 	}
 	return changed, nil
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/utils/message"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 // CreateMessage implements MessageRepository interface.
@@ -20,18 +21,44 @@ func (repo *Repository) CreateMessage(userID, channelID uuid.UUID, text string)
 	if userID == uuid.Nil || channelID == uuid.Nil {
 		return nil, repository.ErrNilID
 	}
+	return repo.createMessage(userID, channelID, optional.UUID{}, text, nil)
+}
+
+// CreateThreadMessage implements MessageRepository interface.
+func (repo *Repository) CreateThreadMessage(userID, channelID, threadID uuid.UUID, text string) (*model.Message, error) {
+	if userID == uuid.Nil || channelID == uuid.Nil || threadID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+	return repo.createMessage(userID, channelID, optional.UUIDFrom(threadID), text, nil)
+}
+
+// CreateMessageWithActions implements MessageRepository interface.
+func (repo *Repository) CreateMessageWithActions(userID, channelID uuid.UUID, threadID optional.UUID, text string, actions model.MessageActions) (*model.Message, error) {
+	if userID == uuid.Nil || channelID == uuid.Nil || (threadID.Valid && threadID.UUID == uuid.Nil) {
+		return nil, repository.ErrNilID
+	}
+	return repo.createMessage(userID, channelID, threadID, text, actions)
+}
 
+func (repo *Repository) createMessage(userID, channelID uuid.UUID, threadID optional.UUID, text string, actions model.MessageActions) (*model.Message, error) {
 	m := &model.Message{
 		ID:        uuid.Must(uuid.NewV4()),
 		UserID:    userID,
 		ChannelID: channelID,
 		Text:      text,
+		ThreadID:  threadID,
 		Stamps:    []model.MessageStamp{},
 	}
 	err := repo.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(m).Error; err != nil {
 			return err
 		}
+		if len(actions) > 0 {
+			m.Attachment = &model.MessageAttachment{MessageID: m.ID, Actions: actions}
+			if err := tx.Create(m.Attachment).Error; err != nil {
+				return err
+			}
+		}
 
 		clm := &model.ChannelLatestMessage{
 			ChannelID: m.ChannelID,
@@ -75,7 +102,19 @@ func (repo *Repository) UpdateMessage(messageID uuid.UUID, text string) error {
 	if messageID == uuid.Nil {
 		return repository.ErrNilID
 	}
+	return repo.updateMessage(messageID, text, nil, false)
+}
 
+// UpdateMessageWithActions implements MessageRepository interface.
+func (repo *Repository) UpdateMessageWithActions(messageID uuid.UUID, text string, actions model.MessageActions) error {
+	if messageID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.updateMessage(messageID, text, actions, true)
+}
+
+// updateMessage メッセージの本文を更新します setActionsがtrueの場合はアクションも置き換えます
+func (repo *Repository) updateMessage(messageID uuid.UUID, text string, actions model.MessageActions, setActions bool) error {
 	var (
 		old model.Message
 		new model.Message
@@ -100,8 +139,13 @@ func (repo *Repository) UpdateMessage(messageID uuid.UUID, text string) error {
 		if err := tx.Model(&old).Update("text", text).Error; err != nil {
 			return err
 		}
+		if setActions {
+			if err := setMessageActions(tx, messageID, actions); err != nil {
+				return err
+			}
+		}
 
-		return tx.Where(&model.Message{ID: messageID}).First(&new).Error
+		return tx.Preload("Attachment").Where(&model.Message{ID: messageID}).First(&new).Error
 	})
 	if err != nil {
 		return err
@@ -117,6 +161,47 @@ func (repo *Repository) UpdateMessage(messageID uuid.UUID, text string) error {
 	return nil
 }
 
+// HideMessage implements MessageRepository interface.
+func (repo *Repository) HideMessage(messageID uuid.UUID) error {
+	if messageID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	var m model.Message
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&m, &model.Message{ID: messageID}).Error; err != nil {
+			return convertError(err)
+		}
+		// 非表示化は編集ではないので、updated_atは更新しない
+		if err := tx.Model(&m).UpdateColumn("hidden", true).Error; err != nil {
+			return err
+		}
+		m.Hidden = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	repo.hub.Publish(hub.Message{
+		Name: event.MessageHidden,
+		Fields: hub.Fields{
+			"message_id": messageID,
+			"message":    &m,
+		},
+	})
+	return nil
+}
+
+// setMessageActions メッセージのアクションを置き換えます actionsが空の場合は削除します
+func setMessageActions(tx *gorm.DB, messageID uuid.UUID, actions model.MessageActions) error {
+	if len(actions) == 0 {
+		return tx.Delete(&model.MessageAttachment{}, &model.MessageAttachment{MessageID: messageID}).Error
+	}
+	return tx.
+		Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"actions", "updated_at"})}).
+		Create(&model.MessageAttachment{MessageID: messageID, Actions: actions}).
+		Error
+}
+
 // DeleteMessage implements MessageRepository interface.
 func (repo *Repository) DeleteMessage(messageID uuid.UUID) error {
 	if messageID == uuid.Nil {
@@ -207,6 +292,9 @@ func (repo *Repository) GetMessages(query repository.MessagesQuery) (messages []
 	if query.User != uuid.Nil {
 		tx = tx.Where("messages.user_id = ?", query.User)
 	}
+	if query.Thread != uuid.Nil {
+		tx = tx.Where("messages.thread_id = ?", query.Thread)
+	}
 	if query.ChannelsSubscribedByUser != uuid.Nil {
 		tx = tx.Where("channels.is_forced = TRUE OR channels.id IN (SELECT s.channel_id FROM users_subscribe_channels s WHERE s.user_id = ?)", query.ChannelsSubscribedByUser)
 	}
@@ -270,6 +358,33 @@ func (repo *Repository) GetDeletedMessagesAfter(after time.Time, limit int) (mes
 	return
 }
 
+// GetThreadFollowerIDs implements MessageRepository interface.
+func (repo *Repository) GetThreadFollowerIDs(threadID uuid.UUID) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0)
+	if threadID == uuid.Nil {
+		return ids, nil
+	}
+	return ids, repo.db.
+		Model(&model.Message{}).
+		Distinct("user_id").
+		Where("id = ? OR thread_id = ?", threadID, threadID).
+		Pluck("user_id", &ids).
+		Error
+}
+
+// GetArchivedMessagesByID implements MessageRepository interface.
+func (repo *Repository) GetArchivedMessagesByID(messageID uuid.UUID) ([]*model.ArchivedMessage, error) {
+	ams := make([]*model.ArchivedMessage, 0)
+	if messageID == uuid.Nil {
+		return ams, nil
+	}
+	return ams, repo.db.
+		Where(&model.ArchivedMessage{MessageID: messageID}).
+		Order("date_time").
+		Find(&ams).
+		Error
+}
+
 // SetMessageUnread implements MessageRepository interface.
 func (repo *Repository) SetMessageUnread(userID, messageID uuid.UUID, noticeable bool) error {
 	if userID == uuid.Nil || messageID == uuid.Nil {
@@ -355,7 +470,7 @@ func (repo *Repository) GetChannelLatestMessages(query repository.ChannelLatestM
 
 	tx := repo.db.
 		Unscoped().
-		Select("m.id, m.user_id, m.channel_id, m.text, m.created_at, m.updated_at, m.deleted_at").
+		Select("m.id, m.user_id, m.channel_id, m.text, m.thread_id, m.created_at, m.updated_at, m.deleted_at").
 		Table("channel_latest_messages clm").
 		Joins("INNER JOIN messages m ON clm.message_id = m.id").
 		Joins("INNER JOIN channels c ON clm.channel_id = c.id").
@@ -439,5 +554,6 @@ func (repo *Repository) RemoveStampFromMessage(messageID, stampID, userID uuid.U
 func messagePreloads(db *gorm.DB) *gorm.DB {
 	return db.
 		Preload("Stamps").
-		Preload("Pin")
+		Preload("Pin").
+		Preload("Attachment")
 }
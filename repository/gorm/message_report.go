@@ -1,18 +1,22 @@
 package gorm
 
 import (
+	"time"
+
 	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
 
+	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/utils/gormUtil"
 )
 
 // CreateMessageReport implements MessageReportRepository interface.
-func (repo *Repository) CreateMessageReport(messageID, reporterID uuid.UUID, reason string) error {
+func (repo *Repository) CreateMessageReport(messageID, reporterID uuid.UUID, reason string) (*model.MessageReport, error) {
 	// nil check
 	if messageID == uuid.Nil || reporterID == uuid.Nil {
-		return repository.ErrNilID
+		return nil, repository.ErrNilID
 	}
 
 	// make report
@@ -21,20 +25,45 @@ func (repo *Repository) CreateMessageReport(messageID, reporterID uuid.UUID, rea
 		MessageID: messageID,
 		Reporter:  reporterID,
 		Reason:    reason,
+		State:     model.MessageReportStateOpen,
 	}
 	if err := repo.db.Create(r).Error; err != nil {
 		if gormUtil.IsMySQLDuplicatedRecordErr(err) {
-			return repository.ErrAlreadyExists
+			return nil, repository.ErrAlreadyExists
 		}
-		return err
+		return nil, err
 	}
-	return nil
+	repo.hub.Publish(hub.Message{
+		Name: event.MessageReported,
+		Fields: hub.Fields{
+			"report_id":  r.ID,
+			"message_id": r.MessageID,
+			"report":     r,
+		},
+	})
+	return r, nil
+}
+
+// GetMessageReport implements MessageReportRepository interface.
+func (repo *Repository) GetMessageReport(reportID uuid.UUID) (*model.MessageReport, error) {
+	if reportID == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var r model.MessageReport
+	if err := repo.db.First(&r, &model.MessageReport{ID: reportID}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &r, nil
 }
 
 // GetMessageReports implements MessageReportRepository interface.
-func (repo *Repository) GetMessageReports(offset, limit int) (arr []*model.MessageReport, err error) {
+func (repo *Repository) GetMessageReports(query repository.MessageReportsQuery) (arr []*model.MessageReport, err error) {
 	arr = make([]*model.MessageReport, 0)
-	err = repo.db.Scopes(gormUtil.LimitAndOffset(limit, offset)).Order("created_at").Find(&arr).Error
+	tx := repo.db.Scopes(gormUtil.LimitAndOffset(query.Limit, query.Offset)).Order("created_at")
+	if query.State.Valid {
+		tx = tx.Where(&model.MessageReport{State: query.State.String})
+	}
+	err = tx.Find(&arr).Error
 	return arr, err
 }
 
@@ -57,3 +86,31 @@ func (repo *Repository) GetMessageReportsByReporterID(reporterID uuid.UUID) (arr
 	err = repo.db.Where(&model.MessageReport{Reporter: reporterID}).Order("created_at").Find(&arr).Error
 	return arr, err
 }
+
+// HandleMessageReport implements MessageReportRepository interface.
+func (repo *Repository) HandleMessageReport(reportID, handlerID uuid.UUID, state, action string) error {
+	if reportID == uuid.Nil || handlerID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	// 同時に対応された場合に1つだけが成功するよう、未対応の場合のみ更新する
+	result := repo.db.
+		Model(&model.MessageReport{}).
+		Where("id = ? AND state = ?", reportID, model.MessageReportStateOpen).
+		Updates(map[string]interface{}{
+			"state":      state,
+			"action":     action,
+			"handler_id": handlerID,
+			"handled_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		var r model.MessageReport
+		if err := repo.db.First(&r, &model.MessageReport{ID: reportID}).Error; err != nil {
+			return convertError(err)
+		}
+		return repository.ErrForbidden
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/utils/optional"
@@ -74,6 +75,111 @@ func TestRepositoryImpl_CreateMessage(t *testing.T) {
 	})
 }
 
+func TestRepositoryImpl_CreateThreadMessage(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+	root := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+	t.Run("failures", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.CreateThreadMessage(user.GetID(), channel.ID, uuid.Nil, "a")
+		assert.Error(t, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+
+		m, err := repo.CreateThreadMessage(user.GetID(), channel.ID, root.ID, "test")
+		if assert.NoError(err) {
+			assert.NotZero(m.ID)
+			assert.Equal(user.GetID(), m.UserID)
+			assert.Equal(channel.ID, m.ChannelID)
+			assert.Equal("test", m.Text)
+			assert.Equal(optional.UUIDFrom(root.ID), m.ThreadID)
+		}
+
+		messages, _, err := repo.GetMessages(repository.MessagesQuery{Thread: root.ID})
+		if assert.NoError(err) && assert.Len(messages, 1) {
+			assert.Equal(m.ID, messages[0].ID)
+		}
+	})
+}
+
+func TestRepositoryImpl_CreateMessageWithActions(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+	actions := model.MessageActions{{ID: "ok", Type: model.MessageActionButton, Label: "OK"}}
+
+	_, err := repo.CreateMessageWithActions(uuid.Nil, channel.ID, optional.UUID{}, "a", actions)
+	assert.EqualError(err, repository.ErrNilID.Error())
+	_, err = repo.CreateMessageWithActions(user.GetID(), channel.ID, optional.UUIDFrom(uuid.Nil), "a", actions)
+	assert.EqualError(err, repository.ErrNilID.Error())
+
+	sub := getHub(repo).Subscribe(10, event.MessageCreated)
+	defer getHub(repo).Unsubscribe(sub)
+
+	m, err := repo.CreateMessageWithActions(user.GetID(), channel.ID, optional.UUID{}, "with actions", actions)
+	require.NoError(err)
+	if assert.NotNil(m.Attachment) {
+		assert.Equal(actions, m.Attachment.Actions)
+	}
+
+	// 作成イベントのメッセージにアクションが含まれる
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case ev := <-sub.Receiver:
+			if ev.Fields["message_id"].(uuid.UUID) != m.ID {
+				continue
+			}
+			em := ev.Fields["message"].(*model.Message)
+			if assert.NotNil(em.Attachment) {
+				assert.Equal(actions, em.Attachment.Actions)
+			}
+			done = true
+		case <-timeout:
+			t.Fatal("MessageCreated event was not published")
+		}
+	}
+
+	got, err := repo.GetMessageByID(m.ID)
+	if assert.NoError(err) && assert.NotNil(got.Attachment) {
+		assert.Equal(actions, got.Attachment.Actions)
+	}
+
+	reply, err := repo.CreateMessageWithActions(user.GetID(), channel.ID, optional.UUIDFrom(m.ID), "reply", actions)
+	if assert.NoError(err) {
+		assert.Equal(optional.UUIDFrom(m.ID), reply.ThreadID)
+		assert.NotNil(reply.Attachment)
+	}
+}
+
+func TestRepositoryImpl_GetThreadFollowerIDs(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+	user2 := mustMakeUser(t, repo, rand)
+	user3 := mustMakeUser(t, repo, rand)
+	root := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	mustMakeMessage(t, repo, user3.GetID(), channel.ID)
+
+	for i := 0; i < 2; i++ {
+		_, err := repo.CreateThreadMessage(user2.GetID(), channel.ID, root.ID, "reply")
+		require.NoError(err)
+	}
+
+	ids, err := repo.GetThreadFollowerIDs(root.ID)
+	if assert.NoError(err) {
+		assert.ElementsMatch([]uuid.UUID{user.GetID(), user2.GetID()}, ids)
+	}
+
+	ids, err = repo.GetThreadFollowerIDs(uuid.Nil)
+	if assert.NoError(err) {
+		assert.Empty(ids)
+	}
+}
+
 func TestRepositoryImpl_UpdateMessage(t *testing.T) {
 	t.Parallel()
 	repo, assert, _, user, channel := setupWithUserAndChannel(t, common3)
@@ -92,6 +198,63 @@ func TestRepositoryImpl_UpdateMessage(t *testing.T) {
 	}
 }
 
+func TestRepositoryImpl_UpdateMessageWithActions(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+	actions := model.MessageActions{{ID: "ok", Type: model.MessageActionButton, Label: "OK"}}
+
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+	assert.EqualError(repo.UpdateMessageWithActions(uuid.Must(uuid.NewV4()), "new message", actions), repository.ErrNotFound.Error())
+	assert.EqualError(repo.UpdateMessageWithActions(uuid.Nil, "new message", actions), repository.ErrNilID.Error())
+
+	require.NoError(repo.UpdateMessageWithActions(m.ID, "new message", actions))
+	got, err := repo.GetMessageByID(m.ID)
+	if assert.NoError(err) {
+		assert.Equal("new message", got.Text)
+		if assert.NotNil(got.Attachment) {
+			assert.Equal(actions, got.Attachment.Actions)
+		}
+	}
+
+	// 本文のみの更新ではアクションは変わらない
+	require.NoError(repo.UpdateMessage(m.ID, "text only"))
+	got, err = repo.GetMessageByID(m.ID)
+	if assert.NoError(err) && assert.NotNil(got.Attachment) {
+		assert.Equal(actions, got.Attachment.Actions)
+	}
+
+	// 空にするとアクションが削除される
+	require.NoError(repo.UpdateMessageWithActions(m.ID, "no actions", model.MessageActions{}))
+	got, err = repo.GetMessageByID(m.ID)
+	if assert.NoError(err) {
+		assert.Equal("no actions", got.Text)
+		assert.Nil(got.Attachment)
+	}
+}
+
+func TestRepositoryImpl_GetArchivedMessagesByID(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	originalText := m.Text
+	require.NoError(repo.UpdateMessage(m.ID, "edited 1"))
+	require.NoError(repo.UpdateMessage(m.ID, "edited 2"))
+
+	ams, err := repo.GetArchivedMessagesByID(m.ID)
+	if assert.NoError(err) && assert.Len(ams, 2) {
+		assert.Equal(originalText, ams[0].Text)
+		assert.Equal("edited 1", ams[1].Text)
+		assert.False(ams[1].DateTime.Before(ams[0].DateTime))
+	}
+
+	ams, err = repo.GetArchivedMessagesByID(uuid.Nil)
+	if assert.NoError(err) {
+		assert.Empty(ams)
+	}
+}
+
 func TestRepositoryImpl_DeleteMessage(t *testing.T) {
 	t.Parallel()
 	repo, assert, _, user, channel := setupWithUserAndChannel(t, common3)
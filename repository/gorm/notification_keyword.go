@@ -0,0 +1,90 @@
+package gorm
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/motoki317/sc"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+func makeNotificationKeywordCache(db *gorm.DB) *sc.Cache[struct{}, map[uuid.UUID][]string] {
+	// Lazy load
+	return sc.NewMust(func(_ context.Context, _ struct{}) (map[uuid.UUID][]string, error) {
+		var tmp []*model.NotificationKeyword
+		if err := db.Order("created_at").Find(&tmp).Error; err != nil {
+			return nil, err
+		}
+
+		keywords := make(map[uuid.UUID][]string)
+		for _, k := range tmp {
+			keywords[k.UserID] = append(keywords[k.UserID], k.Keyword)
+		}
+		return keywords, nil
+	}, 365*24*time.Hour, 365*24*time.Hour)
+}
+
+// GetNotificationKeywords implements NotificationKeywordRepository interface.
+func (repo *Repository) GetNotificationKeywords(userID uuid.UUID) ([]string, error) {
+	keywords := make([]string, 0)
+	if userID == uuid.Nil {
+		return keywords, nil
+	}
+	return keywords, repo.db.
+		Model(&model.NotificationKeyword{}).
+		Where(&model.NotificationKeyword{UserID: userID}).
+		Order("created_at").
+		Pluck("keyword", &keywords).
+		Error
+}
+
+// SetNotificationKeywords implements NotificationKeywordRepository interface.
+func (repo *Repository) SetNotificationKeywords(userID uuid.UUID, keywords []string) error {
+	if userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	if len(keywords) > model.MaxNotificationKeywords {
+		return repository.ArgError("keywords", "too many keywords")
+	}
+
+	now := time.Now()
+	records := make([]*model.NotificationKeyword, 0, len(keywords))
+	seen := make(map[string]struct{}, len(keywords))
+	for i, k := range keywords {
+		k = strings.TrimSpace(k)
+		if l := len([]rune(k)); l == 0 || l > model.MaxNotificationKeywordLength {
+			return repository.ArgError("keywords", "invalid keyword length")
+		}
+		if _, ok := seen[strings.ToLower(k)]; ok {
+			continue
+		}
+		seen[strings.ToLower(k)] = struct{}{}
+		// 登録順を保持するために作成日時をずらす
+		records = append(records, &model.NotificationKeyword{UserID: userID, Keyword: k, CreatedAt: now.Add(time.Duration(i) * time.Microsecond)})
+	}
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(&model.NotificationKeyword{UserID: userID}).Delete(&model.NotificationKeyword{}).Error; err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		return tx.Create(&records).Error
+	})
+	if err != nil {
+		return err
+	}
+	repo.keywords.Purge()
+	return nil
+}
+
+// GetAllNotificationKeywords implements NotificationKeywordRepository interface.
+func (repo *Repository) GetAllNotificationKeywords() (map[uuid.UUID][]string, error) {
+	return repo.keywords.Get(context.Background(), struct{}{})
+}
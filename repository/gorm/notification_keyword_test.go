@@ -0,0 +1,86 @@
+package gorm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+func TestRepositoryImpl_SetNotificationKeywords(t *testing.T) {
+	t.Parallel()
+	repo, assert, require := setup(t, common)
+
+	user := mustMakeUser(t, repo, rand)
+
+	assert.EqualError(repo.SetNotificationKeywords(uuid.Nil, []string{"traQ"}), repository.ErrNilID.Error())
+	assert.IsType(&repository.ArgumentError{}, repo.SetNotificationKeywords(user.GetID(), []string{" "}))
+	assert.IsType(&repository.ArgumentError{}, repo.SetNotificationKeywords(user.GetID(), []string{strings.Repeat("a", model.MaxNotificationKeywordLength+1)}))
+	assert.IsType(&repository.ArgumentError{}, repo.SetNotificationKeywords(user.GetID(), make([]string, model.MaxNotificationKeywords+1)))
+
+	if assert.NoError(repo.SetNotificationKeywords(user.GetID(), []string{"traQ", " SysAd ", "TRAQ", "新機能"})) {
+		keywords, err := repo.GetNotificationKeywords(user.GetID())
+		require.NoError(err)
+		assert.Equal([]string{"traQ", "SysAd", "新機能"}, keywords)
+	}
+
+	// 置き換え
+	if assert.NoError(repo.SetNotificationKeywords(user.GetID(), []string{"部内"})) {
+		keywords, err := repo.GetNotificationKeywords(user.GetID())
+		require.NoError(err)
+		assert.Equal([]string{"部内"}, keywords)
+	}
+
+	// 全削除
+	if assert.NoError(repo.SetNotificationKeywords(user.GetID(), nil)) {
+		keywords, err := repo.GetNotificationKeywords(user.GetID())
+		require.NoError(err)
+		assert.Empty(keywords)
+	}
+}
+
+func TestRepositoryImpl_GetNotificationKeywords(t *testing.T) {
+	t.Parallel()
+	repo, assert, _ := setup(t, common)
+
+	user := mustMakeUser(t, repo, rand)
+
+	keywords, err := repo.GetNotificationKeywords(uuid.Nil)
+	if assert.NoError(err) {
+		assert.Empty(keywords)
+	}
+	keywords, err = repo.GetNotificationKeywords(user.GetID())
+	if assert.NoError(err) {
+		assert.Empty(keywords)
+	}
+}
+
+func TestRepositoryImpl_GetAllNotificationKeywords(t *testing.T) {
+	t.Parallel()
+	repo, assert, require := setup(t, common)
+
+	user1 := mustMakeUser(t, repo, rand)
+	user2 := mustMakeUser(t, repo, rand)
+	user3 := mustMakeUser(t, repo, rand)
+	require.NoError(repo.SetNotificationKeywords(user1.GetID(), []string{"traQ", "SysAd"}))
+	require.NoError(repo.SetNotificationKeywords(user2.GetID(), []string{"部内"}))
+
+	keywords, err := repo.GetAllNotificationKeywords()
+	if assert.NoError(err) {
+		assert.Equal([]string{"traQ", "SysAd"}, keywords[user1.GetID()])
+		assert.Equal([]string{"部内"}, keywords[user2.GetID()])
+		assert.NotContains(keywords, user3.GetID())
+	}
+
+	// 更新するとキャッシュが破棄される
+	require.NoError(repo.SetNotificationKeywords(user1.GetID(), []string{"traP"}))
+	require.NoError(repo.SetNotificationKeywords(user2.GetID(), nil))
+	keywords, err = repo.GetAllNotificationKeywords()
+	if assert.NoError(err) {
+		assert.Equal([]string{"traP"}, keywords[user1.GetID()])
+		assert.NotContains(keywords, user2.GetID())
+	}
+}
@@ -0,0 +1,151 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormUtil"
+	"github.com/traPtitech/traQ/utils/random"
+)
+
+const outgoingWebhookSigningSecretLength = 40
+
+// SetOutgoingWebhook implements OutgoingWebhookRepository interface.
+func (repo *Repository) SetOutgoingWebhook(webhookID uuid.UUID, args repository.SetOutgoingWebhookArgs) (*model.OutgoingWebhook, error) {
+	if webhookID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	var ow model.OutgoingWebhook
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Take(&model.WebhookBot{}, &model.WebhookBot{ID: webhookID}).Error; err != nil {
+			return convertError(err)
+		}
+
+		triggerWords := model.OutgoingWebhookTriggerWords(args.TriggerWords)
+		if triggerWords == nil {
+			triggerWords = model.OutgoingWebhookTriggerWords{}
+		}
+
+		err := tx.Take(&ow, &model.OutgoingWebhook{WebhookID: webhookID}).Error
+		switch {
+		case err == nil:
+			changes := map[string]interface{}{
+				"url":           args.URL,
+				"trigger_words": triggerWords,
+				"reply":         args.Reply,
+			}
+			if err := tx.Model(&ow).Updates(changes).Error; err != nil {
+				return err
+			}
+			ow.URL = args.URL
+			ow.TriggerWords = triggerWords
+			ow.Reply = args.Reply
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			ow = model.OutgoingWebhook{
+				WebhookID:     webhookID,
+				URL:           args.URL,
+				TriggerWords:  triggerWords,
+				SigningSecret: random.SecureAlphaNumeric(outgoingWebhookSigningSecretLength),
+				Reply:         args.Reply,
+			}
+			if err := tx.Omit("Channels", "Webhook").Create(&ow).Error; err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+
+		if err := tx.Delete(&model.OutgoingWebhookChannel{}, &model.OutgoingWebhookChannel{WebhookID: webhookID}).Error; err != nil {
+			return err
+		}
+		ow.Channels = make([]*model.OutgoingWebhookChannel, len(args.ChannelIDs))
+		for i, id := range args.ChannelIDs {
+			ow.Channels[i] = &model.OutgoingWebhookChannel{WebhookID: webhookID, ChannelID: id}
+		}
+		if len(ow.Channels) > 0 {
+			if err := tx.Create(&ow.Channels).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ow, nil
+}
+
+// GetOutgoingWebhook implements OutgoingWebhookRepository interface.
+func (repo *Repository) GetOutgoingWebhook(webhookID uuid.UUID) (*model.OutgoingWebhook, error) {
+	if webhookID == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var ow model.OutgoingWebhook
+	if err := repo.db.Preload("Channels").Take(&ow, &model.OutgoingWebhook{WebhookID: webhookID}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &ow, nil
+}
+
+// GetOutgoingWebhooksByChannelID implements OutgoingWebhookRepository interface.
+func (repo *Repository) GetOutgoingWebhooksByChannelID(channelID uuid.UUID) ([]*model.OutgoingWebhook, error) {
+	webhooks := make([]*model.OutgoingWebhook, 0)
+	if channelID == uuid.Nil {
+		return webhooks, nil
+	}
+	return webhooks, repo.db.
+		Select("outgoing_webhooks.*").
+		Preload("Webhook").
+		Joins("INNER JOIN outgoing_webhook_channels ON outgoing_webhook_channels.webhook_id = outgoing_webhooks.webhook_id").
+		Joins("INNER JOIN webhook_bots ON webhook_bots.id = outgoing_webhooks.webhook_id AND webhook_bots.deleted_at IS NULL").
+		Where("outgoing_webhook_channels.channel_id = ?", channelID).
+		Find(&webhooks).
+		Error
+}
+
+// DeleteOutgoingWebhook implements OutgoingWebhookRepository interface.
+func (repo *Repository) DeleteOutgoingWebhook(webhookID uuid.UUID) error {
+	if webhookID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.Delete(&model.OutgoingWebhook{}, &model.OutgoingWebhook{WebhookID: webhookID})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// WriteOutgoingWebhookLog implements OutgoingWebhookRepository interface.
+func (repo *Repository) WriteOutgoingWebhookLog(log *model.OutgoingWebhookLog) error {
+	if log == nil || log.RequestID == uuid.Nil {
+		return nil
+	}
+	return repo.db.Create(log).Error
+}
+
+// GetOutgoingWebhookLogs implements OutgoingWebhookRepository interface.
+func (repo *Repository) GetOutgoingWebhookLogs(webhookID uuid.UUID, limit, offset int) ([]*model.OutgoingWebhookLog, error) {
+	logs := make([]*model.OutgoingWebhookLog, 0)
+	if webhookID == uuid.Nil {
+		return logs, nil
+	}
+	return logs, repo.db.Where(&model.OutgoingWebhookLog{WebhookID: webhookID}).
+		Order("date_time DESC").
+		Scopes(gormUtil.LimitAndOffset(limit, offset)).
+		Find(&logs).
+		Error
+}
+
+// PurgeOutgoingWebhookLogs implements OutgoingWebhookRepository interface.
+func (repo *Repository) PurgeOutgoingWebhookLogs(before time.Time) error {
+	return repo.db.Delete(&model.OutgoingWebhookLog{}, "date_time < ?", before).Error
+}
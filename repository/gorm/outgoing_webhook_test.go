@@ -0,0 +1,145 @@
+package gorm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+func TestRepositoryImpl_SetOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common)
+
+	t.Run("Nil id", func(t *testing.T) {
+		t.Parallel()
+		_, err := repo.SetOutgoingWebhook(uuid.Nil, repository.SetOutgoingWebhookArgs{})
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		t.Parallel()
+		_, err := repo.SetOutgoingWebhook(uuid.Must(uuid.NewV4()), repository.SetOutgoingWebhookArgs{URL: "https://example.com"})
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+		wb := mustMakeWebhook(t, repo, rand, channel.ID, user.GetID(), "")
+		ch2 := mustMakeChannel(t, repo, rand)
+
+		ow, err := repo.SetOutgoingWebhook(wb.GetID(), repository.SetOutgoingWebhookArgs{
+			URL:          "https://example.com",
+			ChannelIDs:   []uuid.UUID{channel.ID},
+			TriggerWords: []string{"!deploy"},
+			Reply:        true,
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(ow.SigningSecret)
+		secret := ow.SigningSecret
+
+		ow, err = repo.SetOutgoingWebhook(wb.GetID(), repository.SetOutgoingWebhookArgs{
+			URL:        "https://example.com/hook",
+			ChannelIDs: []uuid.UUID{ch2.ID},
+		})
+		require.NoError(t, err)
+
+		ow, err = repo.GetOutgoingWebhook(wb.GetID())
+		if assert.NoError(err) {
+			assert.Equal("https://example.com/hook", ow.URL)
+			assert.Equal(secret, ow.SigningSecret)
+			assert.Empty(ow.TriggerWords)
+			assert.False(ow.Reply)
+			assert.ElementsMatch([]uuid.UUID{ch2.ID}, ow.ChannelIDs())
+		}
+	})
+}
+
+func TestRepositoryImpl_GetOutgoingWebhooksByChannelID(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common)
+
+	wb1 := mustMakeWebhook(t, repo, rand, channel.ID, user.GetID(), "")
+	wb2 := mustMakeWebhook(t, repo, rand, channel.ID, user.GetID(), "")
+	for _, wb := range []model.Webhook{wb1, wb2} {
+		_, err := repo.SetOutgoingWebhook(wb.GetID(), repository.SetOutgoingWebhookArgs{
+			URL:        "https://example.com",
+			ChannelIDs: []uuid.UUID{channel.ID},
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, repo.DeleteWebhook(wb2.GetID()))
+
+	webhooks, err := repo.GetOutgoingWebhooksByChannelID(channel.ID)
+	if assert.NoError(t, err) && assert.Len(t, webhooks, 1) {
+		assert.Equal(t, wb1.GetID(), webhooks[0].WebhookID)
+		if assert.NotNil(t, webhooks[0].Webhook) {
+			assert.Equal(t, wb1.GetBotUserID(), webhooks[0].Webhook.BotUserID)
+		}
+	}
+}
+
+func TestRepositoryImpl_DeleteOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common)
+
+	t.Run("Nil id", func(t *testing.T) {
+		t.Parallel()
+		assert.EqualError(t, repo.DeleteOutgoingWebhook(uuid.Nil), repository.ErrNilID.Error())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		t.Parallel()
+		assert.EqualError(t, repo.DeleteOutgoingWebhook(uuid.Must(uuid.NewV4())), repository.ErrNotFound.Error())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+		wb := mustMakeWebhook(t, repo, rand, channel.ID, user.GetID(), "")
+		_, err := repo.SetOutgoingWebhook(wb.GetID(), repository.SetOutgoingWebhookArgs{
+			URL:        "https://example.com",
+			ChannelIDs: []uuid.UUID{channel.ID},
+		})
+		require.NoError(t, err)
+
+		if assert.NoError(t, repo.DeleteOutgoingWebhook(wb.GetID())) {
+			_, err := repo.GetOutgoingWebhook(wb.GetID())
+			assert.EqualError(t, err, repository.ErrNotFound.Error())
+		}
+	})
+}
+
+func TestRepositoryImpl_OutgoingWebhookLogs(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common)
+	wb := mustMakeWebhook(t, repo, rand, channel.ID, user.GetID(), "")
+
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, repo.WriteOutgoingWebhookLog(&model.OutgoingWebhookLog{
+			RequestID: uuid.Must(uuid.NewV4()),
+			WebhookID: wb.GetID(),
+			MessageID: uuid.Must(uuid.NewV4()),
+			Result:    "ok",
+			Code:      200,
+			DateTime:  now.Add(-time.Duration(i) * time.Hour),
+		}))
+	}
+
+	logs, err := repo.GetOutgoingWebhookLogs(wb.GetID(), 2, 0)
+	if assert.NoError(t, err) && assert.Len(t, logs, 2) {
+		assert.True(t, logs[0].DateTime.After(logs[1].DateTime))
+	}
+
+	require.NoError(t, repo.PurgeOutgoingWebhookLogs(now.Add(-90*time.Minute)))
+	logs, err = repo.GetOutgoingWebhookLogs(wb.GetID(), 10, 0)
+	if assert.NoError(t, err) {
+		assert.Len(t, logs, 2)
+	}
+}
@@ -1,30 +1,37 @@
 package gorm
 
 import (
+	"github.com/gofrs/uuid"
 	"github.com/leandro-lugaresi/hub"
+	"github.com/motoki317/sc"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
 	"github.com/traPtitech/traQ/migration"
+	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 )
 
 // Repository リポジトリ実装
 type Repository struct {
-	db     *gorm.DB
-	hub    *hub.Hub
-	logger *zap.Logger
-	stamps *stampRepository
+	db          *gorm.DB
+	hub         *hub.Hub
+	logger      *zap.Logger
+	stamps      *stampRepository
+	keywords    *sc.Cache[struct{}, map[uuid.UUID][]string]
+	dndSettings *sc.Cache[struct{}, []*model.UserSettings]
 }
 
 // NewGormRepository リポジトリ実装を初期化して生成します。
 // スキーマが初期化された場合、init: true を返します。
 func NewGormRepository(db *gorm.DB, hub *hub.Hub, logger *zap.Logger, doMigration bool) (repo repository.Repository, init bool, err error) {
 	repo = &Repository{
-		db:     db,
-		hub:    hub,
-		logger: logger.Named("repository"),
-		stamps: makeStampRepository(db),
+		db:          db,
+		hub:         hub,
+		logger:      logger.Named("repository"),
+		stamps:      makeStampRepository(db),
+		keywords:    makeNotificationKeywordCache(db),
+		dndSettings: makeDNDUserSettingsCache(db),
 	}
 	if doMigration {
 		if init, err = migration.Migrate(db); err != nil {
@@ -131,6 +131,10 @@ func getDB(repo repository.Repository) *gorm.DB {
 	return repo.(*Repository).db
 }
 
+func getHub(repo repository.Repository) *hub.Hub {
+	return repo.(*Repository).hub
+}
+
 func assertAndRequire(t *testing.T) (*assert.Assertions, *require.Assertions) {
 	return assert.New(t), require.New(t)
 }
@@ -274,6 +278,16 @@ func mustMakeWebhook(t *testing.T, repo repository.Repository, name string, chan
 	return w
 }
 
+func mustMakeBot(t *testing.T, repo repository.Repository, name string, creatorID uuid.UUID, state model.BotState) *model.Bot {
+	t.Helper()
+	if name == rand {
+		name = random.AlphaNumeric(20)
+	}
+	b, err := repo.CreateBot(name, "po", "desc", mustMakeDummyFile(t, repo).ID, creatorID, model.BotModeHTTP, state, "https://example.com")
+	require.NoError(t, err)
+	return b
+}
+
 func mustChangeChannelSubscription(t *testing.T, repo repository.Repository, channelID, userID uuid.UUID) {
 	t.Helper()
 	_, _, err := repo.ChangeChannelSubscription(channelID, repository.ChangeChannelSubscriptionArgs{Subscription: map[uuid.UUID]model.ChannelSubscribeLevel{userID: model.ChannelSubscribeLevelMarkAndNotify}})
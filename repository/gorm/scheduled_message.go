@@ -0,0 +1,186 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+// CreateScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) CreateScheduledMessage(userID, channelID uuid.UUID, text string, scheduledAt time.Time) (*model.ScheduledMessage, error) {
+	if userID == uuid.Nil || channelID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	sm := &model.ScheduledMessage{
+		ID:          uuid.Must(uuid.NewV4()),
+		UserID:      userID,
+		ChannelID:   channelID,
+		Text:        text,
+		ScheduledAt: scheduledAt,
+	}
+	if err := repo.db.Create(sm).Error; err != nil {
+		return nil, err
+	}
+	return sm, nil
+}
+
+// UpdateScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) UpdateScheduledMessage(id uuid.UUID, args repository.UpdateScheduledMessageArgs) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	changes := map[string]interface{}{}
+	if args.Text.Valid {
+		changes["text"] = args.Text.String
+	}
+	if args.ScheduledAt.Valid {
+		changes["scheduled_at"] = args.ScheduledAt.Time
+	}
+
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var sm model.ScheduledMessage
+		if err := tx.First(&sm, &model.ScheduledMessage{ID: id}).Error; err != nil {
+			return convertError(err)
+		}
+		if len(changes) == 0 {
+			if !sm.IsPending() {
+				return repository.ErrForbidden
+			}
+			return nil
+		}
+		// 送信中に更新されると二重送信の原因になるため、送信待ちの場合のみ更新する
+		result := tx.Model(&sm).Where("state = ?", model.ScheduledMessagePending).Updates(changes)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrForbidden
+		}
+		return nil
+	})
+}
+
+// DeleteScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) DeleteScheduledMessage(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.Delete(&model.ScheduledMessage{ID: id})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// GetScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) GetScheduledMessage(id uuid.UUID) (*model.ScheduledMessage, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var sm model.ScheduledMessage
+	if err := repo.db.First(&sm, &model.ScheduledMessage{ID: id}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &sm, nil
+}
+
+// GetScheduledMessagesByUserID implements ScheduledMessageRepository interface.
+func (repo *Repository) GetScheduledMessagesByUserID(userID uuid.UUID) ([]*model.ScheduledMessage, error) {
+	sms := make([]*model.ScheduledMessage, 0)
+	if userID == uuid.Nil {
+		return sms, nil
+	}
+	return sms, repo.db.
+		Where(&model.ScheduledMessage{UserID: userID}).
+		Order("scheduled_at").
+		Find(&sms).
+		Error
+}
+
+// GetDueScheduledMessages implements ScheduledMessageRepository interface.
+func (repo *Repository) GetDueScheduledMessages(until time.Time, limit int) ([]*model.ScheduledMessage, error) {
+	sms := make([]*model.ScheduledMessage, 0)
+	tx := repo.db.
+		Where("state = ? AND scheduled_at <= ? AND (retry_at IS NULL OR retry_at <= ?)", model.ScheduledMessagePending, until, until).
+		Order("scheduled_at")
+	if limit > 0 {
+		tx = tx.Limit(limit)
+	}
+	return sms, tx.Find(&sms).Error
+}
+
+// ClaimScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) ClaimScheduledMessage(id uuid.UUID, now time.Time) (bool, error) {
+	if id == uuid.Nil {
+		return false, nil
+	}
+	result := repo.db.
+		Model(&model.ScheduledMessage{}).
+		Where("id = ? AND state = ?", id, model.ScheduledMessagePending).
+		Updates(map[string]interface{}{
+			"state":      model.ScheduledMessageSending,
+			"claimed_at": now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ReleaseScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) ReleaseScheduledMessage(id uuid.UUID, retryAt time.Time) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.db.
+		Model(&model.ScheduledMessage{}).
+		Where("id = ? AND state = ?", id, model.ScheduledMessageSending).
+		Updates(map[string]interface{}{
+			"state":      model.ScheduledMessagePending,
+			"claimed_at": nil,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"retry_at":   retryAt,
+		}).
+		Error
+}
+
+// ReleaseStaleScheduledMessages implements ScheduledMessageRepository interface.
+func (repo *Repository) ReleaseStaleScheduledMessages(claimedBefore time.Time) (int64, error) {
+	result := repo.db.
+		Model(&model.ScheduledMessage{}).
+		Where("state = ? AND claimed_at <= ?", model.ScheduledMessageSending, claimedBefore).
+		Updates(map[string]interface{}{
+			"state":      model.ScheduledMessagePending,
+			"claimed_at": nil,
+			"attempts":   gorm.Expr("attempts + 1"),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// SetScheduledMessageError implements ScheduledMessageRepository interface.
+func (repo *Repository) SetScheduledMessageError(id uuid.UUID, reason string) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var sm model.ScheduledMessage
+		if err := tx.First(&sm, &model.ScheduledMessage{ID: id}).Error; err != nil {
+			return convertError(err)
+		}
+		return tx.Model(&sm).Updates(map[string]interface{}{
+			"state":      model.ScheduledMessageFailed,
+			"error":      reason,
+			"claimed_at": nil,
+			"retry_at":   nil,
+		}).Error
+	})
+}
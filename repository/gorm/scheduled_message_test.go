@@ -0,0 +1,249 @@
+package gorm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestRepositoryImpl_CreateScheduledMessage(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.CreateScheduledMessage(uuid.Nil, channel.ID, "a", time.Now())
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+
+		at := time.Now().Add(time.Hour)
+		sm, err := repo.CreateScheduledMessage(user.GetID(), channel.ID, "test", at)
+		require.NoError(err)
+		assert.NotEmpty(sm.ID)
+		assert.Equal("test", sm.Text)
+		assert.True(sm.IsPending())
+
+		got, err := repo.GetScheduledMessage(sm.ID)
+		require.NoError(err)
+		assert.Equal(user.GetID(), got.UserID)
+		assert.WithinDuration(at, got.ScheduledAt, time.Second)
+	})
+}
+
+func TestRepositoryImpl_UpdateScheduledMessage(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.UpdateScheduledMessage(uuid.Nil, repository.UpdateScheduledMessageArgs{}), repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.UpdateScheduledMessage(uuid.Must(uuid.NewV4()), repository.UpdateScheduledMessageArgs{}), repository.ErrNotFound.Error())
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+
+		sm, err := repo.CreateScheduledMessage(user.GetID(), channel.ID, "test", time.Now())
+		require.NoError(err)
+		require.NoError(repo.SetScheduledMessageError(sm.ID, "channel archived"))
+
+		assert.EqualError(repo.UpdateScheduledMessage(sm.ID, repository.UpdateScheduledMessageArgs{Text: optional.StringFrom("updated")}), repository.ErrForbidden.Error())
+		got, err := repo.GetScheduledMessage(sm.ID)
+		require.NoError(err)
+		assert.Equal("test", got.Text)
+		assert.Equal(model.ScheduledMessageFailed, got.State)
+	})
+
+	t.Run("sending", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+
+		sm, err := repo.CreateScheduledMessage(user.GetID(), channel.ID, "test", time.Now())
+		require.NoError(err)
+		ok, err := repo.ClaimScheduledMessage(sm.ID, time.Now())
+		require.NoError(err)
+		require.True(ok)
+
+		assert.EqualError(repo.UpdateScheduledMessage(sm.ID, repository.UpdateScheduledMessageArgs{Text: optional.StringFrom("updated")}), repository.ErrForbidden.Error())
+		got, err := repo.GetScheduledMessage(sm.ID)
+		require.NoError(err)
+		assert.Equal(model.ScheduledMessageSending, got.State)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+
+		sm, err := repo.CreateScheduledMessage(user.GetID(), channel.ID, "test", time.Now())
+		require.NoError(err)
+
+		require.NoError(repo.UpdateScheduledMessage(sm.ID, repository.UpdateScheduledMessageArgs{Text: optional.StringFrom("updated")}))
+		got, err := repo.GetScheduledMessage(sm.ID)
+		require.NoError(err)
+		assert.Equal("updated", got.Text)
+		assert.True(got.IsPending())
+	})
+}
+
+func TestRepositoryImpl_DeleteScheduledMessage(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.DeleteScheduledMessage(uuid.Nil), repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.DeleteScheduledMessage(uuid.Must(uuid.NewV4())), repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+
+		sm, err := repo.CreateScheduledMessage(user.GetID(), channel.ID, "test", time.Now())
+		require.NoError(err)
+		require.NoError(repo.DeleteScheduledMessage(sm.ID))
+		_, err = repo.GetScheduledMessage(sm.ID)
+		assert.EqualError(err, repository.ErrNotFound.Error())
+	})
+}
+
+func TestRepositoryImpl_GetDueScheduledMessages(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+
+	now := time.Now()
+	due, err := repo.CreateScheduledMessage(user.GetID(), channel.ID, "due", now.Add(-time.Minute))
+	require.NoError(err)
+	failed, err := repo.CreateScheduledMessage(user.GetID(), channel.ID, "failed", now.Add(-time.Minute))
+	require.NoError(err)
+	require.NoError(repo.SetScheduledMessageError(failed.ID, "channel archived"))
+	_, err = repo.CreateScheduledMessage(user.GetID(), channel.ID, "future", now.Add(time.Hour))
+	require.NoError(err)
+
+	sms, err := repo.GetDueScheduledMessages(now, 0)
+	require.NoError(err)
+	ids := make([]uuid.UUID, 0, len(sms))
+	for _, sm := range sms {
+		ids = append(ids, sm.ID)
+	}
+	assert.Contains(ids, due.ID)
+	assert.NotContains(ids, failed.ID)
+
+	sms, err = repo.GetScheduledMessagesByUserID(user.GetID())
+	require.NoError(err)
+	assert.Len(sms, 3)
+}
+
+func TestRepositoryImpl_ClaimScheduledMessage(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+
+	sm, err := repo.CreateScheduledMessage(user.GetID(), channel.ID, "claim", time.Now().Add(-time.Minute))
+	require.NoError(err)
+
+	ok, err := repo.ClaimScheduledMessage(uuid.Nil, time.Now())
+	if assert.NoError(err) {
+		assert.False(ok)
+	}
+
+	// 1度だけ送信中にできる
+	ok, err = repo.ClaimScheduledMessage(sm.ID, time.Now())
+	if assert.NoError(err) {
+		assert.True(ok)
+	}
+	ok, err = repo.ClaimScheduledMessage(sm.ID, time.Now())
+	if assert.NoError(err) {
+		assert.False(ok)
+	}
+
+	// 送信中のメッセージは送信対象に含まれない
+	sms, err := repo.GetDueScheduledMessages(time.Now(), 0)
+	require.NoError(err)
+	for _, s := range sms {
+		assert.NotEqual(sm.ID, s.ID)
+	}
+	claimed, err := repo.GetScheduledMessage(sm.ID)
+	require.NoError(err)
+	assert.Equal(model.ScheduledMessageSending, claimed.State)
+	assert.True(claimed.ClaimedAt.Valid)
+
+	// 送信待ちに戻すと再試行日時以降に再び送信対象になる
+	retryAt := time.Now().Add(time.Minute)
+	require.NoError(repo.ReleaseScheduledMessage(sm.ID, retryAt))
+	released, err := repo.GetScheduledMessage(sm.ID)
+	require.NoError(err)
+	assert.True(released.IsPending())
+	assert.Equal(1, released.Attempts)
+	assert.False(released.ClaimedAt.Valid)
+
+	sms, err = repo.GetDueScheduledMessages(time.Now(), 0)
+	require.NoError(err)
+	for _, s := range sms {
+		assert.NotEqual(sm.ID, s.ID)
+	}
+	sms, err = repo.GetDueScheduledMessages(retryAt.Add(time.Second), 0)
+	require.NoError(err)
+	ids := make([]uuid.UUID, 0, len(sms))
+	for _, s := range sms {
+		ids = append(ids, s.ID)
+	}
+	assert.Contains(ids, sm.ID)
+
+	ok, err = repo.ClaimScheduledMessage(sm.ID, time.Now())
+	if assert.NoError(err) {
+		assert.True(ok)
+	}
+}
+
+func TestRepositoryImpl_ReleaseStaleScheduledMessages(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+
+	now := time.Now()
+	stale, err := repo.CreateScheduledMessage(user.GetID(), channel.ID, "stale", now.Add(-time.Hour))
+	require.NoError(err)
+	ok, err := repo.ClaimScheduledMessage(stale.ID, now.Add(-time.Hour))
+	require.NoError(err)
+	require.True(ok)
+	sending, err := repo.CreateScheduledMessage(user.GetID(), channel.ID, "sending", now.Add(-time.Hour))
+	require.NoError(err)
+	ok, err = repo.ClaimScheduledMessage(sending.ID, now)
+	require.NoError(err)
+	require.True(ok)
+
+	n, err := repo.ReleaseStaleScheduledMessages(now.Add(-time.Minute))
+	require.NoError(err)
+	assert.GreaterOrEqual(n, int64(1))
+
+	got, err := repo.GetScheduledMessage(stale.ID)
+	require.NoError(err)
+	assert.True(got.IsPending())
+	assert.Equal(1, got.Attempts)
+	got, err = repo.GetScheduledMessage(sending.ID)
+	require.NoError(err)
+	assert.Equal(model.ScheduledMessageSending, got.State)
+}
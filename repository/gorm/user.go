@@ -150,6 +150,9 @@ func (repo *Repository) makeGetUsersTx(query repository.UsersQuery) *gorm.DB {
 	if query.IsBot.Valid {
 		tx = tx.Where("users.bot = ?", query.IsBot.Bool)
 	}
+	if query.Role.Valid {
+		tx = tx.Where("users.role = ?", query.Role.String)
+	}
 	if query.IsSubscriberAtMarkLevelOf.Valid {
 		tx = tx.Joins("INNER JOIN users_subscribe_channels ON users_subscribe_channels.user_id = users.id AND users_subscribe_channels.channel_id = ? AND users_subscribe_channels.mark = true", query.IsSubscriberAtMarkLevelOf.UUID)
 	}
@@ -231,6 +234,9 @@ func (repo *Repository) UpdateUser(id uuid.UUID, args repository.UpdateUserArgs)
 		if args.Bio.Valid {
 			changes["bio"] = args.Bio.String
 		}
+		if args.Email.Valid {
+			changes["email"] = args.Email.String
+		}
 		if args.LastOnline.Valid {
 			changes["last_online"] = args.LastOnline
 		}
@@ -1,13 +1,35 @@
 package gorm
 
 import (
+	"context"
+	"time"
+
 	"github.com/gofrs/uuid"
+	"github.com/motoki317/sc"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+const (
+	defaultNotifyCitation       = false
+	defaultEmailDigestFrequency = model.EmailDigestDaily
 )
 
-const defaultNotifyCitation = false
+func makeDNDUserSettingsCache(db *gorm.DB) *sc.Cache[struct{}, []*model.UserSettings] {
+	// Lazy load
+	// 読み込み時点で解除済みのおやすみモードは含めない。その後に解除されたものはIsDNDActiveで除外される
+	return sc.NewMust(func(_ context.Context, _ struct{}) ([]*model.UserSettings, error) {
+		settings := make([]*model.UserSettings, 0)
+		return settings, db.
+			Where("dnd_until > ? OR quiet_hours_enabled = ?", time.Now(), true).
+			Find(&settings).
+			Error
+	}, time.Hour, time.Hour)
+}
 
 // UpdateNotifyCitation implements UserSettingsRepository interface
 func (repo *Repository) UpdateNotifyCitation(userID uuid.UUID, isEnable bool) error {
@@ -69,8 +91,9 @@ func (repo *Repository) GetUserSettings(userID uuid.UUID) (*model.UserSettings,
 	if err := repo.db.First(&settings, "user_id=?", userID).Error; err != nil {
 		err = convertError(err)
 		dus := &model.UserSettings{
-			UserID:         userID,
-			NotifyCitation: defaultNotifyCitation,
+			UserID:               userID,
+			NotifyCitation:       defaultNotifyCitation,
+			EmailDigestFrequency: defaultEmailDigestFrequency,
 		}
 		if err == repository.ErrNotFound {
 			return dus, nil
@@ -80,3 +103,70 @@ func (repo *Repository) GetUserSettings(userID uuid.UUID) (*model.UserSettings,
 
 	return &settings, nil
 }
+
+// UpdateEmailDigestFrequency implements UserSettingsRepository interface
+func (repo *Repository) UpdateEmailDigestFrequency(userID uuid.UUID, frequency model.EmailDigestFrequency) error {
+	if userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	if !frequency.Valid() {
+		return repository.ArgError("frequency", "invalid frequency")
+	}
+	return repo.upsertUserSettings(&model.UserSettings{
+		UserID:               userID,
+		EmailDigestFrequency: frequency,
+	}, "email_digest_frequency")
+}
+
+// SetEmailDigestSentAt implements UserSettingsRepository interface
+func (repo *Repository) SetEmailDigestSentAt(userID uuid.UUID, sentAt time.Time) error {
+	if userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.upsertUserSettings(&model.UserSettings{
+		UserID:            userID,
+		EmailDigestSentAt: optional.TimeFrom(sentAt),
+	}, "email_digest_sent_at")
+}
+
+// UpdateDNDSettings implements UserSettingsRepository interface
+func (repo *Repository) UpdateDNDSettings(userID uuid.UUID, args repository.UpdateDNDSettingsArgs) error {
+	if userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	if len(args.QuietHoursStart) > 0 && !model.IsValidClock(args.QuietHoursStart) {
+		return repository.ArgError("args.QuietHoursStart", "invalid time")
+	}
+	if len(args.QuietHoursEnd) > 0 && !model.IsValidClock(args.QuietHoursEnd) {
+		return repository.ArgError("args.QuietHoursEnd", "invalid time")
+	}
+	if !model.IsValidTimezone(args.QuietHoursTimezone) {
+		return repository.ArgError("args.QuietHoursTimezone", "invalid timezone")
+	}
+	err := repo.upsertUserSettings(&model.UserSettings{
+		UserID:               userID,
+		DNDUntil:             args.Until,
+		DNDMuteForceChannels: args.MuteForceChannels,
+		QuietHoursEnabled:    args.QuietHoursEnabled,
+		QuietHoursStart:      args.QuietHoursStart,
+		QuietHoursEnd:        args.QuietHoursEnd,
+		QuietHoursTimezone:   args.QuietHoursTimezone,
+	}, "dnd_until", "dnd_mute_force_channels", "quiet_hours_enabled", "quiet_hours_start", "quiet_hours_end", "quiet_hours_timezone")
+	if err != nil {
+		return err
+	}
+	repo.dndSettings.Purge()
+	return nil
+}
+
+// GetDNDUserSettings implements UserSettingsRepository interface
+func (repo *Repository) GetDNDUserSettings() ([]*model.UserSettings, error) {
+	return repo.dndSettings.Get(context.Background(), struct{}{})
+}
+
+// upsertUserSettings ユーザー設定が存在しない場合は作成し、存在する場合はcolumnsのみを更新します
+func (repo *Repository) upsertUserSettings(settings *model.UserSettings, columns ...string) error {
+	return repo.db.Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).Create(settings).Error
+}
@@ -0,0 +1,154 @@
+package gorm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestRepositoryImpl_GetUserSettings(t *testing.T) {
+	t.Parallel()
+	repo, assert, _ := setup(t, common)
+
+	user := mustMakeUser(t, repo, rand)
+
+	_, err := repo.GetUserSettings(uuid.Nil)
+	assert.EqualError(err, repository.ErrNilID.Error())
+
+	us, err := repo.GetUserSettings(user.GetID())
+	if assert.NoError(err) {
+		assert.Equal(user.GetID(), us.UserID)
+		assert.False(us.NotifyCitation)
+		assert.Equal(model.EmailDigestDaily, us.EmailDigestFrequency)
+		assert.False(us.EmailDigestSentAt.Valid)
+		assert.False(us.DNDUntil.Valid)
+		assert.False(us.QuietHoursEnabled)
+	}
+}
+
+func TestRepositoryImpl_UpdateEmailDigestFrequency(t *testing.T) {
+	t.Parallel()
+	repo, assert, require := setup(t, common)
+
+	user := mustMakeUser(t, repo, rand)
+
+	assert.EqualError(repo.UpdateEmailDigestFrequency(uuid.Nil, model.EmailDigestOff), repository.ErrNilID.Error())
+	assert.IsType(&repository.ArgumentError{}, repo.UpdateEmailDigestFrequency(user.GetID(), "weekly"))
+
+	require.NoError(repo.UpdateNotifyCitation(user.GetID(), true))
+	for _, f := range []model.EmailDigestFrequency{model.EmailDigestOff, model.EmailDigestHourly} {
+		if assert.NoError(repo.UpdateEmailDigestFrequency(user.GetID(), f)) {
+			us, err := repo.GetUserSettings(user.GetID())
+			require.NoError(err)
+			assert.Equal(f, us.EmailDigestFrequency)
+			assert.True(us.NotifyCitation) // 他の設定は変更されない
+		}
+	}
+}
+
+func TestRepositoryImpl_SetEmailDigestSentAt(t *testing.T) {
+	t.Parallel()
+	repo, assert, require := setup(t, common)
+
+	user := mustMakeUser(t, repo, rand)
+	now := time.Now().Truncate(time.Microsecond)
+
+	assert.EqualError(repo.SetEmailDigestSentAt(uuid.Nil, now), repository.ErrNilID.Error())
+
+	// 設定が存在しない場合は作成される
+	if assert.NoError(repo.SetEmailDigestSentAt(user.GetID(), now)) {
+		us, err := repo.GetUserSettings(user.GetID())
+		require.NoError(err)
+		assert.True(us.EmailDigestSentAt.Valid)
+		assert.WithinDuration(now, us.EmailDigestSentAt.Time, time.Millisecond)
+		assert.Equal(model.EmailDigestDaily, us.EmailDigestFrequency)
+	}
+}
+
+func TestRepositoryImpl_UpdateDNDSettings(t *testing.T) {
+	t.Parallel()
+	repo, assert, require := setup(t, common)
+
+	user := mustMakeUser(t, repo, rand)
+	until := time.Now().Add(time.Hour).Truncate(time.Microsecond)
+
+	assert.EqualError(repo.UpdateDNDSettings(uuid.Nil, repository.UpdateDNDSettingsArgs{}), repository.ErrNilID.Error())
+	assert.IsType(&repository.ArgumentError{}, repo.UpdateDNDSettings(user.GetID(), repository.UpdateDNDSettingsArgs{QuietHoursStart: "24:00"}))
+	assert.IsType(&repository.ArgumentError{}, repo.UpdateDNDSettings(user.GetID(), repository.UpdateDNDSettingsArgs{QuietHoursEnd: "7:00"}))
+	assert.IsType(&repository.ArgumentError{}, repo.UpdateDNDSettings(user.GetID(), repository.UpdateDNDSettingsArgs{QuietHoursTimezone: "Asia/Nowhere"}))
+
+	require.NoError(repo.UpdateEmailDigestFrequency(user.GetID(), model.EmailDigestOff))
+	if assert.NoError(repo.UpdateDNDSettings(user.GetID(), repository.UpdateDNDSettingsArgs{
+		Until:              optional.TimeFrom(until),
+		MuteForceChannels:  true,
+		QuietHoursEnabled:  true,
+		QuietHoursStart:    "22:00",
+		QuietHoursEnd:      "07:00",
+		QuietHoursTimezone: "Asia/Tokyo",
+	})) {
+		us, err := repo.GetUserSettings(user.GetID())
+		require.NoError(err)
+		assert.True(us.DNDUntil.Valid)
+		assert.WithinDuration(until, us.DNDUntil.Time, time.Millisecond)
+		assert.True(us.DNDMuteForceChannels)
+		assert.True(us.QuietHoursEnabled)
+		assert.Equal("22:00", us.QuietHoursStart)
+		assert.Equal("07:00", us.QuietHoursEnd)
+		assert.Equal("Asia/Tokyo", us.QuietHoursTimezone)
+		assert.Equal(model.EmailDigestOff, us.EmailDigestFrequency) // 他の設定は変更されない
+	}
+
+	// 解除
+	if assert.NoError(repo.UpdateDNDSettings(user.GetID(), repository.UpdateDNDSettingsArgs{})) {
+		us, err := repo.GetUserSettings(user.GetID())
+		require.NoError(err)
+		assert.False(us.DNDUntil.Valid)
+		assert.False(us.DNDMuteForceChannels)
+		assert.False(us.QuietHoursEnabled)
+		assert.Empty(us.QuietHoursStart)
+	}
+}
+
+func TestRepositoryImpl_GetDNDUserSettings(t *testing.T) {
+	t.Parallel()
+	repo, assert, require := setup(t, common)
+
+	snoozed := mustMakeUser(t, repo, rand)
+	expired := mustMakeUser(t, repo, rand)
+	quiet := mustMakeUser(t, repo, rand)
+	normal := mustMakeUser(t, repo, rand)
+	now := time.Now()
+
+	require.NoError(repo.UpdateDNDSettings(snoozed.GetID(), repository.UpdateDNDSettingsArgs{Until: optional.TimeFrom(now.Add(time.Hour))}))
+	require.NoError(repo.UpdateDNDSettings(expired.GetID(), repository.UpdateDNDSettingsArgs{Until: optional.TimeFrom(now.Add(-time.Hour))}))
+	require.NoError(repo.UpdateDNDSettings(quiet.GetID(), repository.UpdateDNDSettingsArgs{QuietHoursEnabled: true, QuietHoursStart: "22:00", QuietHoursEnd: "07:00"}))
+	require.NoError(repo.UpdateNotifyCitation(normal.GetID(), true))
+
+	settings, err := repo.GetDNDUserSettings()
+	if assert.NoError(err) {
+		ids := make([]uuid.UUID, 0, len(settings))
+		for _, s := range settings {
+			ids = append(ids, s.UserID)
+		}
+		assert.Contains(ids, snoozed.GetID())
+		assert.Contains(ids, quiet.GetID())
+		assert.NotContains(ids, expired.GetID())
+		assert.NotContains(ids, normal.GetID())
+	}
+
+	// 設定を変更するとキャッシュが破棄される
+	require.NoError(repo.UpdateDNDSettings(normal.GetID(), repository.UpdateDNDSettingsArgs{Until: optional.TimeFrom(now.Add(time.Hour))}))
+	settings, err = repo.GetDNDUserSettings()
+	if assert.NoError(err) {
+		ids := make([]uuid.UUID, 0, len(settings))
+		for _, s := range settings {
+			ids = append(ids, s.UserID)
+		}
+		assert.Contains(ids, normal.GetID())
+	}
+}
@@ -236,6 +236,20 @@ func TestRepositoryImpl_UpdateUser(t *testing.T) {
 		})
 	})
 
+	t.Run("Email", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+
+		user := mustMakeUser(t, repo, rand)
+		newEmail := "user@example.com"
+
+		if assert.NoError(repo.UpdateUser(user.GetID(), repository.UpdateUserArgs{Email: optional.StringFrom(newEmail)})) {
+			u, err := repo.GetUser(user.GetID(), true)
+			require.NoError(err)
+			assert.Equal(newEmail, u.GetEmail())
+		}
+	})
+
 	t.Run("Role", func(t *testing.T) {
 		t.Parallel()
 
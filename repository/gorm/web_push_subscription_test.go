@@ -0,0 +1,126 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	random2 "github.com/traPtitech/traQ/utils/random"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+func TestRepositoryImpl_RegisterWebPushSubscription(t *testing.T) {
+	t.Parallel()
+	repo, assert, require := setup(t, common)
+
+	id1 := mustMakeUser(t, repo, rand).GetID()
+	id2 := mustMakeUser(t, repo, rand).GetID()
+	endpoint1 := "https://push.example.com/" + random2.AlphaNumeric(20)
+	endpoint2 := "https://push.example.com/" + random2.AlphaNumeric(20)
+
+	cases := []struct {
+		user     uuid.UUID
+		endpoint string
+		error    bool
+	}{
+		{id1, endpoint1, false},
+		{id2, endpoint2, false},
+		{id2, endpoint2, false},
+		{id1, endpoint2, true},
+		{uuid.Nil, endpoint2, true},
+		{id1, "", true},
+	}
+
+	for _, v := range cases {
+		err := repo.RegisterWebPushSubscription(v.user, repository.RegisterWebPushSubscriptionArgs{
+			Endpoint: v.endpoint,
+			P256dh:   "p256dh",
+			Auth:     "auth",
+		})
+		if v.error {
+			assert.Error(err)
+		} else {
+			assert.NoError(err)
+		}
+	}
+	assert.EqualValues(2, count(t, getDB(repo).Model(model.WebPushSubscription{}).Where("user_id IN (?, ?)", id1, id2)))
+
+	// 同じエンドポイントの再登録で鍵が更新される
+	require.NoError(repo.RegisterWebPushSubscription(id1, repository.RegisterWebPushSubscriptionArgs{
+		Endpoint: endpoint1,
+		P256dh:   "p256dh2",
+		Auth:     "auth2",
+	}))
+	subs, err := repo.GetWebPushSubscriptions(set.UUID{id1: {}})
+	require.NoError(err)
+	if assert.Len(subs[id1], 1) {
+		assert.Equal("p256dh2", subs[id1][0].P256dh)
+		assert.Equal("auth2", subs[id1][0].Auth)
+	}
+}
+
+func TestRepositoryImpl_UnregisterWebPushSubscription(t *testing.T) {
+	t.Parallel()
+	repo, assert, require := setup(t, common)
+
+	id1 := mustMakeUser(t, repo, rand).GetID()
+	id2 := mustMakeUser(t, repo, rand).GetID()
+	endpoint := "https://push.example.com/" + random2.AlphaNumeric(20)
+	require.NoError(repo.RegisterWebPushSubscription(id1, repository.RegisterWebPushSubscriptionArgs{Endpoint: endpoint, P256dh: "p256dh", Auth: "auth"}))
+
+	assert.EqualError(repo.UnregisterWebPushSubscription(uuid.Nil, endpoint), repository.ErrNilID.Error())
+	assert.EqualError(repo.UnregisterWebPushSubscription(id2, endpoint), repository.ErrNotFound.Error())
+	assert.EqualError(repo.UnregisterWebPushSubscription(id1, ""), repository.ErrNotFound.Error())
+	assert.NoError(repo.UnregisterWebPushSubscription(id1, endpoint))
+	assert.EqualError(repo.UnregisterWebPushSubscription(id1, endpoint), repository.ErrNotFound.Error())
+}
+
+func TestRepositoryImpl_GetWebPushSubscriptions(t *testing.T) {
+	t.Parallel()
+	repo, assert, require := setup(t, common)
+
+	id1 := mustMakeUser(t, repo, rand).GetID()
+	id2 := mustMakeUser(t, repo, rand).GetID()
+	id3 := mustMakeUser(t, repo, rand).GetID()
+	for _, id := range []uuid.UUID{id1, id1, id2} {
+		require.NoError(repo.RegisterWebPushSubscription(id, repository.RegisterWebPushSubscriptionArgs{
+			Endpoint: "https://push.example.com/" + random2.AlphaNumeric(20),
+			P256dh:   "p256dh",
+			Auth:     "auth",
+		}))
+	}
+
+	subs, err := repo.GetWebPushSubscriptions(set.UUID{id1: {}, id2: {}, id3: {}})
+	if assert.NoError(err) {
+		assert.Len(subs[id1], 2)
+		assert.Len(subs[id2], 1)
+		assert.Len(subs[id3], 0)
+	}
+}
+
+func TestRepositoryImpl_DeleteWebPushSubscriptions(t *testing.T) {
+	t.Parallel()
+	repo, assert, require := setup(t, common)
+
+	id := mustMakeUser(t, repo, rand).GetID()
+	endpoints := make([]string, 3)
+	for i := range endpoints {
+		endpoints[i] = "https://push.example.com/" + random2.AlphaNumeric(20)
+		require.NoError(repo.RegisterWebPushSubscription(id, repository.RegisterWebPushSubscriptionArgs{Endpoint: endpoints[i], P256dh: "p256dh", Auth: "auth"}))
+	}
+
+	cases := []struct {
+		endpoints []string
+		expect    int
+	}{
+		{[]string{}, 3},
+		{[]string{endpoints[0], ""}, 2},
+		{[]string{endpoints[1], endpoints[2]}, 0},
+	}
+	for _, v := range cases {
+		assert.NoError(repo.DeleteWebPushSubscriptions(v.endpoints))
+		assert.EqualValues(v.expect, count(t, getDB(repo).Model(model.WebPushSubscription{}).Where("user_id = ?", id)))
+	}
+}
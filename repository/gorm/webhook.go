@@ -12,6 +12,7 @@ import (
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/rbac/role"
+	"github.com/traPtitech/traQ/utils/gormUtil"
 )
 
 // CreateWebhook implements WebhookRepository interface.
@@ -39,6 +40,7 @@ func (repo *Repository) CreateWebhook(name, description string, channelID, iconF
 		Secret:      secret,
 		ChannelID:   channelID,
 		CreatorID:   creatorID,
+		EventTypes:  model.AllWebhookEventTypes(),
 	}
 
 	err := repo.db.Transaction(func(tx *gorm.DB) error {
@@ -134,6 +136,9 @@ func (repo *Repository) UpdateWebhook(id uuid.UUID, args repository.UpdateWebhoo
 
 			changes["creator_id"] = args.CreatorID.UUID
 		}
+		if args.EventTypes != nil {
+			changes["event_types"] = args.EventTypes
+		}
 		if len(changes) > 0 {
 			if err := tx.Model(&model.WebhookBot{ID: id}).Updates(changes).Error; err != nil {
 				return err
@@ -189,6 +194,9 @@ func (repo *Repository) DeleteWebhook(id uuid.UUID) error {
 		if err := tx.Delete(&model.WebhookBot{ID: id}).Error; err != nil {
 			return err
 		}
+		if err := tx.Delete(&model.OutgoingWebhook{}, &model.OutgoingWebhook{WebhookID: id}).Error; err != nil {
+			return err
+		}
 		if err := tx.Model(&model.User{}).Where(&model.User{ID: b.BotUserID}).Update("status", model.UserAccountStatusDeactivated).Error; err != nil {
 			return err
 		}
@@ -261,3 +269,45 @@ func (repo *Repository) GetWebhooksByCreator(creatorID uuid.UUID) (arr []model.W
 	}
 	return arr, nil
 }
+
+// WriteWebhookLog implements WebhookRepository interface.
+func (repo *Repository) WriteWebhookLog(log *model.WebhookLog) error {
+	if log == nil || log.ID == uuid.Nil {
+		return nil
+	}
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(log).Error; err != nil {
+			return err
+		}
+
+		// 最新のWebhookLogsLimit件より古いログを消去
+		var oldest model.WebhookLog
+		err := tx.Select("date_time").
+			Where(&model.WebhookLog{WebhookID: log.WebhookID}).
+			Order("date_time DESC").
+			Offset(repository.WebhookLogsLimit).
+			Limit(1).
+			Take(&oldest).
+			Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil
+			}
+			return err
+		}
+		return tx.Delete(&model.WebhookLog{}, "webhook_id = ? AND date_time <= ?", log.WebhookID, oldest.DateTime).Error
+	})
+}
+
+// GetWebhookLogs implements WebhookRepository interface.
+func (repo *Repository) GetWebhookLogs(webhookID uuid.UUID, limit, offset int) ([]*model.WebhookLog, error) {
+	logs := make([]*model.WebhookLog, 0)
+	if webhookID == uuid.Nil {
+		return logs, nil
+	}
+	return logs, repo.db.Where(&model.WebhookLog{WebhookID: webhookID}).
+		Order("date_time DESC").
+		Scopes(gormUtil.LimitAndOffset(limit, offset)).
+		Find(&logs).
+		Error
+}
@@ -3,9 +3,11 @@ package gorm
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
@@ -275,3 +277,50 @@ func TestRepositoryImpl_GetWebhooksByCreator(t *testing.T) {
 		}
 	})
 }
+
+func TestRepositoryImpl_WebhookLogs(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common)
+	wb := mustMakeWebhook(t, repo, rand, channel.ID, user.GetID(), "")
+
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, repo.WriteWebhookLog(&model.WebhookLog{
+			ID:        uuid.Must(uuid.NewV4()),
+			WebhookID: wb.GetID(),
+			IP:        "192.0.2.1",
+			Body:      "test",
+			Result:    "ng",
+			Error:     "X-TRAQ-Signature is wrong",
+			Code:      400,
+			DateTime:  now.Add(-time.Duration(i) * time.Hour),
+		}))
+	}
+
+	logs, err := repo.GetWebhookLogs(wb.GetID(), 2, 0)
+	if assert.NoError(t, err) && assert.Len(t, logs, 2) {
+		assert.True(t, logs[0].DateTime.After(logs[1].DateTime))
+	}
+
+	logs, err = repo.GetWebhookLogs(uuid.Nil, 10, 0)
+	if assert.NoError(t, err) {
+		assert.Empty(t, logs)
+	}
+
+	// 最新のWebhookLogsLimit件のみ保持される
+	for i := 0; i < repository.WebhookLogsLimit; i++ {
+		require.NoError(t, repo.WriteWebhookLog(&model.WebhookLog{
+			ID:        uuid.Must(uuid.NewV4()),
+			WebhookID: wb.GetID(),
+			Result:    "ok",
+			Code:      204,
+			DateTime:  now.Add(time.Duration(i+1) * time.Second),
+		}))
+	}
+	logs, err = repo.GetWebhookLogs(wb.GetID(), repository.WebhookLogsLimit+10, 0)
+	if assert.NoError(t, err) && assert.Len(t, logs, repository.WebhookLogsLimit) {
+		for _, l := range logs {
+			assert.Equal(t, "ok", l.Result)
+		}
+	}
+}
@@ -15,6 +15,8 @@ import (
 type MessagesQuery struct {
 	User    uuid.UUID
 	Channel uuid.UUID
+	// Thread 指定したスレッドへの返信メッセージを指定
+	Thread uuid.UUID
 	// ChannelsSubscribedByUser 指定したユーザーが購読しているチャンネルのメッセージを指定
 	ChannelsSubscribedByUser uuid.UUID
 	Since                    optional.Time
@@ -43,6 +45,20 @@ type MessageRepository interface {
 	// 引数にuuid.Nilを指定するとErrNilIDを返します。
 	// DBによるエラーを返すことがあります。
 	CreateMessage(userID, channelID uuid.UUID, text string) (*model.Message, error)
+	// CreateThreadMessage 指定したスレッドへの返信メッセージを作成します
+	//
+	// 成功した場合、メッセージとnilを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreateThreadMessage(userID, channelID, threadID uuid.UUID, text string) (*model.Message, error)
+	// CreateMessageWithActions アクション付きのメッセージを作成します
+	//
+	// threadIDを指定した場合、そのスレッドへの返信メッセージを作成します。
+	// アクションはメッセージと同時に保存され、作成イベントのメッセージに含まれます。
+	// 成功した場合、メッセージとnilを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreateMessageWithActions(userID, channelID uuid.UUID, threadID optional.UUID, text string, actions model.MessageActions) (*model.Message, error)
 	// UpdateMessage 指定したメッセージを更新します
 	//
 	// 成功した場合、nilを返します。
@@ -50,6 +66,21 @@ type MessageRepository interface {
 	// 引数にuuid.Nilを指定するとErrNilIDを返します。
 	// DBによるエラーを返すことがあります。
 	UpdateMessage(messageID uuid.UUID, text string) error
+	// UpdateMessageWithActions 指定したメッセージの本文とアクションを同時に更新します
+	//
+	// 既存のアクションは全て置き換えられます。actionsが空の場合はアクションを削除します。
+	// 成功した場合、nilを返します。
+	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateMessageWithActions(messageID uuid.UUID, text string, actions model.MessageActions) error
+	// HideMessage 指定したメッセージを非表示にします
+	//
+	// 成功した場合、nilを返します。
+	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	HideMessage(messageID uuid.UUID) error
 	// DeleteMessage 指定したメッセージを削除します
 	//
 	// 成功した場合、nilを返します。
@@ -81,6 +112,19 @@ type MessageRepository interface {
 	// 指定した範囲内にlimitを超えてメッセージが存在していた場合、trueを返します。
 	// DBによるエラーを返すことがあります。
 	GetDeletedMessagesAfter(after time.Time, limit int) (messages []*model.Message, more bool, err error)
+	// GetThreadFollowerIDs 指定したスレッドのフォロワーのIDを取得します
+	//
+	// スレッドの親メッセージの投稿者と、スレッドに返信したユーザーがフォロワーになります。
+	// 成功した場合、ユーザーUUIDの配列とnilを返します。
+	// 存在しないスレッドを指定した場合、空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetThreadFollowerIDs(threadID uuid.UUID) ([]uuid.UUID, error)
+	// GetArchivedMessagesByID 指定したメッセージの編集前のアーカイブを取得します
+	//
+	// 成功した場合、dateTimeで昇順ソートされたアーカイブの配列とnilを返します。
+	// 存在しないメッセージを指定した場合、空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetArchivedMessagesByID(messageID uuid.UUID) ([]*model.ArchivedMessage, error)
 	// SetMessageUnread 指定したメッセージを未読にします
 	//
 	// 成功した場合、nilを返します。
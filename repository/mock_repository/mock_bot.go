@@ -80,6 +80,20 @@ func (mr *MockBotRepositoryMockRecorder) CreateBot(name, displayName, descriptio
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBot", reflect.TypeOf((*MockBotRepository)(nil).CreateBot), name, displayName, description, iconFileID, creatorID, mode, state, webhookURL)
 }
 
+// CreateBotEventDeadLetter mocks base method.
+func (m *MockBotRepository) CreateBotEventDeadLetter(letter *model.BotEventDeadLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBotEventDeadLetter", letter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBotEventDeadLetter indicates an expected call of CreateBotEventDeadLetter.
+func (mr *MockBotRepositoryMockRecorder) CreateBotEventDeadLetter(letter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBotEventDeadLetter", reflect.TypeOf((*MockBotRepository)(nil).CreateBotEventDeadLetter), letter)
+}
+
 // DeleteBot mocks base method.
 func (m *MockBotRepository) DeleteBot(id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -94,6 +108,20 @@ func (mr *MockBotRepositoryMockRecorder) DeleteBot(id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBot", reflect.TypeOf((*MockBotRepository)(nil).DeleteBot), id)
 }
 
+// DeleteBotEventDeadLetter mocks base method.
+func (m *MockBotRepository) DeleteBotEventDeadLetter(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBotEventDeadLetter", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBotEventDeadLetter indicates an expected call of DeleteBotEventDeadLetter.
+func (mr *MockBotRepositoryMockRecorder) DeleteBotEventDeadLetter(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBotEventDeadLetter", reflect.TypeOf((*MockBotRepository)(nil).DeleteBotEventDeadLetter), id)
+}
+
 // GetBotByBotUserID mocks base method.
 func (m *MockBotRepository) GetBotByBotUserID(id uuid.UUID) (*model.Bot, error) {
 	m.ctrl.T.Helper()
@@ -139,6 +167,36 @@ func (mr *MockBotRepositoryMockRecorder) GetBotByID(id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBotByID", reflect.TypeOf((*MockBotRepository)(nil).GetBotByID), id)
 }
 
+// GetBotEventDeadLetter mocks base method.
+func (m *MockBotRepository) GetBotEventDeadLetter(id uuid.UUID) (*model.BotEventDeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBotEventDeadLetter", id)
+	ret0, _ := ret[0].(*model.BotEventDeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBotEventDeadLetter indicates an expected call of GetBotEventDeadLetter.
+func (mr *MockBotRepositoryMockRecorder) GetBotEventDeadLetter(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBotEventDeadLetter", reflect.TypeOf((*MockBotRepository)(nil).GetBotEventDeadLetter), id)
+}
+
+// GetBotEventDeadLetters mocks base method.
+func (m *MockBotRepository) GetBotEventDeadLetters(botID uuid.UUID, limit, offset int) ([]*model.BotEventDeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBotEventDeadLetters", botID, limit, offset)
+	ret0, _ := ret[0].([]*model.BotEventDeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBotEventDeadLetters indicates an expected call of GetBotEventDeadLetters.
+func (mr *MockBotRepositoryMockRecorder) GetBotEventDeadLetters(botID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBotEventDeadLetters", reflect.TypeOf((*MockBotRepository)(nil).GetBotEventDeadLetters), botID, limit, offset)
+}
+
 // GetBotEventLogs mocks base method.
 func (m *MockBotRepository) GetBotEventLogs(botID uuid.UUID, limit, offset int) ([]*model.BotEventLog, error) {
 	m.ctrl.T.Helper()
@@ -184,6 +242,20 @@ func (mr *MockBotRepositoryMockRecorder) GetParticipatingChannelIDsByBot(botID i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParticipatingChannelIDsByBot", reflect.TypeOf((*MockBotRepository)(nil).GetParticipatingChannelIDsByBot), botID)
 }
 
+// PurgeBotEventDeadLetters mocks base method.
+func (m *MockBotRepository) PurgeBotEventDeadLetters(before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeBotEventDeadLetters", before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeBotEventDeadLetters indicates an expected call of PurgeBotEventDeadLetters.
+func (mr *MockBotRepositoryMockRecorder) PurgeBotEventDeadLetters(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeBotEventDeadLetters", reflect.TypeOf((*MockBotRepository)(nil).PurgeBotEventDeadLetters), before)
+}
+
 // PurgeBotEventLogs mocks base method.
 func (m *MockBotRepository) PurgeBotEventLogs(before time.Time) error {
 	m.ctrl.T.Helper()
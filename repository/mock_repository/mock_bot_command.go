@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: bot_command.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/traPtitech/traQ/model"
+	repository "github.com/traPtitech/traQ/repository"
+)
+
+// MockBotCommandRepository is a mock of BotCommandRepository interface.
+type MockBotCommandRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBotCommandRepositoryMockRecorder
+}
+
+// MockBotCommandRepositoryMockRecorder is the mock recorder for MockBotCommandRepository.
+type MockBotCommandRepositoryMockRecorder struct {
+	mock *MockBotCommandRepository
+}
+
+// NewMockBotCommandRepository creates a new mock instance.
+func NewMockBotCommandRepository(ctrl *gomock.Controller) *MockBotCommandRepository {
+	mock := &MockBotCommandRepository{ctrl: ctrl}
+	mock.recorder = &MockBotCommandRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBotCommandRepository) EXPECT() *MockBotCommandRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetBotCommands mocks base method.
+func (m *MockBotCommandRepository) GetBotCommands(query repository.BotCommandsQuery) ([]*model.BotCommand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBotCommands", query)
+	ret0, _ := ret[0].([]*model.BotCommand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBotCommands indicates an expected call of GetBotCommands.
+func (mr *MockBotCommandRepositoryMockRecorder) GetBotCommands(query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBotCommands", reflect.TypeOf((*MockBotCommandRepository)(nil).GetBotCommands), query)
+}
+
+// SetBotCommands mocks base method.
+func (m *MockBotCommandRepository) SetBotCommands(botID uuid.UUID, commands []*model.BotCommand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBotCommands", botID, commands)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBotCommands indicates an expected call of SetBotCommands.
+func (mr *MockBotCommandRepositoryMockRecorder) SetBotCommands(botID, commands interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBotCommands", reflect.TypeOf((*MockBotCommandRepository)(nil).SetBotCommands), botID, commands)
+}
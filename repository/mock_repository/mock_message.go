@@ -12,6 +12,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/traPtitech/traQ/model"
 	repository "github.com/traPtitech/traQ/repository"
+	optional "github.com/traPtitech/traQ/utils/optional"
 )
 
 // MockMessageRepository is a mock of MessageRepository interface.
@@ -67,6 +68,36 @@ func (mr *MockMessageRepositoryMockRecorder) CreateMessage(userID, channelID, te
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessage", reflect.TypeOf((*MockMessageRepository)(nil).CreateMessage), userID, channelID, text)
 }
 
+// CreateMessageWithActions mocks base method.
+func (m *MockMessageRepository) CreateMessageWithActions(userID, channelID uuid.UUID, threadID optional.UUID, text string, actions model.MessageActions) (*model.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMessageWithActions", userID, channelID, threadID, text, actions)
+	ret0, _ := ret[0].(*model.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMessageWithActions indicates an expected call of CreateMessageWithActions.
+func (mr *MockMessageRepositoryMockRecorder) CreateMessageWithActions(userID, channelID, threadID, text, actions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessageWithActions", reflect.TypeOf((*MockMessageRepository)(nil).CreateMessageWithActions), userID, channelID, threadID, text, actions)
+}
+
+// CreateThreadMessage mocks base method.
+func (m *MockMessageRepository) CreateThreadMessage(userID, channelID, threadID uuid.UUID, text string) (*model.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateThreadMessage", userID, channelID, threadID, text)
+	ret0, _ := ret[0].(*model.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateThreadMessage indicates an expected call of CreateThreadMessage.
+func (mr *MockMessageRepositoryMockRecorder) CreateThreadMessage(userID, channelID, threadID, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateThreadMessage", reflect.TypeOf((*MockMessageRepository)(nil).CreateThreadMessage), userID, channelID, threadID, text)
+}
+
 // DeleteMessage mocks base method.
 func (m *MockMessageRepository) DeleteMessage(messageID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -95,6 +126,21 @@ func (mr *MockMessageRepositoryMockRecorder) DeleteUnreadsByChannelID(channelID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnreadsByChannelID", reflect.TypeOf((*MockMessageRepository)(nil).DeleteUnreadsByChannelID), channelID, userID)
 }
 
+// GetArchivedMessagesByID mocks base method.
+func (m *MockMessageRepository) GetArchivedMessagesByID(messageID uuid.UUID) ([]*model.ArchivedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedMessagesByID", messageID)
+	ret0, _ := ret[0].([]*model.ArchivedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedMessagesByID indicates an expected call of GetArchivedMessagesByID.
+func (mr *MockMessageRepositoryMockRecorder) GetArchivedMessagesByID(messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedMessagesByID", reflect.TypeOf((*MockMessageRepository)(nil).GetArchivedMessagesByID), messageID)
+}
+
 // GetChannelLatestMessages mocks base method.
 func (m *MockMessageRepository) GetChannelLatestMessages(query repository.ChannelLatestMessagesQuery) ([]*model.Message, error) {
 	m.ctrl.T.Helper()
@@ -157,6 +203,21 @@ func (mr *MockMessageRepositoryMockRecorder) GetMessages(query interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessages", reflect.TypeOf((*MockMessageRepository)(nil).GetMessages), query)
 }
 
+// GetThreadFollowerIDs mocks base method.
+func (m *MockMessageRepository) GetThreadFollowerIDs(threadID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThreadFollowerIDs", threadID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThreadFollowerIDs indicates an expected call of GetThreadFollowerIDs.
+func (mr *MockMessageRepositoryMockRecorder) GetThreadFollowerIDs(threadID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThreadFollowerIDs", reflect.TypeOf((*MockMessageRepository)(nil).GetThreadFollowerIDs), threadID)
+}
+
 // GetUnreadMessagesByUserID mocks base method.
 func (m *MockMessageRepository) GetUnreadMessagesByUserID(userID uuid.UUID) ([]*model.Message, error) {
 	m.ctrl.T.Helper()
@@ -203,6 +264,20 @@ func (mr *MockMessageRepositoryMockRecorder) GetUserUnreadChannels(userID interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserUnreadChannels", reflect.TypeOf((*MockMessageRepository)(nil).GetUserUnreadChannels), userID)
 }
 
+// HideMessage mocks base method.
+func (m *MockMessageRepository) HideMessage(messageID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HideMessage", messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HideMessage indicates an expected call of HideMessage.
+func (mr *MockMessageRepositoryMockRecorder) HideMessage(messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HideMessage", reflect.TypeOf((*MockMessageRepository)(nil).HideMessage), messageID)
+}
+
 // RemoveStampFromMessage mocks base method.
 func (m *MockMessageRepository) RemoveStampFromMessage(messageID, stampID, userID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -244,3 +319,17 @@ func (mr *MockMessageRepositoryMockRecorder) UpdateMessage(messageID, text inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessage", reflect.TypeOf((*MockMessageRepository)(nil).UpdateMessage), messageID, text)
 }
+
+// UpdateMessageWithActions mocks base method.
+func (m *MockMessageRepository) UpdateMessageWithActions(messageID uuid.UUID, text string, actions model.MessageActions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMessageWithActions", messageID, text, actions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMessageWithActions indicates an expected call of UpdateMessageWithActions.
+func (mr *MockMessageRepositoryMockRecorder) UpdateMessageWithActions(messageID, text, actions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessageWithActions", reflect.TypeOf((*MockMessageRepository)(nil).UpdateMessageWithActions), messageID, text, actions)
+}
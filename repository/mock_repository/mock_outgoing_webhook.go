@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: outgoing_webhook.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/traPtitech/traQ/model"
+	repository "github.com/traPtitech/traQ/repository"
+)
+
+// MockOutgoingWebhookRepository is a mock of OutgoingWebhookRepository interface.
+type MockOutgoingWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutgoingWebhookRepositoryMockRecorder
+}
+
+// MockOutgoingWebhookRepositoryMockRecorder is the mock recorder for MockOutgoingWebhookRepository.
+type MockOutgoingWebhookRepositoryMockRecorder struct {
+	mock *MockOutgoingWebhookRepository
+}
+
+// NewMockOutgoingWebhookRepository creates a new mock instance.
+func NewMockOutgoingWebhookRepository(ctrl *gomock.Controller) *MockOutgoingWebhookRepository {
+	mock := &MockOutgoingWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockOutgoingWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutgoingWebhookRepository) EXPECT() *MockOutgoingWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteOutgoingWebhook mocks base method.
+func (m *MockOutgoingWebhookRepository) DeleteOutgoingWebhook(webhookID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOutgoingWebhook", webhookID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOutgoingWebhook indicates an expected call of DeleteOutgoingWebhook.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) DeleteOutgoingWebhook(webhookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOutgoingWebhook", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).DeleteOutgoingWebhook), webhookID)
+}
+
+// GetOutgoingWebhook mocks base method.
+func (m *MockOutgoingWebhookRepository) GetOutgoingWebhook(webhookID uuid.UUID) (*model.OutgoingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingWebhook", webhookID)
+	ret0, _ := ret[0].(*model.OutgoingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutgoingWebhook indicates an expected call of GetOutgoingWebhook.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) GetOutgoingWebhook(webhookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhook", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).GetOutgoingWebhook), webhookID)
+}
+
+// GetOutgoingWebhookLogs mocks base method.
+func (m *MockOutgoingWebhookRepository) GetOutgoingWebhookLogs(webhookID uuid.UUID, limit, offset int) ([]*model.OutgoingWebhookLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingWebhookLogs", webhookID, limit, offset)
+	ret0, _ := ret[0].([]*model.OutgoingWebhookLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutgoingWebhookLogs indicates an expected call of GetOutgoingWebhookLogs.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) GetOutgoingWebhookLogs(webhookID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhookLogs", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).GetOutgoingWebhookLogs), webhookID, limit, offset)
+}
+
+// GetOutgoingWebhooksByChannelID mocks base method.
+func (m *MockOutgoingWebhookRepository) GetOutgoingWebhooksByChannelID(channelID uuid.UUID) ([]*model.OutgoingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingWebhooksByChannelID", channelID)
+	ret0, _ := ret[0].([]*model.OutgoingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutgoingWebhooksByChannelID indicates an expected call of GetOutgoingWebhooksByChannelID.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) GetOutgoingWebhooksByChannelID(channelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhooksByChannelID", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).GetOutgoingWebhooksByChannelID), channelID)
+}
+
+// PurgeOutgoingWebhookLogs mocks base method.
+func (m *MockOutgoingWebhookRepository) PurgeOutgoingWebhookLogs(before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeOutgoingWebhookLogs", before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeOutgoingWebhookLogs indicates an expected call of PurgeOutgoingWebhookLogs.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) PurgeOutgoingWebhookLogs(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeOutgoingWebhookLogs", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).PurgeOutgoingWebhookLogs), before)
+}
+
+// SetOutgoingWebhook mocks base method.
+func (m *MockOutgoingWebhookRepository) SetOutgoingWebhook(webhookID uuid.UUID, args repository.SetOutgoingWebhookArgs) (*model.OutgoingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOutgoingWebhook", webhookID, args)
+	ret0, _ := ret[0].(*model.OutgoingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOutgoingWebhook indicates an expected call of SetOutgoingWebhook.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) SetOutgoingWebhook(webhookID, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOutgoingWebhook", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).SetOutgoingWebhook), webhookID, args)
+}
+
+// WriteOutgoingWebhookLog mocks base method.
+func (m *MockOutgoingWebhookRepository) WriteOutgoingWebhookLog(log *model.OutgoingWebhookLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteOutgoingWebhookLog", log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteOutgoingWebhookLog indicates an expected call of WriteOutgoingWebhookLog.
+func (mr *MockOutgoingWebhookRepositoryMockRecorder) WriteOutgoingWebhookLog(log interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteOutgoingWebhookLog", reflect.TypeOf((*MockOutgoingWebhookRepository)(nil).WriteOutgoingWebhookLog), log)
+}
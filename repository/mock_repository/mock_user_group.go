@@ -0,0 +1,196 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_group.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/traPtitech/traQ/model"
+	repository "github.com/traPtitech/traQ/repository"
+)
+
+// MockUserGroupRepository is a mock of UserGroupRepository interface.
+type MockUserGroupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserGroupRepositoryMockRecorder
+}
+
+// MockUserGroupRepositoryMockRecorder is the mock recorder for MockUserGroupRepository.
+type MockUserGroupRepositoryMockRecorder struct {
+	mock *MockUserGroupRepository
+}
+
+// NewMockUserGroupRepository creates a new mock instance.
+func NewMockUserGroupRepository(ctrl *gomock.Controller) *MockUserGroupRepository {
+	mock := &MockUserGroupRepository{ctrl: ctrl}
+	mock.recorder = &MockUserGroupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserGroupRepository) EXPECT() *MockUserGroupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddUserToGroup mocks base method.
+func (m *MockUserGroupRepository) AddUserToGroup(userID, groupID uuid.UUID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserToGroup", userID, groupID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUserToGroup indicates an expected call of AddUserToGroup.
+func (mr *MockUserGroupRepositoryMockRecorder) AddUserToGroup(userID, groupID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToGroup", reflect.TypeOf((*MockUserGroupRepository)(nil).AddUserToGroup), userID, groupID, role)
+}
+
+// AddUserToGroupAdmin mocks base method.
+func (m *MockUserGroupRepository) AddUserToGroupAdmin(userID, groupID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserToGroupAdmin", userID, groupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUserToGroupAdmin indicates an expected call of AddUserToGroupAdmin.
+func (mr *MockUserGroupRepositoryMockRecorder) AddUserToGroupAdmin(userID, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToGroupAdmin", reflect.TypeOf((*MockUserGroupRepository)(nil).AddUserToGroupAdmin), userID, groupID)
+}
+
+// CreateUserGroup mocks base method.
+func (m *MockUserGroupRepository) CreateUserGroup(name, description, gType string, adminID, iconFileID uuid.UUID) (*model.UserGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserGroup", name, description, gType, adminID, iconFileID)
+	ret0, _ := ret[0].(*model.UserGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserGroup indicates an expected call of CreateUserGroup.
+func (mr *MockUserGroupRepositoryMockRecorder) CreateUserGroup(name, description, gType, adminID, iconFileID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserGroup", reflect.TypeOf((*MockUserGroupRepository)(nil).CreateUserGroup), name, description, gType, adminID, iconFileID)
+}
+
+// DeleteUserGroup mocks base method.
+func (m *MockUserGroupRepository) DeleteUserGroup(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserGroup", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserGroup indicates an expected call of DeleteUserGroup.
+func (mr *MockUserGroupRepositoryMockRecorder) DeleteUserGroup(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserGroup", reflect.TypeOf((*MockUserGroupRepository)(nil).DeleteUserGroup), id)
+}
+
+// GetAllUserGroups mocks base method.
+func (m *MockUserGroupRepository) GetAllUserGroups() ([]*model.UserGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllUserGroups")
+	ret0, _ := ret[0].([]*model.UserGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllUserGroups indicates an expected call of GetAllUserGroups.
+func (mr *MockUserGroupRepositoryMockRecorder) GetAllUserGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUserGroups", reflect.TypeOf((*MockUserGroupRepository)(nil).GetAllUserGroups))
+}
+
+// GetUserBelongingGroupIDs mocks base method.
+func (m *MockUserGroupRepository) GetUserBelongingGroupIDs(userID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserBelongingGroupIDs", userID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserBelongingGroupIDs indicates an expected call of GetUserBelongingGroupIDs.
+func (mr *MockUserGroupRepositoryMockRecorder) GetUserBelongingGroupIDs(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBelongingGroupIDs", reflect.TypeOf((*MockUserGroupRepository)(nil).GetUserBelongingGroupIDs), userID)
+}
+
+// GetUserGroup mocks base method.
+func (m *MockUserGroupRepository) GetUserGroup(id uuid.UUID) (*model.UserGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserGroup", id)
+	ret0, _ := ret[0].(*model.UserGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserGroup indicates an expected call of GetUserGroup.
+func (mr *MockUserGroupRepositoryMockRecorder) GetUserGroup(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserGroup", reflect.TypeOf((*MockUserGroupRepository)(nil).GetUserGroup), id)
+}
+
+// GetUserGroupByName mocks base method.
+func (m *MockUserGroupRepository) GetUserGroupByName(name string) (*model.UserGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserGroupByName", name)
+	ret0, _ := ret[0].(*model.UserGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserGroupByName indicates an expected call of GetUserGroupByName.
+func (mr *MockUserGroupRepositoryMockRecorder) GetUserGroupByName(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserGroupByName", reflect.TypeOf((*MockUserGroupRepository)(nil).GetUserGroupByName), name)
+}
+
+// RemoveUserFromGroup mocks base method.
+func (m *MockUserGroupRepository) RemoveUserFromGroup(userID, groupID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserFromGroup", userID, groupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveUserFromGroup indicates an expected call of RemoveUserFromGroup.
+func (mr *MockUserGroupRepositoryMockRecorder) RemoveUserFromGroup(userID, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserFromGroup", reflect.TypeOf((*MockUserGroupRepository)(nil).RemoveUserFromGroup), userID, groupID)
+}
+
+// RemoveUserFromGroupAdmin mocks base method.
+func (m *MockUserGroupRepository) RemoveUserFromGroupAdmin(userID, groupID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserFromGroupAdmin", userID, groupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveUserFromGroupAdmin indicates an expected call of RemoveUserFromGroupAdmin.
+func (mr *MockUserGroupRepositoryMockRecorder) RemoveUserFromGroupAdmin(userID, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserFromGroupAdmin", reflect.TypeOf((*MockUserGroupRepository)(nil).RemoveUserFromGroupAdmin), userID, groupID)
+}
+
+// UpdateUserGroup mocks base method.
+func (m *MockUserGroupRepository) UpdateUserGroup(id uuid.UUID, args repository.UpdateUserGroupArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserGroup", id, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserGroup indicates an expected call of UpdateUserGroup.
+func (mr *MockUserGroupRepositoryMockRecorder) UpdateUserGroup(id, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserGroup", reflect.TypeOf((*MockUserGroupRepository)(nil).UpdateUserGroup), id, args)
+}
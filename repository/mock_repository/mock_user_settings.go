@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_settings.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/traPtitech/traQ/model"
+	repository "github.com/traPtitech/traQ/repository"
+)
+
+// MockUserSettingsRepository is a mock of UserSettingsRepository interface.
+type MockUserSettingsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSettingsRepositoryMockRecorder
+}
+
+// MockUserSettingsRepositoryMockRecorder is the mock recorder for MockUserSettingsRepository.
+type MockUserSettingsRepositoryMockRecorder struct {
+	mock *MockUserSettingsRepository
+}
+
+// NewMockUserSettingsRepository creates a new mock instance.
+func NewMockUserSettingsRepository(ctrl *gomock.Controller) *MockUserSettingsRepository {
+	mock := &MockUserSettingsRepository{ctrl: ctrl}
+	mock.recorder = &MockUserSettingsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSettingsRepository) EXPECT() *MockUserSettingsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetDNDUserSettings mocks base method.
+func (m *MockUserSettingsRepository) GetDNDUserSettings() ([]*model.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDNDUserSettings")
+	ret0, _ := ret[0].([]*model.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDNDUserSettings indicates an expected call of GetDNDUserSettings.
+func (mr *MockUserSettingsRepositoryMockRecorder) GetDNDUserSettings() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDNDUserSettings", reflect.TypeOf((*MockUserSettingsRepository)(nil).GetDNDUserSettings))
+}
+
+// GetNotifyCitation mocks base method.
+func (m *MockUserSettingsRepository) GetNotifyCitation(userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifyCitation", userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifyCitation indicates an expected call of GetNotifyCitation.
+func (mr *MockUserSettingsRepositoryMockRecorder) GetNotifyCitation(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifyCitation", reflect.TypeOf((*MockUserSettingsRepository)(nil).GetNotifyCitation), userID)
+}
+
+// GetUserSettings mocks base method.
+func (m *MockUserSettingsRepository) GetUserSettings(userID uuid.UUID) (*model.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSettings", userID)
+	ret0, _ := ret[0].(*model.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSettings indicates an expected call of GetUserSettings.
+func (mr *MockUserSettingsRepositoryMockRecorder) GetUserSettings(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSettings", reflect.TypeOf((*MockUserSettingsRepository)(nil).GetUserSettings), userID)
+}
+
+// SetEmailDigestSentAt mocks base method.
+func (m *MockUserSettingsRepository) SetEmailDigestSentAt(userID uuid.UUID, sentAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailDigestSentAt", userID, sentAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEmailDigestSentAt indicates an expected call of SetEmailDigestSentAt.
+func (mr *MockUserSettingsRepositoryMockRecorder) SetEmailDigestSentAt(userID, sentAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailDigestSentAt", reflect.TypeOf((*MockUserSettingsRepository)(nil).SetEmailDigestSentAt), userID, sentAt)
+}
+
+// UpdateDNDSettings mocks base method.
+func (m *MockUserSettingsRepository) UpdateDNDSettings(userID uuid.UUID, args repository.UpdateDNDSettingsArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDNDSettings", userID, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDNDSettings indicates an expected call of UpdateDNDSettings.
+func (mr *MockUserSettingsRepositoryMockRecorder) UpdateDNDSettings(userID, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDNDSettings", reflect.TypeOf((*MockUserSettingsRepository)(nil).UpdateDNDSettings), userID, args)
+}
+
+// UpdateEmailDigestFrequency mocks base method.
+func (m *MockUserSettingsRepository) UpdateEmailDigestFrequency(userID uuid.UUID, frequency model.EmailDigestFrequency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmailDigestFrequency", userID, frequency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmailDigestFrequency indicates an expected call of UpdateEmailDigestFrequency.
+func (mr *MockUserSettingsRepositoryMockRecorder) UpdateEmailDigestFrequency(userID, frequency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmailDigestFrequency", reflect.TypeOf((*MockUserSettingsRepository)(nil).UpdateEmailDigestFrequency), userID, frequency)
+}
+
+// UpdateNotifyCitation mocks base method.
+func (m *MockUserSettingsRepository) UpdateNotifyCitation(userID uuid.UUID, isEnable bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotifyCitation", userID, isEnable)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotifyCitation indicates an expected call of UpdateNotifyCitation.
+func (mr *MockUserSettingsRepositoryMockRecorder) UpdateNotifyCitation(userID, isEnable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotifyCitation", reflect.TypeOf((*MockUserSettingsRepository)(nil).UpdateNotifyCitation), userID, isEnable)
+}
@@ -0,0 +1,169 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/traPtitech/traQ/model"
+	repository "github.com/traPtitech/traQ/repository"
+)
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookRepository) CreateWebhook(name, description string, channelID, iconFileID, creatorID uuid.UUID, secret string) (model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", name, description, channelID, iconFileID, creatorID, secret)
+	ret0, _ := ret[0].(model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) CreateWebhook(name, description, channelID, iconFileID, creatorID, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).CreateWebhook), name, description, channelID, iconFileID, creatorID, secret)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookRepository) DeleteWebhook(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) DeleteWebhook(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).DeleteWebhook), id)
+}
+
+// GetAllWebhooks mocks base method.
+func (m *MockWebhookRepository) GetAllWebhooks() ([]model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllWebhooks")
+	ret0, _ := ret[0].([]model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllWebhooks indicates an expected call of GetAllWebhooks.
+func (mr *MockWebhookRepositoryMockRecorder) GetAllWebhooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllWebhooks", reflect.TypeOf((*MockWebhookRepository)(nil).GetAllWebhooks))
+}
+
+// GetWebhook mocks base method.
+func (m *MockWebhookRepository) GetWebhook(id uuid.UUID) (model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", id)
+	ret0, _ := ret[0].(model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) GetWebhook(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).GetWebhook), id)
+}
+
+// GetWebhookByBotUserID mocks base method.
+func (m *MockWebhookRepository) GetWebhookByBotUserID(id uuid.UUID) (model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookByBotUserID", id)
+	ret0, _ := ret[0].(model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookByBotUserID indicates an expected call of GetWebhookByBotUserID.
+func (mr *MockWebhookRepositoryMockRecorder) GetWebhookByBotUserID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookByBotUserID", reflect.TypeOf((*MockWebhookRepository)(nil).GetWebhookByBotUserID), id)
+}
+
+// GetWebhookLogs mocks base method.
+func (m *MockWebhookRepository) GetWebhookLogs(webhookID uuid.UUID, limit, offset int) ([]*model.WebhookLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookLogs", webhookID, limit, offset)
+	ret0, _ := ret[0].([]*model.WebhookLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookLogs indicates an expected call of GetWebhookLogs.
+func (mr *MockWebhookRepositoryMockRecorder) GetWebhookLogs(webhookID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookLogs", reflect.TypeOf((*MockWebhookRepository)(nil).GetWebhookLogs), webhookID, limit, offset)
+}
+
+// GetWebhooksByCreator mocks base method.
+func (m *MockWebhookRepository) GetWebhooksByCreator(creatorID uuid.UUID) ([]model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooksByCreator", creatorID)
+	ret0, _ := ret[0].([]model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooksByCreator indicates an expected call of GetWebhooksByCreator.
+func (mr *MockWebhookRepositoryMockRecorder) GetWebhooksByCreator(creatorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooksByCreator", reflect.TypeOf((*MockWebhookRepository)(nil).GetWebhooksByCreator), creatorID)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockWebhookRepository) UpdateWebhook(id uuid.UUID, args repository.UpdateWebhookArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", id, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) UpdateWebhook(id, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).UpdateWebhook), id, args)
+}
+
+// WriteWebhookLog mocks base method.
+func (m *MockWebhookRepository) WriteWebhookLog(log *model.WebhookLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteWebhookLog", log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteWebhookLog indicates an expected call of WriteWebhookLog.
+func (mr *MockWebhookRepositoryMockRecorder) WriteWebhookLog(log interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteWebhookLog", reflect.TypeOf((*MockWebhookRepository)(nil).WriteWebhookLog), log)
+}
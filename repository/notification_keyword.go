@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// NotificationKeywordRepository キーワード通知リポジトリ
+type NotificationKeywordRepository interface {
+	// GetNotificationKeywords 指定したユーザーの通知キーワードを取得します
+	//
+	// 成功した場合、登録順のキーワードの配列とnilを返します。
+	// 存在しないユーザーを指定した場合は空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetNotificationKeywords(userID uuid.UUID) ([]string, error)
+	// SetNotificationKeywords 指定したユーザーの通知キーワードを置き換えます
+	//
+	// 成功した場合にnilを返します。大文字・小文字のみが異なるキーワードは1つにまとめます。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// 空のキーワード、長すぎるキーワードや多すぎるキーワードを指定した場合、ArgumentErrorを返します。
+	// DBによるエラーを返すことがあります。
+	SetNotificationKeywords(userID uuid.UUID, keywords []string) error
+	// GetAllNotificationKeywords 全ユーザーの通知キーワードを取得します
+	//
+	// 成功した場合、ユーザー毎のキーワードの配列とnilを返します。
+	// 結果はキャッシュされ、SetNotificationKeywordsで破棄されます。返り値のmapは変更しないでください。
+	// DBによるエラーを返すことがあります。
+	GetAllNotificationKeywords() (map[uuid.UUID][]string, error)
+}
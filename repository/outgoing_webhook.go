@@ -0,0 +1,65 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// SetOutgoingWebhookArgs 送信Webhook設定引数
+type SetOutgoingWebhookArgs struct {
+	URL          string
+	ChannelIDs   []uuid.UUID
+	TriggerWords []string
+	Reply        bool
+}
+
+// OutgoingWebhookRepository 送信Webhookリポジトリ
+type OutgoingWebhookRepository interface {
+	// SetOutgoingWebhook 指定したWebhookの送信設定を作成・更新します
+	//
+	// 送信対象チャンネルは全て置き換えられます。署名用シークレットは作成時に生成されます。
+	// 成功した場合、送信設定とnilを返します。
+	// 存在しないWebhookの場合、ErrNotFoundを返します。
+	// webhookIDにuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	SetOutgoingWebhook(webhookID uuid.UUID, args SetOutgoingWebhookArgs) (*model.OutgoingWebhook, error)
+	// GetOutgoingWebhook 指定したWebhookの送信設定を取得します
+	//
+	// 成功した場合、送信設定とnilを返します。
+	// 存在しなかった場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetOutgoingWebhook(webhookID uuid.UUID) (*model.OutgoingWebhook, error)
+	// GetOutgoingWebhooksByChannelID 指定したチャンネルを送信対象とする送信設定を全て取得します
+	//
+	// 成功した場合、送信設定の配列とnilを返します。
+	// 削除されたWebhookの送信設定は含まれません。
+	// DBによるエラーを返すことがあります。
+	GetOutgoingWebhooksByChannelID(channelID uuid.UUID) ([]*model.OutgoingWebhook, error)
+	// DeleteOutgoingWebhook 指定したWebhookの送信設定を削除します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しなかった場合、ErrNotFoundを返します。
+	// webhookIDにuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteOutgoingWebhook(webhookID uuid.UUID) error
+	// WriteOutgoingWebhookLog 送信Webhookの配送ログを書き込みます
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	WriteOutgoingWebhookLog(log *model.OutgoingWebhookLog) error
+	// GetOutgoingWebhookLogs 指定したWebhookの配送ログを取得します
+	//
+	// 成功した場合、配送ログの配列とnilを返します。負のoffset, limitは無視されます。
+	// 存在しないWebhookを指定した場合、空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetOutgoingWebhookLogs(webhookID uuid.UUID, limit, offset int) ([]*model.OutgoingWebhookLog, error)
+	// PurgeOutgoingWebhookLogs 指定した時間以前の送信Webhookの配送ログを全て消去します
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	PurgeOutgoingWebhookLogs(before time.Time) error
+}
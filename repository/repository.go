@@ -15,10 +15,15 @@ type Repository interface {
 	StarRepository
 	PinRepository
 	DeviceRepository
+	WebPushSubscriptionRepository
+	NotificationKeywordRepository
 	FileRepository
 	WebhookRepository
+	OutgoingWebhookRepository
 	OAuth2Repository
 	BotRepository
+	BotCommandRepository
 	ClipRepository
 	OgpCacheRepository
+	ScheduledMessageRepository
 }
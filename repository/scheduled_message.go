@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// UpdateScheduledMessageArgs 予約投稿メッセージ更新引数
+type UpdateScheduledMessageArgs struct {
+	Text        optional.String
+	ScheduledAt optional.Time
+}
+
+// ScheduledMessageRepository 予約投稿メッセージリポジトリ
+type ScheduledMessageRepository interface {
+	// CreateScheduledMessage 予約投稿メッセージを作成します
+	//
+	// 成功した場合、予約投稿メッセージとnilを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreateScheduledMessage(userID, channelID uuid.UUID, text string, scheduledAt time.Time) (*model.ScheduledMessage, error)
+	// UpdateScheduledMessage 指定した送信待ちの予約投稿メッセージを更新します
+	//
+	// 成功した場合、nilを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// 存在しない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// 送信待ちでない予約投稿メッセージを指定した場合、ErrForbiddenを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateScheduledMessage(id uuid.UUID, args UpdateScheduledMessageArgs) error
+	// DeleteScheduledMessage 指定した予約投稿メッセージを削除します
+	//
+	// 成功した場合、nilを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// 存在しない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteScheduledMessage(id uuid.UUID) error
+	// GetScheduledMessage 指定した予約投稿メッセージを取得します
+	//
+	// 成功した場合、予約投稿メッセージとnilを返します。
+	// 存在しない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetScheduledMessage(id uuid.UUID) (*model.ScheduledMessage, error)
+	// GetScheduledMessagesByUserID 指定したユーザーの予約投稿メッセージを予約日時の昇順で取得します
+	//
+	// 成功した場合、予約投稿メッセージの配列とnilを返します。
+	// 存在しないユーザーを指定した場合、空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetScheduledMessagesByUserID(userID uuid.UUID) ([]*model.ScheduledMessage, error)
+	// GetDueScheduledMessages 予約日時(再試行待ちの場合は再試行日時)がuntil以前の送信待ち予約投稿メッセージを予約日時の昇順で取得します
+	//
+	// 成功した場合、予約投稿メッセージの配列とnilを返します。負のlimitは無視されます。
+	// DBによるエラーを返すことがあります。
+	GetDueScheduledMessages(until time.Time, limit int) ([]*model.ScheduledMessage, error)
+	// ClaimScheduledMessage 指定した送信待ちの予約投稿メッセージを送信中状態にします
+	//
+	// 送信中状態にできた場合、trueとnilを返します。
+	// 予約投稿メッセージが存在しない、或いは送信待ちでない場合、falseとnilを返します。
+	// DBによるエラーを返すことがあります。
+	ClaimScheduledMessage(id uuid.UUID, now time.Time) (bool, error)
+	// ReleaseScheduledMessage 指定した送信中の予約投稿メッセージを送信待ち状態に戻し、retryAtに再試行するようにします
+	//
+	// 再試行回数が1増えます。
+	// 成功した場合、nilを返します。送信中でない予約投稿メッセージを指定した場合は何もしません。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	ReleaseScheduledMessage(id uuid.UUID, retryAt time.Time) error
+	// ReleaseStaleScheduledMessages claimedBefore以前から送信中のままの予約投稿メッセージを送信待ち状態に戻します
+	//
+	// 送信処理中にプロセスが停止した予約投稿メッセージの回収に使います。再試行回数が1増えます。
+	// 成功した場合、戻した予約投稿メッセージの数とnilを返します。
+	// DBによるエラーを返すことがあります。
+	ReleaseStaleScheduledMessages(claimedBefore time.Time) (int64, error)
+	// SetScheduledMessageError 指定した予約投稿メッセージを送信失敗状態にします
+	//
+	// 成功した場合、nilを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// 存在しない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	SetScheduledMessageError(id uuid.UUID, reason string) error
+}
@@ -30,6 +30,7 @@ type UpdateUserArgs struct {
 		State model.UserAccountStatus
 	}
 	Bio         optional.String
+	Email       optional.String
 	IconFileID  optional.UUID
 	LastOnline  optional.Time
 	HomeChannel optional.UUID
@@ -52,6 +53,7 @@ type UsersQuery struct {
 	IsGMemberOf                 optional.UUID
 	IsSubscriberAtMarkLevelOf   optional.UUID
 	IsSubscriberAtNotifyLevelOf optional.UUID
+	Role                        optional.String
 	EnableProfileLoading        bool
 }
 
@@ -97,6 +99,12 @@ func (q UsersQuery) SubscriberAtNotifyLevelOf(channelID uuid.UUID) UsersQuery {
 	return q
 }
 
+// RoleOf roleロールを持つユーザーである
+func (q UsersQuery) RoleOf(role string) UsersQuery {
+	q.Role = optional.StringFrom(role)
+	return q
+}
+
 // LoadProfile ユーザーの追加プロファイル情報を読み込むかどうか
 func (q UsersQuery) LoadProfile() UsersQuery {
 	q.EnableProfileLoading = true
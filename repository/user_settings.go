@@ -1,11 +1,30 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/gofrs/uuid"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
+// UpdateDNDSettingsArgs おやすみモード設定更新引数
+type UpdateDNDSettingsArgs struct {
+	// Until この日時までおやすみモードにします (無効の場合は手動おやすみモードを解除します)
+	Until optional.Time
+	// MuteForceChannels おやすみモード中に強制通知チャンネルのプッシュ通知も送信しないかどうか
+	MuteForceChannels bool
+	// QuietHoursEnabled 定期おやすみ時間帯を有効にするかどうか
+	QuietHoursEnabled bool
+	// QuietHoursStart 定期おやすみ時間帯の開始時刻 (HH:MM)
+	QuietHoursStart string
+	// QuietHoursEnd 定期おやすみ時間帯の終了時刻 (HH:MM)
+	QuietHoursEnd string
+	// QuietHoursTimezone 定期おやすみ時間帯のタイムゾーン (IANA名)
+	QuietHoursTimezone string
+}
+
 // UserSettingsRepository ユーザセッティングレポジトリ
 type UserSettingsRepository interface {
 	// UpdateNotifyCitation メッセージ引用通知を設定します
@@ -23,4 +42,24 @@ type UserSettingsRepository interface {
 	// GetUserSettings ユーザー設定を返します
 	// DBによるエラーを返すことがあります
 	GetUserSettings(userID uuid.UUID) (*model.UserSettings, error)
+	// UpdateEmailDigestFrequency 未読通知メールダイジェストの送信頻度を設定します
+	//
+	// model.EmailDigestOffを指定するとメールダイジェストを送信しません
+	// 不正な送信頻度を指定するとArgErrorを返します
+	// DBによるエラーを返すことがあります
+	UpdateEmailDigestFrequency(userID uuid.UUID, frequency model.EmailDigestFrequency) error
+	// SetEmailDigestSentAt 最後に未読通知メールダイジェストを送信した日時を記録します
+	// DBによるエラーを返すことがあります
+	SetEmailDigestSentAt(userID uuid.UUID, sentAt time.Time) error
+	// UpdateDNDSettings おやすみモード設定を更新します
+	//
+	// 不正な時刻・タイムゾーンを指定するとArgErrorを返します
+	// DBによるエラーを返すことがあります
+	UpdateDNDSettings(userID uuid.UUID, args UpdateDNDSettingsArgs) error
+	// GetDNDUserSettings 現在以降におやすみモードの可能性があるユーザーの設定を全て返します
+	//
+	// 実際におやすみモードかどうかはmodel.UserSettings.IsDNDActiveで判定してください
+	// 結果はキャッシュされ、UpdateDNDSettingsで破棄されます。返り値は変更しないでください
+	// DBによるエラーを返すことがあります
+	GetDNDUserSettings() ([]*model.UserSettings, error)
 }
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+// RegisterWebPushSubscriptionArgs Web Push購読情報登録引数
+type RegisterWebPushSubscriptionArgs struct {
+	Endpoint string
+	P256dh   string
+	Auth     string
+}
+
+// WebPushSubscriptionRepository Web Push購読情報リポジトリ
+type WebPushSubscriptionRepository interface {
+	// RegisterWebPushSubscription Web Pushの購読情報を登録します
+	//
+	// 成功した場合にnilを返します。既に登録されていた場合は鍵を更新します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// endpointが空文字列の場合、ArgumentErrorを返します。
+	// 登録しようとしたendpointが既に他のユーザーと関連づけられていた場合はArgumentErrorを返します。
+	// DBによるエラーを返すことがあります。
+	RegisterWebPushSubscription(userID uuid.UUID, args RegisterWebPushSubscriptionArgs) error
+	// UnregisterWebPushSubscription 指定したユーザーのWeb Pushの購読情報を削除します
+	//
+	// 成功した場合にnilを返します。
+	// 指定したユーザーの購読情報が存在しなかった場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	UnregisterWebPushSubscription(userID uuid.UUID, endpoint string) error
+	// GetWebPushSubscriptions 指定したユーザーの全Web Push購読情報を取得します
+	//
+	// 成功した場合、ユーザー毎の購読情報の配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetWebPushSubscriptions(userIDs set.UUID) (map[uuid.UUID][]*model.WebPushSubscription, error)
+	// DeleteWebPushSubscriptions Web Pushの購読情報を削除します
+	//
+	// 成功した、或いは既に削除されていた場合にnilを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteWebPushSubscriptions(endpoints []string) error
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/traPtitech/traQ/utils/optional"
 )
 
+// WebhookLogsLimit Webhook毎に保持する受信ログの最大件数
+const WebhookLogsLimit = 200
+
 // UpdateWebhookArgs Webhook情報更新引数
 type UpdateWebhookArgs struct {
 	Name        optional.String
@@ -14,6 +17,8 @@ type UpdateWebhookArgs struct {
 	ChannelID   optional.UUID
 	Secret      optional.String
 	CreatorID   optional.UUID
+	// EventTypes nilの場合は変更しません
+	EventTypes model.WebhookEventTypes
 }
 
 // WebhookRepository Webhookボットリポジトリ
@@ -62,4 +67,16 @@ type WebhookRepository interface {
 	// 存在しないユーザーを指定した場合は空配列とnilを返します。
 	// DBによるエラーを返すことがあります。
 	GetWebhooksByCreator(creatorID uuid.UUID) ([]model.Webhook, error)
+	// WriteWebhookLog Webhookの受信ログを書き込みます
+	//
+	// Webhook毎に最新のWebhookLogsLimit件のみ保持し、それより古いログは消去します。
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	WriteWebhookLog(log *model.WebhookLog) error
+	// GetWebhookLogs 指定したWebhookの受信ログを新しい順に取得します
+	//
+	// 成功した場合、受信ログの配列とnilを返します。負のoffset, limitは無視されます。
+	// 存在しないWebhookを指定した場合、空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetWebhookLogs(webhookID uuid.UUID, limit, offset int) ([]*model.WebhookLog, error)
 }
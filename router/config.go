@@ -20,6 +20,8 @@ type Config struct {
 	Gzipped bool
 	// AllowSignUp ユーザーが自分自身で登録できるかどうか
 	AllowSignUp bool
+	// HideDMMessageHistory DMのメッセージ編集履歴を投稿者以外に非公開にするかどうか
+	HideDMMessageHistory bool
 	// AccessTokenExp アクセストークンの有効時間(秒)
 	AccessTokenExp int
 	// IsRefreshEnabled リフレッシュトークンを発行するかどうか
@@ -28,6 +30,8 @@ type Config struct {
 	WebRTCSecretKey string
 	// WebRTCAPIKey WebRTCクレデンシャル用APIキー
 	WebRTCAPIKey string
+	// WebPushVAPIDPublicKey Web PushのVAPID公開鍵 (空の場合はWeb Push無効)
+	WebPushVAPIDPublicKey string
 	// ExternalAuth 外部認証設定
 	ExternalAuth ExternalAuthConfig
 }
@@ -79,7 +83,9 @@ func provideV3Config(c *Config) v3.Config {
 		Revision:                        c.Revision,
 		WebRTCSecretKey:                 c.WebRTCSecretKey,
 		WebRTCAPIKey:                    c.WebRTCAPIKey,
+		WebPushVAPIDPublicKey:           c.WebPushVAPIDPublicKey,
 		AllowSignUp:                     c.AllowSignUp,
+		HideDMMessageHistory:            c.HideDMMessageHistory,
 		EnabledExternalAccountProviders: c.ExternalAuth.ValidProviders(),
 	}
 }
@@ -1,21 +1,25 @@
 package consts
 
 const (
-	ParamChannelID      = "channelID"
-	ParamPinID          = "pinID"
-	ParamUserID         = "userID"
-	ParamUsername       = "username"
-	ParamGroupID        = "groupID"
-	ParamTagID          = "tagID"
-	ParamStampID        = "stampID"
-	ParamStampPaletteID = "paletteID"
-	ParamMessageID      = "messageID"
-	ParamReferenceID    = "referenceID"
-	ParamFileID         = "fileID"
-	ParamWebhookID      = "webhookID"
-	ParamTokenID        = "tokenID"
-	ParamBotID          = "botID"
-	ParamClientID       = "clientID"
-	ParamClipFolderID   = "folderID"
-	ParamURL            = "url"
+	ParamChannelID          = "channelID"
+	ParamPinID              = "pinID"
+	ParamUserID             = "userID"
+	ParamUsername           = "username"
+	ParamGroupID            = "groupID"
+	ParamTagID              = "tagID"
+	ParamStampID            = "stampID"
+	ParamStampPaletteID     = "paletteID"
+	ParamMessageID          = "messageID"
+	ParamReferenceID        = "referenceID"
+	ParamFileID             = "fileID"
+	ParamWebhookID          = "webhookID"
+	ParamTokenID            = "tokenID"
+	ParamBotID              = "botID"
+	ParamClientID           = "clientID"
+	ParamClipFolderID       = "folderID"
+	ParamScheduledMessageID = "scheduledMessageID"
+	ParamMessageReportID    = "reportID"
+	ParamDeadLetterID       = "deadLetterID"
+	ParamActionID           = "actionID"
+	ParamURL                = "url"
 )
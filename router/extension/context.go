@@ -58,6 +58,11 @@ func BindAndValidate(c echo.Context, i interface{}) error {
 	if err := c.Bind(i); err != nil {
 		return err
 	}
+	return Validate(c, i)
+}
+
+// Validate 構造体iを検証します
+func Validate(c echo.Context, i interface{}) error {
 	if err := vd.ValidateWithContext(utils.NewRequestValidateContext(c), i); err != nil {
 		if e, ok := err.(vd.InternalError); ok {
 			return herror.InternalServerError(e.InternalError())
@@ -221,3 +221,18 @@ func CheckClipFolderAccessPerm() echo.MiddlewareFunc {
 		}
 	}
 }
+
+// CheckScheduledMessageAccessPerm ScheduledMessageアクセス権限を確認するミドルウェア
+func CheckScheduledMessageAccessPerm() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user := c.Get(consts.KeyUser).(model.UserInfo)
+			sm := c.Get(consts.KeyParamScheduledMessage).(*model.ScheduledMessage)
+			if user.GetID() == sm.UserID {
+				return next(c) // 予約者のアクセス
+			}
+
+			return herror.NotFound()
+		}
+	}
+}
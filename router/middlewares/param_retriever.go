@@ -176,3 +176,17 @@ func (pr *ParamRetriever) ClipFolderID() echo.MiddlewareFunc {
 		return pr.repo.GetClipFolder(v)
 	})
 }
+
+// ScheduledMessageID リクエストURLの`scheduledMessageID`パラメータからScheduledMessageを取り出す
+func (pr *ParamRetriever) ScheduledMessageID() echo.MiddlewareFunc {
+	return pr.byUUID(consts.ParamScheduledMessageID, consts.KeyParamScheduledMessage, func(c echo.Context, v uuid.UUID) (interface{}, error) {
+		return pr.repo.GetScheduledMessage(v)
+	})
+}
+
+// MessageReportID リクエストURLの`reportID`パラメータからMessageReportを取り出す
+func (pr *ParamRetriever) MessageReportID() echo.MiddlewareFunc {
+	return pr.byUUID(consts.ParamMessageReportID, consts.KeyParamMessageReport, func(c echo.Context, v uuid.UUID) (interface{}, error) {
+		return pr.repo.GetMessageReport(v)
+	})
+}
@@ -0,0 +1,86 @@
+package middlewares
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/extension/herror"
+)
+
+const (
+	// webhookLogBodyLimit 受信ログに保存するリクエストボディの最大バイト数
+	webhookLogBodyLimit = 1000
+
+	webhookLogResultOK = "ok"
+	webhookLogResultNG = "ng"
+)
+
+// WebhookLogging Webhookの受信ログを記録するミドルウェア
+//
+// retrieve.WebhookIDの後に使用してください。
+// adapterには受信したエンドポイントの外部サービスアダプター名を指定します。
+func WebhookLogging(repo repository.WebhookRepository, logger *zap.Logger, adapter string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			w := c.Get(consts.KeyParamWebhook).(model.Webhook)
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return herror.InternalServerError(err)
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			err = next(c)
+
+			log := &model.WebhookLog{
+				ID:        uuid.Must(uuid.NewV4()),
+				WebhookID: w.GetID(),
+				Adapter:   adapter,
+				IP:        c.RealIP(),
+				Body:      truncateBody(body, webhookLogBodyLimit),
+				Result:    webhookLogResultOK,
+				Code:      c.Response().Status,
+				DateTime:  time.Now(),
+			}
+			if err != nil {
+				var he *echo.HTTPError
+				if errors.As(err, &he) {
+					log.Code = he.Code
+					log.Error = fmt.Sprint(he.Message)
+				} else {
+					// スタックトレース等の内部情報は記録しない
+					log.Code = http.StatusInternalServerError
+					log.Error = http.StatusText(http.StatusInternalServerError)
+				}
+			}
+			if log.Code >= 400 {
+				log.Result = webhookLogResultNG
+			}
+			if err := repo.WriteWebhookLog(log); err != nil {
+				logger.Error("failed to write webhook log", zap.Error(err), zap.Stringer("webhookID", w.GetID()))
+			}
+
+			return err
+		}
+	}
+}
+
+// truncateBody bをlimitバイト以下に切り詰め、不正なUTF-8のバイト列を取り除いた文字列を返します
+func truncateBody(b []byte, limit int) string {
+	if len(b) > limit {
+		b = b[:limit]
+	}
+	return strings.ToValidUTF8(string(b), "")
+}
@@ -200,3 +200,17 @@ var IsValidBotEvents = vd.By(func(value interface{}) error {
 	}
 	return nil
 })
+
+// IsValidWebhookEvents 有効なWebhookイベントのセットである
+var IsValidWebhookEvents = vd.By(func(value interface{}) error {
+	s, ok := value.(model.WebhookEventTypes)
+	if !ok || s == nil {
+		return nil
+	}
+	for v := range s {
+		if !v.Valid() {
+			return errors.New("must be valid webhook event type")
+		}
+	}
+	return nil
+})
@@ -0,0 +1,251 @@
+package v3
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+// PutBotCommandsRequest PUT /bots/:botID/commands リクエストボディ
+type PutBotCommandsRequest struct {
+	Commands []*BotCommandRequest `json:"commands"`
+}
+
+// BotCommandRequest スラッシュコマンドの定義
+type BotCommandRequest struct {
+	Name        string                      `json:"name"`
+	Description string                      `json:"description"`
+	Arguments   []*model.BotCommandArgument `json:"arguments"`
+}
+
+func (r PutBotCommandsRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Commands, vd.NotNil, vd.Length(0, 100), vd.By(uniqueBotCommandNames)),
+	)
+}
+
+func (r BotCommandRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Name, validator.BotCommandNameRuleRequired...),
+		vd.Field(&r.Description, vd.RuneLength(0, 1000)),
+		vd.Field(&r.Arguments, vd.Length(0, 20), vd.By(validateBotCommandArguments)),
+	)
+}
+
+func uniqueBotCommandNames(v interface{}) error {
+	names := make(map[string]bool)
+	for _, c := range v.([]*BotCommandRequest) {
+		if c == nil {
+			return errors.New("must not contain null")
+		}
+		if names[c.Name] {
+			return errors.New("must not contain duplicate names")
+		}
+		names[c.Name] = true
+	}
+	return nil
+}
+
+func validateBotCommandArguments(v interface{}) error {
+	names := make(map[string]bool)
+	for _, arg := range v.([]*model.BotCommandArgument) {
+		if arg == nil {
+			return errors.New("must not contain null")
+		}
+		if err := vd.Validate(arg.Name, validator.BotCommandNameRuleRequired...); err != nil {
+			return errors.New("name " + err.Error())
+		}
+		if err := vd.Validate(arg.Description, vd.RuneLength(0, 1000)); err != nil {
+			return errors.New("description " + err.Error())
+		}
+		if !arg.Type.Valid() {
+			return errors.New("type must be one of string, integer, boolean")
+		}
+		if names[arg.Name] {
+			return errors.New("must not contain duplicate names")
+		}
+		names[arg.Name] = true
+	}
+	return nil
+}
+
+// GetBotCommands GET /bots/:botID/commands
+func (h *Handlers) GetBotCommands(c echo.Context) error {
+	b := getParamBot(c)
+
+	commands, err := h.Repo.GetBotCommands(repository.BotCommandsQuery{}.Bot(b.ID))
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatBotCommands(commands))
+}
+
+// PutBotCommands PUT /bots/:botID/commands
+func (h *Handlers) PutBotCommands(c echo.Context) error {
+	b := getParamBot(c)
+
+	var req PutBotCommandsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	commands := make([]*model.BotCommand, len(req.Commands))
+	for i, cmd := range req.Commands {
+		args := model.BotCommandArguments(cmd.Arguments)
+		if args == nil {
+			args = model.BotCommandArguments{}
+		}
+		commands[i] = &model.BotCommand{
+			Name:        cmd.Name,
+			Description: cmd.Description,
+			Arguments:   args,
+		}
+	}
+	if err := h.Repo.SetBotCommands(b.ID, commands); err != nil {
+		switch err {
+		case repository.ErrAlreadyExists:
+			return herror.Conflict("command names must be unique")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetChannelCommands GET /channels/:channelID/commands
+func (h *Handlers) GetChannelCommands(c echo.Context) error {
+	ch := getParamChannel(c)
+
+	commands, err := h.getChannelBotCommands(ch, repository.BotCommandsQuery{})
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatBotCommands(commands))
+}
+
+// getChannelBotCommands チャンネルで使用可能なスラッシュコマンドのうち、queryに一致するものを取得します
+//
+// チャンネルに参加している有効なBOTのコマンド、DMの場合は相手のBOTのコマンドが対象です
+func (h *Handlers) getChannelBotCommands(ch *model.Channel, query repository.BotCommandsQuery) ([]*model.BotCommand, error) {
+	if ch.IsDMChannel() {
+		return h.getDMChannelBotCommands(ch.ID, query)
+	}
+	return h.Repo.GetBotCommands(query.Active().CMemberOf(ch.ID))
+}
+
+// getDMChannelBotCommands DMの相手のBOTのスラッシュコマンドのうち、queryに一致するものを取得します
+func (h *Handlers) getDMChannelBotCommands(channelID uuid.UUID, query repository.BotCommandsQuery) ([]*model.BotCommand, error) {
+	members, err := h.ChannelManager.GetDMChannelMembers(channelID)
+	if err != nil {
+		return nil, err
+	}
+	commands := make([]*model.BotCommand, 0)
+	for _, member := range members {
+		bots, err := h.Repo.GetBots(repository.BotsQuery{}.Active().BotUserID(member))
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range bots {
+			cmds, err := h.Repo.GetBotCommands(query.Bot(b.ID))
+			if err != nil {
+				return nil, err
+			}
+			commands = append(commands, cmds...)
+		}
+	}
+	return commands, nil
+}
+
+// PostChannelCommandRequest POST /channels/:channelID/commands リクエストボディ
+type PostChannelCommandRequest struct {
+	Text string `json:"text"`
+}
+
+func (r PostChannelCommandRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Text, vd.Required, vd.RuneLength(1, 10000)),
+	)
+}
+
+// InvokeChannelCommand POST /channels/:channelID/commands
+func (h *Handlers) InvokeChannelCommand(c echo.Context) error {
+	userID := getRequestUserID(c)
+	ch := getParamChannel(c)
+
+	var req PostChannelCommandRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+	if ch.IsArchived() {
+		return herror.BadRequest(fmt.Sprintf("channel #%s has been archived", h.ChannelManager.PublicChannelTree().GetChannelPath(ch.ID)))
+	}
+
+	name, text, ok := model.ParseBotCommandInvocation(req.Text)
+	if !ok {
+		return herror.BadRequest("text must start with /{command name}")
+	}
+	// `/name@bot`の形式でBOTを指定できる
+	name, botName, _ := strings.Cut(name, "@")
+
+	// コマンドはBOTが参加しているチャンネル、またはBOTとのDMでのみ使用可能
+	commands, err := h.getChannelBotCommands(ch, repository.BotCommandsQuery{}.Named(name))
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	var (
+		cmd *model.BotCommand
+		b   *model.Bot
+	)
+	for _, candidate := range commands {
+		cb, err := h.Repo.GetBotByID(candidate.BotID)
+		if err != nil {
+			return herror.InternalServerError(err)
+		}
+		if len(botName) > 0 {
+			u, err := h.Repo.GetUser(cb.BotUserID, false)
+			if err != nil {
+				return herror.InternalServerError(err)
+			}
+			if u.GetName() != botName {
+				continue
+			}
+		}
+		if cmd != nil {
+			return herror.Conflict(fmt.Sprintf("multiple bots provide /%s, specify the bot as /%s@{bot name}", name, name))
+		}
+		cmd, b = candidate, cb
+	}
+	if cmd == nil {
+		return herror.NotFound("command not found")
+	}
+
+	args, err := cmd.ParseArguments(text)
+	if err != nil {
+		return herror.BadRequest(err.Error())
+	}
+
+	h.Hub.Publish(hub.Message{
+		Name: event.BotCommandInvoked,
+		Fields: hub.Fields{
+			"bot_id":     b.ID,
+			"command":    cmd,
+			"user_id":    userID,
+			"channel_id": ch.ID,
+			"arguments":  args,
+			"text":       text,
+		},
+	})
+	return c.NoContent(http.StatusNoContent)
+}
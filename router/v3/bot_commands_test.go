@@ -0,0 +1,564 @@
+package v3
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/random"
+)
+
+// createBotCommand BOTにスラッシュコマンドを必ず登録します
+func createBotCommand(t *testing.T, env *Env, botID uuid.UUID, args ...*model.BotCommandArgument) *model.BotCommand {
+	t.Helper()
+	if args == nil {
+		args = model.BotCommandArguments{}
+	}
+	cmd := &model.BotCommand{
+		Name:        random.AlphaNumeric(20),
+		Description: "desc",
+		Arguments:   args,
+	}
+	require.NoError(t, env.Repository.SetBotCommands(botID, []*model.BotCommand{cmd}))
+	return cmd
+}
+
+func TestPutBotCommandsRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	arg := func(name string, typ model.BotCommandArgumentType) *model.BotCommandArgument {
+		return &model.BotCommandArgument{Name: name, Type: typ}
+	}
+	tests := []struct {
+		name     string
+		commands []*BotCommandRequest
+		wantErr  bool
+	}{
+		{
+			"nil commands",
+			nil,
+			true,
+		},
+		{
+			"empty commands",
+			[]*BotCommandRequest{},
+			false,
+		},
+		{
+			"null command",
+			[]*BotCommandRequest{nil},
+			true,
+		},
+		{
+			"empty name",
+			[]*BotCommandRequest{{Name: ""}},
+			true,
+		},
+		{
+			"bad name",
+			[]*BotCommandRequest{{Name: "こまんど"}},
+			true,
+		},
+		{
+			"too long name",
+			[]*BotCommandRequest{{Name: strings.Repeat("a", 33)}},
+			true,
+		},
+		{
+			"duplicate names",
+			[]*BotCommandRequest{{Name: "po"}, {Name: "po"}},
+			true,
+		},
+		{
+			"too long description",
+			[]*BotCommandRequest{{Name: "po", Description: strings.Repeat("a", 1001)}},
+			true,
+		},
+		{
+			"null argument",
+			[]*BotCommandRequest{{Name: "po", Arguments: []*model.BotCommandArgument{nil}}},
+			true,
+		},
+		{
+			"bad argument name",
+			[]*BotCommandRequest{{Name: "po", Arguments: []*model.BotCommandArgument{arg("ひきすう", model.BotCommandArgumentString)}}},
+			true,
+		},
+		{
+			"bad argument type",
+			[]*BotCommandRequest{{Name: "po", Arguments: []*model.BotCommandArgument{arg("a", "float")}}},
+			true,
+		},
+		{
+			"duplicate argument names",
+			[]*BotCommandRequest{{Name: "po", Arguments: []*model.BotCommandArgument{arg("a", model.BotCommandArgumentString), arg("a", model.BotCommandArgumentInteger)}}},
+			true,
+		},
+		{
+			"success",
+			[]*BotCommandRequest{
+				{Name: "po", Description: "desc", Arguments: []*model.BotCommandArgument{arg("a", model.BotCommandArgumentInteger), arg("b", model.BotCommandArgumentString)}},
+				{Name: "po-2"},
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := PutBotCommandsRequest{Commands: tt.commands}
+			if err := r.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandlers_GetBotCommands(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/bots/{botId}/commands"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	commonSession := env.S(t, user.GetID())
+	bot := env.CreateBot(t, rand, user.GetID())
+	cmd := createBotCommand(t, env, bot.ID, &model.BotCommandArgument{Name: "count", Type: model.BotCommandArgumentInteger, Required: true})
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, bot.ID.String()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV4()).String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, bot.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+
+		first := obj.First().Object()
+		first.Value("id").String().Equal(cmd.ID.String())
+		first.Value("botId").String().Equal(bot.ID.String())
+		first.Value("name").String().Equal(cmd.Name)
+		first.Value("description").String().Equal(cmd.Description)
+		first.Value("createdAt").String().NotEmpty()
+		first.Value("updatedAt").String().NotEmpty()
+
+		args := first.Value("arguments").Array()
+		args.Length().Equal(1)
+		args.First().Object().Value("name").String().Equal("count")
+		args.First().Object().Value("type").String().Equal(string(model.BotCommandArgumentInteger))
+		args.First().Object().Value("required").Boolean().True()
+	})
+}
+
+func TestHandlers_PutBotCommands(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/bots/{botId}/commands"
+	env := Setup(t, common1)
+	user1 := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	commonSession := env.S(t, user1.GetID())
+	bot1 := env.CreateBot(t, rand, user1.GetID())
+	bot2 := env.CreateBot(t, rand, user2.GetID())
+	bot3 := env.CreateBot(t, rand, user1.GetID())
+	taken := createBotCommand(t, env, bot2.ID)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bot1.ID.String()).
+			WithJSON(&PutBotCommandsRequest{Commands: []*BotCommandRequest{}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PutBotCommandsRequest{Commands: []*BotCommandRequest{{Name: "po"}, {Name: "po"}}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bot2.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PutBotCommandsRequest{Commands: []*BotCommandRequest{}}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, uuid.Must(uuid.NewV4()).String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PutBotCommandsRequest{Commands: []*BotCommandRequest{}}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success (same name as another bot)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bot3.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PutBotCommandsRequest{Commands: []*BotCommandRequest{{Name: taken.Name}}}).
+			Expect().
+			Status(http.StatusNoContent)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		name := random.AlphaNumeric(20)
+		e.PUT(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PutBotCommandsRequest{Commands: []*BotCommandRequest{{
+				Name:        name,
+				Description: "desc",
+				Arguments:   []*model.BotCommandArgument{{Name: "a", Type: model.BotCommandArgumentBoolean}},
+			}}}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		commands, err := env.Repository.GetBotCommands(repository.BotCommandsQuery{}.Bot(bot1.ID))
+		require.NoError(t, err)
+		if assert.Len(t, commands, 1) {
+			assert.Equal(t, name, commands[0].Name)
+			assert.Equal(t, "desc", commands[0].Description)
+			assert.Len(t, commands[0].Arguments, 1)
+		}
+
+		// 空配列で全て削除
+		e.PUT(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PutBotCommandsRequest{Commands: []*BotCommandRequest{}}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		commands, err = env.Repository.GetBotCommands(repository.BotCommandsQuery{}.Bot(bot1.ID))
+		require.NoError(t, err)
+		assert.Len(t, commands, 0)
+	})
+}
+
+func TestHandlers_GetChannelCommands(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/channels/{channelId}/commands"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	commonSession := env.S(t, user.GetID())
+	channel := env.CreateChannel(t, rand)
+
+	activeBot := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.ChangeBotState(activeBot.ID, model.BotActive))
+	require.NoError(t, env.Repository.AddBotToChannel(activeBot.ID, channel.ID))
+	activeCmd := createBotCommand(t, env, activeBot.ID)
+
+	inactiveBot := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.AddBotToChannel(inactiveBot.ID, channel.ID))
+	createBotCommand(t, env, inactiveBot.ID)
+
+	notJoinedBot := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.ChangeBotState(notJoinedBot.ID, model.BotActive))
+	notJoinedCmd := createBotCommand(t, env, notJoinedBot.ID)
+
+	dm := env.CreateDMChannel(t, user.GetID(), notJoinedBot.BotUserID)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, channel.ID.String()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV4()).String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, channel.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+		obj.First().Object().Value("id").String().Equal(activeCmd.ID.String())
+	})
+
+	t.Run("success (dm)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, dm.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+		obj.First().Object().Value("id").String().Equal(notJoinedCmd.ID.String())
+	})
+}
+
+func TestHandlers_InvokeChannelCommand(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/channels/{channelId}/commands"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	commonSession := env.S(t, user.GetID())
+	channel := env.CreateChannel(t, rand)
+	archived := env.CreateChannel(t, rand)
+	otherChannel := env.CreateChannel(t, rand)
+
+	bot := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.ChangeBotState(bot.ID, model.BotActive))
+	require.NoError(t, env.Repository.AddBotToChannel(bot.ID, channel.ID))
+	require.NoError(t, env.Repository.AddBotToChannel(bot.ID, archived.ID))
+	require.NoError(t, env.CM.ArchiveChannel(archived.ID, user.GetID()))
+	cmd := createBotCommand(t, env, bot.ID,
+		&model.BotCommandArgument{Name: "count", Type: model.BotCommandArgumentInteger, Required: true},
+		&model.BotCommandArgument{Name: "text", Type: model.BotCommandArgumentString},
+	)
+	dm := env.CreateDMChannel(t, user.GetID(), bot.BotUserID)
+
+	inactiveBot := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.AddBotToChannel(inactiveBot.ID, channel.ID))
+	inactiveCmd := createBotCommand(t, env, inactiveBot.ID)
+
+	// 同名のコマンドを持つBOTが参加しているチャンネル
+	shared := env.CreateChannel(t, rand)
+	otherBot := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.ChangeBotState(otherBot.ID, model.BotActive))
+	require.NoError(t, env.Repository.SetBotCommands(otherBot.ID, []*model.BotCommand{{Name: cmd.Name}}))
+	require.NoError(t, env.Repository.AddBotToChannel(bot.ID, shared.ID))
+	require.NoError(t, env.Repository.AddBotToChannel(otherBot.ID, shared.ID))
+	otherBotUser, err := env.Repository.GetUser(otherBot.BotUserID, false)
+	require.NoError(t, err)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, channel.ID.String()).
+			WithJSON(&PostChannelCommandRequest{Text: "/" + cmd.Name + " 1"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (empty text)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostChannelCommandRequest{Text: ""}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (not a command)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostChannelCommandRequest{Text: cmd.Name + " 1"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (archived channel)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, archived.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostChannelCommandRequest{Text: "/" + cmd.Name + " 1"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (missing argument)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostChannelCommandRequest{Text: "/" + cmd.Name}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (invalid argument)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostChannelCommandRequest{Text: "/" + cmd.Name + " one"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("not found (channel)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV4()).String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostChannelCommandRequest{Text: "/" + cmd.Name + " 1"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("not found (command)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostChannelCommandRequest{Text: "/" + random.AlphaNumeric(20)}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("not found (bot not in channel)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, otherChannel.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostChannelCommandRequest{Text: "/" + cmd.Name + " 1"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("not found (inactive bot)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostChannelCommandRequest{Text: "/" + inactiveCmd.Name}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("conflict (multiple bots)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, shared.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostChannelCommandRequest{Text: "/" + cmd.Name + " 1"}).
+			Expect().
+			Status(http.StatusConflict)
+	})
+
+	t.Run("not found (unknown bot specified)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, shared.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostChannelCommandRequest{Text: "/" + cmd.Name + "@" + random.AlphaNumeric(20)}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success (bot specified)", func(t *testing.T) {
+		t.Parallel()
+		sub := env.Hub.Subscribe(10, event.BotCommandInvoked)
+		defer env.Hub.Unsubscribe(sub)
+
+		e := env.R(t)
+		e.POST(path, shared.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostChannelCommandRequest{Text: "/" + cmd.Name + "@" + otherBotUser.GetName()}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case ev := <-sub.Receiver:
+				if ev.Fields["channel_id"].(uuid.UUID) != shared.ID {
+					continue
+				}
+				assert.Equal(t, otherBot.ID, ev.Fields["bot_id"])
+				return
+			case <-timeout:
+				t.Fatal("BotCommandInvoked event was not published")
+			}
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		sub := env.Hub.Subscribe(10, event.BotCommandInvoked)
+		defer env.Hub.Unsubscribe(sub)
+
+		e := env.R(t)
+		e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostChannelCommandRequest{Text: "/" + cmd.Name + " 3 hello world"}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case ev := <-sub.Receiver:
+				if ev.Fields["bot_id"].(uuid.UUID) != bot.ID || ev.Fields["channel_id"].(uuid.UUID) != channel.ID {
+					continue
+				}
+				assert.Equal(t, user.GetID(), ev.Fields["user_id"])
+				assert.Equal(t, map[string]interface{}{"count": int64(3), "text": "hello world"}, ev.Fields["arguments"])
+				return
+			case <-timeout:
+				t.Fatal("BotCommandInvoked event was not published")
+			}
+		}
+	})
+
+	t.Run("success (dm)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, dm.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostChannelCommandRequest{Text: "/" + cmd.Name + " 1"}).
+			Expect().
+			Status(http.StatusNoContent)
+	})
+}
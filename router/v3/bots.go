@@ -2,7 +2,9 @@ package v3
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"regexp"
 
 	vd "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -137,13 +139,14 @@ func (h *Handlers) GetBot(c echo.Context) error {
 
 // PatchBotRequest PATCH /bots/:botID リクエストボディ
 type PatchBotRequest struct {
-	DisplayName     optional.String     `json:"displayName"`
-	Description     optional.String     `json:"description"`
-	Mode            optional.String     `json:"mode"`
-	Endpoint        optional.String     `json:"endpoint"`
-	Privileged      optional.Bool       `json:"privileged"`
-	DeveloperID     optional.UUID       `json:"developerId"`
-	SubscribeEvents model.BotEventTypes `json:"subscribeEvents"`
+	DisplayName     optional.String       `json:"displayName"`
+	Description     optional.String       `json:"description"`
+	Mode            optional.String       `json:"mode"`
+	Endpoint        optional.String       `json:"endpoint"`
+	Privileged      optional.Bool         `json:"privileged"`
+	DeveloperID     optional.UUID         `json:"developerId"`
+	SubscribeEvents model.BotEventTypes   `json:"subscribeEvents"`
+	EventFilter     *model.BotEventFilter `json:"eventFilter"`
 }
 
 func (r PatchBotRequest) ValidateWithContext(ctx context.Context) error {
@@ -154,9 +157,51 @@ func (r PatchBotRequest) ValidateWithContext(ctx context.Context) error {
 		vd.Field(&r.Endpoint, is.URL, validator.NotInternalURL),
 		vd.Field(&r.DeveloperID, validator.NotNilUUID, utils.IsActiveHumanUserID),
 		vd.Field(&r.SubscribeEvents, utils.IsValidBotEvents),
+		vd.Field(&r.EventFilter, vd.WithContext(validateBotEventFilter)),
 	)
 }
 
+func validateBotEventFilter(ctx context.Context, v interface{}) error {
+	f, _ := v.(*model.BotEventFilter)
+	if f == nil {
+		return nil
+	}
+
+	if len(f.Channels) > 100 {
+		return errors.New("channels: the length must be no more than 100")
+	}
+	channels := make(map[uuid.UUID]bool, len(f.Channels))
+	for _, c := range f.Channels {
+		if c == nil {
+			return errors.New("channels: must not contain null")
+		}
+		if err := vd.ValidateWithContext(ctx, c.ChannelID, vd.Required, utils.IsPublicChannelID); err != nil {
+			return errors.New("channels: " + err.Error())
+		}
+		if channels[c.ChannelID] {
+			return errors.New("channels: must not contain duplicate channels")
+		}
+		channels[c.ChannelID] = true
+	}
+
+	if len(f.MessagePrefixes) > 20 {
+		return errors.New("messagePrefixes: the length must be no more than 20")
+	}
+	for _, p := range f.MessagePrefixes {
+		if err := vd.Validate(p, vd.Required, vd.RuneLength(1, 100)); err != nil {
+			return errors.New("messagePrefixes: " + err.Error())
+		}
+	}
+
+	if err := vd.Validate(f.MessagePattern, vd.RuneLength(0, 200)); err != nil {
+		return errors.New("messagePattern: " + err.Error())
+	}
+	if _, err := regexp.Compile(f.MessagePattern); err != nil {
+		return errors.New("messagePattern: invalid regular expression")
+	}
+	return nil
+}
+
 // EditBot PATCH /bots/:botID
 func (h *Handlers) EditBot(c echo.Context) error {
 	b := getParamBot(c)
@@ -184,6 +229,7 @@ func (h *Handlers) EditBot(c echo.Context) error {
 		Privileged:      req.Privileged,
 		CreatorID:       req.DeveloperID,
 		SubscribeEvents: req.SubscribeEvents,
+		EventFilter:     req.EventFilter,
 	}
 
 	if err := h.Repo.UpdateBot(b.ID, args); err != nil {
@@ -255,6 +301,76 @@ func (h *Handlers) GetBotLogs(c echo.Context) error {
 	return c.JSON(http.StatusOK, formatBotEventLogs(logs))
 }
 
+// GetBotDeadLettersRequest GET /bots/:botID/dead-letters リクエストクエリ
+type GetBotDeadLettersRequest struct {
+	Limit  int `query:"limit"`
+	Offset int `query:"offset"`
+}
+
+func (r *GetBotDeadLettersRequest) Validate() error {
+	if r.Limit == 0 {
+		r.Limit = 30
+	}
+	return vd.ValidateStruct(r,
+		vd.Field(&r.Limit, vd.Min(1), vd.Max(200)),
+		vd.Field(&r.Offset, vd.Min(0)),
+	)
+}
+
+// GetBotDeadLetters GET /bots/:botID/dead-letters
+func (h *Handlers) GetBotDeadLetters(c echo.Context) error {
+	b := getParamBot(c)
+
+	var req GetBotDeadLettersRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	letters, err := h.Repo.GetBotEventDeadLetters(b.ID, req.Limit, req.Offset)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, formatBotEventDeadLetters(letters))
+}
+
+// RedeliverBotDeadLetter POST /bots/:botID/dead-letters/:deadLetterID/redeliver
+func (h *Handlers) RedeliverBotDeadLetter(c echo.Context) error {
+	b := getParamBot(c)
+	letterID := getParamAsUUID(c, consts.ParamDeadLetterID)
+
+	letter, err := h.Repo.GetBotEventDeadLetter(letterID)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	if letter.BotID != b.ID {
+		return herror.NotFound()
+	}
+	if b.State != model.BotActive {
+		return herror.BadRequest("this bot is not active")
+	}
+
+	ok, err := h.BOT.RedeliverDeadLetter(b, letter)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound: // 同時に再送された
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	if !ok {
+		// 再送が予約された
+		return c.NoContent(http.StatusAccepted)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
 // GetChannelBots GET /channels/:channelID/bots
 func (h *Handlers) GetChannelBots(c echo.Context) error {
 	channelID := getParamAsUUID(c, consts.ParamChannelID)
@@ -315,6 +431,7 @@ func (h *Handlers) ReissueBot(c echo.Context) error {
 	return c.JSON(http.StatusOK, echo.Map{
 		"verificationToken": b.VerificationToken,
 		"accessToken":       t.AccessToken,
+		"signingSecret":     b.SigningSecret,
 	})
 }
 
@@ -234,6 +234,7 @@ func TestHandlers_CreateBot(t *testing.T) {
 		obj.Value("updatedAt").String().NotEmpty()
 		obj.Value("tokens").Object().Value("verificationToken").String().NotEmpty()
 		obj.Value("tokens").Object().Value("accessToken").String().NotEmpty()
+		obj.Value("tokens").Object().Value("signingSecret").String().NotEmpty()
 		obj.Value("endpoint").String().Equal("https://example.com")
 		obj.Value("privileged").Boolean().False()
 		obj.Value("channels").Array().Length().Equal(0)
@@ -261,6 +262,7 @@ func TestHandlers_CreateBot(t *testing.T) {
 		obj.Value("updatedAt").String().NotEmpty()
 		obj.Value("tokens").Object().Value("verificationToken").String().NotEmpty()
 		obj.Value("tokens").Object().Value("accessToken").String().NotEmpty()
+		obj.Value("tokens").Object().Value("signingSecret").String().NotEmpty()
 		obj.Value("endpoint").String().Equal("")
 		obj.Value("privileged").Boolean().False()
 		obj.Value("channels").Array().Length().Equal(0)
@@ -345,6 +347,7 @@ func TestHandlers_GetBot(t *testing.T) {
 		botEquals(t, bot1, obj)
 		obj.Value("tokens").Object().Value("verificationToken").String().NotEmpty()
 		obj.Value("tokens").Object().Value("accessToken").String().NotEmpty()
+		obj.Value("tokens").Object().Value("signingSecret").String().NotEmpty()
 		obj.Value("endpoint").String().Equal("https://example.com")
 		obj.Value("privileged").Boolean().False()
 		obj.Value("channels").Array().Length().Equal(0)
@@ -466,6 +469,28 @@ func TestHandlers_EditBot(t *testing.T) {
 			Status(http.StatusBadRequest)
 	})
 
+	t.Run("bad request (event filter, invalid pattern)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PatchBotRequest{EventFilter: &model.BotEventFilter{MessagePattern: "("}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (event filter, non existent channel)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PatchBotRequest{EventFilter: &model.BotEventFilter{
+				Channels: []*model.BotChannelFilter{{ChannelID: uuid.Must(uuid.NewV4())}},
+			}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
 	t.Run("bad request (change mode to HTTP and endpoint not set)", func(t *testing.T) {
 		t.Parallel()
 		e := env.R(t)
@@ -532,6 +557,10 @@ func TestHandlers_EditBot(t *testing.T) {
 				SubscribeEvents: map[model.BotEventType]struct{}{
 					event.Ping: {},
 				},
+				EventFilter: &model.BotEventFilter{
+					MessagePrefixes: []string{"!"},
+					MessagePattern:  `^!\w+`,
+				},
 			}).
 			Expect().
 			Status(http.StatusNoContent)
@@ -751,6 +780,160 @@ func TestHandlers_GetBotLogs(t *testing.T) {
 	})
 }
 
+func TestHandlers_GetBotDeadLetters(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/bots/{botId}/dead-letters"
+	env := Setup(t, common1)
+	user1 := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	commonSession := env.S(t, user1.GetID())
+	bot1 := env.CreateBot(t, rand, user1.GetID())
+	bot2 := env.CreateBot(t, rand, user2.GetID())
+
+	letter := &model.BotEventDeadLetter{
+		BotID:     bot1.ID,
+		Event:     event.MessageCreated,
+		Body:      "{}",
+		RequestID: uuid.Must(uuid.NewV4()),
+		Attempts:  4,
+		Result:    "ne",
+		Error:     "connection refused",
+		Code:      -1,
+	}
+	require.NoError(t, env.Repository.CreateBotEventDeadLetter(letter))
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, bot1.ID.String()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (negative limit)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithQuery("limit", -1).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, bot2.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV4()).String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+
+		first := obj.First().Object()
+		first.Keys().ContainsOnly(
+			"id", "botId", "event", "body", "requestId", "attempts", "result", "code", "error", "createdAt",
+		)
+		first.Value("id").String().Equal(letter.ID.String())
+		first.Value("botId").String().Equal(letter.BotID.String())
+		first.Value("event").String().Equal(letter.Event.String())
+		first.Value("body").String().Equal(letter.Body)
+		first.Value("requestId").String().Equal(letter.RequestID.String())
+		first.Value("attempts").Number().Equal(letter.Attempts)
+		first.Value("result").String().Equal(letter.Result)
+		first.Value("code").Number().Equal(letter.Code)
+		first.Value("error").String().Equal(letter.Error)
+		first.Value("createdAt").String().NotEmpty()
+	})
+}
+
+func TestHandlers_RedeliverBotDeadLetter(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/bots/{botId}/dead-letters/{deadLetterId}/redeliver"
+	env := Setup(t, common1)
+	user1 := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	commonSession := env.S(t, user1.GetID())
+	bot1 := env.CreateBot(t, rand, user1.GetID())
+	bot2 := env.CreateBot(t, rand, user2.GetID())
+	bot3 := env.CreateBot(t, rand, user1.GetID())
+
+	letter := &model.BotEventDeadLetter{
+		BotID:     bot1.ID,
+		Event:     event.MessageCreated,
+		Body:      "{}",
+		RequestID: uuid.Must(uuid.NewV4()),
+		Attempts:  4,
+		Result:    "ne",
+		Code:      -1,
+	}
+	require.NoError(t, env.Repository.CreateBotEventDeadLetter(letter))
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bot1.ID.String(), letter.ID.String()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bot2.ID.String(), letter.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found (dead letter)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bot1.ID.String(), uuid.Must(uuid.NewV4()).String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("not found (other bot)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bot3.ID.String(), letter.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request (inactive bot)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bot1.ID.String(), letter.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+}
+
 func TestHandlers_GetChannelBots(t *testing.T) {
 	t.Parallel()
 	path := "/api/v3/channels/{channelId}/bots"
@@ -936,6 +1119,7 @@ func TestHandlers_ReissueBot(t *testing.T) {
 
 		obj.Value("verificationToken").String().NotEmpty()
 		obj.Value("accessToken").String().NotEmpty()
+		obj.Value("signingSecret").String().NotEmpty().NotEqual(bot1.SigningSecret)
 	})
 }
 
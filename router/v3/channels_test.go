@@ -654,6 +654,11 @@ func TestHandlers_GetChannelPins(t *testing.T) {
 	require.NoError(t, err)
 	m1, err = env.MM.Get(m1.GetID())
 	require.NoError(t, err)
+	hiddenChannel := env.CreateChannel(t, rand)
+	hm := env.CreateMessage(t, user.GetID(), hiddenChannel.ID, "hidden content")
+	_, err = env.MM.Pin(hm.GetID(), user.GetID())
+	require.NoError(t, err)
+	require.NoError(t, env.MM.Hide(hm.GetID()))
 	commonSession := env.S(t, user.GetID())
 
 	t.Run("not logged in", func(t *testing.T) {
@@ -691,6 +696,24 @@ func TestHandlers_GetChannelPins(t *testing.T) {
 
 		messageEquals(t, m1, first.Value("message").Object())
 	})
+
+	t.Run("success (hidden message)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, hiddenChannel.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+
+		msg := obj.First().Object().Value("message").Object()
+		msg.Value("id").String().Equal(hm.GetID().String())
+		msg.Value("content").String().Empty()
+		msg.Value("hidden").Boolean().True()
+	})
 }
 
 func Test_channelEventsQuery_Validate(t *testing.T) {
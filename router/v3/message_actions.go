@@ -0,0 +1,164 @@
+package v3
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+const (
+	messageActionsLimit       = 25
+	messageActionOptionsLimit = 25
+)
+
+func validateMessageActions(v interface{}) error {
+	actions := v.(model.MessageActions)
+	if len(actions) > messageActionsLimit {
+		return fmt.Errorf("the number of actions must be no more than %d", messageActionsLimit)
+	}
+	ids := make(map[string]bool, len(actions))
+	for _, a := range actions {
+		if a == nil {
+			return errors.New("must not contain null")
+		}
+		if err := vd.Validate(a.ID, validator.MessageActionIDRule...); err != nil {
+			return errors.New("id " + err.Error())
+		}
+		if ids[a.ID] {
+			return errors.New("must not contain duplicate ids")
+		}
+		ids[a.ID] = true
+		if err := vd.Validate(a.Label, vd.Required, vd.RuneLength(1, 100)); err != nil {
+			return errors.New("label " + err.Error())
+		}
+		if err := vd.Validate(a.Value, vd.RuneLength(0, 1000)); err != nil {
+			return errors.New("value " + err.Error())
+		}
+
+		switch a.Type {
+		case model.MessageActionButton:
+			if len(a.Options) > 0 {
+				return errors.New("button must not have options")
+			}
+		case model.MessageActionSelect:
+			if err := validateMessageActionOptions(a.Options); err != nil {
+				return err
+			}
+		default:
+			return errors.New("type must be one of button, select")
+		}
+	}
+	return nil
+}
+
+func validateMessageActionOptions(options []*model.MessageActionOption) error {
+	if len(options) == 0 || len(options) > messageActionOptionsLimit {
+		return fmt.Errorf("the number of options must be between 1 and %d", messageActionOptionsLimit)
+	}
+	values := make(map[string]bool, len(options))
+	for _, o := range options {
+		if o == nil {
+			return errors.New("options must not contain null")
+		}
+		if err := vd.Validate(o.Label, vd.Required, vd.RuneLength(1, 100)); err != nil {
+			return errors.New("option label " + err.Error())
+		}
+		if err := vd.Validate(o.Value, vd.Required, vd.RuneLength(1, 1000)); err != nil {
+			return errors.New("option value " + err.Error())
+		}
+		if values[o.Value] {
+			return errors.New("options must not contain duplicate values")
+		}
+		values[o.Value] = true
+	}
+	return nil
+}
+
+// ensureMessageActionsAllowed アクションを付与できるユーザー(BOT)かどうかを確認します
+func (h *Handlers) ensureMessageActionsAllowed(userID uuid.UUID) error {
+	user, err := h.Repo.GetUser(userID, false)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	if user.GetUserType() != model.UserTypeBot {
+		return herror.Forbidden("only bots can attach actions to messages")
+	}
+	return nil
+}
+
+// PostMessageActionRequest POST /messages/:messageID/actions/:actionID リクエストボディ
+type PostMessageActionRequest struct {
+	Value string `json:"value"`
+}
+
+func (r PostMessageActionRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Value, vd.RuneLength(0, 1000)),
+	)
+}
+
+// InvokeMessageAction POST /messages/:messageID/actions/:actionID
+func (h *Handlers) InvokeMessageAction(c echo.Context) error {
+	userID := getRequestUserID(c)
+	m := getParamMessage(c)
+	actionID := c.Param(consts.ParamActionID)
+
+	var req PostMessageActionRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	action := m.GetActions().Find(actionID)
+	if action == nil {
+		return herror.NotFound("action not found")
+	}
+	if h.ChannelManager.PublicChannelTree().IsArchivedChannel(m.GetChannelID()) {
+		return herror.BadRequest(fmt.Sprintf("channel #%s has been archived", h.ChannelManager.PublicChannelTree().GetChannelPath(m.GetChannelID())))
+	}
+
+	value := action.Value
+	if action.Type == model.MessageActionSelect {
+		if !action.HasOption(req.Value) {
+			return herror.BadRequest("invalid value")
+		}
+		value = req.Value
+	}
+
+	b, err := h.Repo.GetBotByBotUserID(m.GetUserID())
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound: // deleted bot
+			return herror.NotFound("action not found")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	if b.State != model.BotActive {
+		return herror.NotFound("action not found")
+	}
+
+	h.Hub.Publish(hub.Message{
+		Name: event.BotMessageActionInvoked,
+		Fields: hub.Fields{
+			"bot_id":     b.ID,
+			"message_id": m.GetID(),
+			"channel_id": m.GetChannelID(),
+			"action_id":  action.ID,
+			"value":      value,
+			"user_id":    userID,
+		},
+	})
+	return c.NoContent(http.StatusNoContent)
+}
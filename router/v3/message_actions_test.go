@@ -0,0 +1,199 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/random"
+)
+
+func TestHandlers_PostMessage_Actions(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/channels/{channelId}/messages"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	ch := env.CreateChannel(t, rand)
+	s := env.S(t, user.GetID())
+	botS := env.S(t, bot.BotUserID)
+
+	actions := model.MessageActions{
+		{ID: "approve", Type: model.MessageActionButton, Label: "Approve", Value: "yes"},
+		{ID: "env", Type: model.MessageActionSelect, Label: "Environment", Options: []*model.MessageActionOption{
+			{Label: "Production", Value: "prod"},
+			{Label: "Staging", Value: "stg"},
+		}},
+	}
+
+	t.Run("forbidden (human user)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageRequest{Content: "po", Actions: actions}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request (select without options)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithCookie(session.CookieName, botS).
+			WithJSON(&PostMessageRequest{Content: "po", Actions: model.MessageActions{
+				{ID: "env", Type: model.MessageActionSelect, Label: "Environment"},
+			}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (duplicate ids)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithCookie(session.CookieName, botS).
+			WithJSON(&PostMessageRequest{Content: "po", Actions: model.MessageActions{actions[0], actions[0]}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path, ch.ID).
+			WithCookie(session.CookieName, botS).
+			WithJSON(&PostMessageRequest{Content: "po", Actions: actions}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("actions").Array().Length().Equal(2)
+		obj.Value("actions").Array().Element(0).Object().Value("id").String().Equal("approve")
+		obj.Value("actions").Array().Element(1).Object().Value("options").Array().Length().Equal(2)
+	})
+}
+
+func TestHandlers_InvokeMessageAction(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/actions/{actionId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.ChangeBotState(bot.ID, model.BotActive))
+	inactiveBot := env.CreateBot(t, rand, user.GetID())
+	ch := env.CreateChannel(t, rand)
+	archived := env.CreateChannel(t, rand)
+	s := env.S(t, user.GetID())
+
+	m, err := env.MM.Create(ch.ID, bot.BotUserID, random.AlphaNumeric(20), model.MessageActions{
+		{ID: "approve", Type: model.MessageActionButton, Label: "Approve", Value: "yes"},
+		{ID: "env", Type: model.MessageActionSelect, Label: "Environment", Options: []*model.MessageActionOption{
+			{Label: "Production", Value: "prod"},
+		}},
+	})
+	require.NoError(t, err)
+	plain := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	actions := model.MessageActions{{ID: "approve", Type: model.MessageActionButton, Label: "Approve", Value: "yes"}}
+	inArchived, err := env.MM.Create(archived.ID, bot.BotUserID, random.AlphaNumeric(20), actions)
+	require.NoError(t, err)
+	require.NoError(t, env.CM.ArchiveChannel(archived.ID, user.GetID()))
+	byInactive, err := env.MM.Create(ch.ID, inactiveBot.BotUserID, random.AlphaNumeric(20), actions)
+	require.NoError(t, err)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID(), "approve").
+			WithJSON(&PostMessageActionRequest{}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("message not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV4()), "approve").
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageActionRequest{}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("action not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID(), "reject").
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageActionRequest{}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("message without actions", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, plain.GetID(), "approve").
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageActionRequest{}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("archived channel", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, inArchived.GetID(), "approve").
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageActionRequest{}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("inactive bot", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, byInactive.GetID(), "approve").
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageActionRequest{}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("invalid select value", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID(), "env").
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageActionRequest{Value: "stg"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success (button)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID(), "approve").
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageActionRequest{}).
+			Expect().
+			Status(http.StatusNoContent)
+	})
+
+	t.Run("success (select)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID(), "env").
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageActionRequest{Value: "prod"}).
+			Expect().
+			Status(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,194 @@
+package v3
+
+import (
+	"net/http"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// PostMessageReportRequest POST /messages/:messageID/reports リクエストボディ
+type PostMessageReportRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (r PostMessageReportRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Reason, vd.Required, vd.RuneLength(1, 1000)),
+	)
+}
+
+// ReportMessage POST /messages/:messageID/reports
+func (h *Handlers) ReportMessage(c echo.Context) error {
+	userID := getRequestUserID(c)
+	m := getParamMessage(c)
+
+	var req PostMessageReportRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if m.GetUserID() == userID {
+		return herror.BadRequest("you cannot report your own message")
+	}
+
+	r, err := h.Repo.CreateMessageReport(m.GetID(), userID, req.Reason)
+	if err != nil {
+		switch err {
+		case repository.ErrAlreadyExists:
+			return herror.Conflict("already reported")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.JSON(http.StatusCreated, formatMessageReport(r))
+}
+
+// GetMessageReportsRequest GET /message-reports リクエストクエリ
+type GetMessageReportsRequest struct {
+	State  string `query:"state"`
+	Limit  int    `query:"limit"`
+	Offset int    `query:"offset"`
+}
+
+func (r *GetMessageReportsRequest) Validate() error {
+	if r.Limit == 0 {
+		r.Limit = 50
+	}
+	return vd.ValidateStruct(r,
+		vd.Field(&r.State, vd.In(model.MessageReportStateOpen, model.MessageReportStateResolved, model.MessageReportStateDismissed)),
+		vd.Field(&r.Limit, vd.Min(1), vd.Max(200)),
+		vd.Field(&r.Offset, vd.Min(0)),
+	)
+}
+
+// GetMessageReports GET /message-reports
+func (h *Handlers) GetMessageReports(c echo.Context) error {
+	var req GetMessageReportsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	q := repository.MessageReportsQuery{
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	if len(req.State) > 0 {
+		q.State = optional.StringFrom(req.State)
+	}
+
+	rs, err := h.Repo.GetMessageReports(q)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatMessageReports(rs))
+}
+
+// GetMessageReport GET /message-reports/:reportID
+func (h *Handlers) GetMessageReport(c echo.Context) error {
+	return c.JSON(http.StatusOK, formatMessageReport(getParamMessageReport(c)))
+}
+
+// PostResolveMessageReportRequest POST /message-reports/:reportID/resolve リクエストボディ
+type PostResolveMessageReportRequest struct {
+	Action string `json:"action"`
+}
+
+func (r PostResolveMessageReportRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Action, vd.Required, vd.In(model.MessageReportActionNone, model.MessageReportActionHide, model.MessageReportActionDelete)),
+	)
+}
+
+// ResolveMessageReport POST /message-reports/:reportID/resolve
+func (h *Handlers) ResolveMessageReport(c echo.Context) error {
+	userID := getRequestUserID(c)
+	r := getParamMessageReport(c)
+
+	var req PostResolveMessageReportRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if !r.IsOpen() {
+		return herror.BadRequest("this report has already been handled")
+	}
+	if req.Action == model.MessageReportActionDelete {
+		// 対応済みにした後に削除できないことが分からないよう、先に確認する
+		m, err := h.MessageManager.Get(r.MessageID)
+		if err != nil && err != message.ErrNotFound {
+			return herror.InternalServerError(err)
+		}
+		if m != nil && h.ChannelManager.IsPublicChannel(m.GetChannelID()) && h.ChannelManager.PublicChannelTree().IsArchivedChannel(m.GetChannelID()) {
+			return herror.BadRequest("the channel of this message has been archived")
+		}
+	}
+
+	// 同時に対応された場合に処置が重複しないよう、先に対応済みにする
+	if err := h.Repo.HandleMessageReport(r.ID, userID, model.MessageReportStateResolved, req.Action); err != nil {
+		switch err {
+		case repository.ErrForbidden:
+			return herror.BadRequest("this report has already been handled")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	// メッセージに対する処置
+	switch req.Action {
+	case model.MessageReportActionHide:
+		if err := h.MessageManager.Hide(r.MessageID); err != nil && err != message.ErrNotFound {
+			return herror.InternalServerError(err)
+		}
+	case model.MessageReportActionDelete:
+		if err := h.MessageManager.Delete(r.MessageID); err != nil {
+			switch err {
+			case message.ErrNotFound: // 既に削除済み
+			case message.ErrChannelArchived:
+				return herror.BadRequest("the channel of this message has been archived")
+			default:
+				return herror.InternalServerError(err)
+			}
+		}
+	}
+
+	// 非表示・削除した場合は同じメッセージへの他の未対応の通報も対応済みにする
+	if req.Action != model.MessageReportActionNone {
+		others, err := h.Repo.GetMessageReportsByMessageID(r.MessageID)
+		if err != nil {
+			return herror.InternalServerError(err)
+		}
+		for _, other := range others {
+			if other.ID == r.ID || !other.IsOpen() {
+				continue
+			}
+			if err := h.Repo.HandleMessageReport(other.ID, userID, model.MessageReportStateResolved, req.Action); err != nil && err != repository.ErrForbidden {
+				return herror.InternalServerError(err)
+			}
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DismissMessageReport POST /message-reports/:reportID/dismiss
+func (h *Handlers) DismissMessageReport(c echo.Context) error {
+	userID := getRequestUserID(c)
+	r := getParamMessageReport(c)
+
+	if err := h.Repo.HandleMessageReport(r.ID, userID, model.MessageReportStateDismissed, model.MessageReportActionNone); err != nil {
+		switch err {
+		case repository.ErrForbidden:
+			return herror.BadRequest("this report has already been handled")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
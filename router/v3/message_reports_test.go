@@ -0,0 +1,352 @@
+package v3
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/session"
+)
+
+func messageReportEquals(t *testing.T, expect *model.MessageReport, actual *httpexpect.Object) {
+	t.Helper()
+	actual.Value("id").String().Equal(expect.ID.String())
+	actual.Value("messageId").String().Equal(expect.MessageID.String())
+	actual.Value("reporter").String().Equal(expect.Reporter.String())
+	actual.Value("reason").String().Equal(expect.Reason)
+	actual.Value("state").String().Equal(expect.State)
+}
+
+func TestPostMessageReportRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		reason  string
+		wantErr bool
+	}{
+		{"empty", "", true},
+		{"too long", strings.Repeat("a", 1001), true},
+		{"success", "spam", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := PostMessageReportRequest{Reason: tt.reason}
+			if err := r.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPostResolveMessageReportRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		action  string
+		wantErr bool
+	}{
+		{"empty", "", true},
+		{"unknown", "ban", true},
+		{"none", model.MessageReportActionNone, false},
+		{"hide", model.MessageReportActionHide, false},
+		{"delete", model.MessageReportActionDelete, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := PostResolveMessageReportRequest{Action: tt.action}
+			if err := r.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandlers_ReportMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/reports"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	reported := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	env.CreateMessageReport(t, reported.GetID(), user2.GetID(), rand)
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithJSON(&PostMessageReportRequest{Reason: "spam"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (own message)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageReportRequest{Reason: "spam"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, s2).
+			WithJSON(&PostMessageReportRequest{Reason: "spam"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, reported.GetID()).
+			WithCookie(session.CookieName, s2).
+			WithJSON(&PostMessageReportRequest{Reason: "spam"}).
+			Expect().
+			Status(http.StatusConflict)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s2).
+			WithJSON(&PostMessageReportRequest{Reason: "spam"}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("messageId").String().Equal(m.GetID().String())
+		obj.Value("reporter").String().Equal(user2.GetID().String())
+		obj.Value("reason").String().Equal("spam")
+		obj.Value("state").String().Equal(model.MessageReportStateOpen)
+	})
+}
+
+func TestHandlers_GetMessageReport(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/message-reports/{reportId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, admin.GetID(), ch.ID, rand)
+	r := env.CreateMessageReport(t, m.GetID(), user.GetID(), rand)
+	s := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, r.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, r.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, r.ID).
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		messageReportEquals(t, r, obj)
+	})
+}
+
+func TestHandlers_ResolveMessageReport(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/message-reports/{reportId}/resolve"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	ch := env.CreateChannel(t, rand)
+	s := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		m := env.CreateMessage(t, admin.GetID(), ch.ID, rand)
+		r := env.CreateMessageReport(t, m.GetID(), user.GetID(), rand)
+		e := env.R(t)
+		e.POST(path, r.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostResolveMessageReportRequest{Action: model.MessageReportActionNone}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request (invalid action)", func(t *testing.T) {
+		t.Parallel()
+		m := env.CreateMessage(t, admin.GetID(), ch.ID, rand)
+		r := env.CreateMessageReport(t, m.GetID(), user.GetID(), rand)
+		e := env.R(t)
+		e.POST(path, r.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PostResolveMessageReportRequest{Action: "ban"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (already handled)", func(t *testing.T) {
+		t.Parallel()
+		m := env.CreateMessage(t, admin.GetID(), ch.ID, rand)
+		r := env.CreateMessageReport(t, m.GetID(), user.GetID(), rand)
+		require.NoError(t, env.Repository.HandleMessageReport(r.ID, admin.GetID(), model.MessageReportStateDismissed, model.MessageReportActionNone))
+		e := env.R(t)
+		e.POST(path, r.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PostResolveMessageReportRequest{Action: model.MessageReportActionNone}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (already handled, message untouched)", func(t *testing.T) {
+		t.Parallel()
+		m := env.CreateMessage(t, admin.GetID(), ch.ID, rand)
+		r := env.CreateMessageReport(t, m.GetID(), user.GetID(), rand)
+		require.NoError(t, env.Repository.HandleMessageReport(r.ID, admin.GetID(), model.MessageReportStateDismissed, model.MessageReportActionNone))
+		assert.ErrorIs(t, env.Repository.HandleMessageReport(r.ID, admin.GetID(), model.MessageReportStateResolved, model.MessageReportActionHide), repository.ErrForbidden)
+
+		e := env.R(t)
+		e.POST(path, r.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PostResolveMessageReportRequest{Action: model.MessageReportActionHide}).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		msg, err := env.MM.Get(m.GetID())
+		require.NoError(t, err)
+		assert.False(t, msg.IsHidden())
+	})
+
+	t.Run("success (hide)", func(t *testing.T) {
+		t.Parallel()
+		m := env.CreateMessage(t, admin.GetID(), ch.ID, rand)
+		r := env.CreateMessageReport(t, m.GetID(), user.GetID(), rand)
+		r2 := env.CreateMessageReport(t, m.GetID(), user2.GetID(), rand)
+		e := env.R(t)
+		e.POST(path, r.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PostResolveMessageReportRequest{Action: model.MessageReportActionHide}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		hidden, err := env.MM.Get(m.GetID())
+		require.NoError(t, err)
+		assert.True(t, hidden.IsHidden())
+		assert.Empty(t, hidden.GetText())
+
+		for _, id := range []uuid.UUID{r.ID, r2.ID} {
+			handled, err := env.Repository.GetMessageReport(id)
+			require.NoError(t, err)
+			assert.Equal(t, model.MessageReportStateResolved, handled.State)
+			assert.Equal(t, model.MessageReportActionHide, handled.Action)
+			assert.Equal(t, admin.GetID(), handled.HandlerID.UUID)
+		}
+	})
+
+	t.Run("success (delete)", func(t *testing.T) {
+		t.Parallel()
+		m := env.CreateMessage(t, admin.GetID(), ch.ID, rand)
+		r := env.CreateMessageReport(t, m.GetID(), user.GetID(), rand)
+		e := env.R(t)
+		e.POST(path, r.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PostResolveMessageReportRequest{Action: model.MessageReportActionDelete}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		_, err := env.MM.Get(m.GetID())
+		assert.Error(t, err)
+	})
+}
+
+func TestHandlers_DismissMessageReport(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/message-reports/{reportId}/dismiss"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	ch := env.CreateChannel(t, rand)
+	s := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		m := env.CreateMessage(t, admin.GetID(), ch.ID, rand)
+		r := env.CreateMessageReport(t, m.GetID(), user.GetID(), rand)
+		e := env.R(t)
+		e.POST(path, r.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		m := env.CreateMessage(t, admin.GetID(), ch.ID, rand)
+		r := env.CreateMessageReport(t, m.GetID(), user.GetID(), rand)
+		e := env.R(t)
+		e.POST(path, r.ID).
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusNoContent)
+
+		handled, err := env.Repository.GetMessageReport(r.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.MessageReportStateDismissed, handled.State)
+		assert.Equal(t, model.MessageReportActionNone, handled.Action)
+
+		m2, err := env.MM.Get(m.GetID())
+		require.NoError(t, err)
+		assert.False(t, m2.IsHidden())
+	})
+}
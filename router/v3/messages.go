@@ -1,10 +1,12 @@
 package v3
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
 	"github.com/labstack/echo/v4"
 
 	"github.com/traPtitech/traQ/model"
@@ -46,7 +48,23 @@ func (h *Handlers) SearchMessages(c echo.Context) error {
 	}
 
 	var q search.Query
-	if err := bindAndValidate(c, &q); err != nil {
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	// インライン検索クエリ
+	if raw := c.QueryParam("q"); len(raw) > 0 {
+		if err := search.ParseQuery(raw, h.Repo, h.ChannelManager.PublicChannelTree(), &q); err != nil {
+			var perrs search.QueryParseErrors
+			if errors.As(err, &perrs) {
+				return herror.BadRequest(perrs)
+			}
+			return herror.InternalServerError(err)
+		}
+	}
+
+	// インライン検索クエリの反映後に検証する
+	if err := validate(c, &q); err != nil {
 		return err
 	}
 
@@ -68,12 +86,18 @@ func (h *Handlers) SearchMessages(c echo.Context) error {
 	}
 
 	type res struct {
-		TotalHits int64             `json:"totalHits"`
-		Hits      []message.Message `json:"hits"`
+		TotalHits  int64                  `json:"totalHits"`
+		Hits       []message.Message      `json:"hits"`
+		Highlights map[uuid.UUID][]string `json:"highlights"`
+		Facets     *search.Facets         `json:"facets"`
+		NextCursor string                 `json:"nextCursor"`
 	}
 	response := res{
-		TotalHits: r.TotalHits(),
-		Hits:      r.Hits(),
+		TotalHits:  r.TotalHits(),
+		Hits:       r.Hits(),
+		Highlights: r.Highlights(),
+		Facets:     r.Facets(),
+		NextCursor: r.NextCursor(),
 	}
 	return c.JSON(http.StatusOK, response)
 }
@@ -83,15 +107,40 @@ func (h *Handlers) GetMessage(c echo.Context) error {
 	return c.JSON(http.StatusOK, getParamMessage(c))
 }
 
+// GetMessageHistory GET /messages/:messageID/history
+func (h *Handlers) GetMessageHistory(c echo.Context) error {
+	userID := getRequestUserID(c)
+	m := getParamMessage(c)
+
+	// 非表示にされたメッセージの編集履歴は非公開
+	if m.IsHidden() {
+		return herror.Forbidden("the history of this message is not available")
+	}
+
+	// DMの編集履歴は設定により投稿者以外に非公開
+	if h.Config.HideDMMessageHistory && userID != m.GetUserID() && !h.ChannelManager.IsPublicChannel(m.GetChannelID()) {
+		return herror.Forbidden("the history of this message is not available")
+	}
+
+	ams, err := h.Repo.GetArchivedMessagesByID(m.GetID())
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatMessageRevisions(ams, m))
+}
+
 // PostMessageRequest POST /channels/:channelID/messages等リクエストボディ
 type PostMessageRequest struct {
 	Content string `json:"content"`
 	Embed   bool   `json:"embed" query:"embed"`
+	// Actions メッセージに付与するボタンやセレクトメニュー BOTのみ指定可能
+	Actions model.MessageActions `json:"actions"`
 }
 
 func (r PostMessageRequest) Validate() error {
 	return vd.ValidateStruct(&r,
 		vd.Field(&r.Content, vd.Required, vd.RuneLength(1, 10000)),
+		vd.Field(&r.Actions, vd.By(validateMessageActions)),
 	)
 }
 
@@ -114,7 +163,14 @@ func (h *Handlers) EditMessage(c echo.Context) error {
 		req.Content = h.Replacer.Replace(req.Content)
 	}
 
-	if err := h.MessageManager.Edit(m.GetID(), req.Content); err != nil {
+	// アクションが指定された場合は本文と同時に置き換える
+	if req.Actions != nil {
+		if err := h.ensureMessageActionsAllowed(userID); err != nil {
+			return err
+		}
+	}
+
+	if err := h.MessageManager.Edit(m.GetID(), req.Content, req.Actions); err != nil {
 		switch err {
 		case message.ErrChannelArchived:
 			return herror.BadRequest("the channel of this message has been archived")
@@ -302,6 +358,51 @@ func (h *Handlers) GetMessageClips(c echo.Context) error {
 	return c.JSON(http.StatusOK, formatMessageClips(clips))
 }
 
+// GetMessageReplies GET /messages/:messageID/replies
+func (h *Handlers) GetMessageReplies(c echo.Context) error {
+	m := getParamMessage(c)
+
+	var req MessagesQuery
+	if err := req.bind(c); err != nil {
+		return err
+	}
+
+	return serveMessages(c, h.MessageManager, req.convertT(getThreadRootID(m)))
+}
+
+// PostMessageReply POST /messages/:messageID/replies
+func (h *Handlers) PostMessageReply(c echo.Context) error {
+	userID := getRequestUserID(c)
+	m := getParamMessage(c)
+
+	var req PostMessageRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if req.Embed {
+		req.Content = h.Replacer.Replace(req.Content)
+	}
+	if req.Actions != nil {
+		if err := h.ensureMessageActionsAllowed(userID); err != nil {
+			return err
+		}
+	}
+
+	reply, err := h.MessageManager.CreateReply(m.GetID(), userID, req.Content, req.Actions)
+	if err != nil {
+		switch err {
+		case message.ErrChannelArchived:
+			return herror.BadRequest("the channel of this message has been archived")
+		case message.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.JSON(http.StatusCreated, reply)
+}
+
 // GetMessages GET /channels/:channelID/messages
 func (h *Handlers) GetMessages(c echo.Context) error {
 	channelID := getParamAsUUID(c, consts.ParamChannelID)
@@ -327,8 +428,13 @@ func (h *Handlers) PostMessage(c echo.Context) error {
 	if req.Embed {
 		req.Content = h.Replacer.Replace(req.Content)
 	}
+	if req.Actions != nil {
+		if err := h.ensureMessageActionsAllowed(userID); err != nil {
+			return err
+		}
+	}
 
-	m, err := h.MessageManager.Create(ch.ID, userID, req.Content)
+	m, err := h.MessageManager.Create(ch.ID, userID, req.Content, req.Actions)
 	if err != nil {
 		switch err {
 		case message.ErrChannelArchived:
@@ -372,8 +478,13 @@ func (h *Handlers) PostDirectMessage(c echo.Context) error {
 	if req.Embed {
 		req.Content = h.Replacer.Replace(req.Content)
 	}
+	if req.Actions != nil {
+		if err := h.ensureMessageActionsAllowed(myID); err != nil {
+			return err
+		}
+	}
 
-	m, err := h.MessageManager.CreateDM(myID, targetID, req.Content)
+	m, err := h.MessageManager.CreateDM(myID, targetID, req.Content, req.Actions)
 	if err != nil {
 		return herror.InternalServerError(err)
 	}
@@ -140,6 +140,120 @@ func TestHandlers_GetMessage(t *testing.T) {
 	})
 }
 
+func TestHandlers_GetMessageHistory(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/history"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	user3 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	dm := env.CreateDMChannel(t, user2.GetID(), user3.GetID())
+	m := env.CreateMessage(t, user.GetID(), ch.ID, "original")
+	require.NoError(t, env.MM.Edit(m.GetID(), "edited", nil))
+	dmm := env.CreateMessage(t, user2.GetID(), dm.ID, rand)
+	s := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, m.GetID()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found (dm)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, dmm.GetID()).
+			WithCookie(session.CookieName, env.S(t, user.GetID())).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success (not edited)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, dmm.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+		obj.First().Object().Value("content").String().Equal(dmm.GetText())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(2)
+		obj.Element(0).Object().Value("content").String().Equal("original")
+		obj.Element(1).Object().Value("content").String().Equal("edited")
+		obj.Element(1).Object().Value("updatedAt").String().NotEmpty()
+	})
+}
+
+func TestHandlers_GetMessageHistory_HideDMMessageHistory(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/history"
+	env := Setup(t, hideDM)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	dm := env.CreateDMChannel(t, user.GetID(), user2.GetID())
+	m := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	dmm := env.CreateMessage(t, user.GetID(), dm.ID, "original")
+	require.NoError(t, env.MM.Edit(dmm.GetID(), "edited", nil))
+
+	t.Run("forbidden (dm, not author)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, dmm.GetID()).
+			WithCookie(session.CookieName, env.S(t, user2.GetID())).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("success (dm, author)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, dmm.GetID()).
+			WithCookie(session.CookieName, env.S(t, user.GetID())).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(2)
+		obj.Element(0).Object().Value("content").String().Equal("original")
+		obj.Element(1).Object().Value("content").String().Equal("edited")
+	})
+
+	t.Run("success (public channel, not author)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, m.GetID()).
+			WithCookie(session.CookieName, env.S(t, user2.GetID())).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array().
+			Length().
+			Equal(1)
+	})
+}
+
 func TestPostMessageRequest_Validate(t *testing.T) {
 	t.Parallel()
 
@@ -949,6 +1063,146 @@ func TestHandlers_GetMessageClips(t *testing.T) {
 	})
 }
 
+func TestHandlers_GetMessageReplies(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/replies"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	root := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	r1, err := env.MM.CreateReply(root.GetID(), user.GetID(), "reply1", nil)
+	require.NoError(t, err)
+	r2, err := env.MM.CreateReply(r1.GetID(), user.GetID(), "reply2", nil)
+	require.NoError(t, err)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, root.GetID()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, root.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(2)
+
+		messageEquals(t, r2, obj.Element(0).Object())
+		messageEquals(t, r1, obj.Element(1).Object())
+	})
+
+	t.Run("success (from reply)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, r1.GetID()).
+			WithCookie(session.CookieName, s).
+			WithQuery("order", "asc").
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(2)
+
+		messageEquals(t, r1, obj.Element(0).Object())
+		messageEquals(t, r2, obj.Element(1).Object())
+	})
+}
+
+func TestHandlers_PostMessageReply(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/replies"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	root := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	archived := env.CreateChannel(t, rand)
+	archivedMessage := env.CreateMessage(t, user.GetID(), archived.ID, rand)
+	require.NoError(t, env.CM.ArchiveChannel(archived.ID, user.GetID()))
+	s := env.S(t, user.GetID())
+
+	req := &PostMessageRequest{
+		Content: "Hello, traP",
+	}
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, root.GetID()).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("archived", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, archivedMessage.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, root.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageRequest{Content: ""}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path, root.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("userId").String().Equal(user.GetID().String())
+		obj.Value("channelId").String().Equal(ch.ID.String())
+		obj.Value("content").String().Equal("Hello, traP")
+		obj.Value("threadId").String().Equal(root.GetID().String())
+	})
+}
+
 func TestHandlers_GetMessages(t *testing.T) {
 	t.Parallel()
 
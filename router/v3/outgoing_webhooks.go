@@ -0,0 +1,128 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/router/utils"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+// PutOutgoingWebhookRequest PUT /webhooks/:webhookID/outgoing リクエストボディ
+type PutOutgoingWebhookRequest struct {
+	URL          string      `json:"url"`
+	Channels     []uuid.UUID `json:"channels"`
+	TriggerWords []string    `json:"triggerWords"`
+	Reply        bool        `json:"reply"`
+}
+
+func (r PutOutgoingWebhookRequest) ValidateWithContext(ctx context.Context) error {
+	return vd.ValidateStructWithContext(ctx, &r,
+		vd.Field(&r.URL, vd.Required, is.URL, validator.NotInternalURL),
+		vd.Field(&r.Channels, vd.Required, vd.Length(1, 100), vd.Each(vd.Required, utils.IsPublicChannelID), vd.By(uniqueOutgoingWebhookChannels)),
+		vd.Field(&r.TriggerWords, vd.Length(0, 20), vd.Each(vd.Required, vd.RuneLength(1, 100))),
+	)
+}
+
+func uniqueOutgoingWebhookChannels(v interface{}) error {
+	channels := make(map[uuid.UUID]bool)
+	for _, id := range v.([]uuid.UUID) {
+		if channels[id] {
+			return errors.New("must not contain duplicate channels")
+		}
+		channels[id] = true
+	}
+	return nil
+}
+
+// GetOutgoingWebhook GET /webhooks/:webhookID/outgoing
+func (h *Handlers) GetOutgoingWebhook(c echo.Context) error {
+	w := getParamWebhook(c)
+
+	ow, err := h.Repo.GetOutgoingWebhook(w.GetID())
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound("outgoing webhook is not configured")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.JSON(http.StatusOK, formatOutgoingWebhook(ow))
+}
+
+// PutOutgoingWebhook PUT /webhooks/:webhookID/outgoing
+func (h *Handlers) PutOutgoingWebhook(c echo.Context) error {
+	w := getParamWebhook(c)
+
+	var req PutOutgoingWebhookRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	ow, err := h.Repo.SetOutgoingWebhook(w.GetID(), repository.SetOutgoingWebhookArgs{
+		URL:          req.URL,
+		ChannelIDs:   req.Channels,
+		TriggerWords: req.TriggerWords,
+		Reply:        req.Reply,
+	})
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatOutgoingWebhook(ow))
+}
+
+// DeleteOutgoingWebhook DELETE /webhooks/:webhookID/outgoing
+func (h *Handlers) DeleteOutgoingWebhook(c echo.Context) error {
+	w := getParamWebhook(c)
+
+	if err := h.Repo.DeleteOutgoingWebhook(w.GetID()); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound("outgoing webhook is not configured")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetOutgoingWebhookLogsRequest GET /webhooks/:webhookID/outgoing/logs リクエストクエリ
+type GetOutgoingWebhookLogsRequest struct {
+	Limit  int `query:"limit"`
+	Offset int `query:"offset"`
+}
+
+func (r *GetOutgoingWebhookLogsRequest) Validate() error {
+	if r.Limit == 0 {
+		r.Limit = 30
+	}
+	return vd.ValidateStruct(r,
+		vd.Field(&r.Limit, vd.Min(1), vd.Max(200)),
+		vd.Field(&r.Offset, vd.Min(0)),
+	)
+}
+
+// GetOutgoingWebhookLogs GET /webhooks/:webhookID/outgoing/logs
+func (h *Handlers) GetOutgoingWebhookLogs(c echo.Context) error {
+	w := getParamWebhook(c)
+
+	var req GetOutgoingWebhookLogsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	logs, err := h.Repo.GetOutgoingWebhookLogs(w.GetID(), req.Limit, req.Offset)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatOutgoingWebhookLogs(logs))
+}
@@ -0,0 +1,257 @@
+package v3
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/session"
+)
+
+func TestHandlers_GetOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/webhooks/{webhookId}/outgoing"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	wh := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+	wh2 := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	ow, err := env.Repository.SetOutgoingWebhook(wh.GetID(), repository.SetOutgoingWebhookArgs{
+		URL:          "https://example.com",
+		ChannelIDs:   []uuid.UUID{ch.ID},
+		TriggerWords: []string{"!deploy"},
+	})
+	require.NoError(t, err)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, wh.GetID()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, wh.GetID()).
+			WithCookie(session.CookieName, s2).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, wh2.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, wh.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("webhookId").String().Equal(wh.GetID().String())
+		obj.Value("url").String().Equal("https://example.com")
+		obj.Value("channels").Array().Elements(ch.ID.String())
+		obj.Value("triggerWords").Array().Elements("!deploy")
+		obj.Value("signingSecret").String().Equal(ow.SigningSecret)
+		obj.Value("reply").Boolean().False()
+	})
+}
+
+func TestHandlers_PutOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/webhooks/{webhookId}/outgoing"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	ch2 := env.CreateChannel(t, rand)
+	wh := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, wh.GetID()).
+			WithJSON(&PutOutgoingWebhookRequest{URL: "https://example.com", Channels: []uuid.UUID{ch.ID}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, wh.GetID()).
+			WithCookie(session.CookieName, s2).
+			WithJSON(&PutOutgoingWebhookRequest{URL: "https://example.com", Channels: []uuid.UUID{ch.ID}}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		reqs := map[string]*PutOutgoingWebhookRequest{
+			"empty url":             {URL: "", Channels: []uuid.UUID{ch.ID}},
+			"invalid url":           {URL: "po", Channels: []uuid.UUID{ch.ID}},
+			"empty channels":        {URL: "https://example.com", Channels: []uuid.UUID{}},
+			"unknown channel":       {URL: "https://example.com", Channels: []uuid.UUID{uuid.Must(uuid.NewV4())}},
+			"duplicate channels":    {URL: "https://example.com", Channels: []uuid.UUID{ch.ID, ch.ID}},
+			"empty trigger word":    {URL: "https://example.com", Channels: []uuid.UUID{ch.ID}, TriggerWords: []string{""}},
+			"too long trigger word": {URL: "https://example.com", Channels: []uuid.UUID{ch.ID}, TriggerWords: []string{strings.Repeat("a", 101)}},
+		}
+		for name, req := range reqs {
+			req := req
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+				e := env.R(t)
+				e.PUT(path, wh.GetID()).
+					WithCookie(session.CookieName, s).
+					WithJSON(req).
+					Expect().
+					Status(http.StatusBadRequest)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.PUT(path, wh.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutOutgoingWebhookRequest{URL: "https://example.com", Channels: []uuid.UUID{ch.ID}, Reply: true}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+		secret := obj.Value("signingSecret").String().NotEmpty().Raw()
+
+		// 更新してもシークレットは変わらず、送信対象チャンネルは置き換えられる
+		obj = e.PUT(path, wh.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutOutgoingWebhookRequest{URL: "https://example.com/hook", Channels: []uuid.UUID{ch2.ID}, TriggerWords: []string{"!po"}}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+		obj.Value("signingSecret").String().Equal(secret)
+		obj.Value("url").String().Equal("https://example.com/hook")
+		obj.Value("reply").Boolean().False()
+
+		ow, err := env.Repository.GetOutgoingWebhook(wh.GetID())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{ch2.ID}, ow.ChannelIDs())
+		assert.EqualValues(t, []string{"!po"}, ow.TriggerWords)
+	})
+}
+
+func TestHandlers_DeleteOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/webhooks/{webhookId}/outgoing"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	wh := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+	wh2 := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+	s := env.S(t, user.GetID())
+
+	_, err := env.Repository.SetOutgoingWebhook(wh.GetID(), repository.SetOutgoingWebhookArgs{
+		URL:        "https://example.com",
+		ChannelIDs: []uuid.UUID{ch.ID},
+	})
+	require.NoError(t, err)
+
+	t.Run("not configured", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, wh2.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, wh.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNoContent)
+
+		_, err := env.Repository.GetOutgoingWebhook(wh.GetID())
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
+
+func TestHandlers_GetOutgoingWebhookLogs(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/webhooks/{webhookId}/outgoing/logs"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	wh := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+	s := env.S(t, user.GetID())
+
+	log := &model.OutgoingWebhookLog{
+		RequestID: uuid.Must(uuid.NewV4()),
+		WebhookID: wh.GetID(),
+		MessageID: uuid.Must(uuid.NewV4()),
+		Result:    "ng",
+		Code:      500,
+		DateTime:  time.Now(),
+	}
+	require.NoError(t, env.Repository.WriteOutgoingWebhookLog(log))
+
+	t.Run("bad request (negative limit)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, wh.GetID()).
+			WithCookie(session.CookieName, s).
+			WithQuery("limit", -1).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, wh.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+		first := obj.First().Object()
+		first.Value("requestId").String().Equal(log.RequestID.String())
+		first.Value("messageId").String().Equal(log.MessageID.String())
+		first.Value("result").String().Equal("ng")
+		first.Value("code").Number().Equal(500)
+	})
+}
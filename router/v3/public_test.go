@@ -31,6 +31,7 @@ func TestHandlers_GetVersion(t *testing.T) {
 	flags := obj.Value("flags").Object()
 
 	flags.Value("signUpAllowed").Boolean().False()
+	flags.Value("webPushVapidPublicKey").String().Empty()
 
 	ext := flags.Value("externalLogin").Array()
 	ext.Length().Equal(1)
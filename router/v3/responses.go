@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/utils/optional"
 
 	"github.com/gofrs/uuid"
@@ -142,15 +143,16 @@ func formatUserDetail(user model.UserInfo, uts []model.UserTag, g []uuid.UUID) *
 }
 
 type Webhook struct {
-	WebhookID   string    `json:"id"`
-	BotUserID   string    `json:"botUserId"`
-	DisplayName string    `json:"displayName"`
-	Description string    `json:"description"`
-	Secure      bool      `json:"secure"`
-	ChannelID   string    `json:"channelId"`
-	OwnerID     string    `json:"ownerId"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	WebhookID   string                  `json:"id"`
+	BotUserID   string                  `json:"botUserId"`
+	DisplayName string                  `json:"displayName"`
+	Description string                  `json:"description"`
+	Secure      bool                    `json:"secure"`
+	ChannelID   string                  `json:"channelId"`
+	OwnerID     string                  `json:"ownerId"`
+	EventTypes  model.WebhookEventTypes `json:"eventTypes"`
+	CreatedAt   time.Time               `json:"createdAt"`
+	UpdatedAt   time.Time               `json:"updatedAt"`
 }
 
 func formatWebhook(w model.Webhook) *Webhook {
@@ -162,6 +164,7 @@ func formatWebhook(w model.Webhook) *Webhook {
 		Secure:      len(w.GetSecret()) > 0,
 		ChannelID:   w.GetChannelID().String(),
 		OwnerID:     w.GetCreatorID().String(),
+		EventTypes:  w.GetEventTypes(),
 		CreatedAt:   w.GetCreatedAt(),
 		UpdatedAt:   w.GetUpdatedAt(),
 	}
@@ -212,22 +215,24 @@ func formatBots(bs []*model.Bot) []*Bot {
 type BotTokens struct {
 	VerificationToken string `json:"verificationToken"`
 	AccessToken       string `json:"accessToken"`
+	SigningSecret     string `json:"signingSecret"`
 }
 
 type BotDetail struct {
-	ID              uuid.UUID           `json:"id"`
-	BotUserID       uuid.UUID           `json:"botUserId"`
-	Description     string              `json:"description"`
-	DeveloperID     uuid.UUID           `json:"developerId"`
-	SubscribeEvents model.BotEventTypes `json:"subscribeEvents"`
-	Mode            model.BotMode       `json:"mode"`
-	State           model.BotState      `json:"state"`
-	CreatedAt       time.Time           `json:"createdAt"`
-	UpdatedAt       time.Time           `json:"updatedAt"`
-	Tokens          BotTokens           `json:"tokens"`
-	Endpoint        string              `json:"endpoint"`
-	Privileged      bool                `json:"privileged"`
-	Channels        []uuid.UUID         `json:"channels"`
+	ID              uuid.UUID            `json:"id"`
+	BotUserID       uuid.UUID            `json:"botUserId"`
+	Description     string               `json:"description"`
+	DeveloperID     uuid.UUID            `json:"developerId"`
+	SubscribeEvents model.BotEventTypes  `json:"subscribeEvents"`
+	Mode            model.BotMode        `json:"mode"`
+	State           model.BotState       `json:"state"`
+	CreatedAt       time.Time            `json:"createdAt"`
+	UpdatedAt       time.Time            `json:"updatedAt"`
+	Tokens          BotTokens            `json:"tokens"`
+	Endpoint        string               `json:"endpoint"`
+	Privileged      bool                 `json:"privileged"`
+	Channels        []uuid.UUID          `json:"channels"`
+	EventFilter     model.BotEventFilter `json:"eventFilter"`
 }
 
 func formatBotDetail(b *model.Bot, t *model.OAuth2Token, channels []uuid.UUID) *BotDetail {
@@ -244,11 +249,47 @@ func formatBotDetail(b *model.Bot, t *model.OAuth2Token, channels []uuid.UUID) *
 		Tokens: BotTokens{
 			VerificationToken: b.VerificationToken,
 			AccessToken:       t.AccessToken,
+			SigningSecret:     b.SigningSecret,
 		},
-		Endpoint:   b.PostURL,
-		Privileged: b.Privileged,
-		Channels:   channels,
+		Endpoint:    b.PostURL,
+		Privileged:  b.Privileged,
+		Channels:    channels,
+		EventFilter: b.EventFilter,
+	}
+}
+
+type BotCommand struct {
+	ID          uuid.UUID                 `json:"id"`
+	BotID       uuid.UUID                 `json:"botId"`
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	Arguments   model.BotCommandArguments `json:"arguments"`
+	CreatedAt   time.Time                 `json:"createdAt"`
+	UpdatedAt   time.Time                 `json:"updatedAt"`
+}
+
+func formatBotCommand(c *model.BotCommand) *BotCommand {
+	args := c.Arguments
+	if args == nil {
+		args = model.BotCommandArguments{}
+	}
+	return &BotCommand{
+		ID:          c.ID,
+		BotID:       c.BotID,
+		Name:        c.Name,
+		Description: c.Description,
+		Arguments:   args,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+func formatBotCommands(commands []*model.BotCommand) []*BotCommand {
+	res := make([]*BotCommand, len(commands))
+	for i, c := range commands {
+		res[i] = formatBotCommand(c)
 	}
+	return res
 }
 
 type botEventLogResponse struct {
@@ -279,6 +320,130 @@ func formatBotEventLogs(logs []*model.BotEventLog) []*botEventLogResponse {
 	return res
 }
 
+type webhookLogResponse struct {
+	ID        uuid.UUID `json:"id"`
+	WebhookID uuid.UUID `json:"webhookId"`
+	Adapter   string    `json:"adapter"`
+	IP        string    `json:"ip"`
+	Body      string    `json:"body"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error"`
+	Code      int       `json:"code"`
+	DateTime  time.Time `json:"datetime"`
+}
+
+func formatWebhookLog(log *model.WebhookLog) *webhookLogResponse {
+	return &webhookLogResponse{
+		ID:        log.ID,
+		WebhookID: log.WebhookID,
+		Adapter:   log.Adapter,
+		IP:        log.IP,
+		Body:      log.Body,
+		Result:    log.Result,
+		Error:     log.Error,
+		Code:      log.Code,
+		DateTime:  log.DateTime,
+	}
+}
+
+func formatWebhookLogs(logs []*model.WebhookLog) []*webhookLogResponse {
+	res := make([]*webhookLogResponse, len(logs))
+	for i, log := range logs {
+		res[i] = formatWebhookLog(log)
+	}
+	return res
+}
+
+type outgoingWebhookResponse struct {
+	WebhookID     uuid.UUID   `json:"webhookId"`
+	URL           string      `json:"url"`
+	Channels      []uuid.UUID `json:"channels"`
+	TriggerWords  []string    `json:"triggerWords"`
+	SigningSecret string      `json:"signingSecret"`
+	Reply         bool        `json:"reply"`
+	CreatedAt     time.Time   `json:"createdAt"`
+	UpdatedAt     time.Time   `json:"updatedAt"`
+}
+
+func formatOutgoingWebhook(ow *model.OutgoingWebhook) *outgoingWebhookResponse {
+	triggerWords := []string(ow.TriggerWords)
+	if triggerWords == nil {
+		triggerWords = []string{}
+	}
+	return &outgoingWebhookResponse{
+		WebhookID:     ow.WebhookID,
+		URL:           ow.URL,
+		Channels:      ow.ChannelIDs(),
+		TriggerWords:  triggerWords,
+		SigningSecret: ow.SigningSecret,
+		Reply:         ow.Reply,
+		CreatedAt:     ow.CreatedAt,
+		UpdatedAt:     ow.UpdatedAt,
+	}
+}
+
+type outgoingWebhookLogResponse struct {
+	RequestID uuid.UUID `json:"requestId"`
+	WebhookID uuid.UUID `json:"webhookId"`
+	MessageID uuid.UUID `json:"messageId"`
+	Result    string    `json:"result"`
+	Code      int       `json:"code"`
+	Error     string    `json:"error"`
+	DateTime  time.Time `json:"datetime"`
+}
+
+func formatOutgoingWebhookLogs(logs []*model.OutgoingWebhookLog) []*outgoingWebhookLogResponse {
+	res := make([]*outgoingWebhookLogResponse, len(logs))
+	for i, log := range logs {
+		res[i] = &outgoingWebhookLogResponse{
+			RequestID: log.RequestID,
+			WebhookID: log.WebhookID,
+			MessageID: log.MessageID,
+			Result:    log.Result,
+			Code:      log.Code,
+			Error:     log.Error,
+			DateTime:  log.DateTime,
+		}
+	}
+	return res
+}
+
+type botEventDeadLetterResponse struct {
+	ID        uuid.UUID          `json:"id"`
+	BotID     uuid.UUID          `json:"botId"`
+	Event     model.BotEventType `json:"event"`
+	Body      string             `json:"body"`
+	RequestID uuid.UUID          `json:"requestId"`
+	Attempts  int                `json:"attempts"`
+	Result    string             `json:"result"`
+	Code      int                `json:"code"`
+	Error     string             `json:"error"`
+	CreatedAt time.Time          `json:"createdAt"`
+}
+
+func formatBotEventDeadLetter(letter *model.BotEventDeadLetter) *botEventDeadLetterResponse {
+	return &botEventDeadLetterResponse{
+		ID:        letter.ID,
+		BotID:     letter.BotID,
+		Event:     letter.Event,
+		Body:      letter.Body,
+		RequestID: letter.RequestID,
+		Attempts:  letter.Attempts,
+		Result:    letter.Result,
+		Code:      letter.Code,
+		Error:     letter.Error,
+		CreatedAt: letter.CreatedAt,
+	}
+}
+
+func formatBotEventDeadLetters(letters []*model.BotEventDeadLetter) []*botEventDeadLetterResponse {
+	res := make([]*botEventDeadLetterResponse, len(letters))
+	for i, letter := range letters {
+		res[i] = formatBotEventDeadLetter(letter)
+	}
+	return res
+}
+
 type Message struct {
 	ID        uuid.UUID            `json:"id"`
 	UserID    uuid.UUID            `json:"userId"`
@@ -288,7 +453,8 @@ type Message struct {
 	UpdatedAt time.Time            `json:"updatedAt"`
 	Pinned    bool                 `json:"pinned"`
 	Stamps    []model.MessageStamp `json:"stamps"`
-	ThreadID  optional.UUID        `json:"threadId"` // TODO
+	ThreadID  optional.UUID        `json:"threadId"`
+	Hidden    bool                 `json:"hidden"`
 }
 
 func formatMessage(m *model.Message) *Message {
@@ -296,11 +462,13 @@ func formatMessage(m *model.Message) *Message {
 		ID:        m.ID,
 		UserID:    m.UserID,
 		ChannelID: m.ChannelID,
-		Content:   m.Text,
+		Content:   message.VisibleText(m),
 		CreatedAt: m.CreatedAt,
 		UpdatedAt: m.UpdatedAt,
 		Pinned:    m.Pin != nil,
 		Stamps:    m.Stamps,
+		ThreadID:  m.ThreadID,
+		Hidden:    m.Hidden,
 	}
 }
 
@@ -623,3 +791,92 @@ func formatStampPalettes(cfs []*model.StampPalette) []*StampPalette {
 	sort.Slice(res, func(i, j int) bool { return res[i].ID.String() < res[j].ID.String() })
 	return res
 }
+
+type ScheduledMessage struct {
+	ID          uuid.UUID                   `json:"id"`
+	UserID      uuid.UUID                   `json:"userId"`
+	ChannelID   uuid.UUID                   `json:"channelId"`
+	Content     string                      `json:"content"`
+	ScheduledAt time.Time                   `json:"scheduledAt"`
+	State       model.ScheduledMessageState `json:"state"`
+	Error       string                      `json:"error"`
+	CreatedAt   time.Time                   `json:"createdAt"`
+	UpdatedAt   time.Time                   `json:"updatedAt"`
+}
+
+func formatScheduledMessage(sm *model.ScheduledMessage) *ScheduledMessage {
+	return &ScheduledMessage{
+		ID:          sm.ID,
+		UserID:      sm.UserID,
+		ChannelID:   sm.ChannelID,
+		Content:     sm.Text,
+		ScheduledAt: sm.ScheduledAt,
+		State:       sm.State,
+		Error:       sm.Error,
+		CreatedAt:   sm.CreatedAt,
+		UpdatedAt:   sm.UpdatedAt,
+	}
+}
+
+func formatScheduledMessages(sms []*model.ScheduledMessage) []*ScheduledMessage {
+	res := make([]*ScheduledMessage, len(sms))
+	for i, sm := range sms {
+		res[i] = formatScheduledMessage(sm)
+	}
+	return res
+}
+
+type MessageRevision struct {
+	Content   string    `json:"content"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// formatMessageRevisions 古い順に並べた編集履歴を返す 最後の要素は現在の内容
+func formatMessageRevisions(ams []*model.ArchivedMessage, m message.Message) []*MessageRevision {
+	res := make([]*MessageRevision, 0, len(ams)+1)
+	for _, am := range ams {
+		res = append(res, &MessageRevision{
+			Content:   am.Text,
+			UpdatedAt: am.DateTime,
+		})
+	}
+	res = append(res, &MessageRevision{
+		Content:   m.GetText(),
+		UpdatedAt: m.GetUpdatedAt(),
+	})
+	return res
+}
+
+type MessageReport struct {
+	ID        uuid.UUID     `json:"id"`
+	MessageID uuid.UUID     `json:"messageId"`
+	Reporter  uuid.UUID     `json:"reporter"`
+	Reason    string        `json:"reason"`
+	State     string        `json:"state"`
+	Action    string        `json:"action"`
+	HandlerID optional.UUID `json:"handlerId"`
+	HandledAt optional.Time `json:"handledAt"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+func formatMessageReport(r *model.MessageReport) *MessageReport {
+	return &MessageReport{
+		ID:        r.ID,
+		MessageID: r.MessageID,
+		Reporter:  r.Reporter,
+		Reason:    r.Reason,
+		State:     r.State,
+		Action:    r.Action,
+		HandlerID: r.HandlerID,
+		HandledAt: r.HandledAt,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func formatMessageReports(rs []*model.MessageReport) []*MessageReport {
+	res := make([]*MessageReport, len(rs))
+	for i, r := range rs {
+		res[i] = formatMessageReport(r)
+	}
+	return res
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/traPtitech/traQ/router/extension"
 	"github.com/traPtitech/traQ/router/middlewares"
 	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/service/bot"
 	botWS "github.com/traPtitech/traQ/service/bot/ws"
 	"github.com/traPtitech/traQ/service/channel"
 	"github.com/traPtitech/traQ/service/counter"
@@ -28,6 +29,7 @@ import (
 type Handlers struct {
 	RBAC           rbac.RBAC
 	Repo           repository.Repository
+	BOT            bot.Service
 	WS             *ws.Streamer
 	BotWS          *botWS.Streamer
 	Hub            *hub.Hub
@@ -55,9 +57,15 @@ type Config struct {
 	// WebRTCAPIKey WebRTCクレデンシャル用APIキー
 	WebRTCAPIKey string
 
+	// WebPushVAPIDPublicKey Web PushのVAPID公開鍵 (空の場合はWeb Push無効)
+	WebPushVAPIDPublicKey string
+
 	// AllowSignUp ユーザーが自分自身で登録できるかどうか
 	AllowSignUp bool
 
+	// HideDMMessageHistory DMのメッセージ編集履歴を投稿者以外に非公開にするかどうか
+	HideDMMessageHistory bool
+
 	// EnabledExternalAccountLink リンク可能な外部認証アカウントのプロバイダ
 	EnabledExternalAccountProviders map[string]bool
 }
@@ -80,6 +88,10 @@ func (h *Handlers) Setup(e *echo.Group) {
 	requiresChannelAccessPerm := middlewares.CheckChannelAccessPerm(h.ChannelManager)
 	requiresGroupAdminPerm := middlewares.CheckUserGroupAdminPerm(h.RBAC)
 	requiresClipFolderAccessPerm := middlewares.CheckClipFolderAccessPerm()
+	requiresScheduledMessageAccessPerm := middlewares.CheckScheduledMessageAccessPerm()
+	webhookLogging := func(adapter string) echo.MiddlewareFunc {
+		return middlewares.WebhookLogging(h.Repo, h.Logger, adapter)
+	}
 
 	api := e.Group("/v3", middlewares.UserAuthenticate(h.Repo, h.SessStore))
 	{
@@ -121,6 +133,8 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiUsersMe.PUT("/icon", h.ChangeMyIcon, requires(permission.ChangeMyIcon))
 				apiUsersMe.PUT("/password", h.PutMyPassword, requires(permission.ChangeMyPassword), blockBot)
 				apiUsersMe.POST("/fcm-device", h.PostMyFCMDevice, requires(permission.RegisterFCMDevice), blockBot)
+				apiUsersMe.POST("/web-push-subscriptions", h.PostMyWebPushSubscription, requires(permission.RegisterFCMDevice), blockBot)
+				apiUsersMe.DELETE("/web-push-subscriptions", h.DeleteMyWebPushSubscription, requires(permission.RegisterFCMDevice), blockBot)
 				apiUsersMe.GET("/view-states", h.GetMyViewStates, requires(permission.ConnectNotificationStream), blockBot)
 				apiUsersMeTags := apiUsersMe.Group("/tags")
 				{
@@ -169,6 +183,12 @@ func (h *Handlers) Setup(e *echo.Group) {
 					apiUsersMeSettings.GET("", h.GetMySettings, requires(permission.GetMe))
 					apiUsersMeSettings.GET("/notify-citation", h.GetMyNotifyCitation, requires(permission.GetMe))
 					apiUsersMeSettings.PUT("/notify-citation", h.PutMyNotifyCitation, requires(permission.EditMe))
+					apiUsersMeSettings.GET("/email-digest", h.GetMyEmailDigest, requires(permission.GetMe))
+					apiUsersMeSettings.PUT("/email-digest", h.PutMyEmailDigest, requires(permission.EditMe))
+					apiUsersMeSettings.GET("/dnd", h.GetMyDND, requires(permission.GetMe))
+					apiUsersMeSettings.PUT("/dnd", h.PutMyDND, requires(permission.EditMe))
+					apiUsersMeSettings.GET("/notification-keywords", h.GetMyNotificationKeywords, requires(permission.GetMe))
+					apiUsersMeSettings.PUT("/notification-keywords", h.PutMyNotificationKeywords, requires(permission.EditMe))
 				}
 			}
 		}
@@ -191,6 +211,8 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiChannelsCID.PUT("/subscribers", h.SetChannelSubscribers, requires(permission.EditChannelSubscription))
 				apiChannelsCID.PATCH("/subscribers", h.EditChannelSubscribers, requires(permission.EditChannelSubscription))
 				apiChannelsCID.GET("/bots", h.GetChannelBots, requires(permission.GetChannel))
+				apiChannelsCID.GET("/commands", h.GetChannelCommands, requires(permission.GetChannel))
+				apiChannelsCID.POST("/commands", h.InvokeChannelCommand, requires(permission.PostMessage))
 				apiChannelsCID.GET("/events", h.GetChannelEvents, requires(permission.GetChannel))
 			}
 		}
@@ -202,10 +224,15 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiMessagesMID.GET("", h.GetMessage, requires(permission.GetMessage))
 				apiMessagesMID.PUT("", h.EditMessage, bodyLimit(100), requires(permission.EditMessage))
 				apiMessagesMID.DELETE("", h.DeleteMessage, requires(permission.DeleteMessage))
+				apiMessagesMID.GET("/history", h.GetMessageHistory, requires(permission.GetMessage))
 				apiMessagesMID.GET("/pin", h.GetPin, requires(permission.GetMessage))
 				apiMessagesMID.POST("/pin", h.CreatePin, requires(permission.CreateMessagePin))
 				apiMessagesMID.DELETE("/pin", h.RemovePin, requires(permission.DeleteMessagePin))
 				apiMessagesMID.GET("/clips", h.GetMessageClips, requires(permission.GetClipFolder))
+				apiMessagesMID.GET("/replies", h.GetMessageReplies, requires(permission.GetMessage))
+				apiMessagesMID.POST("/replies", h.PostMessageReply, bodyLimit(100), requires(permission.PostMessage))
+				apiMessagesMID.POST("/reports", h.ReportMessage, requires(permission.ReportMessage))
+				apiMessagesMID.POST("/actions/:actionID", h.InvokeMessageAction, requires(permission.PostMessage))
 				apiMessagesMIDStamps := apiMessagesMID.Group("/stamps")
 				{
 					apiMessagesMIDStamps.GET("", h.GetMessageStamps, requires(permission.GetMessage))
@@ -273,6 +300,11 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiWebhooksWID.GET("/icon", h.GetWebhookIcon, requires(permission.GetWebhook))
 				apiWebhooksWID.PUT("/icon", h.ChangeWebhookIcon, requires(permission.EditWebhook))
 				apiWebhooksWID.GET("/messages", h.GetWebhookMessages, requires(permission.GetWebhook))
+				apiWebhooksWID.GET("/logs", h.GetWebhookLogs, requires(permission.GetWebhook))
+				apiWebhooksWID.GET("/outgoing", h.GetOutgoingWebhook, requires(permission.GetWebhook))
+				apiWebhooksWID.PUT("/outgoing", h.PutOutgoingWebhook, requires(permission.EditWebhook))
+				apiWebhooksWID.DELETE("/outgoing", h.DeleteOutgoingWebhook, requires(permission.EditWebhook))
+				apiWebhooksWID.GET("/outgoing/logs", h.GetOutgoingWebhookLogs, requires(permission.GetWebhook))
 			}
 		}
 		apiGroups := api.Group("/groups")
@@ -334,6 +366,10 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiBotsBID.GET("/icon", h.GetBotIcon, requires(permission.GetBot))
 				apiBotsBID.PUT("/icon", h.ChangeBotIcon, requiresBotAccessPerm, requires(permission.EditBot))
 				apiBotsBID.GET("/logs", h.GetBotLogs, requiresBotAccessPerm, requires(permission.GetBot))
+				apiBotsBID.GET("/commands", h.GetBotCommands, requires(permission.GetBot))
+				apiBotsBID.PUT("/commands", h.PutBotCommands, requiresBotAccessPerm, requires(permission.EditBot))
+				apiBotsBID.GET("/dead-letters", h.GetBotDeadLetters, requiresBotAccessPerm, requires(permission.GetBot))
+				apiBotsBID.POST("/dead-letters/:deadLetterID/redeliver", h.RedeliverBotDeadLetter, requiresBotAccessPerm, requires(permission.EditBot))
 				apiBotsBIDActions := apiBotsBID.Group("/actions", requiresBotAccessPerm)
 				{
 					apiBotsBIDActions.POST("/activate", h.ActivateBot, requires(permission.EditBot))
@@ -366,6 +402,27 @@ func (h *Handlers) Setup(e *echo.Group) {
 				}
 			}
 		}
+		apiMessageReports := api.Group("/message-reports")
+		{
+			apiMessageReports.GET("", h.GetMessageReports, requires(permission.GetMessageReports))
+			apiMessageReportsRID := apiMessageReports.Group("/:reportID", retrieve.MessageReportID())
+			{
+				apiMessageReportsRID.GET("", h.GetMessageReport, requires(permission.GetMessageReports))
+				apiMessageReportsRID.POST("/resolve", h.ResolveMessageReport, requires(permission.HandleMessageReports))
+				apiMessageReportsRID.POST("/dismiss", h.DismissMessageReport, requires(permission.HandleMessageReports))
+			}
+		}
+		apiScheduledMessages := api.Group("/scheduled-messages")
+		{
+			apiScheduledMessages.GET("", h.GetScheduledMessages, requires(permission.GetMessage))
+			apiScheduledMessages.POST("", h.CreateScheduledMessage, bodyLimit(100), requires(permission.PostMessage))
+			apiScheduledMessagesSMID := apiScheduledMessages.Group("/:scheduledMessageID", retrieve.ScheduledMessageID(), requiresScheduledMessageAccessPerm)
+			{
+				apiScheduledMessagesSMID.GET("", h.GetScheduledMessage, requires(permission.GetMessage))
+				apiScheduledMessagesSMID.PATCH("", h.EditScheduledMessage, bodyLimit(100), requires(permission.EditMessage))
+				apiScheduledMessagesSMID.DELETE("", h.DeleteScheduledMessage, requires(permission.DeleteMessage))
+			}
+		}
 		api.GET("/ws", echo.WrapHandler(h.WS), requires(permission.ConnectNotificationStream), blockBot)
 		api.GET("/ogp", h.GetOgp, blockBot)
 	}
@@ -378,7 +435,9 @@ func (h *Handlers) Setup(e *echo.Group) {
 		}
 		apiNoAuth.POST("/login", h.Login, noLogin)
 		apiNoAuth.POST("/logout", h.Logout)
-		apiNoAuth.POST("/webhooks/:webhookID", h.PostWebhook, retrieve.WebhookID())
+		apiNoAuth.POST("/webhooks/:webhookID", h.PostWebhook, retrieve.WebhookID(), webhookLogging(""))
+		apiNoAuth.POST("/webhooks/:webhookID/github", h.PostWebhookGitHub, retrieve.WebhookID(), webhookLogging("github"))
+		apiNoAuth.POST("/webhooks/:webhookID/gitlab", h.PostWebhookGitLab, retrieve.WebhookID(), webhookLogging("gitlab"))
 		apiNoAuthPublic := apiNoAuth.Group("/public")
 		{
 			apiNoAuthPublic.GET("/icon/:username", h.GetPublicUserIcon)
@@ -44,6 +44,7 @@ const (
 	s1       = "s1"
 	s2       = "s2"
 	s3       = "s3"
+	hideDM   = "hide-dm"
 	rand     = "random"
 )
 
@@ -59,6 +60,7 @@ func TestMain(m *testing.M) {
 		s1,
 		s2,
 		s3,
+		hideDM,
 	}
 	if err := migration.CreateDatabasesIfNotExists("mysql", fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8mb4&parseTime=true", user, pass, host, port), dbPrefix, dbs...); err != nil {
 		panic(err)
@@ -140,6 +142,8 @@ func TestMain(m *testing.M) {
 				WebRTCSecretKey: "dummy.secret.key",
 				WebRTCAPIKey:    "dummy.api.key",
 				AllowSignUp:     false,
+				// DMの編集履歴非公開のテストはhideDM環境で行う
+				HideDMMessageHistory: key == hideDM,
 				EnabledExternalAccountProviders: map[string]bool{
 					"traq": true,
 				},
@@ -302,7 +306,7 @@ func (env *Env) CreateMessage(t *testing.T, userID, channelID uuid.UUID, text st
 	if text == rand {
 		text = random.AlphaNumeric(20)
 	}
-	m, err := env.MM.Create(channelID, userID, text)
+	m, err := env.MM.Create(channelID, userID, text, nil)
 	require.NoError(t, err)
 	return m
 }
@@ -451,6 +455,28 @@ func (env *Env) CreateClipFolder(t *testing.T, name, desc string, creatorID uuid
 	return cf
 }
 
+// CreateScheduledMessage 予約投稿メッセージを必ず作成します
+func (env *Env) CreateScheduledMessage(t *testing.T, userID, channelID uuid.UUID, text string, scheduledAt time.Time) *model.ScheduledMessage {
+	t.Helper()
+	if text == rand {
+		text = random.AlphaNumeric(20)
+	}
+	sm, err := env.Repository.CreateScheduledMessage(userID, channelID, text, scheduledAt)
+	require.NoError(t, err)
+	return sm
+}
+
+// CreateMessageReport メッセージ通報を必ず作成します
+func (env *Env) CreateMessageReport(t *testing.T, messageID, reporterID uuid.UUID, reason string) *model.MessageReport {
+	t.Helper()
+	if reason == rand {
+		reason = random.AlphaNumeric(20)
+	}
+	r, err := env.Repository.CreateMessageReport(messageID, reporterID, reason)
+	require.NoError(t, err)
+	return r
+}
+
 func getEnvOrDefault(env string, def string) string {
 	s := os.Getenv(env)
 	if len(s) == 0 {
@@ -0,0 +1,141 @@
+package v3
+
+import (
+	"net/http"
+	"time"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/extension"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// GetScheduledMessages GET /scheduled-messages
+func (h *Handlers) GetScheduledMessages(c echo.Context) error {
+	userID := getRequestUserID(c)
+
+	sms, err := h.Repo.GetScheduledMessagesByUserID(userID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return extension.ServeJSONWithETag(c, formatScheduledMessages(sms))
+}
+
+// PostScheduledMessageRequest POST /scheduled-messages リクエストボディ
+type PostScheduledMessageRequest struct {
+	ChannelID   uuid.UUID `json:"channelId"`
+	Content     string    `json:"content"`
+	ScheduledAt time.Time `json:"scheduledAt"`
+	Embed       bool      `json:"embed" query:"embed"`
+}
+
+func (r PostScheduledMessageRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.ChannelID, vd.Required),
+		vd.Field(&r.Content, vd.Required, vd.RuneLength(1, 10000)),
+		vd.Field(&r.ScheduledAt, vd.Required, vd.Min(time.Now()).Error("must be in the future")),
+	)
+}
+
+// CreateScheduledMessage POST /scheduled-messages
+func (h *Handlers) CreateScheduledMessage(c echo.Context) error {
+	userID := getRequestUserID(c)
+
+	var req PostScheduledMessageRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	// アクセス権確認
+	if ok, err := h.ChannelManager.IsChannelAccessibleToUser(userID, req.ChannelID); err != nil {
+		return herror.InternalServerError(err)
+	} else if !ok {
+		return herror.BadRequest("invalid channelId")
+	}
+	if h.ChannelManager.IsPublicChannel(req.ChannelID) && h.ChannelManager.PublicChannelTree().IsArchivedChannel(req.ChannelID) {
+		return herror.BadRequest("this channel has been archived")
+	}
+
+	if req.Embed {
+		req.Content = h.Replacer.Replace(req.Content)
+	}
+
+	sm, err := h.Repo.CreateScheduledMessage(userID, req.ChannelID, req.Content, req.ScheduledAt)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusCreated, formatScheduledMessage(sm))
+}
+
+// GetScheduledMessage GET /scheduled-messages/:scheduledMessageID
+func (h *Handlers) GetScheduledMessage(c echo.Context) error {
+	return c.JSON(http.StatusOK, formatScheduledMessage(getParamScheduledMessage(c)))
+}
+
+// PatchScheduledMessageRequest PATCH /scheduled-messages/:scheduledMessageID リクエストボディ
+type PatchScheduledMessageRequest struct {
+	Content     optional.String `json:"content"`
+	ScheduledAt optional.Time   `json:"scheduledAt"`
+	Embed       bool            `json:"embed" query:"embed"`
+}
+
+func (r PatchScheduledMessageRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Content, vd.RuneLength(1, 10000)),
+		vd.Field(&r.ScheduledAt, vd.Min(time.Now()).Error("must be in the future")),
+	)
+}
+
+// EditScheduledMessage PATCH /scheduled-messages/:scheduledMessageID
+func (h *Handlers) EditScheduledMessage(c echo.Context) error {
+	sm := getParamScheduledMessage(c)
+
+	var req PatchScheduledMessageRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	// 送信中・送信失敗のメッセージは編集できない
+	if !sm.IsPending() {
+		return herror.Conflict("this scheduled message is not pending")
+	}
+
+	if req.Embed && req.Content.Valid {
+		req.Content.String = h.Replacer.Replace(req.Content.String)
+	}
+
+	args := repository.UpdateScheduledMessageArgs{
+		Text:        req.Content,
+		ScheduledAt: req.ScheduledAt,
+	}
+	if err := h.Repo.UpdateScheduledMessage(sm.ID, args); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		case repository.ErrForbidden:
+			return herror.Conflict("this scheduled message is not pending")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteScheduledMessage DELETE /scheduled-messages/:scheduledMessageID
+func (h *Handlers) DeleteScheduledMessage(c echo.Context) error {
+	sm := getParamScheduledMessage(c)
+
+	if err := h.Repo.DeleteScheduledMessage(sm.ID); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,341 @@
+package v3
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func scheduledMessageEquals(t *testing.T, expect *model.ScheduledMessage, actual *httpexpect.Object) {
+	t.Helper()
+	actual.Value("id").String().Equal(expect.ID.String())
+	actual.Value("userId").String().Equal(expect.UserID.String())
+	actual.Value("channelId").String().Equal(expect.ChannelID.String())
+	actual.Value("content").String().Equal(expect.Text)
+	actual.Value("scheduledAt").String().NotEmpty()
+	actual.Value("state").String().Equal(string(expect.State))
+	actual.Value("error").String().Equal(expect.Error)
+}
+
+func TestPostScheduledMessageRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	cid := uuid.Must(uuid.NewV4())
+	tests := []struct {
+		name    string
+		req     PostScheduledMessageRequest
+		wantErr bool
+	}{
+		{
+			"empty channel id",
+			PostScheduledMessageRequest{Content: "a", ScheduledAt: time.Now().Add(time.Hour)},
+			true,
+		},
+		{
+			"empty content",
+			PostScheduledMessageRequest{ChannelID: cid, ScheduledAt: time.Now().Add(time.Hour)},
+			true,
+		},
+		{
+			"too long content",
+			PostScheduledMessageRequest{ChannelID: cid, Content: strings.Repeat("a", 10001), ScheduledAt: time.Now().Add(time.Hour)},
+			true,
+		},
+		{
+			"past",
+			PostScheduledMessageRequest{ChannelID: cid, Content: "a", ScheduledAt: time.Now().Add(-time.Hour)},
+			true,
+		},
+		{
+			"success",
+			PostScheduledMessageRequest{ChannelID: cid, Content: "a", ScheduledAt: time.Now().Add(time.Hour)},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandlers_CreateScheduledMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/scheduled-messages"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	archived := env.CreateChannel(t, rand)
+	require.NoError(t, env.CM.ArchiveChannel(archived.ID, user.GetID()))
+	userSession := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (past)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, userSession).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: ch.ID, Content: "a", ScheduledAt: time.Now().Add(-time.Hour)}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (unknown channel)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, userSession).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: uuid.Must(uuid.NewV4()), Content: "a", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (archived)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, userSession).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: archived.ID, Content: "a", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path).
+			WithCookie(session.CookieName, userSession).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: ch.ID, Content: "scheduled", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("id").String().NotEmpty()
+		obj.Value("userId").String().Equal(user.GetID().String())
+		obj.Value("channelId").String().Equal(ch.ID.String())
+		obj.Value("content").String().Equal("scheduled")
+		obj.Value("error").String().Empty()
+	})
+}
+
+func TestHandlers_GetScheduledMessages(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/scheduled-messages"
+	env := Setup(t, common1)
+	user1 := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	sm := env.CreateScheduledMessage(t, user1.GetID(), ch.ID, rand, time.Now().Add(time.Hour))
+	env.CreateScheduledMessage(t, user2.GetID(), ch.ID, rand, time.Now().Add(time.Hour))
+	user1Session := env.S(t, user1.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, user1Session).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+		scheduledMessageEquals(t, sm, obj.First().Object())
+	})
+}
+
+func TestHandlers_GetScheduledMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/scheduled-messages/{scheduledMessageId}"
+	env := Setup(t, common1)
+	user1 := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	sm1 := env.CreateScheduledMessage(t, user1.GetID(), ch.ID, rand, time.Now().Add(time.Hour))
+	sm2 := env.CreateScheduledMessage(t, user2.GetID(), ch.ID, rand, time.Now().Add(time.Hour))
+	user1Session := env.S(t, user1.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, sm1.ID.String()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("other's scheduled message", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, sm2.ID.String()).
+			WithCookie(session.CookieName, user1Session).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV4()).String()).
+			WithCookie(session.CookieName, user1Session).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, sm1.ID.String()).
+			WithCookie(session.CookieName, user1Session).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		scheduledMessageEquals(t, sm1, obj)
+	})
+}
+
+func TestHandlers_EditScheduledMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/scheduled-messages/{scheduledMessageId}"
+	env := Setup(t, common1)
+	user1 := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	sm1 := env.CreateScheduledMessage(t, user1.GetID(), ch.ID, rand, time.Now().Add(time.Hour))
+	sm2 := env.CreateScheduledMessage(t, user2.GetID(), ch.ID, rand, time.Now().Add(time.Hour))
+	sending := env.CreateScheduledMessage(t, user1.GetID(), ch.ID, rand, time.Now().Add(time.Hour))
+	ok, err := env.Repository.ClaimScheduledMessage(sending.ID, time.Now())
+	require.NoError(t, err)
+	require.True(t, ok)
+	failed := env.CreateScheduledMessage(t, user1.GetID(), ch.ID, rand, time.Now().Add(time.Hour))
+	require.NoError(t, env.Repository.SetScheduledMessageError(failed.ID, "failed"))
+	user1Session := env.S(t, user1.GetID())
+
+	t.Run("other's scheduled message", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, sm2.ID.String()).
+			WithCookie(session.CookieName, user1Session).
+			WithJSON(&PatchScheduledMessageRequest{Content: optional.StringFrom("edited")}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, sm1.ID.String()).
+			WithCookie(session.CookieName, user1Session).
+			WithJSON(&PatchScheduledMessageRequest{ScheduledAt: optional.TimeFrom(time.Now().Add(-time.Hour))}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("conflict (sending)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, sending.ID.String()).
+			WithCookie(session.CookieName, user1Session).
+			WithJSON(&PatchScheduledMessageRequest{Content: optional.StringFrom("edited")}).
+			Expect().
+			Status(http.StatusConflict)
+
+		// 送信中状態は解除されない
+		sm, err := env.Repository.GetScheduledMessage(sending.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.ScheduledMessageSending, sm.State)
+	})
+
+	t.Run("conflict (failed)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, failed.ID.String()).
+			WithCookie(session.CookieName, user1Session).
+			WithJSON(&PatchScheduledMessageRequest{Content: optional.StringFrom("edited")}).
+			Expect().
+			Status(http.StatusConflict)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, sm1.ID.String()).
+			WithCookie(session.CookieName, user1Session).
+			WithJSON(&PatchScheduledMessageRequest{Content: optional.StringFrom("edited")}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		sm, err := env.Repository.GetScheduledMessage(sm1.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "edited", sm.Text)
+	})
+}
+
+func TestHandlers_DeleteScheduledMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/scheduled-messages/{scheduledMessageId}"
+	env := Setup(t, common1)
+	user1 := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	sm1 := env.CreateScheduledMessage(t, user1.GetID(), ch.ID, rand, time.Now().Add(time.Hour))
+	sm2 := env.CreateScheduledMessage(t, user2.GetID(), ch.ID, rand, time.Now().Add(time.Hour))
+	user1Session := env.S(t, user1.GetID())
+
+	t.Run("other's scheduled message", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, sm2.ID.String()).
+			WithCookie(session.CookieName, user1Session).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, sm1.ID.String()).
+			WithCookie(session.CookieName, user1Session).
+			Expect().
+			Status(http.StatusNoContent)
+
+		_, err := env.Repository.GetScheduledMessage(sm1.ID)
+		assert.Error(t, err)
+	})
+}
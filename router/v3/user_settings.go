@@ -2,10 +2,15 @@ package v3
 
 import (
 	"net/http"
+	"time"
 
+	vd "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/labstack/echo/v4"
 
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 // PutMyNotifyCitationRequest PUT /user/me/settings/notify-citation リクエストボディ
@@ -56,3 +61,140 @@ func (h *Handlers) GetMyNotifyCitation(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, &res{NotifyCitation: nc})
 }
+
+// PutMyEmailDigestRequest PUT /users/me/settings/email-digest リクエストボディ
+type PutMyEmailDigestRequest struct {
+	Frequency model.EmailDigestFrequency `json:"frequency"`
+}
+
+func (r PutMyEmailDigestRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Frequency, vd.Required, vd.In(model.EmailDigestOff, model.EmailDigestHourly, model.EmailDigestDaily)),
+	)
+}
+
+// GetMyEmailDigest GET /users/me/settings/email-digest
+func (h *Handlers) GetMyEmailDigest(c echo.Context) error {
+	us, err := h.Repo.GetUserSettings(getRequestUserID(c))
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"frequency": us.EmailDigestFrequency})
+}
+
+// PutMyEmailDigest PUT /users/me/settings/email-digest
+func (h *Handlers) PutMyEmailDigest(c echo.Context) error {
+	var req PutMyEmailDigestRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.Repo.UpdateEmailDigestFrequency(getRequestUserID(c), req.Frequency); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PutMyDNDRequest PUT /users/me/settings/dnd リクエストボディ
+type PutMyDNDRequest struct {
+	Until              optional.Time `json:"dndUntil"`
+	MuteForceChannels  bool          `json:"dndMuteForceChannels"`
+	QuietHoursEnabled  bool          `json:"quietHoursEnabled"`
+	QuietHoursStart    string        `json:"quietHoursStart"`
+	QuietHoursEnd      string        `json:"quietHoursEnd"`
+	QuietHoursTimezone string        `json:"quietHoursTimezone"`
+}
+
+var (
+	isClock    = vd.NewStringRule(model.IsValidClock, "must be in HH:MM format")
+	isTimezone = vd.NewStringRule(model.IsValidTimezone, "must be a valid IANA time zone name")
+)
+
+func (r PutMyDNDRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.QuietHoursStart, vd.When(r.QuietHoursEnabled, vd.Required), isClock),
+		vd.Field(&r.QuietHoursEnd, vd.When(r.QuietHoursEnabled, vd.Required), isClock),
+		vd.Field(&r.QuietHoursTimezone, isTimezone),
+	)
+}
+
+// GetMyDND GET /users/me/settings/dnd
+func (h *Handlers) GetMyDND(c echo.Context) error {
+	us, err := h.Repo.GetUserSettings(getRequestUserID(c))
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"dndUntil":             us.DNDUntil,
+		"dndMuteForceChannels": us.DNDMuteForceChannels,
+		"quietHoursEnabled":    us.QuietHoursEnabled,
+		"quietHoursStart":      us.QuietHoursStart,
+		"quietHoursEnd":        us.QuietHoursEnd,
+		"quietHoursTimezone":   us.QuietHoursTimezone,
+		"active":               us.IsDNDActive(time.Now()),
+	})
+}
+
+// PutMyDND PUT /users/me/settings/dnd
+func (h *Handlers) PutMyDND(c echo.Context) error {
+	var req PutMyDNDRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.Repo.UpdateDNDSettings(getRequestUserID(c), repository.UpdateDNDSettingsArgs{
+		Until:              req.Until,
+		MuteForceChannels:  req.MuteForceChannels,
+		QuietHoursEnabled:  req.QuietHoursEnabled,
+		QuietHoursStart:    req.QuietHoursStart,
+		QuietHoursEnd:      req.QuietHoursEnd,
+		QuietHoursTimezone: req.QuietHoursTimezone,
+	}); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PutMyNotificationKeywordsRequest PUT /users/me/settings/notification-keywords リクエストボディ
+type PutMyNotificationKeywordsRequest struct {
+	Keywords []string `json:"keywords"`
+}
+
+func (r PutMyNotificationKeywordsRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Keywords, vd.NotNil, vd.Length(0, model.MaxNotificationKeywords), vd.Each(vd.Required, vd.RuneLength(1, model.MaxNotificationKeywordLength))),
+	)
+}
+
+// GetMyNotificationKeywords GET /users/me/settings/notification-keywords
+func (h *Handlers) GetMyNotificationKeywords(c echo.Context) error {
+	keywords, err := h.Repo.GetNotificationKeywords(getRequestUserID(c))
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"keywords": keywords})
+}
+
+// PutMyNotificationKeywords PUT /users/me/settings/notification-keywords
+func (h *Handlers) PutMyNotificationKeywords(c echo.Context) error {
+	var req PutMyNotificationKeywordsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.Repo.SetNotificationKeywords(getRequestUserID(c), req.Keywords); err != nil {
+		switch {
+		case repository.IsArgError(err):
+			return herror.BadRequest(err)
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
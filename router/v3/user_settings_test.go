@@ -2,12 +2,17 @@ package v3
 
 import (
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 func TestHandlers_PutMyNotifyCitation(t *testing.T) {
@@ -70,6 +75,9 @@ func TestHandlers_GetMySettings(t *testing.T) {
 
 		obj.Value("id").String().Equal(user.GetID().String())
 		obj.Value("notifyCitation").Boolean().False()
+		obj.Value("emailDigestFrequency").String().Equal("daily")
+		obj.Value("dndUntil").Null()
+		obj.Value("quietHoursEnabled").Boolean().False()
 	})
 }
 
@@ -102,3 +110,257 @@ func TestHandlers_GetMyNotifyCitation(t *testing.T) {
 		obj.Value("notifyCitation").Boolean().False()
 	})
 }
+
+func TestHandlers_GetMyEmailDigest(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/email-digest"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("frequency").String().Equal("daily")
+	})
+}
+
+func TestHandlers_PutMyEmailDigest(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/email-digest"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithJSON(&PutMyEmailDigestRequest{Frequency: model.EmailDigestOff}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyEmailDigestRequest{Frequency: "weekly"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyEmailDigestRequest{Frequency: model.EmailDigestOff}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		us, err := env.Repository.GetUserSettings(user.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, model.EmailDigestOff, us.EmailDigestFrequency)
+	})
+}
+
+func TestHandlers_GetMyDND(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/dnd"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+	require.NoError(t, env.Repository.UpdateDNDSettings(user.GetID(), repository.UpdateDNDSettingsArgs{
+		Until:              optional.TimeFrom(time.Now().Add(time.Hour)),
+		QuietHoursEnabled:  true,
+		QuietHoursStart:    "22:00",
+		QuietHoursEnd:      "07:00",
+		QuietHoursTimezone: "Asia/Tokyo",
+	}))
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("dndUntil").String().NotEmpty()
+		obj.Value("dndMuteForceChannels").Boolean().False()
+		obj.Value("quietHoursEnabled").Boolean().True()
+		obj.Value("quietHoursStart").String().Equal("22:00")
+		obj.Value("quietHoursEnd").String().Equal("07:00")
+		obj.Value("quietHoursTimezone").String().Equal("Asia/Tokyo")
+		obj.Value("active").Boolean().True()
+	})
+}
+
+func TestHandlers_PutMyDND(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/dnd"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithJSON(&PutMyDNDRequest{}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		for _, req := range []*PutMyDNDRequest{
+			{QuietHoursEnabled: true},
+			{QuietHoursEnabled: true, QuietHoursStart: "22:00", QuietHoursEnd: "7:00"},
+			{QuietHoursStart: "22:00", QuietHoursEnd: "07:00", QuietHoursTimezone: "Asia/Nowhere"},
+		} {
+			e := env.R(t)
+			e.PUT(path).
+				WithCookie(session.CookieName, s).
+				WithJSON(req).
+				Expect().
+				Status(http.StatusBadRequest)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyDNDRequest{
+				MuteForceChannels:  true,
+				QuietHoursEnabled:  true,
+				QuietHoursStart:    "22:00",
+				QuietHoursEnd:      "07:00",
+				QuietHoursTimezone: "Asia/Tokyo",
+			}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		us, err := env.Repository.GetUserSettings(user.GetID())
+		require.NoError(t, err)
+		assert.False(t, us.DNDUntil.Valid)
+		assert.True(t, us.DNDMuteForceChannels)
+		assert.True(t, us.QuietHoursEnabled)
+		assert.Equal(t, "22:00", us.QuietHoursStart)
+		assert.Equal(t, "07:00", us.QuietHoursEnd)
+		assert.Equal(t, "Asia/Tokyo", us.QuietHoursTimezone)
+	})
+}
+
+func TestHandlers_GetMyNotificationKeywords(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/notification-keywords"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+	require.NoError(t, env.Repository.SetNotificationKeywords(user.GetID(), []string{"traQ", "SysAd"}))
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("keywords").Array().Equal([]string{"traQ", "SysAd"})
+	})
+}
+
+func TestHandlers_PutMyNotificationKeywords(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/notification-keywords"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithJSON(&PutMyNotificationKeywordsRequest{Keywords: []string{"traQ"}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		for _, req := range []*PutMyNotificationKeywordsRequest{
+			{Keywords: nil},
+			{Keywords: []string{""}},
+			{Keywords: []string{"  "}},
+			{Keywords: []string{strings.Repeat("a", model.MaxNotificationKeywordLength+1)}},
+		} {
+			e := env.R(t)
+			e.PUT(path).
+				WithCookie(session.CookieName, s).
+				WithJSON(req).
+				Expect().
+				Status(http.StatusBadRequest)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyNotificationKeywordsRequest{Keywords: []string{"traQ", "新機能"}}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		keywords, err := env.Repository.GetNotificationKeywords(user.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"traQ", "新機能"}, keywords)
+	})
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"net/http"
 	"sort"
+	"strings"
 	"time"
 
 	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/gofrs/uuid"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/labstack/echo/v4"
@@ -21,6 +23,7 @@ import (
 	"github.com/traPtitech/traQ/service/channel"
 	"github.com/traPtitech/traQ/service/file"
 	"github.com/traPtitech/traQ/service/rbac/role"
+	"github.com/traPtitech/traQ/service/webpush"
 	jwt2 "github.com/traPtitech/traQ/utils/jwt"
 	"github.com/traPtitech/traQ/utils/optional"
 	"github.com/traPtitech/traQ/utils/validator"
@@ -114,6 +117,7 @@ func (h *Handlers) GetMe(c echo.Context) error {
 		"state":       me.GetState().Int(),
 		"permissions": h.RBAC.GetGrantedPermissions(me.GetRole()),
 		"homeChannel": me.GetHomeChannel(),
+		"email":       me.GetEmail(),
 	})
 }
 
@@ -123,6 +127,7 @@ type PatchMeRequest struct {
 	TwitterID   optional.String `json:"twitterId"`
 	Bio         optional.String `json:"bio"`
 	HomeChannel optional.UUID   `json:"homeChannel"`
+	Email       optional.String `json:"email"`
 }
 
 func (r PatchMeRequest) ValidateWithContext(ctx context.Context) error {
@@ -130,6 +135,7 @@ func (r PatchMeRequest) ValidateWithContext(ctx context.Context) error {
 		vd.Field(&r.DisplayName, vd.RuneLength(0, 64)),
 		vd.Field(&r.TwitterID, validator.TwitterIDRule...),
 		vd.Field(&r.Bio, vd.RuneLength(0, 1000)),
+		vd.Field(&r.Email, vd.RuneLength(0, 254), is.EmailFormat),
 	)
 }
 
@@ -156,6 +162,7 @@ func (h *Handlers) EditMe(c echo.Context) error {
 		TwitterID:   req.TwitterID,
 		Bio:         req.Bio,
 		HomeChannel: req.HomeChannel,
+		Email:       req.Email,
 	}
 	if err := h.Repo.UpdateUser(userID, args); err != nil {
 		return herror.InternalServerError(err)
@@ -306,6 +313,101 @@ func (h *Handlers) PostMyFCMDevice(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// WebPushSubscriptionKeys Web Push購読情報の鍵
+type WebPushSubscriptionKeys struct {
+	P256dh string `json:"p256dh"`
+	Auth   string `json:"auth"`
+}
+
+func (k WebPushSubscriptionKeys) Validate() error {
+	if err := vd.ValidateStruct(&k,
+		vd.Field(&k.P256dh, vd.Required, vd.RuneLength(1, 100)),
+		vd.Field(&k.Auth, vd.Required, vd.RuneLength(1, 50)),
+	); err != nil {
+		return err
+	}
+	if _, _, err := webpush.ParseSubscriptionKeys(k.P256dh, k.Auth); err != nil {
+		return vd.NewError("validation_invalid_subscription_keys", "invalid subscription keys")
+	}
+	return nil
+}
+
+// PostMyWebPushSubscriptionRequest POST /users/me/web-push-subscriptions リクエストボディ
+type PostMyWebPushSubscriptionRequest struct {
+	Endpoint string                  `json:"endpoint"`
+	Keys     WebPushSubscriptionKeys `json:"keys"`
+}
+
+func (r PostMyWebPushSubscriptionRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Endpoint, vd.Required, vd.RuneLength(1, 512), is.URL, isHTTPSURL, validator.NotInternalURL),
+		vd.Field(&r.Keys),
+	)
+}
+
+var isHTTPSURL = vd.By(func(value interface{}) error {
+	s, _ := value.(string)
+	if len(s) > 0 && !strings.HasPrefix(s, "https://") {
+		return vd.NewError("validation_is_https_url", "must be https url")
+	}
+	return nil
+})
+
+// PostMyWebPushSubscription POST /users/me/web-push-subscriptions
+func (h *Handlers) PostMyWebPushSubscription(c echo.Context) error {
+	var req PostMyWebPushSubscriptionRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	userID := getRequestUserID(c)
+	if err := h.Repo.RegisterWebPushSubscription(userID, repository.RegisterWebPushSubscriptionArgs{
+		Endpoint: req.Endpoint,
+		P256dh:   req.Keys.P256dh,
+		Auth:     req.Keys.Auth,
+	}); err != nil {
+		switch {
+		case repository.IsArgError(err):
+			return herror.BadRequest(err)
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteMyWebPushSubscriptionRequest DELETE /users/me/web-push-subscriptions リクエストボディ
+type DeleteMyWebPushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+}
+
+func (r DeleteMyWebPushSubscriptionRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Endpoint, vd.Required, vd.RuneLength(1, 512)),
+	)
+}
+
+// DeleteMyWebPushSubscription DELETE /users/me/web-push-subscriptions
+func (h *Handlers) DeleteMyWebPushSubscription(c echo.Context) error {
+	var req DeleteMyWebPushSubscriptionRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	userID := getRequestUserID(c)
+	if err := h.Repo.UnregisterWebPushSubscription(userID, req.Endpoint); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
 // PutUserPasswordRequest PUT /users/:userID/password リクエストボディ
 type PutUserPasswordRequest struct {
 	NewPassword string `json:"newPassword"`
@@ -1,6 +1,10 @@
 package v3
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	cryptoRand "crypto/rand"
+	"encoding/base64"
 	"net/http"
 	"strings"
 	"testing"
@@ -246,6 +250,7 @@ func TestHandlers_CreateUser(t *testing.T) {
 		obj.Value("groups").Array().Length().Equal(0)
 		obj.Value("bio").String().Empty()
 		obj.Value("homeChannel").Null()
+		obj.Value("email").String().Empty()
 	})
 }
 
@@ -314,6 +319,16 @@ func TestHandlers_EditMe(t *testing.T) {
 			Status(http.StatusBadRequest)
 	})
 
+	t.Run("bad request (invalid email)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PatchMeRequest{Email: optional.StringFrom("invalid")}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
 	t.Run("bad request (invalid home channel)", func(t *testing.T) {
 		t.Parallel()
 		e := env.R(t)
@@ -332,6 +347,7 @@ func TestHandlers_EditMe(t *testing.T) {
 			WithJSON(&PatchMeRequest{
 				DisplayName: optional.StringFrom("po"),
 				HomeChannel: optional.UUIDFrom(ch.ID),
+				Email:       optional.StringFrom("po@example.com"),
 			}).
 			Expect().
 			Status(http.StatusNoContent)
@@ -339,6 +355,7 @@ func TestHandlers_EditMe(t *testing.T) {
 		profile, err := env.Repository.GetUser(user.GetID(), true)
 		require.NoError(t, err)
 		assert.EqualValues(t, "po", profile.GetDisplayName())
+		assert.EqualValues(t, "po@example.com", profile.GetEmail())
 		if assert.True(t, profile.GetHomeChannel().Valid) {
 			assert.EqualValues(t, ch.ID, profile.GetHomeChannel().UUID)
 		}
@@ -675,6 +692,158 @@ func TestHandlers_PostMyFCMDevice(t *testing.T) {
 	})
 }
 
+func newTestWebPushSubscriptionKeys(t *testing.T) WebPushSubscriptionKeys {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), cryptoRand.Reader)
+	require.NoError(t, err)
+	return WebPushSubscriptionKeys{
+		P256dh: base64.RawURLEncoding.EncodeToString(elliptic.Marshal(elliptic.P256(), priv.X, priv.Y)),
+		Auth:   base64.RawURLEncoding.EncodeToString([]byte(random2.AlphaNumeric(16))),
+	}
+}
+
+func TestPostMyWebPushSubscriptionRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestWebPushSubscriptionKeys(t)
+	type fields struct {
+		Endpoint string
+		Keys     WebPushSubscriptionKeys
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			"empty",
+			fields{},
+			true,
+		},
+		{
+			"not https",
+			fields{Endpoint: "http://push.example.com/abc", Keys: keys},
+			true,
+		},
+		{
+			"invalid keys",
+			fields{Endpoint: "https://push.example.com/abc", Keys: WebPushSubscriptionKeys{P256dh: "invalid", Auth: keys.Auth}},
+			true,
+		},
+		{
+			"success",
+			fields{Endpoint: "https://push.example.com/abc", Keys: keys},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := PostMyWebPushSubscriptionRequest{
+				Endpoint: tt.fields.Endpoint,
+				Keys:     tt.fields.Keys,
+			}
+			if err := r.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandlers_PostMyWebPushSubscription(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/web-push-subscriptions"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+	keys := newTestWebPushSubscriptionKeys(t)
+	endpoint := "https://push.example.com/" + random2.AlphaNumeric(20)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithJSON(&PostMyWebPushSubscriptionRequest{Endpoint: endpoint, Keys: keys}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (invalid keys)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMyWebPushSubscriptionRequest{Endpoint: endpoint}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMyWebPushSubscriptionRequest{Endpoint: endpoint, Keys: keys}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		subs, err := env.Repository.GetWebPushSubscriptions(set.UUID{user.GetID(): {}})
+		require.NoError(t, err)
+		if assert.Len(t, subs[user.GetID()], 1) {
+			assert.Equal(t, endpoint, subs[user.GetID()][0].Endpoint)
+		}
+	})
+}
+
+func TestHandlers_DeleteMyWebPushSubscription(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/web-push-subscriptions"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+	keys := newTestWebPushSubscriptionKeys(t)
+	endpoint := "https://push.example.com/" + random2.AlphaNumeric(20)
+	require.NoError(t, env.Repository.RegisterWebPushSubscription(user.GetID(), repository.RegisterWebPushSubscriptionArgs{
+		Endpoint: endpoint,
+		P256dh:   keys.P256dh,
+		Auth:     keys.Auth,
+	}))
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path).
+			WithJSON(&DeleteMyWebPushSubscriptionRequest{Endpoint: endpoint}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&DeleteMyWebPushSubscriptionRequest{Endpoint: "https://push.example.com/unknown"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&DeleteMyWebPushSubscriptionRequest{Endpoint: endpoint}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		subs, err := env.Repository.GetWebPushSubscriptions(set.UUID{user.GetID(): {}})
+		require.NoError(t, err)
+		assert.Len(t, subs[user.GetID()], 0)
+	})
+}
+
 func TestPutUserPasswordRequest_Validate(t *testing.T) {
 	t.Parallel()
 
@@ -27,6 +27,11 @@ func bindAndValidate(c echo.Context, i interface{}) error {
 	return extension.BindAndValidate(c, i)
 }
 
+// validate 構造体iを検証します
+func validate(c echo.Context, i interface{}) error {
+	return extension.Validate(c, i)
+}
+
 // isTrue 文字列sが"1", "t", "T", "true", "TRUE", "True"の場合にtrueを返す
 func isTrue(s string) (b bool) {
 	b, _ = strconv.ParseBool(s)
@@ -103,6 +108,16 @@ func getParamClipFolder(c echo.Context) *model.ClipFolder {
 	return c.Get(consts.KeyParamClipFolder).(*model.ClipFolder)
 }
 
+// getParamScheduledMessage URLの:scheduledMessageIDに対応するScheduledMessageを取得
+func getParamScheduledMessage(c echo.Context) *model.ScheduledMessage {
+	return c.Get(consts.KeyParamScheduledMessage).(*model.ScheduledMessage)
+}
+
+// getParamMessageReport URLの:reportIDに対応するMessageReportを取得
+func getParamMessageReport(c echo.Context) *model.MessageReport {
+	return c.Get(consts.KeyParamMessageReport).(*model.MessageReport)
+}
+
 type MessagesQuery struct {
 	Limit     int           `query:"limit"`
 	Offset    int           `query:"offset"`
@@ -149,6 +164,20 @@ func (q *MessagesQuery) convertU(uid uuid.UUID) message.TimelineQuery {
 	return r
 }
 
+func (q *MessagesQuery) convertT(tid uuid.UUID) message.TimelineQuery {
+	r := q.convert()
+	r.Thread = tid
+	return r
+}
+
+// getThreadRootID メッセージが属するスレッドの親メッセージのUUIDを取得
+func getThreadRootID(m message.Message) uuid.UUID {
+	if tid := m.GetThreadID(); tid.Valid {
+		return tid.UUID
+	}
+	return m.GetID()
+}
+
 func serveMessages(c echo.Context, mm message.Manager, query message.TimelineQuery) error {
 	timeline, err := mm.GetTimeline(query)
 	if err != nil {
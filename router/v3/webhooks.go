@@ -21,8 +21,10 @@ import (
 	"github.com/traPtitech/traQ/service/file"
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/rbac/permission"
+	"github.com/traPtitech/traQ/service/webhook/adapter"
 	"github.com/traPtitech/traQ/utils/hmac"
 	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/slack"
 	"github.com/traPtitech/traQ/utils/validator"
 )
 
@@ -116,11 +118,12 @@ func (h *Handlers) GetWebhook(c echo.Context) error {
 
 // PatchWebhookRequest PATCH /webhooks/:webhookID リクエストボディ
 type PatchWebhookRequest struct {
-	Name        optional.String `json:"name"`
-	Description optional.String `json:"description"`
-	ChannelID   optional.UUID   `json:"channelId"`
-	Secret      optional.String `json:"secret"`
-	OwnerID     optional.UUID   `json:"ownerId"`
+	Name        optional.String         `json:"name"`
+	Description optional.String         `json:"description"`
+	ChannelID   optional.UUID           `json:"channelId"`
+	Secret      optional.String         `json:"secret"`
+	OwnerID     optional.UUID           `json:"ownerId"`
+	EventTypes  model.WebhookEventTypes `json:"eventTypes"`
 }
 
 func (r PatchWebhookRequest) ValidateWithContext(ctx context.Context) error {
@@ -130,6 +133,7 @@ func (r PatchWebhookRequest) ValidateWithContext(ctx context.Context) error {
 		vd.Field(&r.ChannelID, validator.NotNilUUID, utils.IsPublicChannelID),
 		vd.Field(&r.Secret, vd.RuneLength(0, 50)),
 		vd.Field(&r.OwnerID, validator.NotNilUUID, utils.IsActiveHumanUserID),
+		vd.Field(&r.EventTypes, utils.IsValidWebhookEvents),
 	)
 }
 
@@ -148,6 +152,7 @@ func (h *Handlers) EditWebhook(c echo.Context) error {
 		ChannelID:   req.ChannelID,
 		Secret:      req.Secret,
 		CreatorID:   req.OwnerID,
+		EventTypes:  req.EventTypes,
 	}
 	if err := h.Repo.UpdateWebhook(w.GetID(), args); err != nil {
 		switch {
@@ -165,10 +170,13 @@ func (h *Handlers) PostWebhook(c echo.Context) error {
 	w := getParamWebhook(c)
 	channelID := w.GetChannelID()
 
-	// text/plainのみ受け付ける
+	// text/plain, もしくはSlack互換のapplication/jsonのみ受け付ける
+	var isSlackPayload bool
 	switch strings.ToLower(c.Request().Header.Get(echo.HeaderContentType)) {
 	case echo.MIMETextPlain, strings.ToLower(echo.MIMETextPlainCharsetUTF8):
 		break
+	case echo.MIMEApplicationJSON, strings.ToLower(echo.MIMEApplicationJSONCharsetUTF8):
+		isSlackPayload = true
 	default:
 		return echo.NewHTTPError(http.StatusUnsupportedMediaType)
 	}
@@ -192,6 +200,19 @@ func (h *Handlers) PostWebhook(c echo.Context) error {
 		}
 	}
 
+	// Slack互換ペイロードの変換
+	text := string(body)
+	if isSlackPayload {
+		p, err := slack.ParsePayload(body)
+		if err != nil {
+			return herror.BadRequest("invalid json payload")
+		}
+		text, err = p.Markdown()
+		if err != nil {
+			return herror.BadRequest("empty message")
+		}
+	}
+
 	// 投稿先チャンネル変更
 	if cid := c.Request().Header.Get(consts.HeaderChannelID); len(cid) > 0 {
 		id, err := uuid.FromString(cid)
@@ -208,11 +229,77 @@ func (h *Handlers) PostWebhook(c echo.Context) error {
 
 	// 埋め込み変換
 	if isTrue(c.QueryParam("embed")) {
-		body = []byte(h.Replacer.Replace(string(body)))
+		text = h.Replacer.Replace(text)
+	}
+
+	// メッセージ投稿
+	if _, err := h.MessageManager.Create(channelID, w.GetBotUserID(), text, nil); err != nil {
+		switch err {
+		case message.ErrChannelArchived:
+			return herror.BadRequest("the channel has been archived")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PostWebhookGitHub POST /webhooks/:webhookID/github
+func (h *Handlers) PostWebhookGitHub(c echo.Context) error {
+	return h.postWebhookWithAdapter(c, adapter.GitHub{})
+}
+
+// PostWebhookGitLab POST /webhooks/:webhookID/gitlab
+func (h *Handlers) PostWebhookGitLab(c echo.Context) error {
+	return h.postWebhookWithAdapter(c, adapter.GitLab{})
+}
+
+func (h *Handlers) postWebhookWithAdapter(c echo.Context, a adapter.Adapter) error {
+	w := getParamWebhook(c)
+
+	// 外部サービスからのリクエストは署名を必ず確認するため、シークレットが必要
+	if len(w.GetSecret()) == 0 {
+		return herror.BadRequest("webhook secret is required for this endpoint")
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	if len(body) == 0 {
+		return herror.BadRequest("empty body")
+	}
+
+	// 各サービスの署名確認
+	if err := a.Verify(c.Request().Header, body, w.GetSecret()); err != nil {
+		return herror.BadRequest("signature is wrong")
+	}
+
+	eventType, text, err := a.Render(c.Request().Header, body)
+	if err != nil {
+		switch err {
+		case adapter.ErrUnsupportedEvent:
+			// 対応していないイベント(pingなど)は投稿せずに成功とする
+			return c.NoContent(http.StatusNoContent)
+		case adapter.ErrInvalidPayload:
+			return herror.BadRequest("invalid payload")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	if !w.GetEventTypes().Contains(eventType) {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	// 投稿先チャンネル確認
+	channelID := w.GetChannelID()
+	if !h.ChannelManager.PublicChannelTree().IsChannelPresent(channelID) {
+		return herror.BadRequest("invalid channel")
 	}
 
 	// メッセージ投稿
-	if _, err := h.MessageManager.Create(channelID, w.GetBotUserID(), string(body)); err != nil {
+	if _, err := h.MessageManager.Create(channelID, w.GetBotUserID(), text, nil); err != nil {
 		switch err {
 		case message.ErrChannelArchived:
 			return herror.BadRequest("the channel has been archived")
@@ -246,3 +333,36 @@ func (h *Handlers) GetWebhookMessages(c echo.Context) error {
 
 	return serveMessages(c, h.MessageManager, req.convertU(w.GetBotUserID()))
 }
+
+// GetWebhookLogsRequest GET /webhooks/:webhookID/logs リクエストクエリ
+type GetWebhookLogsRequest struct {
+	Limit  int `query:"limit"`
+	Offset int `query:"offset"`
+}
+
+func (r *GetWebhookLogsRequest) Validate() error {
+	if r.Limit == 0 {
+		r.Limit = 30
+	}
+	return vd.ValidateStruct(r,
+		vd.Field(&r.Limit, vd.Min(1), vd.Max(repository.WebhookLogsLimit)),
+		vd.Field(&r.Offset, vd.Min(0)),
+	)
+}
+
+// GetWebhookLogs GET /webhooks/:webhookID/logs
+func (h *Handlers) GetWebhookLogs(c echo.Context) error {
+	w := getParamWebhook(c)
+
+	var req GetWebhookLogsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	logs, err := h.Repo.GetWebhookLogs(w.GetID(), req.Limit, req.Offset)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, formatWebhookLogs(logs))
+}
@@ -3,12 +3,14 @@ package v3
 import (
 	"crypto/hmac"
 	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/hex"
 	"net/http"
 	"testing"
 
 	"github.com/gavv/httpexpect/v2"
 	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -30,6 +32,11 @@ func webhookEquals(t *testing.T, expect model.Webhook, actual *httpexpect.Object
 	actual.Value("secure").Boolean().Equal(len(expect.GetSecret()) > 0)
 	actual.Value("channelId").String().Equal(expect.GetChannelID().String())
 	actual.Value("ownerId").String().Equal(expect.GetCreatorID().String())
+	eventTypes := make([]interface{}, 0, len(expect.GetEventTypes()))
+	for _, eventType := range expect.GetEventTypes().Array() {
+		eventTypes = append(eventTypes, eventType)
+	}
+	actual.Value("eventTypes").Array().ContainsOnly(eventTypes...)
 	actual.Value("createdAt").String().NotEmpty()
 	actual.Value("updatedAt").String().NotEmpty()
 }
@@ -290,6 +297,16 @@ func TestHandlers_EditWebhook(t *testing.T) {
 			Status(http.StatusNotFound)
 	})
 
+	t.Run("bad request (invalid event type)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, wh.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(map[string]interface{}{"eventTypes": []string{"push", "deployment"}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
 	t.Run("success", func(t *testing.T) {
 		t.Parallel()
 		e := env.R(t)
@@ -303,6 +320,21 @@ func TestHandlers_EditWebhook(t *testing.T) {
 		require.NoError(t, err)
 		assert.EqualValues(t, "po", wh.GetName())
 	})
+
+	t.Run("success (event types)", func(t *testing.T) {
+		t.Parallel()
+		wh := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+		e := env.R(t)
+		e.PATCH(path, wh.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(map[string]interface{}{"eventTypes": []string{"push", "release"}}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		wh, err := env.Repository.GetWebhook(wh.GetID())
+		require.NoError(t, err)
+		assert.EqualValues(t, []string{"push", "release"}, wh.GetEventTypes().Array())
+	})
 }
 
 func TestHandlers_PostWebhook(t *testing.T) {
@@ -314,6 +346,7 @@ func TestHandlers_PostWebhook(t *testing.T) {
 	user2 := env.CreateUser(t, rand)
 	ch := env.CreateChannel(t, rand)
 	ch2 := env.CreateChannel(t, rand)
+	ch3 := env.CreateChannel(t, rand)
 	dm := env.CreateDMChannel(t, user.GetID(), user2.GetID())
 	archived := env.CreateChannel(t, rand)
 	require.NoError(t, env.CM.ArchiveChannel(archived.ID, user.GetID()))
@@ -403,11 +436,57 @@ func TestHandlers_PostWebhook(t *testing.T) {
 		e := env.R(t)
 		e.POST(path, wh.GetID()).
 			WithHeader("X-TRAQ-Signature", calcHMACSHA1(t, "xxpoxx", wh.GetSecret())).
-			WithJSON(map[string]interface{}{"text": "xxpoxx"}).
+			WithHeader(echo.HeaderContentType, echo.MIMEApplicationXML).
+			WithBytes([]byte("xxpoxx")).
 			Expect().
 			Status(http.StatusUnsupportedMediaType)
 	})
 
+	t.Run("bad request (invalid slack payload)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		body := `{"text":`
+		e.POST(path, wh.GetID()).
+			WithHeader("X-TRAQ-Signature", calcHMACSHA1(t, body, wh.GetSecret())).
+			WithHeader(echo.HeaderContentType, echo.MIMEApplicationJSON).
+			WithBytes([]byte(body)).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (empty slack payload)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		body := `{"username":"CI"}`
+		e.POST(path, wh.GetID()).
+			WithHeader("X-TRAQ-Signature", calcHMACSHA1(t, body, wh.GetSecret())).
+			WithHeader(echo.HeaderContentType, echo.MIMEApplicationJSON).
+			WithBytes([]byte(body)).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success with slack payload", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		body := `{"text":"Build *passed* <https://example.com|#1>"}`
+		e.POST(path, wh.GetID()).
+			WithHeader("X-TRAQ-Signature", calcHMACSHA1(t, body, wh.GetSecret())).
+			WithHeader("X-TRAQ-Channel-id", ch3.ID.String()).
+			WithHeader(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8).
+			WithBytes([]byte(body)).
+			Expect().
+			Status(http.StatusNoContent)
+
+		tl, err := env.MM.GetTimeline(message.TimelineQuery{Channel: ch3.ID})
+		require.NoError(t, err)
+		if assert.Len(t, tl.Records(), 1) {
+			m := tl.Records()[0]
+			assert.EqualValues(t, wh.GetBotUserID(), m.GetUserID())
+			assert.EqualValues(t, "Build **passed** [#1](https://example.com)", m.GetText())
+		}
+	})
+
 	t.Run("success with X-TRAQ-Channel-Id", func(t *testing.T) {
 		t.Parallel()
 		e := env.R(t)
@@ -447,6 +526,178 @@ func TestHandlers_PostWebhook(t *testing.T) {
 	})
 }
 
+func TestHandlers_PostWebhookGitHub(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/webhooks/{webhookId}/github"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	ch2 := env.CreateChannel(t, rand)
+	wh := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+	wh2 := env.CreateWebhook(t, rand, user.GetID(), ch2.ID)
+	require.NoError(t, env.Repository.UpdateWebhook(wh2.GetID(), repository.UpdateWebhookArgs{
+		EventTypes: model.WebhookEventTypes{model.WebhookEventPush: {}},
+	}))
+	insecure := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+	require.NoError(t, env.Repository.UpdateWebhook(insecure.GetID(), repository.UpdateWebhookArgs{
+		Secret: optional.StringFrom(""),
+	}))
+
+	calcSignature := func(t *testing.T, message, secret string) string {
+		t.Helper()
+		mac := hmac.New(sha256.New, []byte(secret))
+		_, _ = mac.Write([]byte(message))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	issueBody := `{"action":"opened","issue":{"number":1,"title":"Bug","html_url":"https://github.com/o/r/issues/1"},"repository":{"full_name":"o/r","html_url":"https://github.com/o/r"},"sender":{"login":"octocat"}}`
+
+	t.Run("bad request (no secret)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, insecure.GetID()).
+			WithHeader("X-GitHub-Event", "issues").
+			WithBytes([]byte(issueBody)).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (bad signature)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithHeader("X-GitHub-Event", "issues").
+			WithHeader("X-Hub-Signature-256", calcSignature(t, "test", wh.GetSecret())).
+			WithBytes([]byte(issueBody)).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (invalid payload)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		body := `{"action":`
+		e.POST(path, wh.GetID()).
+			WithHeader("X-GitHub-Event", "issues").
+			WithHeader("X-Hub-Signature-256", calcSignature(t, body, wh.GetSecret())).
+			WithBytes([]byte(body)).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV4())).
+			WithHeader("X-GitHub-Event", "issues").
+			WithBytes([]byte(issueBody)).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success (ping)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		body := `{"zen":"Keep it logically awesome."}`
+		e.POST(path, wh.GetID()).
+			WithHeader("X-GitHub-Event", "ping").
+			WithHeader("X-Hub-Signature-256", calcSignature(t, body, wh.GetSecret())).
+			WithBytes([]byte(body)).
+			Expect().
+			Status(http.StatusNoContent)
+	})
+
+	t.Run("success (event type disabled)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh2.GetID()).
+			WithHeader("X-GitHub-Event", "issues").
+			WithHeader("X-Hub-Signature-256", calcSignature(t, issueBody, wh2.GetSecret())).
+			WithBytes([]byte(issueBody)).
+			Expect().
+			Status(http.StatusNoContent)
+
+		tl, err := env.MM.GetTimeline(message.TimelineQuery{Channel: ch2.ID})
+		require.NoError(t, err)
+		assert.Len(t, tl.Records(), 0)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithHeader("X-GitHub-Event", "issues").
+			WithHeader("X-Hub-Signature-256", calcSignature(t, issueBody, wh.GetSecret())).
+			WithBytes([]byte(issueBody)).
+			Expect().
+			Status(http.StatusNoContent)
+
+		tl, err := env.MM.GetTimeline(message.TimelineQuery{Channel: ch.ID})
+		require.NoError(t, err)
+		if assert.Len(t, tl.Records(), 1) {
+			m := tl.Records()[0]
+			assert.EqualValues(t, wh.GetBotUserID(), m.GetUserID())
+			assert.EqualValues(t, "octocat opened issue [#1 Bug](https://github.com/o/r/issues/1) in [o/r](https://github.com/o/r)", m.GetText())
+		}
+	})
+}
+
+func TestHandlers_PostWebhookGitLab(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/webhooks/{webhookId}/gitlab"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	wh := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+	insecure := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+	require.NoError(t, env.Repository.UpdateWebhook(insecure.GetID(), repository.UpdateWebhookArgs{
+		Secret: optional.StringFrom(""),
+	}))
+
+	body := `{"action":"create","name":"v1.0.0","url":"https://gitlab.com/g/p/-/releases/v1.0.0","project":{"path_with_namespace":"g/p","web_url":"https://gitlab.com/g/p"}}`
+
+	t.Run("bad request (no secret)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, insecure.GetID()).
+			WithHeader("X-Gitlab-Event", "Release Hook").
+			WithBytes([]byte(body)).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (bad token)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithHeader("X-Gitlab-Event", "Release Hook").
+			WithHeader("X-Gitlab-Token", "wrong").
+			WithBytes([]byte(body)).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithHeader("X-Gitlab-Event", "Release Hook").
+			WithHeader("X-Gitlab-Token", wh.GetSecret()).
+			WithBytes([]byte(body)).
+			Expect().
+			Status(http.StatusNoContent)
+
+		tl, err := env.MM.GetTimeline(message.TimelineQuery{Channel: ch.ID})
+		require.NoError(t, err)
+		if assert.Len(t, tl.Records(), 1) {
+			m := tl.Records()[0]
+			assert.EqualValues(t, wh.GetBotUserID(), m.GetUserID())
+			assert.EqualValues(t, "published release [v1.0.0](https://gitlab.com/g/p/-/releases/v1.0.0) in [g/p](https://gitlab.com/g/p)", m.GetText())
+		}
+	})
+}
+
 func TestHandlers_DeleteWebhook(t *testing.T) {
 	t.Parallel()
 
@@ -541,3 +792,84 @@ func TestHandlers_GetWebhookMessages(t *testing.T) {
 		messageEquals(t, m, obj.First().Object())
 	})
 }
+
+func TestHandlers_GetWebhookLogs(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/webhooks/{webhookId}/logs"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	wh := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+	wh2 := env.CreateWebhook(t, rand, user2.GetID(), ch.ID)
+	s := env.S(t, user.GetID())
+
+	env.R(t).POST("/api/v3/webhooks/{webhookId}", wh.GetID()).
+		WithHeader("X-TRAQ-Signature", "00").
+		WithText("test").
+		Expect().
+		Status(http.StatusBadRequest)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, wh.GetID()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (negative limit)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, wh.GetID()).
+			WithCookie(session.CookieName, s).
+			WithQuery("limit", -1).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, wh2.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, wh.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().Equal(1)
+
+		first := obj.First().Object()
+		first.Keys().ContainsOnly(
+			"id", "webhookId", "adapter", "ip", "body", "result", "error", "code", "datetime",
+		)
+		first.Value("webhookId").String().Equal(wh.GetID().String())
+		first.Value("adapter").String().Empty()
+		first.Value("ip").String().NotEmpty()
+		first.Value("body").String().Equal("test")
+		first.Value("result").String().Equal("ng")
+		first.Value("error").String().Equal("X-TRAQ-Signature is wrong")
+		first.Value("code").Number().Equal(http.StatusBadRequest)
+		first.Value("datetime").String().NotEmpty()
+	})
+}
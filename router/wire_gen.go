@@ -44,6 +44,7 @@ func newRouter(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, ss *servi
 		Replacer:       replacer,
 		EmojiCache:     emojiCache,
 	}
+	botService := ss.BOT
 	streamer := ss.WS
 	wsStreamer := ss.BotWS
 	onlineCounter := ss.OnlineCounter
@@ -56,6 +57,7 @@ func newRouter(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, ss *servi
 	v3Handlers := &v3.Handlers{
 		RBAC:           rbac,
 		Repo:           repo,
+		BOT:            botService,
 		WS:             streamer,
 		BotWS:          wsStreamer,
 		Hub:            hub2,
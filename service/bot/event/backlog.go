@@ -0,0 +1,115 @@
+package event
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// BacklogConfig WebSocket ModeのBOTへのイベントのバックログ設定
+type BacklogConfig struct {
+	// Size BOT毎に保持する最大イベント数 0の場合は保持しません
+	Size int
+	// Retention イベントを保持する期間 0の場合は期間で破棄しません
+	Retention time.Duration
+}
+
+type backlogEntry struct {
+	seq      uint64
+	event    model.BotEventType
+	reqID    uuid.UUID
+	body     []byte
+	datetime time.Time
+}
+
+// botBacklog BOT毎のイベントのバックログ
+//
+// 配送の順序を保証するため、配送中はロックを保持します
+// リプレイはロック中にイベントをコピーし、ロック外で書き込みます
+type botBacklog struct {
+	sync.Mutex
+	lastSeq uint64
+	entries []*backlogEntry // 古い順
+}
+
+// append イベントを追加し、付与したシーケンス番号を返します
+func (bb *botBacklog) append(c BacklogConfig, now time.Time, event model.BotEventType, reqID uuid.UUID, body []byte) uint64 {
+	bb.lastSeq++
+	bb.entries = append(bb.entries, &backlogEntry{
+		seq:      bb.lastSeq,
+		event:    event,
+		reqID:    reqID,
+		body:     append([]byte(nil), body...), // bodyは呼び出し元で再利用されるためコピーする
+		datetime: now,
+	})
+	bb.prune(c, now)
+	return bb.lastSeq
+}
+
+// prune 保持数・保持期間を超えたイベントを破棄します
+func (bb *botBacklog) prune(c BacklogConfig, now time.Time) {
+	i := 0
+	if over := len(bb.entries) - c.Size; over > 0 {
+		i = over
+	}
+	if c.Retention > 0 {
+		for i < len(bb.entries) && now.Sub(bb.entries[i].datetime) > c.Retention {
+			i++
+		}
+	}
+	if i > 0 {
+		bb.entries = append(bb.entries[:0:0], bb.entries[i:]...)
+	}
+}
+
+// since 指定したシーケンス番号より後のイベントを古い順に返します
+//
+// 破棄済みのイベントが含まれる場合、completeはfalseになります
+func (bb *botBacklog) since(c BacklogConfig, now time.Time, seq uint64) (entries []*backlogEntry, complete bool) {
+	bb.prune(c, now)
+
+	if seq > bb.lastSeq {
+		// サーバーの再起動などでシーケンス番号がリセットされている
+		return bb.entries, false
+	}
+	if len(bb.entries) == 0 {
+		return nil, seq == bb.lastSeq
+	}
+	first := bb.entries[0].seq
+	if seq+1 < first {
+		return bb.entries, false
+	}
+	return bb.entries[seq+1-first:], true
+}
+
+// eventBacklog WebSocket ModeのBOTへのイベントのバックログ
+type eventBacklog struct {
+	config BacklogConfig
+	now    func() time.Time
+
+	mu   sync.Mutex
+	bots map[uuid.UUID]*botBacklog // key: BotUserID
+}
+
+func newEventBacklog(c BacklogConfig) *eventBacklog {
+	return &eventBacklog{
+		config: c,
+		now:    time.Now,
+		bots:   make(map[uuid.UUID]*botBacklog),
+	}
+}
+
+// get 指定したBOTのバックログを返します
+func (b *eventBacklog) get(botUserID uuid.UUID) *botBacklog {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	bb, ok := b.bots[botUserID]
+	if !ok {
+		bb = &botBacklog{}
+		b.bots[botUserID] = bb
+	}
+	return bb
+}
@@ -0,0 +1,175 @@
+package event
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func seqs(entries []*backlogEntry) []uint64 {
+	res := make([]uint64, len(entries))
+	for i, e := range entries {
+		res[i] = e.seq
+	}
+	return res
+}
+
+func TestBotBacklog(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	c := BacklogConfig{Size: 3, Retention: time.Minute}
+
+	t.Run("append and since", func(t *testing.T) {
+		t.Parallel()
+		bb := &botBacklog{}
+		for i := 0; i < 3; i++ {
+			assert.EqualValues(t, i+1, bb.append(c, now, Ping, uuid.Nil, []byte("{}")))
+		}
+
+		entries, complete := bb.since(c, now, 0)
+		assert.True(t, complete)
+		assert.Equal(t, []uint64{1, 2, 3}, seqs(entries))
+
+		entries, complete = bb.since(c, now, 2)
+		assert.True(t, complete)
+		assert.Equal(t, []uint64{3}, seqs(entries))
+
+		entries, complete = bb.since(c, now, 3)
+		assert.True(t, complete)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("size exceeded", func(t *testing.T) {
+		t.Parallel()
+		bb := &botBacklog{}
+		for i := 0; i < 5; i++ {
+			bb.append(c, now, Ping, uuid.Nil, []byte("{}"))
+		}
+
+		entries, complete := bb.since(c, now, 1)
+		assert.False(t, complete)
+		assert.Equal(t, []uint64{3, 4, 5}, seqs(entries))
+
+		entries, complete = bb.since(c, now, 2)
+		assert.True(t, complete)
+		assert.Equal(t, []uint64{3, 4, 5}, seqs(entries))
+	})
+
+	t.Run("retention exceeded", func(t *testing.T) {
+		t.Parallel()
+		bb := &botBacklog{}
+		bb.append(c, now.Add(-2*time.Minute), Ping, uuid.Nil, []byte("{}"))
+		bb.append(c, now, Ping, uuid.Nil, []byte("{}"))
+
+		entries, complete := bb.since(c, now, 0)
+		assert.False(t, complete)
+		assert.Equal(t, []uint64{2}, seqs(entries))
+
+		entries, complete = bb.since(c, now.Add(2*time.Minute), 1)
+		assert.False(t, complete)
+		assert.Empty(t, entries)
+
+		_, complete = bb.since(c, now.Add(2*time.Minute), 2)
+		assert.True(t, complete)
+	})
+
+	t.Run("sequence reset", func(t *testing.T) {
+		t.Parallel()
+		bb := &botBacklog{}
+		bb.append(c, now, Ping, uuid.Nil, []byte("{}"))
+
+		entries, complete := bb.since(c, now, 10)
+		assert.False(t, complete)
+		assert.Equal(t, []uint64{1}, seqs(entries))
+	})
+
+	t.Run("body is copied", func(t *testing.T) {
+		t.Parallel()
+		bb := &botBacklog{}
+		body := []byte("{}")
+		bb.append(c, now, Ping, uuid.Nil, body)
+		body[0] = 'x'
+
+		entries, _ := bb.since(c, now, 0)
+		assert.Equal(t, []byte("{}"), entries[0].body)
+	})
+}
+
+func TestWSDispatcher_Replay(t *testing.T) {
+	t.Parallel()
+
+	botUserID := uuid.Must(uuid.NewV4())
+	now := time.Now()
+
+	newDispatcher := func() *wsDispatcher {
+		d := newWSDispatcher(nil, zap.NewNop(), BacklogConfig{Size: 10})
+		d.backlog.now = func() time.Time { return now }
+		bb := d.backlog.get(botUserID)
+		for i := 0; i < 3; i++ {
+			bb.append(d.backlog.config, now, MessageCreated, uuid.Must(uuid.NewV4()), []byte("{}"))
+		}
+		return d
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		d := newDispatcher()
+
+		var written []uint64
+		lastSeq, complete := d.Replay(botUserID, 1, func(typ string, _ uuid.UUID, seq uint64, _ []byte) error {
+			assert.Equal(t, MessageCreated.String(), typ)
+			written = append(written, seq)
+			return nil
+		})
+		assert.True(t, complete)
+		assert.EqualValues(t, 3, lastSeq)
+		assert.Equal(t, []uint64{2, 3}, written)
+	})
+
+	t.Run("write failed", func(t *testing.T) {
+		t.Parallel()
+		d := newDispatcher()
+
+		lastSeq, complete := d.Replay(botUserID, 0, func(_ string, _ uuid.UUID, seq uint64, _ []byte) error {
+			if seq == 2 {
+				return errors.New("buffer is full")
+			}
+			return nil
+		})
+		assert.False(t, complete)
+		assert.EqualValues(t, 1, lastSeq)
+	})
+
+	t.Run("write without lock", func(t *testing.T) {
+		t.Parallel()
+		d := newDispatcher()
+
+		bb := d.backlog.get(botUserID)
+		lastSeq, complete := d.Replay(botUserID, 2, func(string, uuid.UUID, uint64, []byte) error {
+			// 書き込み中に配送されてもデッドロックしない
+			bb.Lock()
+			defer bb.Unlock()
+			bb.append(d.backlog.config, now, MessageCreated, uuid.Nil, []byte("{}"))
+			return nil
+		})
+		assert.True(t, complete)
+		assert.EqualValues(t, 3, lastSeq)
+	})
+
+	t.Run("nothing to replay", func(t *testing.T) {
+		t.Parallel()
+		d := newDispatcher()
+
+		lastSeq, complete := d.Replay(botUserID, 3, func(string, uuid.UUID, uint64, []byte) error {
+			t.Fatal("must not be called")
+			return nil
+		})
+		assert.True(t, complete)
+		assert.EqualValues(t, 3, lastSeq)
+	})
+}
@@ -14,6 +14,10 @@ import (
 type Dispatcher interface {
 	// Send Botにイベントを送信します
 	Send(b *model.Bot, event model.BotEventType, body []byte) (ok bool)
+	// Shutdown 再送待ちのイベントをデッドレターとして保存し、再送処理の終了を待ちます
+	//
+	// Shutdown後に配送に失敗したイベントは再送せずにデッドレターとして保存されます。
+	Shutdown()
 }
 
 // Unicast 単一のBOTにイベントを送信
@@ -2,7 +2,9 @@ package event
 
 import (
 	"bytes"
+	"encoding/hex"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -10,12 +12,15 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/hmac"
 )
 
 const (
 	headerTRAQBotEvent             = "X-TRAQ-BOT-EVENT"
 	headerTRAQBotRequestID         = "X-TRAQ-BOT-REQUEST-ID"
 	headerTRAQBotVerificationToken = "X-TRAQ-BOT-TOKEN"
+	headerTRAQBotTimestamp         = "X-TRAQ-BOT-TIMESTAMP"
+	headerTRAQBotSignature         = "X-TRAQ-BOT-SIGNATURE"
 	headerUserAgent                = "User-Agent"
 	ua                             = "traQ_Bot_Processor/1.0"
 )
@@ -47,6 +52,10 @@ func (d *httpDispatcher) send(b *model.Bot, event model.BotEventType, reqID uuid
 	req.Header.Set(headerTRAQBotVerificationToken, b.VerificationToken)
 
 	start := time.Now()
+	if len(b.SigningSecret) > 0 {
+		req.Header.Set(headerTRAQBotTimestamp, strconv.FormatInt(start.Unix(), 10))
+		req.Header.Set(headerTRAQBotSignature, signPayload(b.SigningSecret, start, body))
+	}
 	res, err := d.client.Do(req)
 	latency := time.Since(start)
 
@@ -60,7 +69,6 @@ func (d *httpDispatcher) send(b *model.Bot, event model.BotEventType, reqID uuid
 	}
 
 	if err != nil {
-		eventSendCounter.WithLabelValues(b.ID.String(), resultNetworkError).Inc()
 		log.Result = resultNetworkError
 		log.Error = err.Error()
 		log.Code = -1
@@ -73,7 +81,18 @@ func (d *httpDispatcher) send(b *model.Bot, event model.BotEventType, reqID uuid
 	} else {
 		log.Result = resultNG
 	}
-	eventSendCounter.WithLabelValues(b.ID.String(), log.Result).Inc()
 	log.Code = res.StatusCode
 	return log.Result == resultOK, log
 }
+
+// signPayload イベントの署名を計算します
+//
+// 署名は`sha256=`に続けて、"{UNIXタイムスタンプ(秒)}.{リクエストボディ}"のHMAC-SHA256を16進数表記したものです。
+// BOTはタイムスタンプが十分新しいことを確認することで、リプレイ攻撃を防ぐことができます。
+func signPayload(secret string, timestamp time.Time, body []byte) string {
+	data := make([]byte, 0, len(body)+20)
+	data = strconv.AppendInt(data, timestamp.Unix(), 10)
+	data = append(data, '.')
+	data = append(data, body...)
+	return "sha256=" + hex.EncodeToString(hmac.SHA256(data, secret))
+}
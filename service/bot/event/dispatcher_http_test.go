@@ -0,0 +1,86 @@
+package event
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+func TestSignPayload(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Unix(1600000000, 0)
+	body := []byte(`{"eventTime":"2020-09-13T12:26:40Z"}`)
+
+	sig := signPayload("secret", ts, body)
+	assert.Equal(t, "sha256=", sig[:7])
+	assert.Len(t, sig, 7+64)
+	assert.Equal(t, sig, signPayload("secret", ts, body))
+	assert.NotEqual(t, sig, signPayload("secret2", ts, body))
+	assert.NotEqual(t, sig, signPayload("secret", ts.Add(time.Second), body))
+	assert.NotEqual(t, sig, signPayload("secret", ts, []byte(`{}`)))
+}
+
+func TestHTTPDispatcher_send(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"eventTime":"2020-09-13T12:26:40Z"}`)
+
+	t.Run("signed", func(t *testing.T) {
+		t.Parallel()
+		var header http.Header
+		var received []byte
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header.Clone()
+			received, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer ts.Close()
+
+		b := &model.Bot{
+			ID:                uuid.Must(uuid.NewV4()),
+			VerificationToken: "token",
+			SigningSecret:     "secret",
+			PostURL:           ts.URL,
+		}
+		ok, _ := newHTTPDispatcher(zap.NewNop()).send(b, MessageCreated, uuid.Must(uuid.NewV4()), body)
+		require.True(t, ok)
+
+		assert.Equal(t, "token", header.Get(headerTRAQBotVerificationToken))
+		timestamp, err := strconv.ParseInt(header.Get(headerTRAQBotTimestamp), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, signPayload("secret", time.Unix(timestamp, 0), received), header.Get(headerTRAQBotSignature))
+	})
+
+	t.Run("no secret", func(t *testing.T) {
+		t.Parallel()
+		var header http.Header
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header.Clone()
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer ts.Close()
+
+		b := &model.Bot{
+			ID:                uuid.Must(uuid.NewV4()),
+			VerificationToken: "token",
+			PostURL:           ts.URL,
+		}
+		ok, _ := newHTTPDispatcher(zap.NewNop()).send(b, MessageCreated, uuid.Must(uuid.NewV4()), body)
+		require.True(t, ok)
+
+		assert.Equal(t, "token", header.Get(headerTRAQBotVerificationToken))
+		assert.Empty(t, header.Get(headerTRAQBotTimestamp))
+		assert.Empty(t, header.Get(headerTRAQBotSignature))
+	})
+}
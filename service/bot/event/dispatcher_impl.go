@@ -1,6 +1,10 @@
 package event
 
 import (
+	"net/http"
+	"sync"
+	"time"
+
 	"github.com/gofrs/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -11,10 +15,20 @@ import (
 	botWS "github.com/traPtitech/traQ/service/bot/ws"
 )
 
-var eventSendCounter = promauto.NewCounterVec(prometheus.CounterOpts{
-	Namespace: "traq",
-	Name:      "bot_event_send_count_total",
-}, []string{"bot_id", "status"})
+var (
+	eventSendCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "traq",
+		Name:      "bot_event_send_count_total",
+	}, []string{"bot_id", "status"})
+	eventRetryCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "traq",
+		Name:      "bot_event_retry_count_total",
+	}, []string{"bot_id"})
+	eventDeadLetterCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "traq",
+		Name:      "bot_event_dead_letter_count_total",
+	}, []string{"bot_id"})
+)
 
 const (
 	resultOK           = "ok"
@@ -23,19 +37,62 @@ const (
 	resultDropped      = "dp"
 )
 
+// maxPendingRetries 同時に再送待ちにできるイベントの最大数 超えた分は再送せずにデッドレターとして保存します
+const maxPendingRetries = 1000
+
+// RetryConfig HTTP ModeのBOTへのイベント再送設定
+type RetryConfig struct {
+	// MaxRetries 最大再送回数 0の場合は再送しません
+	MaxRetries int
+	// InitialBackoff 初回の再送までの待機時間 以降の再送毎に2倍になります
+	InitialBackoff time.Duration
+	// MaxBackoff 再送までの最大待機時間
+	MaxBackoff time.Duration
+}
+
+// backoff retries回目の再送までの待機時間を返します
+func (c RetryConfig) backoff(retries int) time.Duration {
+	d := c.InitialBackoff
+	for i := 1; i < retries && d < c.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > c.MaxBackoff {
+		return c.MaxBackoff
+	}
+	return d
+}
+
 type dispatcherImpl struct {
-	http *httpDispatcher
-	ws   *wsDispatcher
-	l    *zap.Logger
-	repo repository.BotRepository
+	http  *httpDispatcher
+	ws    *wsDispatcher
+	l     *zap.Logger
+	repo  repository.BotRepository
+	retry RetryConfig
+	after func(d time.Duration) <-chan time.Time
+
+	retryMu    sync.Mutex
+	retryWG    sync.WaitGroup
+	retrySlots chan struct{}
+	stop       chan struct{}
+	stopped    bool
 }
 
-func NewDispatcher(logger *zap.Logger, repo repository.BotRepository, s *botWS.Streamer) Dispatcher {
+// NewDispatcher Botイベント配送機を生成します
+//
+// HTTP Modeのボットへの配送に失敗した場合、retryに従って非同期で再送します。
+// 再送を諦めたイベントや、Shutdown時に再送待ちだったイベントはデッドレターとして保存されます。
+// WebSocket Modeのボットへのイベントはbacklogに従って保持され、再接続時にリプレイできます。
+func NewDispatcher(logger *zap.Logger, repo repository.BotRepository, s *botWS.Streamer, retry RetryConfig, backlog BacklogConfig) Dispatcher {
 	return &dispatcherImpl{
-		http: newHTTPDispatcher(logger),
-		ws:   newWSDispatcher(s, logger),
-		l:    logger.Named("bot.dispatcher"),
-		repo: repo,
+		http:  newHTTPDispatcher(logger),
+		ws:    newWSDispatcher(s, logger, backlog),
+		l:     logger.Named("bot.dispatcher"),
+		repo:  repo,
+		retry: retry,
+		after: time.After,
+
+		retrySlots: make(chan struct{}, maxPendingRetries),
+		stop:       make(chan struct{}),
 	}
 }
 
@@ -46,16 +103,125 @@ func (d *dispatcherImpl) Send(b *model.Bot, event model.BotEventType, body []byt
 	switch b.Mode {
 	case model.BotModeHTTP:
 		ok, log = d.http.send(b, event, reqID, body)
+		d.writeLog(log)
+		if !ok && event != Ping { // PINGは疎通確認なので再送しない
+			// bodyは呼び出し元で再利用されるためコピーする
+			d.startRetry(b, event, append([]byte(nil), body...), log)
+			return false
+		}
 	case model.BotModeWebSocket:
 		ok, log = d.ws.send(b, event, reqID, body)
+		d.writeLog(log)
 	default:
 		return false
 	}
 
-	d.writeLog(log)
+	eventSendCounter.WithLabelValues(b.ID.String(), log.Result).Inc()
 	return ok
 }
 
+func (d *dispatcherImpl) Shutdown() {
+	d.retryMu.Lock()
+	if !d.stopped {
+		d.stopped = true
+		close(d.stop)
+	}
+	d.retryMu.Unlock()
+	d.retryWG.Wait()
+}
+
+// startRetry 配送に失敗したイベントの非同期での再送を開始します
+//
+// シャットダウン中の場合や再送待ちのイベントが多すぎる場合は、再送せずにデッドレターとして保存します。
+func (d *dispatcherImpl) startRetry(b *model.Bot, event model.BotEventType, body []byte, last *model.BotEventLog) {
+	d.retryMu.Lock()
+	if d.stopped {
+		d.retryMu.Unlock()
+		d.writeDeadLetter(b, event, body, 1, last)
+		return
+	}
+	select {
+	case d.retrySlots <- struct{}{}:
+	default:
+		d.retryMu.Unlock()
+		d.l.Warn("too many pending retries", zap.Stringer("botId", b.ID))
+		d.writeDeadLetter(b, event, body, 1, last)
+		return
+	}
+	d.retryWG.Add(1)
+	d.retryMu.Unlock()
+
+	go func() {
+		defer func() {
+			<-d.retrySlots
+			d.retryWG.Done()
+		}()
+		d.retryHTTP(b, event, body, last)
+	}()
+}
+
+// retryHTTP 配送に失敗したイベントを再送します
+//
+// 再送を諦めた場合やシャットダウンされた場合、デッドレターとして保存します。
+// イベントの最終的な配送結果はここで計上されます。
+func (d *dispatcherImpl) retryHTTP(b *model.Bot, event model.BotEventType, body []byte, last *model.BotEventLog) {
+	attempts := 1
+	for retries := 1; retries <= d.retry.MaxRetries && isRetryable(last); retries++ {
+		select {
+		case <-d.after(d.retry.backoff(retries)):
+		case <-d.stop:
+			d.writeDeadLetter(b, event, body, attempts, last)
+			return
+		}
+
+		var ok bool
+		eventRetryCounter.WithLabelValues(b.ID.String()).Inc()
+		ok, last = d.http.send(b, event, uuid.Must(uuid.NewV4()), body)
+		attempts++
+		d.writeLog(last)
+		if ok {
+			eventSendCounter.WithLabelValues(b.ID.String(), resultOK).Inc()
+			return
+		}
+	}
+
+	d.writeDeadLetter(b, event, body, attempts, last)
+}
+
+// writeDeadLetter 配送を諦めたイベントをデッドレターとして保存します
+func (d *dispatcherImpl) writeDeadLetter(b *model.Bot, event model.BotEventType, body []byte, attempts int, last *model.BotEventLog) {
+	eventSendCounter.WithLabelValues(b.ID.String(), last.Result).Inc()
+	eventDeadLetterCounter.WithLabelValues(b.ID.String()).Inc()
+	letter := &model.BotEventDeadLetter{
+		ID:        uuid.Must(uuid.NewV4()),
+		BotID:     b.ID,
+		Event:     event,
+		Body:      string(body),
+		RequestID: last.RequestID,
+		Attempts:  attempts,
+		Result:    last.Result,
+		Error:     last.Error,
+		Code:      last.Code,
+	}
+	if err := d.repo.CreateBotEventDeadLetter(letter); err != nil {
+		d.l.Warn("failed to write dead letter", zap.Error(err), zap.Stringer("botId", b.ID), zap.Stringer("requestId", last.RequestID))
+	}
+}
+
+// isRetryable 再送すべき失敗かどうか
+//
+// ネットワークエラー、5xx、429の場合に再送します。それ以外の4xxなどはBOTが拒否したものとして扱います。
+func isRetryable(log *model.BotEventLog) bool {
+	switch log.Result {
+	case resultNetworkError:
+		return true
+	case resultNG:
+		return log.Code >= http.StatusInternalServerError || log.Code == http.StatusTooManyRequests
+	default:
+		return false
+	}
+}
+
 func (d *dispatcherImpl) writeLog(log *model.BotEventLog) {
 	if err := d.repo.WriteBotEventLog(log); err != nil {
 		d.l.Warn("failed to write log", zap.Error(err), zap.Any("eventLog", log))
@@ -0,0 +1,253 @@
+package event
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+)
+
+func TestRetryConfig_backoff(t *testing.T) {
+	t.Parallel()
+
+	c := RetryConfig{
+		MaxRetries:     5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+	}
+	assert.Equal(t, time.Second, c.backoff(1))
+	assert.Equal(t, 2*time.Second, c.backoff(2))
+	assert.Equal(t, 4*time.Second, c.backoff(3))
+	assert.Equal(t, 5*time.Second, c.backoff(4))
+	assert.Equal(t, 5*time.Second, c.backoff(10))
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		log  *model.BotEventLog
+		want bool
+	}{
+		{"ok", &model.BotEventLog{Result: resultOK, Code: http.StatusNoContent}, false},
+		{"network error", &model.BotEventLog{Result: resultNetworkError, Code: -1}, true},
+		{"5xx", &model.BotEventLog{Result: resultNG, Code: http.StatusBadGateway}, true},
+		{"429", &model.BotEventLog{Result: resultNG, Code: http.StatusTooManyRequests}, true},
+		{"4xx", &model.BotEventLog{Result: resultNG, Code: http.StatusBadRequest}, false},
+		{"dropped", &model.BotEventLog{Result: resultDropped}, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, isRetryable(tt.log))
+		})
+	}
+}
+
+func newTestDispatcher(t *testing.T, repo *mock_repository.MockBotRepository, maxRetries int) *dispatcherImpl {
+	t.Helper()
+	d := NewDispatcher(zap.NewNop(), repo, nil, RetryConfig{
+		MaxRetries:     maxRetries,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+	}, BacklogConfig{}).(*dispatcherImpl)
+	d.after = func(time.Duration) <-chan time.Time {
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	}
+	return d
+}
+
+func TestDispatcherImpl_Send(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"eventTime":"2006-01-02T15:04:05Z"}`)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockBotRepository(ctrl)
+		d := newTestDispatcher(t, repo, 3)
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer ts.Close()
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: ts.URL}
+
+		repo.EXPECT().WriteBotEventLog(gomock.Any()).Return(nil).Times(1)
+
+		assert.True(t, d.Send(b, MessageCreated, body))
+	})
+
+	t.Run("retry then success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockBotRepository(ctrl)
+		d := newTestDispatcher(t, repo, 3)
+
+		var count int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&count, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer ts.Close()
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: ts.URL}
+
+		ok, log := d.http.send(b, MessageCreated, uuid.Must(uuid.NewV4()), body)
+		assert.False(t, ok)
+
+		repo.EXPECT().WriteBotEventLog(gomock.Any()).Return(nil).Times(2)
+		d.retryHTTP(b, MessageCreated, body, log)
+		assert.EqualValues(t, 3, atomic.LoadInt32(&count))
+	})
+
+	t.Run("dead letter after retries", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockBotRepository(ctrl)
+		d := newTestDispatcher(t, repo, 2)
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer ts.Close()
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: ts.URL}
+
+		_, log := d.http.send(b, MessageCreated, uuid.Must(uuid.NewV4()), body)
+
+		repo.EXPECT().WriteBotEventLog(gomock.Any()).Return(nil).Times(2)
+		repo.EXPECT().
+			CreateBotEventDeadLetter(gomock.Any()).
+			DoAndReturn(func(letter *model.BotEventDeadLetter) error {
+				assert.Equal(t, b.ID, letter.BotID)
+				assert.Equal(t, MessageCreated, letter.Event)
+				assert.Equal(t, string(body), letter.Body)
+				assert.Equal(t, 3, letter.Attempts)
+				assert.Equal(t, resultNG, letter.Result)
+				assert.Equal(t, http.StatusInternalServerError, letter.Code)
+				return nil
+			}).
+			Times(1)
+		d.retryHTTP(b, MessageCreated, body, log)
+	})
+
+	t.Run("not retryable", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockBotRepository(ctrl)
+		d := newTestDispatcher(t, repo, 3)
+
+		var count int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&count, 1)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer ts.Close()
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: ts.URL}
+
+		_, log := d.http.send(b, MessageCreated, uuid.Must(uuid.NewV4()), body)
+
+		repo.EXPECT().
+			CreateBotEventDeadLetter(gomock.Any()).
+			DoAndReturn(func(letter *model.BotEventDeadLetter) error {
+				assert.Equal(t, 1, letter.Attempts)
+				return nil
+			}).
+			Times(1)
+		d.retryHTTP(b, MessageCreated, body, log)
+		assert.EqualValues(t, 1, atomic.LoadInt32(&count))
+	})
+
+	t.Run("dead letter on shutdown during backoff", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockBotRepository(ctrl)
+		d := newTestDispatcher(t, repo, 3)
+		waiting := make(chan struct{})
+		d.after = func(time.Duration) <-chan time.Time {
+			close(waiting)
+			return nil // シャットダウンされるまで待ち続ける
+		}
+
+		var count int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&count, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer ts.Close()
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: ts.URL}
+
+		repo.EXPECT().WriteBotEventLog(gomock.Any()).Return(nil).Times(1)
+		repo.EXPECT().
+			CreateBotEventDeadLetter(gomock.Any()).
+			DoAndReturn(func(letter *model.BotEventDeadLetter) error {
+				assert.Equal(t, string(body), letter.Body)
+				assert.Equal(t, 1, letter.Attempts)
+				assert.Equal(t, http.StatusServiceUnavailable, letter.Code)
+				return nil
+			}).
+			Times(1)
+
+		assert.False(t, d.Send(b, MessageCreated, body))
+		<-waiting
+		d.Shutdown()
+		assert.EqualValues(t, 1, atomic.LoadInt32(&count))
+	})
+
+	t.Run("dead letter after shutdown", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockBotRepository(ctrl)
+		d := newTestDispatcher(t, repo, 3)
+		d.Shutdown()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer ts.Close()
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: ts.URL}
+
+		repo.EXPECT().WriteBotEventLog(gomock.Any()).Return(nil).Times(1)
+		repo.EXPECT().CreateBotEventDeadLetter(gomock.Any()).Return(nil).Times(1)
+
+		assert.False(t, d.Send(b, MessageCreated, body))
+	})
+
+	t.Run("dead letter when too many pending retries", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockBotRepository(ctrl)
+		d := newTestDispatcher(t, repo, 3)
+		for i := 0; i < maxPendingRetries; i++ {
+			d.retrySlots <- struct{}{}
+		}
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer ts.Close()
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: ts.URL}
+
+		repo.EXPECT().WriteBotEventLog(gomock.Any()).Return(nil).Times(1)
+		repo.EXPECT().CreateBotEventDeadLetter(gomock.Any()).Return(nil).Times(1)
+
+		assert.False(t, d.Send(b, MessageCreated, body))
+		d.Shutdown()
+	})
+}
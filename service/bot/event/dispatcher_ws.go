@@ -12,20 +12,36 @@ import (
 )
 
 type wsDispatcher struct {
-	s *botWS.Streamer
-	l *zap.Logger
+	s       *botWS.Streamer
+	l       *zap.Logger
+	backlog *eventBacklog
 }
 
-func newWSDispatcher(s *botWS.Streamer, logger *zap.Logger) *wsDispatcher {
-	return &wsDispatcher{
+func newWSDispatcher(s *botWS.Streamer, logger *zap.Logger, backlog BacklogConfig) *wsDispatcher {
+	d := &wsDispatcher{
 		s: s,
 		l: logger.Named("bot.dispatcher.ws"),
 	}
+	if backlog.Size > 0 {
+		d.backlog = newEventBacklog(backlog)
+		if s != nil {
+			s.SetReplayer(d)
+		}
+	}
+	return d
 }
 
 func (d *wsDispatcher) send(b *model.Bot, event model.BotEventType, reqID uuid.UUID, body []byte) (ok bool, log *model.BotEventLog) {
+	var seq uint64
 	start := time.Now()
-	errs, attempted := d.s.WriteMessage(event.String(), reqID, body, b.BotUserID)
+	if d.backlog != nil {
+		// 切断中のイベントも後からリプレイできるように保持する
+		bb := d.backlog.get(b.BotUserID)
+		bb.Lock()
+		defer bb.Unlock()
+		seq = bb.append(d.backlog.config, start, event, reqID, body)
+	}
+	errs, attempted := d.s.WriteMessage(event.String(), reqID, seq, body, b.BotUserID)
 	latency := time.Since(start)
 
 	log = &model.BotEventLog{
@@ -37,21 +53,44 @@ func (d *wsDispatcher) send(b *model.Bot, event model.BotEventType, reqID uuid.U
 		DateTime:  start,
 	}
 	if len(errs) > 0 {
-		eventSendCounter.WithLabelValues(b.ID.String(), resultNetworkError).Inc()
 		log.Result = resultNetworkError
 		log.Error = formatErrors(errs)
 		return false, log
 	}
 	if !attempted {
-		eventSendCounter.WithLabelValues(b.ID.String(), resultDropped).Inc()
 		log.Result = resultDropped
 		return false, log
 	}
-	eventSendCounter.WithLabelValues(b.ID.String(), resultOK).Inc()
 	log.Result = resultOK
 	return true, log
 }
 
+// Replay botWS.Replayer 実装
+func (d *wsDispatcher) Replay(botUserID uuid.UUID, since uint64, write botWS.ReplayWriter) (lastSeq uint64, complete bool) {
+	bb := d.backlog.get(botUserID)
+	bb.Lock()
+	entries, complete := bb.since(d.backlog.config, d.backlog.now(), since)
+	// 書き込みが遅いセッションで他の配送を止めないように、コピーしてからロック外で書き込む
+	entries = append([]*backlogEntry(nil), entries...)
+	lastSeq = since
+	if lastSeq > bb.lastSeq {
+		lastSeq = bb.lastSeq
+	}
+	bb.Unlock()
+
+	for _, e := range entries {
+		if err := write(e.event.String(), e.reqID, e.seq, e.body); err != nil {
+			d.l.Warn("failed to replay bot event",
+				zap.Error(err),
+				zap.Stringer("userID", botUserID),
+				zap.Uint64("seq", e.seq))
+			return lastSeq, false
+		}
+		lastSeq = e.seq
+	}
+	return lastSeq, complete
+}
+
 func formatErrors(errs []error) string {
 	var b strings.Builder
 	for _, err := range errs {
@@ -37,6 +37,38 @@ const (
 	TagAdded model.BotEventType = "TAG_ADDED"
 	// TagRemoved タグ削除イベント
 	TagRemoved model.BotEventType = "TAG_REMOVED"
+	// MessageReported メッセージ通報イベント
+	MessageReported model.BotEventType = "MESSAGE_REPORTED"
+	// CommandInvoked スラッシュコマンド実行イベント
+	CommandInvoked model.BotEventType = "COMMAND_INVOKED"
+	// MessageActionInvoked メッセージアクション実行イベント
+	MessageActionInvoked model.BotEventType = "MESSAGE_ACTION_INVOKED"
+	// MessageStampsUpdated メッセージスタンプ更新イベント
+	MessageStampsUpdated model.BotEventType = "MESSAGE_STAMPS_UPDATED"
+	// ChannelNameChanged チャンネル名変更イベント
+	ChannelNameChanged model.BotEventType = "CHANNEL_NAME_CHANGED"
+	// ChannelParentChanged 親チャンネル変更イベント
+	ChannelParentChanged model.BotEventType = "CHANNEL_PARENT_CHANGED"
+	// ChannelArchived チャンネルアーカイブイベント
+	ChannelArchived model.BotEventType = "CHANNEL_ARCHIVED"
+	// ChannelUnarchived チャンネルアーカイブ解除イベント
+	ChannelUnarchived model.BotEventType = "CHANNEL_UNARCHIVED"
+	// UserGroupCreated ユーザーグループ作成イベント
+	UserGroupCreated model.BotEventType = "USER_GROUP_CREATED"
+	// UserGroupUpdated ユーザーグループ更新イベント
+	UserGroupUpdated model.BotEventType = "USER_GROUP_UPDATED"
+	// UserGroupDeleted ユーザーグループ削除イベント
+	UserGroupDeleted model.BotEventType = "USER_GROUP_DELETED"
+	// UserGroupMemberAdded ユーザーグループメンバー追加イベント
+	UserGroupMemberAdded model.BotEventType = "USER_GROUP_MEMBER_ADDED"
+	// UserGroupMemberUpdated ユーザーグループメンバー更新イベント
+	UserGroupMemberUpdated model.BotEventType = "USER_GROUP_MEMBER_UPDATED"
+	// UserGroupMemberRemoved ユーザーグループメンバー削除イベント
+	UserGroupMemberRemoved model.BotEventType = "USER_GROUP_MEMBER_REMOVED"
+	// UserGroupAdminAdded ユーザーグループ管理者追加イベント
+	UserGroupAdminAdded model.BotEventType = "USER_GROUP_ADMIN_ADDED"
+	// UserGroupAdminRemoved ユーザーグループ管理者削除イベント
+	UserGroupAdminRemoved model.BotEventType = "USER_GROUP_ADMIN_REMOVED"
 )
 
 var Types model.BotEventTypes
@@ -62,6 +94,22 @@ func init() {
 		StampCreated,
 		TagAdded,
 		TagRemoved,
+		MessageReported,
+		CommandInvoked,
+		MessageActionInvoked,
+		MessageStampsUpdated,
+		ChannelNameChanged,
+		ChannelParentChanged,
+		ChannelArchived,
+		ChannelUnarchived,
+		UserGroupCreated,
+		UserGroupUpdated,
+		UserGroupDeleted,
+		UserGroupMemberAdded,
+		UserGroupMemberUpdated,
+		UserGroupMemberRemoved,
+		UserGroupAdminAdded,
+		UserGroupAdminRemoved,
 	} {
 		Types[t] = struct{}{}
 	}
@@ -47,3 +47,15 @@ func (mr *MockDispatcherMockRecorder) Send(b, event, body interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockDispatcher)(nil).Send), b, event, body)
 }
+
+// Shutdown mocks base method.
+func (m *MockDispatcher) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockDispatcherMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockDispatcher)(nil).Shutdown))
+}
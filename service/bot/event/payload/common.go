@@ -29,6 +29,7 @@ type Message struct {
 	Embedded  []*message.EmbeddedInfo `json:"embedded"`
 	CreatedAt time.Time               `json:"createdAt"`
 	UpdatedAt time.Time               `json:"updatedAt"`
+	Actions   model.MessageActions    `json:"actions,omitempty"`
 }
 
 func MakeMessage(message *model.Message, user model.UserInfo, embedded []*message.EmbeddedInfo, plain string) Message {
@@ -41,9 +42,18 @@ func MakeMessage(message *model.Message, user model.UserInfo, embedded []*messag
 		Embedded:  embedded,
 		CreatedAt: message.CreatedAt,
 		UpdatedAt: message.UpdatedAt,
+		Actions:   messageActions(message),
 	}
 }
 
+// messageActions BOTにより付与されたメッセージのアクションを返します
+func messageActions(m *model.Message) model.MessageActions {
+	if m.Attachment == nil {
+		return nil
+	}
+	return m.Attachment.Actions
+}
+
 type Channel struct {
 	ID        uuid.UUID `json:"id"`
 	Name      string    `json:"name"`
@@ -88,3 +98,43 @@ func MakeUser(user model.UserInfo) User {
 	}
 	return payload
 }
+
+type UserGroup struct {
+	ID          uuid.UUID         `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Type        string            `json:"type"`
+	Icon        uuid.UUID         `json:"icon"`
+	Admins      []uuid.UUID       `json:"admins"`
+	Members     []UserGroupMember `json:"members"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+}
+
+type UserGroupMember struct {
+	GroupID uuid.UUID `json:"groupId"`
+	UserID  uuid.UUID `json:"userId"`
+	Role    string    `json:"role"`
+}
+
+func MakeUserGroup(g *model.UserGroup) UserGroup {
+	members := make([]UserGroupMember, len(g.Members))
+	for i, m := range g.Members {
+		members[i] = UserGroupMember{
+			GroupID: m.GroupID,
+			UserID:  m.UserID,
+			Role:    m.Role,
+		}
+	}
+	return UserGroup{
+		ID:          g.ID,
+		Name:        g.Name,
+		Description: g.Description,
+		Type:        g.Type,
+		Icon:        g.Icon,
+		Admins:      g.AdminIDArray(),
+		Members:     members,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+}
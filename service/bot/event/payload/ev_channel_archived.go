@@ -0,0 +1,20 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// ChannelArchived CHANNEL_ARCHIVED, CHANNEL_UNARCHIVEDイベントペイロード
+type ChannelArchived struct {
+	Base
+	Channel Channel `json:"channel"`
+}
+
+func MakeChannelArchived(et time.Time, ch *model.Channel, chPath string, chCreator model.UserInfo) *ChannelArchived {
+	return &ChannelArchived{
+		Base:    MakeBase(et),
+		Channel: MakeChannel(ch, chPath, chCreator),
+	}
+}
@@ -0,0 +1,24 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// ChannelNameChanged CHANNEL_NAME_CHANGEDイベントペイロード
+type ChannelNameChanged struct {
+	Base
+	Channel Channel `json:"channel"`
+	OldName string  `json:"oldName"`
+	Updater User    `json:"updater"`
+}
+
+func MakeChannelNameChanged(et time.Time, ch *model.Channel, chPath string, chCreator model.UserInfo, oldName string, user model.UserInfo) *ChannelNameChanged {
+	return &ChannelNameChanged{
+		Base:    MakeBase(et),
+		Channel: MakeChannel(ch, chPath, chCreator),
+		OldName: oldName,
+		Updater: MakeUser(user),
+	}
+}
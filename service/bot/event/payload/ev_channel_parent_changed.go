@@ -0,0 +1,26 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// ChannelParentChanged CHANNEL_PARENT_CHANGEDイベントペイロード
+type ChannelParentChanged struct {
+	Base
+	Channel     Channel   `json:"channel"`
+	OldParentID uuid.UUID `json:"oldParentId"`
+	Updater     User      `json:"updater"`
+}
+
+func MakeChannelParentChanged(et time.Time, ch *model.Channel, chPath string, chCreator model.UserInfo, oldParentID uuid.UUID, user model.UserInfo) *ChannelParentChanged {
+	return &ChannelParentChanged{
+		Base:        MakeBase(et),
+		Channel:     MakeChannel(ch, chPath, chCreator),
+		OldParentID: oldParentID,
+		Updater:     MakeUser(user),
+	}
+}
@@ -0,0 +1,38 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// CommandInvoked COMMAND_INVOKEDイベントペイロード
+type CommandInvoked struct {
+	Base
+	Command   Command                `json:"command"`
+	Arguments map[string]interface{} `json:"arguments"`
+	Text      string                 `json:"text"`
+	User      User                   `json:"user"`
+	ChannelID uuid.UUID              `json:"channelId"`
+}
+
+type Command struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+func MakeCommandInvoked(et time.Time, command *model.BotCommand, args map[string]interface{}, text string, user model.UserInfo, channelID uuid.UUID) *CommandInvoked {
+	return &CommandInvoked{
+		Base: MakeBase(et),
+		Command: Command{
+			ID:   command.ID,
+			Name: command.Name,
+		},
+		Arguments: args,
+		Text:      text,
+		User:      MakeUser(user),
+		ChannelID: channelID,
+	}
+}
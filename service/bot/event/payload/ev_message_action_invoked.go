@@ -0,0 +1,30 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// MessageActionInvoked MESSAGE_ACTION_INVOKEDイベントペイロード
+type MessageActionInvoked struct {
+	Base
+	MessageID uuid.UUID `json:"messageId"`
+	ChannelID uuid.UUID `json:"channelId"`
+	ActionID  string    `json:"actionId"`
+	Value     string    `json:"value"`
+	User      User      `json:"user"`
+}
+
+func MakeMessageActionInvoked(et time.Time, messageID, channelID uuid.UUID, actionID, value string, user model.UserInfo) *MessageActionInvoked {
+	return &MessageActionInvoked{
+		Base:      MakeBase(et),
+		MessageID: messageID,
+		ChannelID: channelID,
+		ActionID:  actionID,
+		Value:     value,
+		User:      MakeUser(user),
+	}
+}
@@ -0,0 +1,24 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// MessageReported MESSAGE_REPORTEDイベントペイロード
+type MessageReported struct {
+	Base
+	ID        uuid.UUID `json:"id"`
+	MessageID uuid.UUID `json:"messageId"`
+}
+
+func MakeMessageReported(et time.Time, report *model.MessageReport) *MessageReported {
+	return &MessageReported{
+		Base:      MakeBase(et),
+		ID:        report.ID,
+		MessageID: report.MessageID,
+	}
+}
@@ -0,0 +1,26 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// MessageStampsUpdated MESSAGE_STAMPS_UPDATEDイベントペイロード
+type MessageStampsUpdated struct {
+	Base
+	MessageID uuid.UUID            `json:"messageId"`
+	ChannelID uuid.UUID            `json:"channelId"`
+	Stamps    []model.MessageStamp `json:"stamps"`
+}
+
+func MakeMessageStampsUpdated(eventTime time.Time, mid, cid uuid.UUID, stamps []model.MessageStamp) *MessageStampsUpdated {
+	return &MessageStampsUpdated{
+		Base:      MakeBase(eventTime),
+		MessageID: mid,
+		ChannelID: cid,
+		Stamps:    stamps,
+	}
+}
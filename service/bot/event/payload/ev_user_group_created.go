@@ -0,0 +1,20 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// UserGroupCreated USER_GROUP_CREATEDイベントペイロード
+type UserGroupCreated struct {
+	Base
+	Group UserGroup `json:"group"`
+}
+
+func MakeUserGroupCreated(et time.Time, group *model.UserGroup) *UserGroupCreated {
+	return &UserGroupCreated{
+		Base:  MakeBase(et),
+		Group: MakeUserGroup(group),
+	}
+}
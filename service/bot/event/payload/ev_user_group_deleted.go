@@ -0,0 +1,20 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// UserGroupDeleted USER_GROUP_DELETEDイベントペイロード
+type UserGroupDeleted struct {
+	Base
+	GroupID uuid.UUID `json:"groupId"`
+}
+
+func MakeUserGroupDeleted(et time.Time, groupID uuid.UUID) *UserGroupDeleted {
+	return &UserGroupDeleted{
+		Base:    MakeBase(et),
+		GroupID: groupID,
+	}
+}
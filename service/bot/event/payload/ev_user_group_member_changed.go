@@ -0,0 +1,29 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// UserGroupMemberChanged USER_GROUP_MEMBER_ADDED, USER_GROUP_MEMBER_UPDATED, USER_GROUP_MEMBER_REMOVED,
+// USER_GROUP_ADMIN_ADDED, USER_GROUP_ADMIN_REMOVEDイベントペイロード
+type UserGroupMemberChanged struct {
+	Base
+	GroupMember UserGroupMemberID `json:"groupMember"`
+}
+
+type UserGroupMemberID struct {
+	GroupID uuid.UUID `json:"groupId"`
+	UserID  uuid.UUID `json:"userId"`
+}
+
+func MakeUserGroupMemberChanged(et time.Time, groupID, userID uuid.UUID) *UserGroupMemberChanged {
+	return &UserGroupMemberChanged{
+		Base: MakeBase(et),
+		GroupMember: UserGroupMemberID{
+			GroupID: groupID,
+			UserID:  userID,
+		},
+	}
+}
@@ -0,0 +1,20 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// UserGroupUpdated USER_GROUP_UPDATEDイベントペイロード
+type UserGroupUpdated struct {
+	Base
+	Group UserGroup `json:"group"`
+}
+
+func MakeUserGroupUpdated(et time.Time, group *model.UserGroup) *UserGroupUpdated {
+	return &UserGroupUpdated{
+		Base:  MakeBase(et),
+		Group: MakeUserGroup(group),
+	}
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+// BotCommandInvoked スラッシュコマンドの実行をコマンドを登録したBOTに送信します
+//
+// コマンドを登録したBOTは購読イベントにかかわらずCOMMAND_INVOKEDを受け取ります。
+func BotCommandInvoked(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	botID := fields["bot_id"].(uuid.UUID)
+	command := fields["command"].(*model.BotCommand)
+	userID := fields["user_id"].(uuid.UUID)
+	channelID := fields["channel_id"].(uuid.UUID)
+	args := fields["arguments"].(map[string]interface{})
+	text := fields["text"].(string)
+
+	bot, err := ctx.GetBot(botID)
+	if err != nil {
+		return fmt.Errorf("failed to GetBot: %w", err)
+	}
+	if bot == nil {
+		return nil
+	}
+
+	user, err := ctx.R().GetUser(userID, false)
+	if err != nil {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+
+	err = ctx.Unicast(
+		event.CommandInvoked,
+		payload.MakeCommandInvoked(datetime, command, args, text, user, channelID),
+		bot,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to unicast: %w", err)
+	}
+	return nil
+}
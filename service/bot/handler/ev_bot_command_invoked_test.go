@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func TestBotCommandInvoked(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypes{},
+		State:           model.BotActive,
+	}
+	u := &model.User{
+		ID:   uuid.NewV3(uuid.Nil, "u"),
+		Name: "testman",
+	}
+	command := &model.BotCommand{
+		ID:    uuid.NewV3(uuid.Nil, "cmd"),
+		BotID: b.ID,
+		Name:  "deploy",
+		Arguments: model.BotCommandArguments{
+			{Name: "env", Type: model.BotCommandArgumentString, Required: true},
+		},
+	}
+	channelID := uuid.NewV3(uuid.Nil, "c")
+	args := map[string]interface{}{"env": "prod"}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+		registerUser(repo, u)
+
+		et := time.Now()
+
+		expectUnicast(handlerCtx, event.CommandInvoked, payload.MakeCommandInvoked(et, command, args, "prod", u, channelID), b)
+		assert.NoError(t, BotCommandInvoked(handlerCtx, et, intevent.BotCommandInvoked, hub.Fields{
+			"bot_id":     b.ID,
+			"command":    command,
+			"user_id":    u.ID,
+			"channel_id": channelID,
+			"arguments":  args,
+			"text":       "prod",
+		}))
+	})
+
+	t.Run("inactive bot", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+		handlerCtx.EXPECT().
+			GetBot(b.ID).
+			Return(nil, nil).
+			AnyTimes()
+
+		assert.NoError(t, BotCommandInvoked(handlerCtx, time.Now(), intevent.BotCommandInvoked, hub.Fields{
+			"bot_id":     b.ID,
+			"command":    command,
+			"user_id":    u.ID,
+			"channel_id": channelID,
+			"arguments":  args,
+			"text":       "prod",
+		}))
+	})
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+// BotMessageActionInvoked メッセージのアクションの実行をメッセージを投稿したBOTに送信します
+//
+// メッセージを投稿したBOTは購読イベントにかかわらずMESSAGE_ACTION_INVOKEDを受け取ります。
+func BotMessageActionInvoked(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	botID := fields["bot_id"].(uuid.UUID)
+	messageID := fields["message_id"].(uuid.UUID)
+	channelID := fields["channel_id"].(uuid.UUID)
+	actionID := fields["action_id"].(string)
+	value := fields["value"].(string)
+	userID := fields["user_id"].(uuid.UUID)
+
+	bot, err := ctx.GetBot(botID)
+	if err != nil {
+		return fmt.Errorf("failed to GetBot: %w", err)
+	}
+	if bot == nil {
+		return nil
+	}
+
+	user, err := ctx.R().GetUser(userID, false)
+	if err != nil {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+
+	err = ctx.Unicast(
+		event.MessageActionInvoked,
+		payload.MakeMessageActionInvoked(datetime, messageID, channelID, actionID, value, user),
+		bot,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to unicast: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func TestBotMessageActionInvoked(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypes{},
+		State:           model.BotActive,
+	}
+	u := &model.User{
+		ID:   uuid.NewV3(uuid.Nil, "u"),
+		Name: "testman",
+	}
+	messageID := uuid.NewV3(uuid.Nil, "m")
+	channelID := uuid.NewV3(uuid.Nil, "c")
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+		registerUser(repo, u)
+
+		et := time.Now()
+
+		expectUnicast(handlerCtx, event.MessageActionInvoked, payload.MakeMessageActionInvoked(et, messageID, channelID, "env", "prod", u), b)
+		assert.NoError(t, BotMessageActionInvoked(handlerCtx, et, intevent.BotMessageActionInvoked, hub.Fields{
+			"bot_id":     b.ID,
+			"message_id": messageID,
+			"channel_id": channelID,
+			"action_id":  "env",
+			"value":      "prod",
+			"user_id":    u.ID,
+		}))
+	})
+
+	t.Run("inactive bot", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+		handlerCtx.EXPECT().
+			GetBot(b.ID).
+			Return(nil, nil).
+			AnyTimes()
+
+		assert.NoError(t, BotMessageActionInvoked(handlerCtx, time.Now(), intevent.BotMessageActionInvoked, hub.Fields{
+			"bot_id":     b.ID,
+			"message_id": messageID,
+			"channel_id": channelID,
+			"action_id":  "env",
+			"value":      "prod",
+			"user_id":    u.ID,
+		}))
+	})
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func ChannelArchived(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	return channelArchiveChanged(ctx, datetime, event.ChannelArchived, fields)
+}
+
+func ChannelUnarchived(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	return channelArchiveChanged(ctx, datetime, event.ChannelUnarchived, fields)
+}
+
+func channelArchiveChanged(ctx Context, datetime time.Time, ev model.BotEventType, fields hub.Fields) error {
+	chID := fields["channel_id"].(uuid.UUID)
+
+	bots, err := ctx.GetChannelBots(chID, ev)
+	if err != nil {
+		return fmt.Errorf("failed to GetChannelBots: %w", err)
+	}
+	bots = filterBotsByChannel(ctx, bots, chID)
+	if len(bots) == 0 {
+		return nil
+	}
+
+	ch, err := ctx.CM().GetChannel(chID)
+	if err != nil {
+		return fmt.Errorf("failed to GetChannel: %w", err)
+	}
+
+	chCreator, err := ctx.R().GetUser(ch.CreatorID, false)
+	if err != nil && err != repository.ErrNotFound {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+
+	if err := ctx.Multicast(
+		ev,
+		payload.MakeChannelArchived(datetime, ch, ctx.CM().PublicChannelTree().GetChannelPath(ch.ID), chCreator),
+		bots,
+	); err != nil {
+		return fmt.Errorf("failed to multicast: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+)
+
+func TestChannelArchived(t *testing.T) {
+	t.Parallel()
+
+	u := &model.User{
+		ID:   uuid.NewV3(uuid.Nil, "u"),
+		Name: "testman",
+	}
+	ch := &model.Channel{
+		ID:        uuid.NewV3(uuid.Nil, "c"),
+		Name:      "test",
+		IsPublic:  true,
+		CreatorID: u.ID,
+	}
+
+	tests := []struct {
+		name    string
+		topic   string
+		ev      model.BotEventType
+		handler eventHandlerFunc
+	}{
+		{"archived", intevent.ChannelArchived, event.ChannelArchived, ChannelArchived},
+		{"unarchived", intevent.ChannelUnarchived, event.ChannelUnarchived, ChannelUnarchived},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			handlerCtx, cm, repo := setup(t, ctrl)
+
+			b := &model.Bot{
+				ID:              uuid.NewV3(uuid.Nil, "b"),
+				BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+				SubscribeEvents: model.BotEventTypesFromArray([]string{tt.ev.String()}),
+				State:           model.BotActive,
+			}
+
+			tree := mock_channel.NewMockTree(ctrl)
+			cm.EXPECT().PublicChannelTree().Return(tree).AnyTimes()
+			tree.EXPECT().GetChannelPath(ch.ID).Return(ch.Name).AnyTimes()
+
+			registerBot(t, handlerCtx, b)
+			registerChannel(cm, ch)
+			registerUser(repo, u)
+
+			handlerCtx.EXPECT().
+				GetChannelBots(ch.ID, tt.ev).
+				Return([]*model.Bot{b}, nil).
+				AnyTimes()
+
+			et := time.Now()
+
+			expectMulticast(handlerCtx, tt.ev, payload.MakeChannelArchived(et, ch, ch.Name, u), []*model.Bot{b})
+			assert.NoError(t, tt.handler(handlerCtx, et, tt.topic, hub.Fields{
+				"channel_id": ch.ID,
+			}))
+		})
+	}
+
+	t.Run("no bots", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+		handlerCtx.EXPECT().
+			GetChannelBots(ch.ID, event.ChannelArchived).
+			Return([]*model.Bot{}, nil).
+			AnyTimes()
+
+		assert.NoError(t, ChannelArchived(handlerCtx, time.Now(), intevent.ChannelArchived, hub.Fields{
+			"channel_id": ch.ID,
+		}))
+	})
+}
@@ -18,6 +18,7 @@ func ChannelCreated(ctx Context, datetime time.Time, _ string, fields hub.Fields
 		if err != nil {
 			return fmt.Errorf("failed to GetBots: %w", err)
 		}
+		bots = filterBotsByChannel(ctx, bots, ch.ID)
 		if len(bots) == 0 {
 			return nil
 		}
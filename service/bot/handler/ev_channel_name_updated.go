@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func ChannelNameUpdated(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	chID := fields["channel_id"].(uuid.UUID)
+	oldName := fields["old_name"].(string)
+	updaterID := fields["updater_id"].(uuid.UUID)
+
+	bots, err := ctx.GetChannelBots(chID, event.ChannelNameChanged)
+	if err != nil {
+		return fmt.Errorf("failed to GetChannelBots: %w", err)
+	}
+	bots = filterBotsByChannel(ctx, bots, chID)
+	if len(bots) == 0 {
+		return nil
+	}
+
+	ch, err := ctx.CM().GetChannel(chID)
+	if err != nil {
+		return fmt.Errorf("failed to GetChannel: %w", err)
+	}
+
+	chCreator, err := ctx.R().GetUser(ch.CreatorID, false)
+	if err != nil && err != repository.ErrNotFound {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+
+	user, err := ctx.R().GetUser(updaterID, false)
+	if err != nil && err != repository.ErrNotFound {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+
+	if err := ctx.Multicast(
+		event.ChannelNameChanged,
+		payload.MakeChannelNameChanged(datetime, ch, ctx.CM().PublicChannelTree().GetChannelPath(ch.ID), chCreator, oldName, user),
+		bots,
+	); err != nil {
+		return fmt.Errorf("failed to multicast: %w", err)
+	}
+	return nil
+}
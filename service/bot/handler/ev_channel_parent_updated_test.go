@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+)
+
+func TestChannelParentUpdated(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.ChannelParentChanged.String()}),
+		State:           model.BotActive,
+	}
+	u := &model.User{
+		ID:   uuid.NewV3(uuid.Nil, "u"),
+		Name: "testman",
+	}
+	oldParentID := uuid.NewV3(uuid.Nil, "p1")
+	ch := &model.Channel{
+		ID:        uuid.NewV3(uuid.Nil, "c"),
+		Name:      "test",
+		ParentID:  uuid.NewV3(uuid.Nil, "p2"),
+		IsPublic:  true,
+		CreatorID: u.ID,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, repo := setup(t, ctrl)
+
+		tree := mock_channel.NewMockTree(ctrl)
+		cm.EXPECT().PublicChannelTree().Return(tree).AnyTimes()
+		tree.EXPECT().GetChannelPath(ch.ID).Return("p2/test").AnyTimes()
+
+		registerBot(t, handlerCtx, b)
+		registerChannel(cm, ch)
+		registerUser(repo, u)
+
+		handlerCtx.EXPECT().
+			GetChannelBots(ch.ID, event.ChannelParentChanged).
+			Return([]*model.Bot{b}, nil).
+			AnyTimes()
+
+		et := time.Now()
+
+		expectMulticast(handlerCtx, event.ChannelParentChanged, payload.MakeChannelParentChanged(et, ch, "p2/test", u, oldParentID, u), []*model.Bot{b})
+		assert.NoError(t, ChannelParentUpdated(handlerCtx, et, intevent.ChannelParentUpdated, hub.Fields{
+			"channel_id":    ch.ID,
+			"old_parent_id": oldParentID,
+			"parent_id":     ch.ParentID,
+			"updater_id":    u.ID,
+		}))
+	})
+}
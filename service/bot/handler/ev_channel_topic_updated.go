@@ -21,6 +21,7 @@ func ChannelTopicUpdated(ctx Context, datetime time.Time, _ string, fields hub.F
 	if err != nil {
 		return fmt.Errorf("failed to GetChannelBots: %w", err)
 	}
+	bots = filterBotsByChannel(ctx, bots, chID)
 	if len(bots) == 0 {
 		return nil
 	}
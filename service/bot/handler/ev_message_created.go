@@ -46,7 +46,7 @@ func MessageCreated(ctx Context, datetime time.Time, _ string, fields hub.Fields
 		if err != nil {
 			return fmt.Errorf("failed to GetBotByBotUserID: %w", err)
 		}
-		if bot == nil || !bot.SubscribeEvents.Contains(event.DirectMessageCreated) {
+		if bot == nil || !bot.SubscribeEvents.Contains(event.DirectMessageCreated) || !bot.EventFilter.MatchMessage(parsed.PlainText) {
 			return nil
 		}
 
@@ -63,8 +63,10 @@ func MessageCreated(ctx Context, datetime time.Time, _ string, fields hub.Fields
 		if err != nil {
 			return fmt.Errorf("failed to GetChannelBots: %w", err)
 		}
+		bots = filterBotsByChannel(ctx, bots, m.ChannelID)
+		bots = filterBotsByMessage(bots, parsed.PlainText)
 
-		// メンションBOT
+		// メンションBOT (イベントフィルタの対象外)
 		done := make(map[uuid.UUID]bool)
 		for _, uid := range parsed.Mentions {
 			if !done[uid] {
@@ -109,6 +109,51 @@ func TestMessageCreated(t *testing.T) {
 		}))
 	})
 
+	t.Run("success (public message, filtered)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, repo := setup(t, ctrl)
+
+		fb := &model.Bot{
+			ID:              uuid.NewV3(uuid.Nil, "fb"),
+			BotUserID:       uuid.NewV3(uuid.Nil, "fbu"),
+			SubscribeEvents: model.BotEventTypesFromArray([]string{event.MessageCreated.String()}),
+			EventFilter:     model.BotEventFilter{MessagePrefixes: []string{"!"}},
+			State:           model.BotActive,
+		}
+		registerBot(t, handlerCtx, b)
+		registerBot(t, handlerCtx, fb)
+
+		m := &model.Message{
+			ID:        uuid.NewV3(uuid.Nil, "m"),
+			UserID:    uuid.NewV3(uuid.Nil, "u"),
+			ChannelID: uuid.NewV3(uuid.Nil, "c"),
+			Text:      "test message",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		parsed := message.Parse(m.Text)
+		mu := &model.User{
+			ID:   m.UserID,
+			Name: "testman",
+		}
+		registerUser(repo, mu)
+		registerChannel(cm, ch)
+		et := time.Now()
+
+		handlerCtx.EXPECT().
+			GetChannelBots(m.ChannelID, event.MessageCreated).
+			Return([]*model.Bot{b, fb}, nil).
+			AnyTimes()
+
+		expectMulticast(handlerCtx, event.MessageCreated, payload.MakeMessageCreated(et, m, mu, parsed), []*model.Bot{b})
+		assert.NoError(t, MessageCreated(handlerCtx, et, intevent.MessageCreated, hub.Fields{
+			"message_id":   m.ID,
+			"message":      m,
+			"parse_result": parsed,
+		}))
+	})
+
 	t.Run("success (dm)", func(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
@@ -55,6 +55,7 @@ func MessageDeleted(ctx Context, datetime time.Time, _ string, fields hub.Fields
 			return fmt.Errorf("failed to GetChannelBots: %w", err)
 		}
 
+		bots = filterBotsByChannel(ctx, bots, m.ChannelID)
 		bots = filterBotUserIDNotEquals(bots, m.UserID)
 		if len(bots) == 0 {
 			return nil
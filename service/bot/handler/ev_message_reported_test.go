@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func TestMessageReported(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.MessageReported.String()}),
+		State:           model.BotActive,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+
+		report := &model.MessageReport{
+			ID:        uuid.NewV3(uuid.Nil, "r"),
+			MessageID: uuid.NewV3(uuid.Nil, "m"),
+			Reporter:  uuid.NewV3(uuid.Nil, "u"),
+			Reason:    "spam",
+			State:     model.MessageReportStateOpen,
+			CreatedAt: time.Now(),
+		}
+		et := time.Now()
+
+		expectMulticast(handlerCtx, event.MessageReported, payload.MakeMessageReported(et, report), []*model.Bot{b})
+		assert.NoError(t, MessageReported(handlerCtx, et, intevent.MessageReported, hub.Fields{
+			"report_id":  report.ID,
+			"message_id": report.MessageID,
+			"report":     report,
+		}))
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/leandro-lugaresi/hub"
 
+	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/service/bot/event"
 	"github.com/traPtitech/traQ/service/bot/event/payload"
 	"github.com/traPtitech/traQ/service/message"
@@ -14,20 +15,68 @@ import (
 func MessageStampsUpdated(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
 	m := fields["message"].(message.Message)
 
+	// メッセージを投稿したBOT
 	bot, err := ctx.GetBotByBotUserID(m.GetUserID())
 	if err != nil {
 		return fmt.Errorf("failed to GetBotByBotUserID: %w", err)
 	}
-	if bot == nil || !bot.SubscribeEvents.Contains(event.BotMessageStampsUpdated) {
+	if bot != nil && bot.SubscribeEvents.Contains(event.BotMessageStampsUpdated) {
+		if err := ctx.Unicast(
+			event.BotMessageStampsUpdated,
+			payload.MakeBotMessageStampsUpdated(datetime, m.GetID(), m.GetStamps()),
+			bot,
+		); err != nil {
+			return fmt.Errorf("failed to unicast: %w", err)
+		}
+	}
+
+	// メッセージのチャンネルに参加しているBOT (投稿したBOTを除く)
+	bots, err := messageStampsUpdatedTargets(ctx, m)
+	if err != nil {
+		return err
+	}
+	bots = filterBotUserIDNotEquals(bots, m.GetUserID())
+	if len(bots) == 0 {
 		return nil
 	}
 
-	if err := ctx.Unicast(
-		event.BotMessageStampsUpdated,
-		payload.MakeBotMessageStampsUpdated(datetime, m.GetID(), m.GetStamps()),
-		bot,
+	if err := ctx.Multicast(
+		event.MessageStampsUpdated,
+		payload.MakeMessageStampsUpdated(datetime, m.GetID(), m.GetChannelID(), m.GetStamps()),
+		bots,
 	); err != nil {
-		return fmt.Errorf("failed to unicast: %w", err)
+		return fmt.Errorf("failed to multicast: %w", err)
 	}
 	return nil
 }
+
+func messageStampsUpdatedTargets(ctx Context, m message.Message) ([]*model.Bot, error) {
+	ch, err := ctx.CM().GetChannel(m.GetChannelID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to GetChannel: %w", err)
+	}
+	if !ch.IsDMChannel() {
+		bots, err := ctx.GetChannelBots(ch.ID, event.MessageStampsUpdated)
+		if err != nil {
+			return nil, fmt.Errorf("failed to GetChannelBots: %w", err)
+		}
+		return filterBotsByChannel(ctx, bots, ch.ID), nil
+	}
+
+	// DMの場合はDMの相手のBOTのみ
+	ids, err := ctx.CM().GetDMChannelMembers(ch.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to GetDMChannelMembers: %w", err)
+	}
+	bots := make([]*model.Bot, 0, len(ids))
+	for _, id := range ids {
+		b, err := ctx.GetBotByBotUserID(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to GetBotByBotUserID: %w", err)
+		}
+		if b != nil && b.SubscribeEvents.Contains(event.MessageStampsUpdated) {
+			bots = append(bots, b)
+		}
+	}
+	return bots, nil
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/service/bot/event"
 	"github.com/traPtitech/traQ/service/bot/event/payload"
-	"github.com/traPtitech/traQ/service/bot/handler/mock_handler"
 	"github.com/traPtitech/traQ/service/message"
 )
 
@@ -26,17 +25,35 @@ func TestMessageStampsUpdated(t *testing.T) {
 		SubscribeEvents: model.BotEventTypesFromArray([]string{event.BotMessageStampsUpdated.String()}),
 		State:           model.BotActive,
 	}
+	b2 := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b2"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu2"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.MessageStampsUpdated.String()}),
+		State:           model.BotActive,
+	}
+	ch := &model.Channel{
+		ID:       uuid.NewV3(uuid.Nil, "c"),
+		Name:     "test",
+		IsPublic: true,
+	}
+	userID := uuid.NewV3(uuid.Nil, "u")
 
 	t.Run("success", func(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
-		handlerCtx := mock_handler.NewMockContext(ctrl)
+		handlerCtx, cm, _ := setup(t, ctrl)
 
 		registerBot(t, handlerCtx, b)
+		registerChannel(cm, ch)
+		handlerCtx.EXPECT().
+			GetChannelBots(ch.ID, event.MessageStampsUpdated).
+			Return([]*model.Bot{}, nil).
+			AnyTimes()
 
 		m := &messageImpl{
 			ID:     uuid.NewV3(uuid.Nil, "m"),
-			UID:    uuid.NewV3(uuid.Nil, "bu"),
+			UID:    b.BotUserID,
+			CID:    ch.ID,
 			Stamps: []model.MessageStamp{},
 		}
 		et := time.Now()
@@ -51,7 +68,7 @@ func TestMessageStampsUpdated(t *testing.T) {
 	t.Run("not subscribe BotMessageStampsUpdated", func(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
-		handlerCtx := mock_handler.NewMockContext(ctrl)
+		handlerCtx, cm, _ := setup(t, ctrl)
 
 		b := &model.Bot{
 			ID:              uuid.NewV3(uuid.Nil, "b"),
@@ -60,14 +77,73 @@ func TestMessageStampsUpdated(t *testing.T) {
 			State:           model.BotActive,
 		}
 		registerBot(t, handlerCtx, b)
+		registerChannel(cm, ch)
+		handlerCtx.EXPECT().
+			GetChannelBots(ch.ID, event.MessageStampsUpdated).
+			Return([]*model.Bot{}, nil).
+			AnyTimes()
 
 		m := &messageImpl{
 			ID:     uuid.NewV3(uuid.Nil, "m"),
 			UID:    b.BotUserID,
+			CID:    ch.ID,
+			Stamps: []model.MessageStamp{},
+		}
+		et := time.Now()
+
+		assert.NoError(t, MessageStampsUpdated(handlerCtx, et, intevent.MessageStampsUpdated, hub.Fields{
+			"message":    m,
+			"message_id": m.ID,
+		}))
+	})
+
+	t.Run("message of user in channel", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, _ := setup(t, ctrl)
+
+		registerChannel(cm, ch)
+		handlerCtx.EXPECT().
+			GetBotByBotUserID(userID).
+			Return(nil, nil).
+			AnyTimes()
+		handlerCtx.EXPECT().
+			GetChannelBots(ch.ID, event.MessageStampsUpdated).
+			Return([]*model.Bot{b2}, nil).
+			AnyTimes()
+
+		m := &messageImpl{
+			ID:     uuid.NewV3(uuid.Nil, "m"),
+			UID:    userID,
+			CID:    ch.ID,
+			Stamps: []model.MessageStamp{{MessageID: uuid.NewV3(uuid.Nil, "m"), UserID: userID, Count: 1}},
+		}
+		et := time.Now()
+
+		expectMulticast(handlerCtx, event.MessageStampsUpdated, payload.MakeMessageStampsUpdated(et, m.ID, ch.ID, m.Stamps), []*model.Bot{b2})
+		assert.NoError(t, MessageStampsUpdated(handlerCtx, et, intevent.MessageStampsUpdated, hub.Fields{
+			"message":    m,
+			"message_id": m.ID,
+		}))
+	})
+
+	t.Run("message in dm", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, repo := setup(t, ctrl)
+
+		registerBot(t, handlerCtx, b2)
+		dm, u := createDMChannel(handlerCtx, cm, repo, b2)
+
+		m := &messageImpl{
+			ID:     uuid.NewV3(uuid.Nil, "m"),
+			UID:    u.ID,
+			CID:    dm.ID,
 			Stamps: []model.MessageStamp{},
 		}
 		et := time.Now()
 
+		expectMulticast(handlerCtx, event.MessageStampsUpdated, payload.MakeMessageStampsUpdated(et, m.ID, dm.ID, m.Stamps), []*model.Bot{b2})
 		assert.NoError(t, MessageStampsUpdated(handlerCtx, et, intevent.MessageStampsUpdated, hub.Fields{
 			"message":    m,
 			"message_id": m.ID,
@@ -79,6 +155,7 @@ type messageImpl struct {
 	message.Message
 	ID     uuid.UUID
 	UID    uuid.UUID
+	CID    uuid.UUID
 	Stamps []model.MessageStamp
 }
 
@@ -90,6 +167,10 @@ func (m *messageImpl) GetUserID() uuid.UUID {
 	return m.UID
 }
 
+func (m *messageImpl) GetChannelID() uuid.UUID {
+	return m.CID
+}
+
 func (m *messageImpl) GetStamps() []model.MessageStamp {
 	return m.Stamps
 }
@@ -45,7 +45,7 @@ func MessageUpdated(ctx Context, datetime time.Time, _ string, fields hub.Fields
 		if err != nil {
 			return fmt.Errorf("failed to GetBotByBotUserID: %w", err)
 		}
-		if bot == nil || !bot.SubscribeEvents.Contains(event.DirectMessageUpdated) {
+		if bot == nil || !bot.SubscribeEvents.Contains(event.DirectMessageUpdated) || !bot.EventFilter.MatchMessage(parsed.PlainText) {
 			return nil
 		}
 
@@ -62,6 +62,8 @@ func MessageUpdated(ctx Context, datetime time.Time, _ string, fields hub.Fields
 		if err != nil {
 			return fmt.Errorf("failed to GetChannelBots: %w", err)
 		}
+		bots = filterBotsByChannel(ctx, bots, m.ChannelID)
+		bots = filterBotsByMessage(bots, parsed.PlainText)
 
 		// ev_message_created.go で定義済み
 		bots = filterBotUserIDNotEquals(bots, m.UserID)
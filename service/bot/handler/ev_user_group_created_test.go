@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func TestUserGroupCreated(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.UserGroupCreated.String()}),
+		State:           model.BotActive,
+	}
+	g := &model.UserGroup{
+		ID:      uuid.NewV3(uuid.Nil, "g"),
+		Name:    "group",
+		Admins:  []*model.UserGroupAdmin{{GroupID: uuid.NewV3(uuid.Nil, "g"), UserID: uuid.NewV3(uuid.Nil, "u")}},
+		Members: []*model.UserGroupMember{},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+
+		et := time.Now()
+
+		expectMulticast(handlerCtx, event.UserGroupCreated, payload.MakeUserGroupCreated(et, g), []*model.Bot{b})
+		assert.NoError(t, UserGroupCreated(handlerCtx, et, intevent.UserGroupCreated, hub.Fields{
+			"group_id": g.ID,
+			"group":    g,
+		}))
+	})
+
+	t.Run("no bots", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+		handlerCtx.EXPECT().
+			GetBots(event.UserGroupCreated).
+			Return([]*model.Bot{}, nil).
+			AnyTimes()
+
+		assert.NoError(t, UserGroupCreated(handlerCtx, time.Now(), intevent.UserGroupCreated, hub.Fields{
+			"group_id": g.ID,
+			"group":    g,
+		}))
+	})
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func UserGroupMemberAdded(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	return userGroupMemberChanged(ctx, datetime, event.UserGroupMemberAdded, fields)
+}
+
+func UserGroupMemberUpdated(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	return userGroupMemberChanged(ctx, datetime, event.UserGroupMemberUpdated, fields)
+}
+
+func UserGroupMemberRemoved(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	return userGroupMemberChanged(ctx, datetime, event.UserGroupMemberRemoved, fields)
+}
+
+func UserGroupAdminAdded(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	return userGroupMemberChanged(ctx, datetime, event.UserGroupAdminAdded, fields)
+}
+
+func UserGroupAdminRemoved(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	return userGroupMemberChanged(ctx, datetime, event.UserGroupAdminRemoved, fields)
+}
+
+func userGroupMemberChanged(ctx Context, datetime time.Time, ev model.BotEventType, fields hub.Fields) error {
+	groupID := fields["group_id"].(uuid.UUID)
+	userID := fields["user_id"].(uuid.UUID)
+
+	bots, err := ctx.GetBots(ev)
+	if err != nil {
+		return fmt.Errorf("failed to GetBots: %w", err)
+	}
+	if len(bots) == 0 {
+		return nil
+	}
+
+	if err := ctx.Multicast(
+		ev,
+		payload.MakeUserGroupMemberChanged(datetime, groupID, userID),
+		bots,
+	); err != nil {
+		return fmt.Errorf("failed to multicast: %w", err)
+	}
+	return nil
+}
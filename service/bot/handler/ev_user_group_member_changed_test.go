@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func TestUserGroupMemberChanged(t *testing.T) {
+	t.Parallel()
+
+	groupID := uuid.NewV3(uuid.Nil, "g")
+	userID := uuid.NewV3(uuid.Nil, "u")
+
+	tests := []struct {
+		name    string
+		topic   string
+		ev      model.BotEventType
+		handler eventHandlerFunc
+	}{
+		{"member added", intevent.UserGroupMemberAdded, event.UserGroupMemberAdded, UserGroupMemberAdded},
+		{"member updated", intevent.UserGroupMemberUpdated, event.UserGroupMemberUpdated, UserGroupMemberUpdated},
+		{"member removed", intevent.UserGroupMemberRemoved, event.UserGroupMemberRemoved, UserGroupMemberRemoved},
+		{"admin added", intevent.UserGroupAdminAdded, event.UserGroupAdminAdded, UserGroupAdminAdded},
+		{"admin removed", intevent.UserGroupAdminRemoved, event.UserGroupAdminRemoved, UserGroupAdminRemoved},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			handlerCtx, _, _ := setup(t, ctrl)
+
+			b := &model.Bot{
+				ID:              uuid.NewV3(uuid.Nil, "b"),
+				BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+				SubscribeEvents: model.BotEventTypesFromArray([]string{tt.ev.String()}),
+				State:           model.BotActive,
+			}
+			registerBot(t, handlerCtx, b)
+
+			et := time.Now()
+
+			expectMulticast(handlerCtx, tt.ev, payload.MakeUserGroupMemberChanged(et, groupID, userID), []*model.Bot{b})
+			assert.NoError(t, tt.handler(handlerCtx, et, tt.topic, hub.Fields{
+				"group_id": groupID,
+				"user_id":  userID,
+			}))
+		})
+	}
+}
+
+type eventHandlerFunc func(ctx Context, datetime time.Time, event string, fields hub.Fields) error
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func UserGroupUpdated(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	groupID := fields["group_id"].(uuid.UUID)
+
+	bots, err := ctx.GetBots(event.UserGroupUpdated)
+	if err != nil {
+		return fmt.Errorf("failed to GetBots: %w", err)
+	}
+	if len(bots) == 0 {
+		return nil
+	}
+
+	group, err := ctx.R().GetUserGroup(groupID)
+	if err != nil {
+		return fmt.Errorf("failed to GetUserGroup: %w", err)
+	}
+
+	if err := ctx.Multicast(
+		event.UserGroupUpdated,
+		payload.MakeUserGroupUpdated(datetime, group),
+		bots,
+	); err != nil {
+		return fmt.Errorf("failed to multicast: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func TestUserGroupUpdated(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.UserGroupUpdated.String()}),
+		State:           model.BotActive,
+	}
+	g := &model.UserGroup{
+		ID:   uuid.NewV3(uuid.Nil, "g"),
+		Name: "group",
+		Members: []*model.UserGroupMember{
+			{GroupID: uuid.NewV3(uuid.Nil, "g"), UserID: uuid.NewV3(uuid.Nil, "u"), Role: "leader"},
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+		repo.MockUserGroupRepository.EXPECT().
+			GetUserGroup(g.ID).
+			Return(g, nil).
+			Times(1)
+
+		et := time.Now()
+
+		expectMulticast(handlerCtx, event.UserGroupUpdated, payload.MakeUserGroupUpdated(et, g), []*model.Bot{b})
+		assert.NoError(t, UserGroupUpdated(handlerCtx, et, intevent.UserGroupUpdated, hub.Fields{
+			"group_id": g.ID,
+		}))
+	})
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// filterBotsByChannel イベントフィルタのチャンネルの条件に一致しないBOTを除外します
+func filterBotsByChannel(ctx Context, bots []*model.Bot, channelID uuid.UUID) []*model.Bot {
+	var ascendants []uuid.UUID
+	loaded := false
+	result := make([]*model.Bot, 0, len(bots))
+	for _, bot := range bots {
+		if bot.EventFilter.HasChannelFilter() {
+			if !loaded {
+				ascendants = ctx.CM().PublicChannelTree().GetAscendantIDs(channelID)
+				loaded = true
+			}
+			if !bot.EventFilter.MatchChannel(channelID, ascendants) {
+				continue
+			}
+		}
+		result = append(result, bot)
+	}
+	return result
+}
+
+// filterBotsByMessage イベントフィルタのメッセージ本文の条件に一致しないBOTを除外します
+func filterBotsByMessage(bots []*model.Bot, text string) []*model.Bot {
+	result := make([]*model.Bot, 0, len(bots))
+	for _, bot := range bots {
+		if bot.EventFilter.MatchMessage(text) {
+			result = append(result, bot)
+		}
+	}
+	return result
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+)
+
+func TestFilterBotsByChannel(t *testing.T) {
+	t.Parallel()
+
+	parent := uuid.NewV3(uuid.Nil, "parent")
+	child := uuid.NewV3(uuid.Nil, "child")
+
+	all := &model.Bot{ID: uuid.NewV3(uuid.Nil, "all")}
+	exact := &model.Bot{
+		ID:          uuid.NewV3(uuid.Nil, "exact"),
+		EventFilter: model.BotEventFilter{Channels: []*model.BotChannelFilter{{ChannelID: parent}}},
+	}
+	recursive := &model.Bot{
+		ID:          uuid.NewV3(uuid.Nil, "recursive"),
+		EventFilter: model.BotEventFilter{Channels: []*model.BotChannelFilter{{ChannelID: parent, IncludeDescendants: true}}},
+	}
+	bots := []*model.Bot{all, exact, recursive}
+
+	t.Run("parent", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, _ := setup(t, ctrl)
+		tree := mock_channel.NewMockTree(ctrl)
+		cm.EXPECT().PublicChannelTree().Return(tree).AnyTimes()
+		tree.EXPECT().GetAscendantIDs(parent).Return([]uuid.UUID{}).Times(1)
+
+		assert.Equal(t, []*model.Bot{all, exact, recursive}, filterBotsByChannel(handlerCtx, bots, parent))
+	})
+
+	t.Run("child", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, _ := setup(t, ctrl)
+		tree := mock_channel.NewMockTree(ctrl)
+		cm.EXPECT().PublicChannelTree().Return(tree).AnyTimes()
+		tree.EXPECT().GetAscendantIDs(child).Return([]uuid.UUID{parent}).Times(1)
+
+		assert.Equal(t, []*model.Bot{all, recursive}, filterBotsByChannel(handlerCtx, bots, child))
+	})
+
+	t.Run("no filters", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+
+		assert.Equal(t, []*model.Bot{all}, filterBotsByChannel(handlerCtx, []*model.Bot{all}, child))
+	})
+}
+
+func TestFilterBotsByMessage(t *testing.T) {
+	t.Parallel()
+
+	all := &model.Bot{ID: uuid.NewV3(uuid.Nil, "all")}
+	prefix := &model.Bot{
+		ID:          uuid.NewV3(uuid.Nil, "prefix"),
+		EventFilter: model.BotEventFilter{MessagePrefixes: []string{"!"}},
+	}
+	pattern := &model.Bot{
+		ID:          uuid.NewV3(uuid.Nil, "pattern"),
+		EventFilter: model.BotEventFilter{MessagePattern: `(?i)hello`},
+	}
+	bots := []*model.Bot{all, prefix, pattern}
+
+	assert.Equal(t, []*model.Bot{all, prefix}, filterBotsByMessage(bots, "!ping"))
+	assert.Equal(t, []*model.Bot{all, pattern}, filterBotsByMessage(bots, "Hello world"))
+	assert.Equal(t, []*model.Bot{all}, filterBotsByMessage(bots, "bye"))
+}
@@ -19,6 +19,7 @@ type Repo struct {
 	*mock_repository.MockTagRepository
 	*mock_repository.MockUserRepository
 	*mock_repository.MockBotRepository
+	*mock_repository.MockUserGroupRepository
 	testUtils.EmptyTestRepository
 }
 
@@ -30,6 +31,8 @@ func setup(t *testing.T, ctrl *gomock.Controller) (*mock_handler.MockContext, *m
 		MockTagRepository:  mock_repository.NewMockTagRepository(ctrl),
 		MockUserRepository: mock_repository.NewMockUserRepository(ctrl),
 		MockBotRepository:  mock_repository.NewMockBotRepository(ctrl),
+
+		MockUserGroupRepository: mock_repository.NewMockUserGroupRepository(ctrl),
 	}
 
 	handlerCtx.EXPECT().
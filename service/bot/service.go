@@ -1,9 +1,20 @@
 package bot
 
-import "context"
+import (
+	"context"
+
+	"github.com/traPtitech/traQ/model"
+)
 
 // Service BOTサービス
 type Service interface {
+	// RedeliverDeadLetter 再送を諦めたイベントを再度配送します
+	//
+	// デッドレターは削除され、通常のイベントと同様に配送されます。
+	// 配送に失敗した場合は再送が行われ、再送を諦めると新たなデッドレターとして保存されます。
+	// 初回の配送に成功した場合、trueとnilを返します。
+	// DBによるエラーを返すことがあります。
+	RedeliverDeadLetter(b *model.Bot, letter *model.BotEventDeadLetter) (ok bool, err error)
 	// Shutdown BOTサービスをシャットダウンします
 	Shutdown(ctx context.Context) error
 }
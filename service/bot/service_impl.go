@@ -36,13 +36,13 @@ type serviceImpl struct {
 }
 
 // NewService ボットサービスを生成します
-func NewService(repo repository.Repository, cm channel.Manager, hub *hub.Hub, s *botWS.Streamer, logger *zap.Logger) Service {
+func NewService(repo repository.Repository, cm channel.Manager, hub *hub.Hub, s *botWS.Streamer, logger *zap.Logger, retry event.RetryConfig, backlog event.BacklogConfig) Service {
 	p := &serviceImpl{
 		repo:       repo,
 		cm:         cm,
 		logger:     logger.Named("bot"),
 		hub:        hub,
-		dispatcher: event.NewDispatcher(logger, repo, s),
+		dispatcher: event.NewDispatcher(logger, repo, s, retry, backlog),
 
 		serviceDone: make(chan struct{}),
 		hubDone:     make(chan struct{}),
@@ -94,6 +94,9 @@ func (p *serviceImpl) start() {
 				if err := p.repo.PurgeBotEventLogs(time.Now().Add(-botEventLogPurgeBefore)); err != nil {
 					p.logger.Error("an error occurred while puring old bot event logs", zap.Error(err))
 				}
+				if err := p.repo.PurgeBotEventDeadLetters(time.Now().Add(-botEventLogPurgeBefore)); err != nil {
+					p.logger.Error("an error occurred while puring old bot event dead letters", zap.Error(err))
+				}
 			case <-p.serviceDone:
 				return
 			}
@@ -109,9 +112,17 @@ func (p *serviceImpl) Shutdown(ctx context.Context) error {
 	close(p.serviceDone)
 	<-p.hubDone
 	<-p.purgerDone
+	p.dispatcher.Shutdown()
 	return nil
 }
 
+func (p *serviceImpl) RedeliverDeadLetter(b *model.Bot, letter *model.BotEventDeadLetter) (bool, error) {
+	if err := p.repo.DeleteBotEventDeadLetter(letter.ID); err != nil {
+		return false, err
+	}
+	return p.dispatcher.Send(b, letter.Event, []byte(letter.Body)), nil
+}
+
 func (p *serviceImpl) CM() channel.Manager {
 	return p.cm
 }
@@ -12,7 +12,7 @@ const (
 	writeWait          = 5 * time.Second
 	pongWait           = 60 * time.Second
 	pingPeriod         = (pongWait * 9) / 10
-	maxReadMessageSize = 1 << 9 // 512B
+	maxReadMessageSize = 1 << 16 // 64KiB
 	messageBufferSize  = 256
 )
 
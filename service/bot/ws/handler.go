@@ -9,6 +9,12 @@ import (
 )
 
 func (s *session) commandHandler(cmd string) {
+	// JSON形式のリクエスト
+	if strings.HasPrefix(strings.TrimSpace(cmd), "{") {
+		s.requestHandler([]byte(cmd))
+		return
+	}
+
 	args := strings.Split(strings.TrimSpace(cmd), ":")
 
 Command:
@@ -77,6 +83,13 @@ Command:
 	}
 }
 
+func (s *session) sendResponseMessage(m *responseMessage) {
+	_ = s.WriteMessage(&rawMessage{
+		t:    websocket.TextMessage,
+		data: m.toJSON(),
+	})
+}
+
 func (s *session) sendErrorMessage(error string) {
 	_ = s.WriteMessage(&rawMessage{
 		t:    websocket.TextMessage,
@@ -16,13 +16,15 @@ func (m marshalledRaw) MarshalJSON() ([]byte, error) {
 type eventMessage struct {
 	Type  string        `json:"type"`
 	ReqID uuid.UUID     `json:"reqId"`
+	Seq   uint64        `json:"seq,omitempty"`
 	Body  marshalledRaw `json:"body"`
 }
 
-func makeEventMessage(t string, reqID uuid.UUID, b []byte) (m *eventMessage) {
+func makeEventMessage(t string, reqID uuid.UUID, seq uint64, b []byte) (m *eventMessage) {
 	return &eventMessage{
 		Type:  t,
 		ReqID: reqID,
+		Seq:   seq,
 		Body:  b,
 	}
 }
@@ -48,3 +50,30 @@ func (m *errorMessage) toJSON() (b []byte) {
 	b, _ = json.Marshal(m)
 	return
 }
+
+type responseMessage struct {
+	Type  string      `json:"type"`
+	ReqID string      `json:"reqId"`
+	Body  interface{} `json:"body"`
+}
+
+func makeAckMessage(reqID string, b interface{}) (m *responseMessage) {
+	return &responseMessage{
+		Type:  "ACK",
+		ReqID: reqID,
+		Body:  b,
+	}
+}
+
+func makeNackMessage(reqID string, err *requestError) (m *responseMessage) {
+	return &responseMessage{
+		Type:  "NACK",
+		ReqID: reqID,
+		Body:  err,
+	}
+}
+
+func (m *responseMessage) toJSON() (b []byte) {
+	b, _ = json.Marshal(m)
+	return
+}
@@ -0,0 +1,351 @@
+package ws
+
+import (
+	"errors"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/websocket"
+	jsonIter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+)
+
+const (
+	// maxRequestIDLength リクエストIDの最大長
+	maxRequestIDLength = 64
+	// maxMessageContentLength 投稿できるメッセージ本文の最大文字数
+	maxMessageContentLength = 10000
+	// maxStampCount 一度に押せるスタンプの最大数
+	maxStampCount = 100
+)
+
+// requestType BOTからのリクエストの種類
+type requestType string
+
+const (
+	// requestMessagePost メッセージ投稿
+	requestMessagePost requestType = "MESSAGE_POST"
+	// requestMessageGet メッセージ取得
+	requestMessageGet requestType = "MESSAGE_GET"
+	// requestMessageStampAdd メッセージにスタンプを押す
+	requestMessageStampAdd requestType = "MESSAGE_STAMP_ADD"
+	// requestChannelJoin チャンネル参加
+	requestChannelJoin requestType = "CHANNEL_JOIN"
+	// requestChannelLeave チャンネル退出
+	requestChannelLeave requestType = "CHANNEL_LEAVE"
+	// requestEventsReplay 受信できなかったイベントのリプレイ
+	requestEventsReplay requestType = "EVENTS_REPLAY"
+)
+
+// request BOTからのリクエスト
+type request struct {
+	Type  requestType         `json:"type"`
+	ReqID string              `json:"reqId"`
+	Body  jsonIter.RawMessage `json:"body"`
+}
+
+type messagePostRequestBody struct {
+	ChannelID uuid.UUID `json:"channelId"`
+	Content   string    `json:"content"`
+}
+
+type messageGetRequestBody struct {
+	MessageID uuid.UUID `json:"messageId"`
+}
+
+type messageStampAddRequestBody struct {
+	MessageID uuid.UUID `json:"messageId"`
+	StampID   uuid.UUID `json:"stampId"`
+	Count     int       `json:"count"`
+}
+
+type channelRequestBody struct {
+	ChannelID uuid.UUID `json:"channelId"`
+}
+
+type eventsReplayRequestBody struct {
+	// Since 最後に受信したイベントのシーケンス番号
+	Since uint64 `json:"since"`
+}
+
+type eventsReplayResponseBody struct {
+	LastSeq  uint64 `json:"lastSeq"`
+	Complete bool   `json:"complete"`
+}
+
+// requestError リクエストの処理に失敗した理由
+type requestError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *requestError) Error() string {
+	return e.Message
+}
+
+func badRequest(msg string) *requestError {
+	return &requestError{Code: http.StatusBadRequest, Message: msg}
+}
+
+func forbidden() *requestError {
+	return &requestError{Code: http.StatusForbidden, Message: "you are not permitted to do this request"}
+}
+
+func notFound(msg string) *requestError {
+	return &requestError{Code: http.StatusNotFound, Message: msg}
+}
+
+func internalServerError() *requestError {
+	return &requestError{Code: http.StatusInternalServerError, Message: http.StatusText(http.StatusInternalServerError)}
+}
+
+// requestHandler JSON形式のリクエストを処理し、ACKもしくはNACKを返します
+func (s *session) requestHandler(data []byte) {
+	var req request
+	if err := json.Unmarshal(data, &req); err != nil {
+		s.sendErrorMessage("invalid request: malformed json")
+		return
+	}
+	if len(req.ReqID) == 0 || len(req.ReqID) > maxRequestIDLength {
+		s.sendErrorMessage("invalid request: reqId must be 1-64 characters")
+		return
+	}
+
+	body, err := s.handleRequest(&req)
+	if err != nil {
+		var reqErr *requestError
+		if !errors.As(err, &reqErr) {
+			s.streamer.logger.Error("failed to handle bot ws request",
+				zap.Error(err),
+				zap.String("type", string(req.Type)),
+				zap.Stringer("userID", s.userID))
+			reqErr = internalServerError()
+		}
+		s.sendResponseMessage(makeNackMessage(req.ReqID, reqErr))
+		return
+	}
+	s.sendResponseMessage(makeAckMessage(req.ReqID, body))
+}
+
+func (s *session) handleRequest(req *request) (interface{}, error) {
+	user, err := s.streamer.repo.GetUser(s.userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.Type {
+	case requestMessagePost:
+		var b messagePostRequestBody
+		if err := unmarshalRequestBody(req, &b); err != nil {
+			return nil, err
+		}
+		return s.postMessage(user, &b)
+	case requestMessageGet:
+		var b messageGetRequestBody
+		if err := unmarshalRequestBody(req, &b); err != nil {
+			return nil, err
+		}
+		return s.getMessage(user, &b)
+	case requestMessageStampAdd:
+		var b messageStampAddRequestBody
+		if err := unmarshalRequestBody(req, &b); err != nil {
+			return nil, err
+		}
+		return nil, s.addMessageStamp(user, &b)
+	case requestChannelJoin:
+		var b channelRequestBody
+		if err := unmarshalRequestBody(req, &b); err != nil {
+			return nil, err
+		}
+		return nil, s.joinChannel(user, &b)
+	case requestChannelLeave:
+		var b channelRequestBody
+		if err := unmarshalRequestBody(req, &b); err != nil {
+			return nil, err
+		}
+		return nil, s.leaveChannel(user, &b)
+	case requestEventsReplay:
+		var b eventsReplayRequestBody
+		if err := unmarshalRequestBody(req, &b); err != nil {
+			return nil, err
+		}
+		return s.replayEvents(&b)
+	default:
+		return nil, badRequest("unknown request type: " + string(req.Type))
+	}
+}
+
+func unmarshalRequestBody(req *request, v interface{}) error {
+	if len(req.Body) == 0 {
+		return badRequest("body is required")
+	}
+	if err := json.Unmarshal(req.Body, v); err != nil {
+		return badRequest("invalid body")
+	}
+	return nil
+}
+
+func (s *session) checkPermission(user model.UserInfo, p permission.Permission) error {
+	if !s.streamer.rbac.IsGranted(user.GetRole(), p) {
+		return forbidden()
+	}
+	return nil
+}
+
+func (s *session) checkChannelAccess(userID, channelID uuid.UUID) error {
+	ok, err := s.streamer.cm.IsChannelAccessibleToUser(userID, channelID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return notFound("channel not found")
+	}
+	return nil
+}
+
+func (s *session) getAccessibleMessage(userID, messageID uuid.UUID) (message.Message, error) {
+	m, err := s.streamer.mm.Get(messageID)
+	if err != nil {
+		if err == message.ErrNotFound {
+			return nil, notFound("message not found")
+		}
+		return nil, err
+	}
+	if err := s.checkChannelAccess(userID, m.GetChannelID()); err != nil {
+		if _, ok := err.(*requestError); ok {
+			return nil, notFound("message not found")
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *session) postMessage(user model.UserInfo, b *messagePostRequestBody) (message.Message, error) {
+	if err := s.checkPermission(user, permission.PostMessage); err != nil {
+		return nil, err
+	}
+	if l := utf8.RuneCountInString(b.Content); l == 0 || l > maxMessageContentLength {
+		return nil, badRequest("content must be 1-10000 characters")
+	}
+	if err := s.checkChannelAccess(user.GetID(), b.ChannelID); err != nil {
+		return nil, err
+	}
+
+	m, err := s.streamer.mm.Create(b.ChannelID, user.GetID(), b.Content, nil)
+	if err != nil {
+		if err == message.ErrChannelArchived {
+			return nil, badRequest("this channel has been archived")
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *session) getMessage(user model.UserInfo, b *messageGetRequestBody) (message.Message, error) {
+	if err := s.checkPermission(user, permission.GetMessage); err != nil {
+		return nil, err
+	}
+	return s.getAccessibleMessage(user.GetID(), b.MessageID)
+}
+
+func (s *session) addMessageStamp(user model.UserInfo, b *messageStampAddRequestBody) error {
+	if err := s.checkPermission(user, permission.AddMessageStamp); err != nil {
+		return err
+	}
+	if b.Count == 0 {
+		b.Count = 1
+	}
+	if b.Count < 1 || b.Count > maxStampCount {
+		return badRequest("count must be 1-100")
+	}
+	if _, err := s.getAccessibleMessage(user.GetID(), b.MessageID); err != nil {
+		return err
+	}
+	ok, err := s.streamer.repo.StampExists(b.StampID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return notFound("stamp not found")
+	}
+
+	if _, err := s.streamer.mm.AddStamps(b.MessageID, b.StampID, user.GetID(), b.Count); err != nil {
+		switch err {
+		case message.ErrChannelArchived:
+			return badRequest("the channel of this message has been archived")
+		case message.ErrNotFound:
+			return notFound("message not found")
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *session) getBot() (*model.Bot, error) {
+	b, err := s.streamer.repo.GetBotByBotUserID(s.userID)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return nil, forbidden()
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *session) joinChannel(user model.UserInfo, b *channelRequestBody) error {
+	if err := s.checkPermission(user, permission.BotActionJoinChannel); err != nil {
+		return err
+	}
+	// 公開チャンネルのみ許可
+	if !s.streamer.cm.IsPublicChannel(b.ChannelID) {
+		return badRequest("invalid channel id")
+	}
+	bot, err := s.getBot()
+	if err != nil {
+		return err
+	}
+	return s.streamer.repo.AddBotToChannel(bot.ID, b.ChannelID)
+}
+
+func (s *session) leaveChannel(user model.UserInfo, b *channelRequestBody) error {
+	if err := s.checkPermission(user, permission.BotActionLeaveChannel); err != nil {
+		return err
+	}
+	if b.ChannelID == uuid.Nil {
+		return badRequest("invalid channel id")
+	}
+	bot, err := s.getBot()
+	if err != nil {
+		return err
+	}
+	return s.streamer.repo.RemoveBotFromChannel(bot.ID, b.ChannelID)
+}
+
+func (s *session) replayEvents(b *eventsReplayRequestBody) (*eventsReplayResponseBody, error) {
+	r := s.streamer.getReplayer()
+	if r == nil {
+		return nil, badRequest("event replay is not enabled")
+	}
+
+	lastSeq, complete := r.Replay(s.userID, b.Since, func(t string, reqID uuid.UUID, seq uint64, body []byte) error {
+		// 接続後に配送済みのイベントは再送しない
+		if s.isDelivered(seq) {
+			return nil
+		}
+		return s.WriteMessage(&rawMessage{
+			t:    websocket.TextMessage,
+			data: makeEventMessage(t, reqID, seq, body).toJSON(),
+		})
+	})
+	return &eventsReplayResponseBody{
+		LastSeq:  lastSeq,
+		Complete: complete,
+	}, nil
+}
@@ -0,0 +1,623 @@
+package ws
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	jsonIter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/service/rbac/role"
+	"github.com/traPtitech/traQ/testUtils"
+)
+
+type Repo struct {
+	*mock_repository.MockUserRepository
+	*mock_repository.MockBotRepository
+	testUtils.EmptyTestRepository
+
+	stamps map[uuid.UUID]bool
+}
+
+func (r *Repo) StampExists(id uuid.UUID) (bool, error) {
+	return r.stamps[id], nil
+}
+
+type fakeMessage struct {
+	message.Message
+
+	id        uuid.UUID
+	channelID uuid.UUID
+	text      string
+}
+
+func (m *fakeMessage) GetChannelID() uuid.UUID {
+	return m.channelID
+}
+
+func (m *fakeMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"id":        m.id,
+		"channelId": m.channelID,
+		"content":   m.text,
+	})
+}
+
+// fakeMM Create, AddStampsの呼び出しを記録するmessage.Manager
+type fakeMM struct {
+	message.Manager
+
+	mu       sync.Mutex
+	messages map[uuid.UUID]*fakeMessage
+	archived map[uuid.UUID]bool
+	created  []string
+	stamps   []int
+}
+
+func (mm *fakeMM) Get(id uuid.UUID) (message.Message, error) {
+	m, ok := mm.messages[id]
+	if !ok {
+		return nil, message.ErrNotFound
+	}
+	return m, nil
+}
+
+func (mm *fakeMM) Create(channelID, _ uuid.UUID, content string, _ model.MessageActions) (message.Message, error) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	if mm.archived[channelID] {
+		return nil, message.ErrChannelArchived
+	}
+	mm.created = append(mm.created, content)
+	return &fakeMessage{id: uuid.NewV3(channelID, content), channelID: channelID, text: content}, nil
+}
+
+func (mm *fakeMM) AddStamps(id, stampID, userID uuid.UUID, n int) (*model.MessageStamp, error) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	if mm.archived[mm.messages[id].channelID] {
+		return nil, message.ErrChannelArchived
+	}
+	mm.stamps = append(mm.stamps, n)
+	return &model.MessageStamp{MessageID: id, StampID: stampID, UserID: userID, Count: n}, nil
+}
+
+type fakeReplayer struct {
+	events []uint64
+}
+
+func (r *fakeReplayer) Replay(_ uuid.UUID, since uint64, write ReplayWriter) (lastSeq uint64, complete bool) {
+	lastSeq = since
+	for _, seq := range r.events {
+		if seq <= since {
+			continue
+		}
+		if err := write("PING", uuid.Nil, seq, []byte(`{}`)); err != nil {
+			return lastSeq, false
+		}
+		lastSeq = seq
+	}
+	return lastSeq, true
+}
+
+var (
+	botID            = uuid.NewV3(uuid.Nil, "bot")
+	botUserID        = uuid.NewV3(uuid.Nil, "bot-user")
+	readUserID       = uuid.NewV3(uuid.Nil, "read-user")
+	noPermUserID     = uuid.NewV3(uuid.Nil, "no-perm-user")
+	normalUserID     = uuid.NewV3(uuid.Nil, "normal-user")
+	brokenUserID     = uuid.NewV3(uuid.Nil, "broken-user")
+	publicChannelID  = uuid.NewV3(uuid.Nil, "public")
+	privateChannelID = uuid.NewV3(uuid.Nil, "private")
+	archivedID       = uuid.NewV3(uuid.Nil, "archived")
+	messageID        = uuid.NewV3(uuid.Nil, "message")
+	privateMessageID = uuid.NewV3(uuid.Nil, "private-message")
+	archivedMsgID    = uuid.NewV3(uuid.Nil, "archived-message")
+	stampID          = uuid.NewV3(uuid.Nil, "stamp")
+)
+
+type testResponse struct {
+	Type  string              `json:"type"`
+	ReqID string              `json:"reqId"`
+	Seq   uint64              `json:"seq"`
+	Body  jsonIter.RawMessage `json:"body"`
+}
+
+func setup(t *testing.T) (*Streamer, *Repo, *fakeMM) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := &Repo{
+		MockUserRepository: mock_repository.NewMockUserRepository(ctrl),
+		MockBotRepository:  mock_repository.NewMockBotRepository(ctrl),
+		stamps:             map[uuid.UUID]bool{stampID: true},
+	}
+	users := map[uuid.UUID]string{
+		botUserID:    role.Bot,
+		readUserID:   role.Read,
+		noPermUserID: "none",
+		normalUserID: role.User,
+	}
+	for id, r := range users {
+		repo.MockUserRepository.EXPECT().GetUser(id, false).Return(&model.User{ID: id, Role: r}, nil).AnyTimes()
+	}
+	repo.MockUserRepository.EXPECT().GetUser(brokenUserID, false).Return(nil, repository.ErrNotFound).AnyTimes()
+	repo.MockBotRepository.EXPECT().GetBotByBotUserID(botUserID).Return(&model.Bot{ID: botID, BotUserID: botUserID}, nil).AnyTimes()
+	repo.MockBotRepository.EXPECT().GetBotByBotUserID(gomock.Not(botUserID)).Return(nil, repository.ErrNotFound).AnyTimes()
+
+	cm := mock_channel.NewMockManager(ctrl)
+	cm.EXPECT().IsChannelAccessibleToUser(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_, channelID uuid.UUID) (bool, error) {
+			return channelID != privateChannelID, nil
+		}).AnyTimes()
+	cm.EXPECT().IsPublicChannel(gomock.Any()).
+		DoAndReturn(func(channelID uuid.UUID) bool {
+			return channelID == publicChannelID || channelID == archivedID
+		}).AnyTimes()
+
+	mm := &fakeMM{
+		messages: map[uuid.UUID]*fakeMessage{
+			messageID:        {id: messageID, channelID: publicChannelID, text: "hello"},
+			privateMessageID: {id: privateMessageID, channelID: privateChannelID, text: "secret"},
+			archivedMsgID:    {id: archivedMsgID, channelID: archivedID, text: "old"},
+		},
+		archived: map[uuid.UUID]bool{archivedID: true},
+	}
+	return NewStreamer(nil, nil, repo, cm, mm, testUtils.NewTestRBAC(), zap.NewNop()), repo, mm
+}
+
+// send リクエストを処理させ、送信されたメッセージを返します
+func send(t *testing.T, s *Streamer, userID uuid.UUID, req string) testResponse {
+	t.Helper()
+	sess := newSession(userID, s, nil)
+	sess.commandHandler(req)
+	require.Len(t, sess.send, 1)
+	msg := <-sess.send
+	var res testResponse
+	require.NoError(t, json.Unmarshal(msg.data, &res))
+	return res
+}
+
+func makeRequest(t requestType, body string) string {
+	return `{"type":"` + string(t) + `","reqId":"req","body":` + body + `}`
+}
+
+func assertAck(t *testing.T, res testResponse) {
+	t.Helper()
+	assert.Equal(t, "ACK", res.Type, string(res.Body))
+	assert.Equal(t, "req", res.ReqID)
+}
+
+func assertNack(t *testing.T, res testResponse, code int, msg string) {
+	t.Helper()
+	assert.Equal(t, "NACK", res.Type)
+	assert.Equal(t, "req", res.ReqID)
+	var e requestError
+	require.NoError(t, json.Unmarshal(res.Body, &e))
+	assert.Equal(t, code, e.Code)
+	if msg != "" {
+		assert.Equal(t, msg, e.Message)
+	}
+}
+
+func TestSession_requestHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("malformed json", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, `{"type":"MESSAGE_GET",`)
+		assert.Equal(t, "ERROR", res.Type)
+		assert.Equal(t, `"invalid request: malformed json"`, string(res.Body))
+	})
+
+	t.Run("empty reqId", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, `{"type":"MESSAGE_GET","body":{}}`)
+		assert.Equal(t, "ERROR", res.Type)
+		assert.Equal(t, `"invalid request: reqId must be 1-64 characters"`, string(res.Body))
+	})
+
+	t.Run("too long reqId", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, `{"type":"MESSAGE_GET","reqId":"`+strings.Repeat("a", 65)+`","body":{}}`)
+		assert.Equal(t, "ERROR", res.Type)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, makeRequest("UNKNOWN", `{}`))
+		assertNack(t, res, 400, "unknown request type: UNKNOWN")
+	})
+
+	t.Run("missing body", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, `{"type":"MESSAGE_GET","reqId":"req"}`)
+		assertNack(t, res, 400, "body is required")
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestMessageGet, `{"messageId":"invalid"}`))
+		assertNack(t, res, 400, "invalid body")
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, brokenUserID, makeRequest(requestMessageGet, `{}`))
+		assertNack(t, res, 500, "Internal Server Error")
+	})
+}
+
+func TestSession_requestHandler_ResponseShape(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ACK", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+		sess := newSession(botUserID, s, nil)
+
+		sess.commandHandler(makeRequest(requestMessageStampAdd, `{"messageId":"`+messageID.String()+`","stampId":"`+stampID.String()+`"}`))
+		require.Len(t, sess.send, 1)
+		assert.JSONEq(t, `{"type":"ACK","reqId":"req","body":null}`, string((<-sess.send).data))
+	})
+
+	t.Run("NACK", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+		sess := newSession(readUserID, s, nil)
+
+		sess.commandHandler(makeRequest(requestMessagePost, `{"channelId":"`+publicChannelID.String()+`","content":"a"}`))
+		require.Len(t, sess.send, 1)
+		assert.JSONEq(t, `{"type":"NACK","reqId":"req","body":{"code":403,"message":"you are not permitted to do this request"}}`, string((<-sess.send).data))
+	})
+}
+
+func TestSession_postMessage(t *testing.T) {
+	t.Parallel()
+
+	body := func(channelID uuid.UUID, content string) string {
+		return `{"channelId":"` + channelID.String() + `","content":"` + content + `"}`
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		s, _, mm := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestMessagePost, body(publicChannelID, "hello")))
+		assertAck(t, res)
+		var m map[string]interface{}
+		require.NoError(t, json.Unmarshal(res.Body, &m))
+		assert.Equal(t, publicChannelID.String(), m["channelId"])
+		assert.Equal(t, "hello", m["content"])
+		assert.Equal(t, []string{"hello"}, mm.created)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		s, _, mm := setup(t)
+
+		res := send(t, s, readUserID, makeRequest(requestMessagePost, body(publicChannelID, "hello")))
+		assertNack(t, res, 403, "")
+		assert.Empty(t, mm.created)
+	})
+
+	t.Run("empty content", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestMessagePost, body(publicChannelID, "")))
+		assertNack(t, res, 400, "content must be 1-10000 characters")
+	})
+
+	t.Run("too long content", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestMessagePost, body(publicChannelID, strings.Repeat("あ", 10001))))
+		assertNack(t, res, 400, "content must be 1-10000 characters")
+	})
+
+	t.Run("inaccessible channel", func(t *testing.T) {
+		t.Parallel()
+		s, _, mm := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestMessagePost, body(privateChannelID, "hello")))
+		assertNack(t, res, 404, "channel not found")
+		assert.Empty(t, mm.created)
+	})
+
+	t.Run("archived channel", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestMessagePost, body(archivedID, "hello")))
+		assertNack(t, res, 400, "this channel has been archived")
+	})
+}
+
+func TestSession_getMessage(t *testing.T) {
+	t.Parallel()
+
+	body := func(id uuid.UUID) string {
+		return `{"messageId":"` + id.String() + `"}`
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, readUserID, makeRequest(requestMessageGet, body(messageID)))
+		assertAck(t, res)
+		var m map[string]interface{}
+		require.NoError(t, json.Unmarshal(res.Body, &m))
+		assert.Equal(t, messageID.String(), m["id"])
+		assert.Equal(t, "hello", m["content"])
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, noPermUserID, makeRequest(requestMessageGet, body(messageID)))
+		assertNack(t, res, 403, "")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestMessageGet, body(uuid.Must(uuid.NewV4()))))
+		assertNack(t, res, 404, "message not found")
+	})
+
+	t.Run("inaccessible message", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestMessageGet, body(privateMessageID)))
+		assertNack(t, res, 404, "message not found")
+	})
+}
+
+func TestSession_addMessageStamp(t *testing.T) {
+	t.Parallel()
+
+	body := func(mID, sID uuid.UUID, count int) string {
+		return `{"messageId":"` + mID.String() + `","stampId":"` + sID.String() + `","count":` + strconv.Itoa(count) + `}`
+	}
+
+	t.Run("success (default count)", func(t *testing.T) {
+		t.Parallel()
+		s, _, mm := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestMessageStampAdd, `{"messageId":"`+messageID.String()+`","stampId":"`+stampID.String()+`"}`))
+		assertAck(t, res)
+		assert.Equal(t, []int{1}, mm.stamps)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		s, _, mm := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestMessageStampAdd, body(messageID, stampID, 100)))
+		assertAck(t, res)
+		assert.Equal(t, []int{100}, mm.stamps)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		s, _, mm := setup(t)
+
+		res := send(t, s, readUserID, makeRequest(requestMessageStampAdd, body(messageID, stampID, 1)))
+		assertNack(t, res, 403, "")
+		assert.Empty(t, mm.stamps)
+	})
+
+	t.Run("too many count", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestMessageStampAdd, body(messageID, stampID, 101)))
+		assertNack(t, res, 400, "count must be 1-100")
+	})
+
+	t.Run("negative count", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestMessageStampAdd, body(messageID, stampID, -1)))
+		assertNack(t, res, 400, "count must be 1-100")
+	})
+
+	t.Run("message not found", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestMessageStampAdd, body(uuid.Must(uuid.NewV4()), stampID, 1)))
+		assertNack(t, res, 404, "message not found")
+	})
+
+	t.Run("inaccessible message", func(t *testing.T) {
+		t.Parallel()
+		s, _, mm := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestMessageStampAdd, body(privateMessageID, stampID, 1)))
+		assertNack(t, res, 404, "message not found")
+		assert.Empty(t, mm.stamps)
+	})
+
+	t.Run("stamp not found", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestMessageStampAdd, body(messageID, uuid.Must(uuid.NewV4()), 1)))
+		assertNack(t, res, 404, "stamp not found")
+	})
+
+	t.Run("archived channel", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestMessageStampAdd, body(archivedMsgID, stampID, 1)))
+		assertNack(t, res, 400, "the channel of this message has been archived")
+	})
+}
+
+func TestSession_joinChannel(t *testing.T) {
+	t.Parallel()
+
+	body := func(id uuid.UUID) string {
+		return `{"channelId":"` + id.String() + `"}`
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		s, repo, _ := setup(t)
+		repo.MockBotRepository.EXPECT().AddBotToChannel(botID, publicChannelID).Return(nil).Times(1)
+
+		res := send(t, s, botUserID, makeRequest(requestChannelJoin, body(publicChannelID)))
+		assertAck(t, res)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, readUserID, makeRequest(requestChannelJoin, body(publicChannelID)))
+		assertNack(t, res, 403, "")
+	})
+
+	t.Run("not public channel", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestChannelJoin, body(privateChannelID)))
+		assertNack(t, res, 400, "invalid channel id")
+	})
+
+	t.Run("not bot", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, normalUserID, makeRequest(requestChannelJoin, body(publicChannelID)))
+		assertNack(t, res, 403, "")
+	})
+}
+
+func TestSession_leaveChannel(t *testing.T) {
+	t.Parallel()
+
+	body := func(id uuid.UUID) string {
+		return `{"channelId":"` + id.String() + `"}`
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		s, repo, _ := setup(t)
+		repo.MockBotRepository.EXPECT().RemoveBotFromChannel(botID, privateChannelID).Return(nil).Times(1)
+
+		res := send(t, s, botUserID, makeRequest(requestChannelLeave, body(privateChannelID)))
+		assertAck(t, res)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, readUserID, makeRequest(requestChannelLeave, body(publicChannelID)))
+		assertNack(t, res, 403, "")
+	})
+
+	t.Run("nil channel id", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestChannelLeave, body(uuid.Nil)))
+		assertNack(t, res, 400, "invalid channel id")
+	})
+
+	t.Run("not bot", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, normalUserID, makeRequest(requestChannelLeave, body(publicChannelID)))
+		assertNack(t, res, 403, "")
+	})
+}
+
+func TestSession_replayEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not enabled", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+
+		res := send(t, s, botUserID, makeRequest(requestEventsReplay, `{"since":0}`))
+		assertNack(t, res, 400, "event replay is not enabled")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+		s.SetReplayer(&fakeReplayer{events: []uint64{1, 2, 3}})
+		sess := newSession(botUserID, s, nil)
+
+		sess.commandHandler(makeRequest(requestEventsReplay, `{"since":1}`))
+		require.Len(t, sess.send, 3)
+		for _, seq := range []uint64{2, 3} {
+			var e testResponse
+			require.NoError(t, json.Unmarshal((<-sess.send).data, &e))
+			assert.Equal(t, "PING", e.Type)
+			assert.Equal(t, seq, e.Seq)
+		}
+		var res testResponse
+		require.NoError(t, json.Unmarshal((<-sess.send).data, &res))
+		assertAck(t, res)
+		assert.JSONEq(t, `{"lastSeq":3,"complete":true}`, string(res.Body))
+	})
+
+	t.Run("skip delivered events", func(t *testing.T) {
+		t.Parallel()
+		s, _, _ := setup(t)
+		s.SetReplayer(&fakeReplayer{events: []uint64{1, 2, 3, 4}})
+		sess := newSession(botUserID, s, nil)
+		sess.delivered(3)
+		sess.delivered(4)
+
+		sess.commandHandler(makeRequest(requestEventsReplay, `{"since":0}`))
+		require.Len(t, sess.send, 3)
+		for _, seq := range []uint64{1, 2} {
+			var e testResponse
+			require.NoError(t, json.Unmarshal((<-sess.send).data, &e))
+			assert.Equal(t, seq, e.Seq)
+		}
+		var res testResponse
+		require.NoError(t, json.Unmarshal((<-sess.send).data, &res))
+		assertAck(t, res)
+		assert.JSONEq(t, `{"lastSeq":4,"complete":true}`, string(res.Body))
+	})
+}
@@ -20,6 +20,10 @@ type session struct {
 	send      chan *rawMessage
 	closed    bool
 	closeWait *sync.Cond
+
+	seqMu    sync.Mutex
+	firstSeq uint64 // このセッションに最初に配送したイベントのシーケンス番号
+	lastSeq  uint64 // このセッションに最後に配送したイベントのシーケンス番号
 }
 
 func newSession(userID uuid.UUID, streamer *Streamer, conn *websocket.Conn) *session {
@@ -108,6 +112,27 @@ func (s *session) WriteMessage(msg *rawMessage) (err error) {
 	}
 }
 
+// delivered 配送したイベントのシーケンス番号を記録します
+func (s *session) delivered(seq uint64) {
+	s.seqMu.Lock()
+	defer s.seqMu.Unlock()
+	if s.firstSeq == 0 {
+		s.firstSeq = seq
+	}
+	if seq > s.lastSeq {
+		s.lastSeq = seq
+	}
+}
+
+// isDelivered 指定したシーケンス番号のイベントをこのセッションに配送済みかどうか
+//
+// イベントはシーケンス番号順に配送されるため、最初と最後に配送したシーケンス番号の間のイベントは配送済みです
+func (s *session) isDelivered(seq uint64) bool {
+	s.seqMu.Lock()
+	defer s.seqMu.Unlock()
+	return s.firstSeq != 0 && s.firstSeq <= seq && seq <= s.lastSeq
+}
+
 func (s *session) write(messageType int, data []byte) error {
 	_ = s.conn.SetWriteDeadline(time.Now().Add(writeWait))
 	return s.conn.WriteMessage(messageType, data)
@@ -11,7 +11,11 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/router/extension/ctxKey"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/service/rbac"
 	"github.com/traPtitech/traQ/service/webrtcv3"
 )
 
@@ -22,10 +26,26 @@ var (
 	ErrBufferIsFull = errors.New("buffer is full")
 )
 
+// ReplayWriter リプレイするイベントをセッションに書き込む関数
+type ReplayWriter func(t string, reqID uuid.UUID, seq uint64, body []byte) error
+
+// Replayer BOTが受信できなかったイベントをリプレイするもの
+type Replayer interface {
+	// Replay 指定したBOTのsinceより後のシーケンス番号のイベントを古い順にwriteに渡します
+	//
+	// 最後に渡したイベントのシーケンス番号と、欠落無くリプレイできたかどうかを返します
+	Replay(botUserID uuid.UUID, since uint64, write ReplayWriter) (lastSeq uint64, complete bool)
+}
+
 // Streamer WebSocketストリーマー
 type Streamer struct {
 	hub      *hub.Hub
 	webrtc   *webrtcv3.Manager
+	repo     repository.Repository
+	cm       channel.Manager
+	mm       message.Manager
+	rbac     rbac.RBAC
+	replayer Replayer
 	logger   *zap.Logger
 	sessions map[uuid.UUID][]*session
 	closed   bool
@@ -33,10 +53,14 @@ type Streamer struct {
 }
 
 // NewStreamer WebSocketストリーマーを生成し起動します
-func NewStreamer(hub *hub.Hub, webrtc *webrtcv3.Manager, logger *zap.Logger) *Streamer {
+func NewStreamer(hub *hub.Hub, webrtc *webrtcv3.Manager, repo repository.Repository, cm channel.Manager, mm message.Manager, rbac rbac.RBAC, logger *zap.Logger) *Streamer {
 	h := &Streamer{
 		hub:      hub,
 		webrtc:   webrtc,
+		repo:     repo,
+		cm:       cm,
+		mm:       mm,
+		rbac:     rbac,
 		logger:   logger.Named("bot.ws"),
 		sessions: make(map[uuid.UUID][]*session),
 		closed:   false,
@@ -71,11 +95,26 @@ func filterSession(sessions []*session, target *session) []*session {
 	return s
 }
 
-// WriteMessage 指定したセッションにメッセージを書き込みます
-func (s *Streamer) WriteMessage(t string, reqID uuid.UUID, body []byte, botUserID uuid.UUID) (errs []error, attempted bool) {
+// SetReplayer イベントのリプレイを行うものを設定します
+func (s *Streamer) SetReplayer(r Replayer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replayer = r
+}
+
+func (s *Streamer) getReplayer() Replayer {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.replayer
+}
+
+// WriteMessage 指定したBOTの全セッションにイベントを書き込みます
+//
+// seqが0の場合、シーケンス番号は付与されません
+func (s *Streamer) WriteMessage(t string, reqID uuid.UUID, seq uint64, body []byte, botUserID uuid.UUID) (errs []error, attempted bool) {
 	m := &rawMessage{
 		t:    websocket.TextMessage,
-		data: makeEventMessage(t, reqID, body).toJSON(),
+		data: makeEventMessage(t, reqID, seq, body).toJSON(),
 	}
 	s.mu.RLock()
 	for _, session := range s.sessions[botUserID] {
@@ -88,6 +127,8 @@ func (s *Streamer) WriteMessage(t string, reqID uuid.UUID, body []byte, botUserI
 					zap.Any("body", body),
 					zap.Stringer("userID", session.userID))
 			}
+		} else if seq > 0 {
+			session.delivered(seq)
 		}
 		attempted = true
 	}
@@ -0,0 +1,298 @@
+package digest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/counter"
+	"github.com/traPtitech/traQ/service/variable"
+)
+
+const (
+	notifierInterval = time.Minute
+	// maxDigestChannels 1通のメールに記載するチャンネルの最大数
+	maxDigestChannels = 20
+)
+
+// Notifier 未読通知メールダイジェストの送信ワーカー
+//
+// 通知対象(noticeable)の未読が付いたユーザーを記録し、ユーザーごとの送信頻度の間隔が経過した時点で
+// オフラインであれば、未読チャンネルの一覧をメールでまとめて送信します
+type Notifier struct {
+	repo   repository.Repository
+	cm     channel.Manager
+	hub    *hub.Hub
+	oc     *counter.OnlineCounter
+	sender Sender
+	origin string
+	l      *zap.Logger
+
+	// pending 未送信の通知対象未読があるユーザーと、その最初の未読が付いた日時
+	pending   map[uuid.UUID]time.Time
+	pendingMu sync.Mutex
+
+	sub     hub.Subscription
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// NewNotifier 未読通知メールダイジェストの送信ワーカーを生成します
+//
+// configが無効な場合、Startしても何もしません
+func NewNotifier(repo repository.Repository, cm channel.Manager, hub *hub.Hub, oc *counter.OnlineCounter, logger *zap.Logger, config Config, origin variable.ServerOriginString) (*Notifier, error) {
+	n := &Notifier{
+		repo:    repo,
+		cm:      cm,
+		hub:     hub,
+		oc:      oc,
+		origin:  string(origin),
+		l:       logger.Named("email_digest"),
+		pending: map[uuid.UUID]time.Time{},
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if config.Valid() {
+		s, err := NewSMTPSender(config)
+		if err != nil {
+			return nil, err
+		}
+		n.sender = s
+	}
+	return n, nil
+}
+
+// Start 送信ワーカーを開始します
+func (n *Notifier) Start() {
+	if n.sender == nil {
+		close(n.stopped)
+		return
+	}
+
+	n.sub = n.hub.Subscribe(100, event.MessageUnread)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case e, ok := <-n.sub.Receiver:
+				if !ok {
+					return
+				}
+				if e.Fields["noticeable"].(bool) {
+					n.addPending(e.Fields["user_id"].(uuid.UUID), time.Now())
+				}
+			case <-n.done:
+				return
+			}
+		}
+	}()
+	// メール送信に時間がかかってもイベントの受信(MessageUnreadの発行元)を止めないよう、別のgoroutineで送信する
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(notifierInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				n.sendDue(now)
+			case <-n.done:
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(n.stopped)
+	}()
+	n.l.Info("email digest notifier started")
+}
+
+// Shutdown 送信ワーカーを停止します
+func (n *Notifier) Shutdown() {
+	if n.sender != nil {
+		n.hub.Unsubscribe(n.sub)
+		close(n.done)
+	}
+	<-n.stopped
+}
+
+func (n *Notifier) addPending(userID uuid.UUID, at time.Time) {
+	n.pendingMu.Lock()
+	defer n.pendingMu.Unlock()
+	if _, ok := n.pending[userID]; !ok {
+		n.pending[userID] = at
+	}
+}
+
+func (n *Notifier) removePending(userID uuid.UUID) {
+	n.pendingMu.Lock()
+	defer n.pendingMu.Unlock()
+	delete(n.pending, userID)
+}
+
+// sendDue 送信間隔が経過したユーザーにダイジェストを送信します
+func (n *Notifier) sendDue(now time.Time) {
+	n.pendingMu.Lock()
+	pending := make(map[uuid.UUID]time.Time, len(n.pending))
+	for id, at := range n.pending {
+		pending[id] = at
+	}
+	n.pendingMu.Unlock()
+
+	for id, since := range pending {
+		if n.process(id, since, now) {
+			n.removePending(id)
+		}
+	}
+}
+
+// process 指定したユーザーのダイジェストを必要に応じて送信します
+//
+// 送信した、または送信する必要がなくなった場合にtrueを返します
+func (n *Notifier) process(userID uuid.UUID, since, now time.Time) bool {
+	logger := n.l.With(zap.Stringer("userID", userID))
+
+	settings, err := n.repo.GetUserSettings(userID)
+	if err != nil {
+		logger.Error("failed to GetUserSettings", zap.Error(err))
+		return false
+	}
+	interval := settings.EmailDigestFrequency.Interval()
+	if interval == 0 {
+		return true // オプトアウト済み
+	}
+	if now.Before(since.Add(interval)) {
+		return false
+	}
+	if n.oc.IsOnline(userID) {
+		return false // オンラインのユーザーには送らない
+	}
+
+	user, err := n.repo.GetUser(userID, true)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return true
+		}
+		logger.Error("failed to GetUser", zap.Error(err))
+		return false
+	}
+	if !user.IsActive() || user.IsBot() {
+		return true
+	}
+	to, err := n.resolveEmail(user)
+	if err != nil {
+		logger.Error("failed to resolve email address", zap.Error(err))
+		return false
+	}
+	if len(to) == 0 {
+		return true
+	}
+
+	unreads, err := n.repo.GetUserUnreadChannels(userID)
+	if err != nil {
+		logger.Error("failed to GetUserUnreadChannels", zap.Error(err))
+		return false
+	}
+	targets := make([]*repository.UserUnreadChannel, 0, len(unreads))
+	for _, u := range unreads {
+		if !u.Noticeable {
+			continue
+		}
+		if settings.EmailDigestSentAt.Valid && !u.UpdatedAt.After(settings.EmailDigestSentAt.Time) {
+			continue // 前回のダイジェストで通知済み
+		}
+		targets = append(targets, u)
+	}
+	if len(targets) == 0 {
+		return true // 既に既読になっている
+	}
+
+	subject, body := n.buildDigest(user, targets)
+	if err := n.sender.Send(to, subject, body); err != nil {
+		logger.Error("failed to send email digest", zap.Error(err))
+		return false
+	}
+	if err := n.repo.SetEmailDigestSentAt(userID, now); err != nil {
+		logger.Error("failed to SetEmailDigestSentAt", zap.Error(err))
+	}
+	return true
+}
+
+// resolveEmail ユーザーの通知先メールアドレスを返します
+//
+// プロフィールのメールアドレスを優先し、未設定の場合は外部アカウントのメールアドレスを使用します
+func (n *Notifier) resolveEmail(user model.UserInfo) (string, error) {
+	if email := user.GetEmail(); len(email) > 0 {
+		return email, nil
+	}
+	accounts, err := n.repo.GetLinkedExternalUserAccounts(user.GetID())
+	if err != nil {
+		return "", err
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].ProviderName < accounts[j].ProviderName })
+	for _, a := range accounts {
+		if email := a.GetEmail(); len(email) > 0 {
+			return email, nil
+		}
+	}
+	return "", nil
+}
+
+// buildDigest ダイジェストメールの件名と本文を生成します
+func (n *Notifier) buildDigest(user model.UserInfo, unreads []*repository.UserUnreadChannel) (subject, body string) {
+	sort.Slice(unreads, func(i, j int) bool { return unreads[i].UpdatedAt.After(unreads[j].UpdatedAt) })
+
+	total := 0
+	for _, u := range unreads {
+		total += u.Count
+	}
+	subject = fmt.Sprintf("[traQ] %d件の未読の通知があります", total)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%sさん\n\ntraQに%d件の未読の通知があります。\n", user.GetResponseDisplayName(), total)
+	for i, u := range unreads {
+		if i >= maxDigestChannels {
+			fmt.Fprintf(&b, "\nほか%dチャンネル\n", len(unreads)-maxDigestChannels)
+			break
+		}
+		title, path := n.channelTitleAndPath(user.GetID(), u.ChannelID)
+		fmt.Fprintf(&b, "\n%s (%d件)\n%s%s\n", title, u.Count, n.origin, path)
+	}
+	b.WriteString("\n--\nこのメールの送信頻度は traQ の設定から変更できます。\n")
+	return subject, b.String()
+}
+
+// channelTitleAndPath チャンネルの表示名とクライアント上のパスを返します
+func (n *Notifier) channelTitleAndPath(userID, channelID uuid.UUID) (title, path string) {
+	tree := n.cm.PublicChannelTree()
+	if tree.IsChannelPresent(channelID) {
+		p := tree.GetChannelPath(channelID)
+		return "#" + p, "/channels/" + p
+	}
+
+	// DM
+	members, err := n.cm.GetDMChannelMembers(channelID)
+	if err == nil {
+		for _, id := range members {
+			if id == userID && len(members) > 1 {
+				continue
+			}
+			if u, err := n.repo.GetUser(id, false); err == nil {
+				return "@" + u.GetResponseDisplayName(), "/users/" + u.GetName()
+			}
+		}
+	}
+	return "ダイレクトメッセージ", "/"
+}
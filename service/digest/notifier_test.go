@@ -0,0 +1,282 @@
+package digest
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/service/counter"
+	"github.com/traPtitech/traQ/testUtils"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+type fakeRepo struct {
+	testUtils.EmptyTestRepository
+
+	users    map[uuid.UUID]*model.User
+	settings map[uuid.UUID]*model.UserSettings
+	accounts map[uuid.UUID][]*model.ExternalProviderUser
+	unreads  map[uuid.UUID][]*repository.UserUnreadChannel
+	sentAt   map[uuid.UUID]time.Time
+}
+
+func (r *fakeRepo) GetUser(id uuid.UUID, _ bool) (model.UserInfo, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return u, nil
+}
+
+func (r *fakeRepo) GetUserSettings(userID uuid.UUID) (*model.UserSettings, error) {
+	if s, ok := r.settings[userID]; ok {
+		return s, nil
+	}
+	return &model.UserSettings{UserID: userID, EmailDigestFrequency: model.EmailDigestDaily}, nil
+}
+
+func (r *fakeRepo) SetEmailDigestSentAt(userID uuid.UUID, sentAt time.Time) error {
+	r.sentAt[userID] = sentAt
+	return nil
+}
+
+func (r *fakeRepo) GetLinkedExternalUserAccounts(userID uuid.UUID) ([]*model.ExternalProviderUser, error) {
+	return r.accounts[userID], nil
+}
+
+func (r *fakeRepo) GetUserUnreadChannels(userID uuid.UUID) ([]*repository.UserUnreadChannel, error) {
+	return r.unreads[userID], nil
+}
+
+type sentMail struct {
+	to, subject, body string
+}
+
+type fakeSender struct {
+	mu   sync.Mutex
+	sent []sentMail
+	err  error
+}
+
+func (s *fakeSender) Send(to, subject, body string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, sentMail{to: to, subject: subject, body: body})
+	return nil
+}
+
+func newUser(name, email string) *model.User {
+	return &model.User{
+		ID:      uuid.NewV3(uuid.Nil, name),
+		Name:    name,
+		Status:  model.UserAccountStatusActive,
+		Profile: &model.UserProfile{Email: email},
+	}
+}
+
+func setup(t *testing.T) (*Notifier, *fakeRepo, *fakeSender, *hub.Hub) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	cm := mock_channel.NewMockManager(ctrl)
+	tree := mock_channel.NewMockTree(ctrl)
+	publicCh := uuid.NewV3(uuid.Nil, "general")
+	cm.EXPECT().PublicChannelTree().Return(tree).AnyTimes()
+	tree.EXPECT().IsChannelPresent(gomock.Any()).DoAndReturn(func(id uuid.UUID) bool { return id == publicCh }).AnyTimes()
+	tree.EXPECT().GetChannelPath(publicCh).Return("general").AnyTimes()
+
+	repo := &fakeRepo{
+		users:    map[uuid.UUID]*model.User{},
+		settings: map[uuid.UUID]*model.UserSettings{},
+		accounts: map[uuid.UUID][]*model.ExternalProviderUser{},
+		unreads:  map[uuid.UUID][]*repository.UserUnreadChannel{},
+		sentAt:   map[uuid.UUID]time.Time{},
+	}
+	h := hub.New()
+	n, err := NewNotifier(repo, cm, h, counter.NewOnlineCounter(h), zap.NewNop(), Config{}, "https://q.example.com")
+	require.NoError(t, err)
+	s := &fakeSender{}
+	n.sender = s
+	return n, repo, s, h
+}
+
+func TestNotifier_process(t *testing.T) {
+	t.Parallel()
+
+	publicCh := uuid.NewV3(uuid.Nil, "general")
+	now := time.Now()
+	since := now.Add(-25 * time.Hour)
+
+	t.Run("not due", func(t *testing.T) {
+		t.Parallel()
+		n, repo, s, _ := setup(t)
+		u := newUser("user", "user@example.com")
+		repo.users[u.ID] = u
+		repo.unreads[u.ID] = []*repository.UserUnreadChannel{{ChannelID: publicCh, Count: 1, Noticeable: true, UpdatedAt: now}}
+
+		assert.False(t, n.process(u.ID, now.Add(-time.Hour), now))
+		assert.Len(t, s.sent, 0)
+	})
+
+	t.Run("hourly", func(t *testing.T) {
+		t.Parallel()
+		n, repo, s, _ := setup(t)
+		u := newUser("user", "user@example.com")
+		repo.users[u.ID] = u
+		repo.settings[u.ID] = &model.UserSettings{UserID: u.ID, EmailDigestFrequency: model.EmailDigestHourly}
+		repo.unreads[u.ID] = []*repository.UserUnreadChannel{{ChannelID: publicCh, Count: 1, Noticeable: true, UpdatedAt: now}}
+
+		assert.True(t, n.process(u.ID, now.Add(-time.Hour), now))
+		assert.Len(t, s.sent, 1)
+	})
+
+	t.Run("opted out", func(t *testing.T) {
+		t.Parallel()
+		n, repo, s, _ := setup(t)
+		u := newUser("user", "user@example.com")
+		repo.users[u.ID] = u
+		repo.settings[u.ID] = &model.UserSettings{UserID: u.ID, EmailDigestFrequency: model.EmailDigestOff}
+		repo.unreads[u.ID] = []*repository.UserUnreadChannel{{ChannelID: publicCh, Count: 1, Noticeable: true, UpdatedAt: now}}
+
+		assert.True(t, n.process(u.ID, since, now))
+		assert.Len(t, s.sent, 0)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		n, repo, s, _ := setup(t)
+		u := newUser("user", "user@example.com")
+		other := newUser("other", "")
+		dm := uuid.NewV3(uuid.Nil, "dm")
+		repo.users[u.ID] = u
+		repo.users[other.ID] = other
+		repo.unreads[u.ID] = []*repository.UserUnreadChannel{
+			{ChannelID: publicCh, Count: 3, Noticeable: true, UpdatedAt: now.Add(-2 * time.Hour)},
+			{ChannelID: dm, Count: 2, Noticeable: true, UpdatedAt: now.Add(-time.Hour)},
+			{ChannelID: uuid.NewV3(uuid.Nil, "random"), Count: 10, Noticeable: false, UpdatedAt: now},
+		}
+		n.cm.(*mock_channel.MockManager).EXPECT().GetDMChannelMembers(dm).Return([]uuid.UUID{u.ID, other.ID}, nil)
+
+		assert.True(t, n.process(u.ID, since, now))
+		if assert.Len(t, s.sent, 1) {
+			m := s.sent[0]
+			assert.Equal(t, "user@example.com", m.to)
+			assert.Equal(t, "[traQ] 5件の未読の通知があります", m.subject)
+			assert.Contains(t, m.body, "#general (3件)\nhttps://q.example.com/channels/general\n")
+			assert.Contains(t, m.body, "@other (2件)\nhttps://q.example.com/users/other\n")
+			assert.NotContains(t, m.body, "10件")
+			assert.Less(t, strings.Index(m.body, "@other"), strings.Index(m.body, "#general")) // 新しい順
+		}
+		assert.Equal(t, now, repo.sentAt[u.ID])
+	})
+
+	t.Run("already notified", func(t *testing.T) {
+		t.Parallel()
+		n, repo, s, _ := setup(t)
+		u := newUser("user", "user@example.com")
+		repo.users[u.ID] = u
+		repo.settings[u.ID] = &model.UserSettings{UserID: u.ID, EmailDigestFrequency: model.EmailDigestDaily, EmailDigestSentAt: optional.TimeFrom(now.Add(-time.Hour))}
+		repo.unreads[u.ID] = []*repository.UserUnreadChannel{{ChannelID: publicCh, Count: 1, Noticeable: true, UpdatedAt: now.Add(-2 * time.Hour)}}
+
+		assert.True(t, n.process(u.ID, since, now))
+		assert.Len(t, s.sent, 0)
+	})
+
+	t.Run("external account email", func(t *testing.T) {
+		t.Parallel()
+		n, repo, s, _ := setup(t)
+		u := newUser("user", "")
+		repo.users[u.ID] = u
+		repo.accounts[u.ID] = []*model.ExternalProviderUser{
+			{UserID: u.ID, ProviderName: "traq", Extra: model.JSON{"externalName": "user"}},
+			{UserID: u.ID, ProviderName: "google", Extra: model.JSON{"externalName": "user@gmail.example.com"}},
+		}
+		repo.unreads[u.ID] = []*repository.UserUnreadChannel{{ChannelID: publicCh, Count: 1, Noticeable: true, UpdatedAt: now}}
+
+		assert.True(t, n.process(u.ID, since, now))
+		if assert.Len(t, s.sent, 1) {
+			assert.Equal(t, "user@gmail.example.com", s.sent[0].to)
+		}
+	})
+
+	t.Run("no email", func(t *testing.T) {
+		t.Parallel()
+		n, repo, s, _ := setup(t)
+		u := newUser("user", "")
+		repo.users[u.ID] = u
+		repo.unreads[u.ID] = []*repository.UserUnreadChannel{{ChannelID: publicCh, Count: 1, Noticeable: true, UpdatedAt: now}}
+
+		assert.True(t, n.process(u.ID, since, now))
+		assert.Len(t, s.sent, 0)
+	})
+
+	t.Run("send failed", func(t *testing.T) {
+		t.Parallel()
+		n, repo, s, _ := setup(t)
+		s.err = errors.New("smtp error")
+		u := newUser("user", "user@example.com")
+		repo.users[u.ID] = u
+		repo.unreads[u.ID] = []*repository.UserUnreadChannel{{ChannelID: publicCh, Count: 1, Noticeable: true, UpdatedAt: now}}
+
+		assert.False(t, n.process(u.ID, since, now)) // 次回再試行する
+		assert.NotContains(t, repo.sentAt, u.ID)
+	})
+
+	t.Run("online", func(t *testing.T) {
+		t.Parallel()
+		n, repo, s, h := setup(t)
+		u := newUser("user", "user@example.com")
+		repo.users[u.ID] = u
+		repo.unreads[u.ID] = []*repository.UserUnreadChannel{{ChannelID: publicCh, Count: 1, Noticeable: true, UpdatedAt: now}}
+
+		// OnlineCounterの購読開始を待つため、オンラインになるまで接続イベントを送る
+		require.Eventually(t, func() bool {
+			if n.oc.IsOnline(u.ID) {
+				return true
+			}
+			h.Publish(hub.Message{Name: event.WSConnected, Fields: hub.Fields{"user_id": u.ID}})
+			return false
+		}, time.Second, 10*time.Millisecond)
+
+		assert.False(t, n.process(u.ID, since, now))
+		assert.Len(t, s.sent, 0)
+	})
+}
+
+func TestNotifier_Start(t *testing.T) {
+	t.Parallel()
+	n, _, _, h := setup(t)
+
+	userID := uuid.NewV3(uuid.Nil, "user")
+	otherID := uuid.NewV3(uuid.Nil, "other")
+	n.Start()
+	h.Publish(hub.Message{Name: event.MessageUnread, Fields: hub.Fields{"message_id": uuid.Nil, "user_id": userID, "noticeable": true}})
+	h.Publish(hub.Message{Name: event.MessageUnread, Fields: hub.Fields{"message_id": uuid.Nil, "user_id": otherID, "noticeable": false}})
+
+	assert.Eventually(t, func() bool {
+		n.pendingMu.Lock()
+		defer n.pendingMu.Unlock()
+		_, ok := n.pending[userID]
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	n.Shutdown()
+
+	n.pendingMu.Lock()
+	defer n.pendingMu.Unlock()
+	assert.NotContains(t, n.pending, otherID)
+}
@@ -0,0 +1,156 @@
+package digest
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// smtpTimeout SMTPサーバーとの接続から送信完了までの制限時間
+const smtpTimeout = 30 * time.Second
+
+// Sender メール送信者
+type Sender interface {
+	// Send toにメールを送信します
+	Send(to, subject, body string) error
+}
+
+// Config SMTP設定
+type Config struct {
+	// Host SMTPサーバーのホスト
+	Host string
+	// Port SMTPサーバーのポート
+	Port int
+	// Username SMTP認証のユーザー名 (空の場合は認証しない)
+	Username string
+	// Password SMTP認証のパスワード
+	Password string
+	// From 送信元メールアドレス
+	From string
+}
+
+// Valid メール送信が有効かどうか
+func (c Config) Valid() bool {
+	return len(c.Host) > 0 && c.Port > 0 && len(c.From) > 0
+}
+
+type smtpSender struct {
+	host    string
+	addr    string
+	auth    smtp.Auth
+	from    *mail.Address
+	timeout time.Duration
+}
+
+// NewSMTPSender SMTPでメールを送信するSenderを生成します
+//
+// サーバーが対応している場合はSTARTTLSを使用します
+func NewSMTPSender(config Config) (Sender, error) {
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+	s := &smtpSender{
+		host:    config.Host,
+		addr:    net.JoinHostPort(config.Host, strconv.Itoa(config.Port)),
+		from:    from,
+		timeout: smtpTimeout,
+	}
+	if len(config.Username) > 0 {
+		s.auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	}
+	return s, nil
+}
+
+func (s *smtpSender) Send(to, subject, body string) error {
+	addr, err := mail.ParseAddress(to)
+	if err != nil {
+		return errors.New("invalid to address")
+	}
+	if strings.ContainsAny(addr.Address, "\r\n") {
+		return errors.New("invalid to address")
+	}
+	return s.send(addr.Address, buildMessage(s.from, addr, subject, body, time.Now()))
+}
+
+// send smtp.SendMailと同様の手順でメールを送信します
+//
+// 応答しないサーバーによって呼び出し元が止まらないよう、接続から送信完了までをs.timeoutで打ち切ります
+func (s *smtpSender) send(to string, msg []byte) error {
+	conn, err := net.DialTimeout("tcp", s.addr, s.timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := c.Auth(s.auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.from.Address); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// buildMessage UTF-8のプレーンテキストメールを組み立てます
+func buildMessage(from, to *mail.Address, subject, body string, now time.Time) []byte {
+	var b bytes.Buffer
+	b.WriteString("From: " + from.String() + "\r\n")
+	b.WriteString("To: " + to.String() + "\r\n")
+	b.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", subject) + "\r\n")
+	b.WriteString("Date: " + now.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: base64\r\n")
+	b.WriteString("\r\n")
+
+	// RFC 2045に従い76文字ごとに改行する
+	encoded := base64.StdEncoding.EncodeToString([]byte(body))
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded + "\r\n")
+	return b.Bytes()
+}
@@ -0,0 +1,173 @@
+package digest
+
+import (
+	"bufio"
+	"encoding/base64"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receivedMail struct {
+	from string
+	to   []string
+	data string
+}
+
+// startFakeSMTPServer 1通だけメールを受信するSMTPサーバーを起動します
+func startFakeSMTPServer(t *testing.T) (host string, port int, received <-chan *receivedMail) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	ch := make(chan *receivedMail, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		tp := textproto.NewConn(conn)
+		m := &receivedMail{}
+
+		_ = tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch cmd {
+			case "EHLO", "HELO":
+				_ = tp.PrintfLine("250 localhost")
+			case "MAIL":
+				m.from = line
+				_ = tp.PrintfLine("250 OK")
+			case "RCPT":
+				m.to = append(m.to, line)
+				_ = tp.PrintfLine("250 OK")
+			case "DATA":
+				_ = tp.PrintfLine("354 Go ahead")
+				b, err := tp.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				m.data = string(b)
+				_ = tp.PrintfLine("250 OK")
+			case "QUIT":
+				_ = tp.PrintfLine("221 Bye")
+				ch <- m
+				return
+			default:
+				_ = tp.PrintfLine("250 OK")
+			}
+		}
+	}()
+
+	addr := l.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, ch
+}
+
+func TestConfig_Valid(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, Config{}.Valid())
+	assert.False(t, Config{Host: "localhost", Port: 25}.Valid())
+	assert.True(t, Config{Host: "localhost", Port: 25, From: "traq@example.com"}.Valid())
+}
+
+func TestNewSMTPSender(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSMTPSender(Config{Host: "localhost", Port: 25, From: "invalid"})
+	assert.Error(t, err)
+	_, err = NewSMTPSender(Config{Host: "localhost", Port: 25, From: "traQ <traq@example.com>"})
+	assert.NoError(t, err)
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	t.Parallel()
+
+	host, port, received := startFakeSMTPServer(t)
+	s, err := NewSMTPSender(Config{Host: host, Port: port, From: "traq@example.com"})
+	require.NoError(t, err)
+
+	assert.Error(t, s.Send("invalid\r\nBcc: a@example.com", "subject", "body"))
+
+	body := strings.Repeat("未読の通知があります。\n", 10)
+	require.NoError(t, s.Send("user@example.com", "[traQ] 未読の通知", body))
+
+	select {
+	case m := <-received:
+		assert.Equal(t, "MAIL FROM:<traq@example.com>", strings.SplitN(m.from, " BODY", 2)[0])
+		assert.Equal(t, []string{"RCPT TO:<user@example.com>"}, m.to)
+
+		msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(m.data)))
+		require.NoError(t, err)
+		subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, "[traQ] 未読の通知", subject)
+		assert.Equal(t, "<user@example.com>", msg.Header.Get("To"))
+		assert.Equal(t, "base64", msg.Header.Get("Content-Transfer-Encoding"))
+
+		raw, err := io.ReadAll(msg.Body)
+		require.NoError(t, err)
+		decoded, err := base64.StdEncoding.DecodeString(strings.NewReplacer("\r", "", "\n", "").Replace(string(raw)))
+		require.NoError(t, err)
+		assert.Equal(t, body, string(decoded))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+func TestBuildMessage(t *testing.T) {
+	t.Parallel()
+
+	from := &mail.Address{Address: "traq@example.com"}
+	to := &mail.Address{Address: "user@example.com"}
+	msg := string(buildMessage(from, to, "subject", strings.Repeat("a", 200), time.Now()))
+
+	parts := strings.SplitN(msg, "\r\n\r\n", 2)
+	require.Len(t, parts, 2)
+	for _, line := range strings.Split(strings.TrimSuffix(parts[1], "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 76)
+	}
+	assert.Contains(t, parts[0], "Content-Type: text/plain; charset=UTF-8")
+	assert.Contains(t, parts[0], "Date: ")
+}
+
+func TestSMTPSender_Send_Timeout(t *testing.T) {
+	t.Parallel()
+
+	// 接続を受け付けるが応答しないサーバー
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = io.Copy(io.Discard, conn)
+	}()
+
+	addr := l.Addr().(*net.TCPAddr)
+	s, err := NewSMTPSender(Config{Host: addr.IP.String(), Port: addr.Port, From: "traq@example.com"})
+	require.NoError(t, err)
+	s.(*smtpSender).timeout = 100 * time.Millisecond
+
+	start := time.Now()
+	err = s.Send("user@example.com", "subject", "body")
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
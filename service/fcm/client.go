@@ -1,6 +1,18 @@
 package fcm
 
-import "github.com/traPtitech/traQ/utils/set"
+import (
+	"time"
+
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+// Config プッシュ通知の送信設定
+type Config struct {
+	// CoalesceWindow 同じユーザー・タグへの通知をまとめる間隔 (0の場合はまとめない)
+	CoalesceWindow time.Duration
+	// DeviceRateLimit デバイスごとの送信レート制限
+	DeviceRateLimit RateLimit
+}
 
 // Client Firebase Cloud Messaging Client
 type Client interface {
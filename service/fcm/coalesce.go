@@ -0,0 +1,144 @@
+package fcm
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+type coalesceKey struct {
+	userID uuid.UUID
+	tag    string
+}
+
+type coalesceState struct {
+	// total 最初の通知からの通知数の合計
+	total int
+	// pending ウィンドウ内で保留している通知数
+	pending         int
+	latest          Payload
+	withUnreadCount bool
+	keywordHit      bool
+	timer           *time.Timer
+}
+
+type coalescingClient struct {
+	c      Client
+	window time.Duration
+
+	mu      sync.Mutex
+	pending map[coalesceKey]*coalesceState
+	closed  bool
+}
+
+// NewCoalescingClient 同じユーザー・タグへの通知をまとめて送信するクライアントを返します
+//
+// 最初の通知はすぐに送信し、その後window以内に送信された同じタグの通知は
+// ウィンドウの終わりに「N件の新しいメッセージ」という1件の通知にまとめてcに送信します。
+// タグが空の通知はまとめません。windowが0以下の場合はcをそのまま返します
+func NewCoalescingClient(c Client, window time.Duration) Client {
+	if window <= 0 {
+		return c
+	}
+	return &coalescingClient{
+		c:       c,
+		window:  window,
+		pending: map[coalesceKey]*coalesceState{},
+	}
+}
+
+func (c *coalescingClient) Send(targetUserIDs set.UUID, payload *Payload, withUnreadCount bool) {
+	if len(payload.Tag) == 0 {
+		c.c.Send(targetUserIDs, payload, withUnreadCount)
+		return
+	}
+
+	immediate := set.UUID{}
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	for uid := range targetUserIDs {
+		key := coalesceKey{userID: uid, tag: payload.Tag}
+		if s, ok := c.pending[key]; ok {
+			s.total++
+			s.pending++
+			s.latest = *payload
+			s.withUnreadCount = s.withUnreadCount || withUnreadCount
+			s.keywordHit = s.keywordHit || payload.KeywordHit
+			pushCoalescedCounter.Inc()
+			continue
+		}
+		c.pending[key] = &coalesceState{
+			total: 1,
+			timer: time.AfterFunc(c.window, func() { c.flush(key) }),
+		}
+		immediate.Add(uid)
+	}
+	c.mu.Unlock()
+
+	if len(immediate) > 0 {
+		c.c.Send(immediate, payload, withUnreadCount)
+	}
+}
+
+// flush keyのウィンドウを終了し、保留している通知があればまとめて送信します
+func (c *coalescingClient) flush(key coalesceKey) {
+	c.mu.Lock()
+	s, ok := c.pending[key]
+	if !ok || c.closed {
+		c.mu.Unlock()
+		return
+	}
+	if s.pending == 0 {
+		delete(c.pending, key)
+		c.mu.Unlock()
+		return
+	}
+	p, withUnreadCount := s.merged()
+	// 連続して通知される場合に備えてウィンドウを延長する
+	s.pending = 0
+	s.withUnreadCount = false
+	s.keywordHit = false
+	s.timer = time.AfterFunc(c.window, func() { c.flush(key) })
+	c.mu.Unlock()
+
+	c.c.Send(set.UUID{key.userID: struct{}{}}, p, withUnreadCount)
+}
+
+// merged 保留している通知をまとめたペイロードを返します
+func (s *coalesceState) merged() (*Payload, bool) {
+	p := s.latest
+	p.Body = fmt.Sprintf("%d件の新しいメッセージ", s.total)
+	p.Image = optional.String{}
+	p.KeywordHit = s.keywordHit
+	return &p, s.withUnreadCount
+}
+
+func (c *coalescingClient) Close() {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.closed = true
+	pending := c.pending
+	c.pending = nil
+	c.mu.Unlock()
+
+	// 保留している通知を送信してから閉じる
+	for key, s := range pending {
+		s.timer.Stop()
+		if s.pending > 0 {
+			p, withUnreadCount := s.merged()
+			c.c.Send(set.UUID{key.userID: struct{}{}}, p, withUnreadCount)
+		}
+	}
+	c.c.Close()
+}
@@ -0,0 +1,126 @@
+package fcm
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+type sent struct {
+	targets         set.UUID
+	payload         Payload
+	withUnreadCount bool
+}
+
+type recordClient struct {
+	mu     sync.Mutex
+	sent   []sent
+	closed bool
+}
+
+func (c *recordClient) Send(targetUserIDs set.UUID, payload *Payload, withUnreadCount bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = append(c.sent, sent{targets: targetUserIDs.Clone(), payload: *payload, withUnreadCount: withUnreadCount})
+}
+
+func (c *recordClient) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+}
+
+func (c *recordClient) get() []sent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]sent{}, c.sent...)
+}
+
+func TestNewCoalescingClient(t *testing.T) {
+	t.Parallel()
+
+	inner := &recordClient{}
+	assert.Equal(t, inner, NewCoalescingClient(inner, 0))
+	assert.IsType(t, &coalescingClient{}, NewCoalescingClient(inner, time.Second))
+}
+
+func TestCoalescingClient_Send(t *testing.T) {
+	t.Parallel()
+
+	user1 := uuid.Must(uuid.NewV4())
+	user2 := uuid.Must(uuid.NewV4())
+	payload := func(body, tag string) *Payload {
+		return &Payload{Type: "new_message", Title: "#general", Body: body, Tag: tag, Image: optional.StringFrom("https://example.com/image.png")}
+	}
+
+	t.Run("coalesce", func(t *testing.T) {
+		t.Parallel()
+		inner := &recordClient{}
+		c := NewCoalescingClient(inner, 100*time.Millisecond)
+
+		c.Send(set.UUIDSetFromArray([]uuid.UUID{user1, user2}), payload("a", "c:1"), true)
+		c.Send(set.UUIDSetFromArray([]uuid.UUID{user1}), payload("b", "c:1"), false)
+		c.Send(set.UUIDSetFromArray([]uuid.UUID{user1}), &Payload{Title: "#general", Body: "c", Tag: "c:1", KeywordHit: true}, false)
+		c.Send(set.UUIDSetFromArray([]uuid.UUID{user1}), payload("d", "c:2"), true) // 別タグ
+		c.Send(set.UUIDSetFromArray([]uuid.UUID{user1}), payload("e", ""), true)    // タグなし
+
+		// 最初の通知はすぐに送信される
+		s := inner.get()
+		if assert.Len(t, s, 3) {
+			assert.Equal(t, set.UUIDSetFromArray([]uuid.UUID{user1, user2}), s[0].targets)
+			assert.Equal(t, "a", s[0].payload.Body)
+			assert.Equal(t, "d", s[1].payload.Body)
+			assert.Equal(t, "e", s[2].payload.Body)
+		}
+
+		// ウィンドウの終わりにまとめて送信される
+		assert.Eventually(t, func() bool { return len(inner.get()) == 4 }, time.Second, 10*time.Millisecond)
+		s = inner.get()
+		assert.Equal(t, set.UUIDSetFromArray([]uuid.UUID{user1}), s[3].targets)
+		assert.Equal(t, "3件の新しいメッセージ", s[3].payload.Body)
+		assert.Equal(t, "c:1", s[3].payload.Tag)
+		assert.False(t, s[3].payload.Image.Valid)
+		assert.True(t, s[3].payload.KeywordHit)
+		assert.False(t, s[3].withUnreadCount)
+
+		// 連続していない通知はすぐに送信される
+		assert.Eventually(t, func() bool {
+			c.(*coalescingClient).mu.Lock()
+			defer c.(*coalescingClient).mu.Unlock()
+			return len(c.(*coalescingClient).pending) == 0
+		}, time.Second, 10*time.Millisecond)
+		c.Send(set.UUIDSetFromArray([]uuid.UUID{user1}), payload("f", "c:1"), true)
+		s = inner.get()
+		if assert.Len(t, s, 5) {
+			assert.Equal(t, "f", s[4].payload.Body)
+		}
+	})
+
+	t.Run("close", func(t *testing.T) {
+		t.Parallel()
+		inner := &recordClient{}
+		c := NewCoalescingClient(inner, time.Hour)
+
+		c.Send(set.UUIDSetFromArray([]uuid.UUID{user1}), payload("a", "c:1"), true)
+		c.Send(set.UUIDSetFromArray([]uuid.UUID{user1}), payload("b", "c:1"), true)
+		c.Close()
+
+		// 保留していた通知は送信される
+		s := inner.get()
+		if assert.Len(t, s, 2) {
+			assert.Equal(t, "2件の新しいメッセージ", s[1].payload.Body)
+			assert.True(t, s[1].withUnreadCount)
+		}
+		assert.True(t, inner.closed)
+
+		// Close後は送信しない
+		c.Send(set.UUIDSetFromArray([]uuid.UUID{user1}), payload("c", "c:3"), true)
+		assert.Len(t, inner.get(), 2)
+	})
+}
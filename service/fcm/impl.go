@@ -22,17 +22,20 @@ type clientImpl struct {
 	repo          repository.Repository
 	logger        *zap.Logger
 	unreadCounter counter.UnreadMessageCounter
+	limiter       *DeviceLimiter
 	queue         chan []*messaging.Message
 	close         chan struct{}
 }
 
 // NewClientWithCredentialsFile Firebase Cloud Messaging Clientを生成します
-func NewClientWithCredentialsFile(repo repository.Repository, logger *zap.Logger, unreadCounter counter.UnreadMessageCounter, file variable.FirebaseCredentialsFilePathString) (Client, error) {
-	return NewClient(repo, logger, unreadCounter, option.WithCredentialsFile(string(file)))
+func NewClientWithCredentialsFile(repo repository.Repository, logger *zap.Logger, unreadCounter counter.UnreadMessageCounter, file variable.FirebaseCredentialsFilePathString, limit RateLimit) (Client, error) {
+	return NewClient(repo, logger, unreadCounter, limit, option.WithCredentialsFile(string(file)))
 }
 
 // NewClient Firebase Cloud Messaging Clientを生成します
-func NewClient(repo repository.Repository, logger *zap.Logger, unreadCounter counter.UnreadMessageCounter, options ...option.ClientOption) (Client, error) {
+//
+// limitが有効な場合、デバイス(トークン)ごとに送信数を制限します
+func NewClient(repo repository.Repository, logger *zap.Logger, unreadCounter counter.UnreadMessageCounter, limit RateLimit, options ...option.ClientOption) (Client, error) {
 	app, err := firebase.NewApp(context.Background(), nil, options...)
 	if err != nil {
 		return nil, err
@@ -48,6 +51,7 @@ func NewClient(repo repository.Repository, logger *zap.Logger, unreadCounter cou
 		repo:          repo,
 		logger:        logger.Named("fcm"),
 		unreadCounter: unreadCounter,
+		limiter:       NewDeviceLimiter("fcm", limit),
 		queue:         make(chan []*messaging.Message),
 		close:         make(chan struct{}),
 	}
@@ -115,6 +119,9 @@ func (c *clientImpl) send(targetUserIDs set.UUID, p *Payload, withUnreadCount bo
 			if p.Image.Valid {
 				data["image"] = p.Image.String
 			}
+			if p.KeywordHit {
+				data["keyword_hit"] = "true"
+			}
 			apns := &messaging.APNSConfig{
 				Headers: apnsHeaders,
 				Payload: &messaging.APNSPayload{
@@ -128,6 +135,9 @@ func (c *clientImpl) send(targetUserIDs set.UUID, p *Payload, withUnreadCount bo
 			}
 
 			for _, token := range tokens {
+				if !c.limiter.Allow(token) {
+					continue
+				}
 				messages = append(messages, &messaging.Message{
 					Data:    data,
 					Android: defaultAndroidConfig,
@@ -149,6 +159,9 @@ func (c *clientImpl) send(targetUserIDs set.UUID, p *Payload, withUnreadCount bo
 		if p.Image.Valid {
 			data["image"] = p.Image.String
 		}
+		if p.KeywordHit {
+			data["keyword_hit"] = "true"
+		}
 		apns := &messaging.APNSConfig{
 			Headers: apnsHeaders,
 			Payload: &messaging.APNSPayload{
@@ -162,6 +175,9 @@ func (c *clientImpl) send(targetUserIDs set.UUID, p *Payload, withUnreadCount bo
 
 		for _, tokens := range tokensMap {
 			for _, token := range tokens {
+				if !c.limiter.Allow(token) {
+					continue
+				}
 				messages = append(messages, &messaging.Message{
 					Data:    data,
 					Android: defaultAndroidConfig,
@@ -227,6 +243,7 @@ func (c *clientImpl) sendMessages(messages []*messaging.Message) {
 			c.logger.Error("failed to DeleteDeviceTokens", zap.Error(err), zap.Strings("invalid_tokens", invalidTokens))
 			return
 		}
+		tokenRemovedCounter.Add(float64(len(invalidTokens)))
 	}
 }
 
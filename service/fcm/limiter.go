@@ -0,0 +1,91 @@
+package fcm
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimit デバイスごとの通知送信レート制限
+type RateLimit struct {
+	// Count Periodあたりに1デバイスへ送信できる通知の最大数
+	Count int
+	// Period レート制限の期間
+	Period time.Duration
+}
+
+// Valid レート制限が有効かどうか
+func (l RateLimit) Valid() bool {
+	return l.Count > 0 && l.Period > 0
+}
+
+// DeviceLimiter デバイスごとの通知送信レートリミッタ
+//
+// デバイス(FCMトークン・Web Pushエンドポイント)ごとに固定ウィンドウで送信数を数えます
+type DeviceLimiter struct {
+	provider  string
+	limit     RateLimit
+	mu        sync.Mutex
+	windows   map[string]*deviceWindow
+	lastSweep time.Time
+}
+
+type deviceWindow struct {
+	start time.Time
+	count int
+}
+
+// NewDeviceLimiter デバイスごとの通知送信レートリミッタを生成します
+//
+// providerはメトリクスのラベルに使用します。
+// limitが無効な場合はnilを返します。nilのDeviceLimiterは全ての送信を許可します
+func NewDeviceLimiter(provider string, limit RateLimit) *DeviceLimiter {
+	if !limit.Valid() {
+		return nil
+	}
+	return &DeviceLimiter{
+		provider:  provider,
+		limit:     limit,
+		windows:   map[string]*deviceWindow{},
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow deviceへの送信を許可するかどうかを返します
+//
+// 許可した場合は送信数に数えます
+func (l *DeviceLimiter) Allow(device string) bool {
+	if l == nil {
+		return true
+	}
+	if !l.allow(device, time.Now()) {
+		pushRateLimitedCounter.WithLabelValues(l.provider).Inc()
+		return false
+	}
+	return true
+}
+
+func (l *DeviceLimiter) allow(device string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// 期限切れのウィンドウを定期的に破棄する
+	if now.Sub(l.lastSweep) >= l.limit.Period {
+		for d, w := range l.windows {
+			if now.Sub(w.start) >= l.limit.Period {
+				delete(l.windows, d)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	w, ok := l.windows[device]
+	if !ok || now.Sub(w.start) >= l.limit.Period {
+		l.windows[device] = &deviceWindow{start: now, count: 1}
+		return true
+	}
+	if w.count >= l.limit.Count {
+		return false
+	}
+	w.count++
+	return true
+}
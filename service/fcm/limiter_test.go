@@ -0,0 +1,36 @@
+package fcm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil limiter", func(t *testing.T) {
+		t.Parallel()
+		l := NewDeviceLimiter("fcm", RateLimit{})
+		assert.Nil(t, l)
+		for i := 0; i < 100; i++ {
+			assert.True(t, l.Allow("token"))
+		}
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		t.Parallel()
+		l := NewDeviceLimiter("fcm", RateLimit{Count: 2, Period: time.Minute})
+		now := time.Now()
+
+		assert.True(t, l.allow("a", now))
+		assert.True(t, l.allow("a", now.Add(time.Second)))
+		assert.False(t, l.allow("a", now.Add(2*time.Second)))
+		assert.True(t, l.allow("b", now.Add(2*time.Second))) // デバイスごとに独立
+
+		// 期間が過ぎると再び送信できる
+		assert.True(t, l.allow("a", now.Add(time.Minute+2*time.Second)))
+		assert.Len(t, l.windows, 1) // 期限切れのbは破棄される
+	})
+}
@@ -0,0 +1,33 @@
+package fcm
+
+import (
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+type multiClient []Client
+
+// NewMultiClient 複数のクライアントに同じペイロードを送信するクライアントを返します
+//
+// FCMとWeb Pushなど、複数の通知プロバイダを併用する場合に使用します
+func NewMultiClient(clients ...Client) Client {
+	switch len(clients) {
+	case 0:
+		return NewNullClient()
+	case 1:
+		return clients[0]
+	default:
+		return multiClient(clients)
+	}
+}
+
+func (m multiClient) Send(targetUserIDs set.UUID, payload *Payload, withUnreadCount bool) {
+	for _, c := range m {
+		c.Send(targetUserIDs, payload, withUnreadCount)
+	}
+}
+
+func (m multiClient) Close() {
+	for _, c := range m {
+		c.Close()
+	}
+}
@@ -27,6 +27,18 @@ var (
 		Namespace: "firebase",
 		Name:      "fcm_batch_request_count_total",
 	}, []string{"result"})
+	tokenRemovedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "firebase",
+		Name:      "fcm_token_removed_count_total",
+	})
+	pushCoalescedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "traq",
+		Name:      "push_coalesced_count_total",
+	})
+	pushRateLimitedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "traq",
+		Name:      "push_rate_limited_count_total",
+	}, []string{"provider"})
 	messageTTL       = messageTTLSeconds * time.Second
 	messageTTLString = strconv.Itoa(messageTTLSeconds)
 
@@ -50,6 +62,8 @@ type Payload struct {
 	Path  string
 	Tag   string
 	Image optional.String
+	// KeywordHit 通知キーワードに一致したことによる通知かどうか
+	KeywordHit bool
 }
 
 // SetBodyWithEllipsis 100文字を超える場合は...で省略
@@ -20,6 +20,8 @@ var (
 type TimelineQuery struct {
 	User    uuid.UUID
 	Channel uuid.UUID
+	// Thread 指定したスレッドへの返信メッセージを指定
+	Thread uuid.UUID
 	// ChannelsSubscribedByUser 指定したユーザーが購読しているチャンネルのメッセージを指定
 	ChannelsSubscribedByUser uuid.UUID
 	Since                    optional.Time
@@ -46,22 +48,34 @@ type Manager interface {
 	GetTimeline(query TimelineQuery) (Timeline, error)
 	// Create メッセージを作成します
 	//
+	// actionsを指定した場合、アクション付きのメッセージを作成します。
 	// 成功した場合、メッセージとnilを返します。
 	// アーカイブされているチャンネルを指定すると、ErrChannelArchivedを返します。
 	// DBによるエラーを返すことがあります。
-	Create(channelID, userID uuid.UUID, content string) (Message, error)
+	Create(channelID, userID uuid.UUID, content string, actions model.MessageActions) (Message, error)
 	// CreateDM ダイレクトメッセージを作成します
 	//
+	// actionsを指定した場合、アクション付きのメッセージを作成します。
 	// 成功した場合、メッセージとnilを返します。
 	// DBによるエラーを返すことがあります。
-	CreateDM(from, to uuid.UUID, content string) (Message, error)
+	CreateDM(from, to uuid.UUID, content string, actions model.MessageActions) (Message, error)
+	// CreateReply 指定したメッセージのスレッドに返信メッセージを作成します
+	//
+	// 返信先のメッセージが既にスレッドへの返信である場合、そのスレッドに返信します。
+	// actionsを指定した場合、アクション付きのメッセージを作成します。
+	// 成功した場合、メッセージとnilを返します。
+	// アーカイブされているチャンネルのメッセージを指定すると、ErrChannelArchivedを返します。
+	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	CreateReply(parentID, userID uuid.UUID, content string, actions model.MessageActions) (Message, error)
 	// Edit 指定したメッセージを編集します
 	//
+	// actionsがnilでない場合、本文と同時にアクションを全て置き換えます。actionsが空の場合はアクションを削除します。
 	// 成功した場合、nilを返します。
 	// アーカイブされているチャンネルを指定すると、ErrChannelArchivedを返します。
 	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
 	// DBによるエラーを返すことがあります。
-	Edit(id uuid.UUID, content string) error
+	Edit(id uuid.UUID, content string, actions model.MessageActions) error
 	// Delete 指定したメッセージを削除します
 	//
 	// 成功した場合、nilを返します。
@@ -69,6 +83,13 @@ type Manager interface {
 	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
 	// DBによるエラーを返すことがあります。
 	Delete(id uuid.UUID) error
+	// Hide 指定したメッセージを非表示にします
+	//
+	// アーカイブされているチャンネルのメッセージも非表示にできます。
+	// 成功した場合、nilを返します。
+	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	Hide(id uuid.UUID) error
 	// Pin 指定したユーザーによって指定したメッセージをピン留めします
 	//
 	// 成功した場合は、ピンとnilを返します。
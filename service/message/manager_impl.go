@@ -13,6 +13,7 @@ import (
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 const (
@@ -66,6 +67,7 @@ func (m *manager) GetTimeline(query TimelineQuery) (Timeline, error) {
 	q := repository.MessagesQuery{
 		User:                     query.User,
 		Channel:                  query.Channel,
+		Thread:                   query.Thread,
 		ChannelsSubscribedByUser: query.ChannelsSubscribedByUser,
 		Since:                    query.Since,
 		Until:                    query.Until,
@@ -91,35 +93,74 @@ func (m *manager) GetTimeline(query TimelineQuery) (Timeline, error) {
 	}, nil
 }
 
-func (m *manager) CreateDM(from, to uuid.UUID, content string) (Message, error) {
+func (m *manager) CreateDM(from, to uuid.UUID, content string, actions model.MessageActions) (Message, error) {
 	// DMチャンネルを取得
 	ch, err := m.CM.GetDMChannel(from, to)
 	if err != nil {
 		return nil, err
 	}
 
-	return m.create(ch.ID, from, content)
+	return m.create(ch.ID, from, optional.UUID{}, content, actions)
 }
 
-func (m *manager) Create(channelID, userID uuid.UUID, content string) (Message, error) {
+func (m *manager) Create(channelID, userID uuid.UUID, content string, actions model.MessageActions) (Message, error) {
 	// チャンネルがアーカイブされているかどうか確認
 	if m.CM.IsPublicChannel(channelID) && m.CM.PublicChannelTree().IsArchivedChannel(channelID) {
 		return nil, ErrChannelArchived
 	}
 
-	return m.create(channelID, userID, content)
+	return m.create(channelID, userID, optional.UUID{}, content, actions)
 }
 
-func (m *manager) create(channelID, userID uuid.UUID, content string) (Message, error) {
-	// 作成
-	msg, err := m.R.CreateMessage(userID, channelID, content)
+func (m *manager) CreateReply(parentID, userID uuid.UUID, content string, actions model.MessageActions) (Message, error) {
+	// 返信先のメッセージ取得
+	parent, err := m.Get(parentID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to CreateMessage: %w", err)
+		return nil, err
+	}
+
+	// チャンネルがアーカイブされているかどうか確認
+	channelID := parent.GetChannelID()
+	if m.CM.IsPublicChannel(channelID) && m.CM.PublicChannelTree().IsArchivedChannel(channelID) {
+		return nil, ErrChannelArchived
+	}
+
+	// スレッドの親メッセージを決定
+	threadID := parent.GetID()
+	if tid := parent.GetThreadID(); tid.Valid {
+		threadID = tid.UUID
+	}
+
+	return m.create(channelID, userID, optional.UUIDFrom(threadID), content, actions)
+}
+
+func (m *manager) create(channelID, userID uuid.UUID, threadID optional.UUID, content string, actions model.MessageActions) (Message, error) {
+	// 作成
+	var (
+		msg *model.Message
+		err error
+	)
+	switch {
+	case len(actions) > 0:
+		msg, err = m.R.CreateMessageWithActions(userID, channelID, threadID, content, actions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to CreateMessageWithActions: %w", err)
+		}
+	case threadID.Valid:
+		msg, err = m.R.CreateThreadMessage(userID, channelID, threadID.UUID, content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to CreateThreadMessage: %w", err)
+		}
+	default:
+		msg, err = m.R.CreateMessage(userID, channelID, content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to CreateMessage: %w", err)
+		}
 	}
 	return &message{Model: msg}, nil
 }
 
-func (m *manager) Edit(id uuid.UUID, content string) error {
+func (m *manager) Edit(id uuid.UUID, content string, actions model.MessageActions) error {
 	// メッセージ取得
 	msg, err := m.Get(id)
 	if err != nil {
@@ -132,7 +173,12 @@ func (m *manager) Edit(id uuid.UUID, content string) error {
 	}
 
 	// 更新
-	if err := m.R.UpdateMessage(id, content); err != nil {
+	if actions != nil {
+		err = m.R.UpdateMessageWithActions(id, content, actions)
+	} else {
+		err = m.R.UpdateMessage(id, content)
+	}
+	if err != nil {
 		switch err {
 		case repository.ErrNotFound:
 			return ErrNotFound
@@ -171,6 +217,21 @@ func (m *manager) Delete(id uuid.UUID) error {
 	return nil
 }
 
+func (m *manager) Hide(id uuid.UUID) error {
+	// 非表示化
+	if err := m.R.HideMessage(id); err != nil {
+		switch err {
+		case repository.ErrNotFound, repository.ErrNilID:
+			return ErrNotFound
+		default:
+			return fmt.Errorf("failed to HideMessage: %w", err)
+		}
+	}
+	m.cache.Forget(id)
+
+	return nil
+}
+
 func (m *manager) Pin(id uuid.UUID, userID uuid.UUID) (*model.Pin, error) {
 	// メッセージ取得
 	msg, err := m.Get(id)
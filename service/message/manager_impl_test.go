@@ -12,6 +12,7 @@ import (
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 func setupM(ctrl *gomock.Controller) (Manager, *mock_channel.MockManager, *Repo, *mock_channel.MockTree) {
@@ -112,7 +113,7 @@ func TestManager_Create(t *testing.T) {
 		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
 		tree.EXPECT().IsArchivedChannel(cid).Return(true).Times(1)
 
-		_, err := m.Create(cid, uuid.NewV3(uuid.Nil, "u1"), content)
+		_, err := m.Create(cid, uuid.NewV3(uuid.Nil, "u1"), content, nil)
 		assert.EqualError(t, err, ErrChannelArchived.Error())
 	})
 
@@ -131,7 +132,7 @@ func TestManager_Create(t *testing.T) {
 			Return(&model.Message{ID: uuid.NewV3(uuid.Nil, "m1"), UserID: uid, ChannelID: cid, Text: content}, nil).
 			Times(1)
 
-		msg, err := m.Create(cid, uid, content)
+		msg, err := m.Create(cid, uid, content, nil)
 		if assert.NoError(t, err) {
 			assert.EqualValues(t, cid, msg.GetChannelID())
 			assert.EqualValues(t, uid, msg.GetUserID())
@@ -161,6 +162,34 @@ func TestManager_Create(t *testing.T) {
 			assert.EqualValues(t, content, result.GetText())
 		}
 	})
+
+	t.Run("success (with actions)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		uid := uuid.NewV3(uuid.Nil, "u1")
+		actions := model.MessageActions{{ID: "ok", Type: model.MessageActionButton, Label: "OK"}}
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			CreateMessageWithActions(uid, cid, optional.UUID{}, content, actions).
+			Return(&model.Message{
+				ID:         uuid.NewV3(uuid.Nil, "m1"),
+				UserID:     uid,
+				ChannelID:  cid,
+				Text:       content,
+				Attachment: &model.MessageAttachment{MessageID: uuid.NewV3(uuid.Nil, "m1"), Actions: actions},
+			}, nil).
+			Times(1)
+
+		msg, err := m.Create(cid, uid, content, actions)
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, actions, msg.(*message).Model.Attachment.Actions)
+		}
+	})
 }
 
 func TestManager_CreateDM(t *testing.T) {
@@ -182,7 +211,7 @@ func TestManager_CreateDM(t *testing.T) {
 			Return(&model.Message{ID: uuid.NewV3(uuid.Nil, "m1"), UserID: from, ChannelID: cid, Text: content}, nil).
 			Times(1)
 
-		msg, err := m.CreateDM(from, to, content)
+		msg, err := m.CreateDM(from, to, content, nil)
 		if assert.NoError(t, err) {
 			assert.EqualValues(t, cid, msg.GetChannelID())
 			assert.EqualValues(t, from, msg.GetUserID())
@@ -214,6 +243,132 @@ func TestManager_CreateDM(t *testing.T) {
 	})
 }
 
+func TestManager_CreateReply(t *testing.T) {
+	t.Parallel()
+	const content = "content"
+
+	t.Run("parent not found", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, _, repo, _ := setupM(ctrl)
+
+		mid := uuid.NewV3(uuid.Nil, "m1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(mid).
+			Return(nil, repository.ErrNotFound).
+			Times(1)
+
+		_, err := m.CreateReply(mid, uuid.NewV3(uuid.Nil, "u1"), content, nil)
+		assert.EqualError(t, err, ErrNotFound.Error())
+	})
+
+	t.Run("channel archived", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		parent := &model.Message{ID: uuid.NewV3(uuid.Nil, "m1"), UserID: uuid.NewV3(uuid.Nil, "u2"), ChannelID: cid}
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(parent.ID).
+			Return(parent, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(true).Times(1)
+
+		_, err := m.CreateReply(parent.ID, uuid.NewV3(uuid.Nil, "u1"), content, nil)
+		assert.EqualError(t, err, ErrChannelArchived.Error())
+	})
+
+	t.Run("success (root)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		uid := uuid.NewV3(uuid.Nil, "u1")
+		parent := &model.Message{ID: uuid.NewV3(uuid.Nil, "m1"), UserID: uuid.NewV3(uuid.Nil, "u2"), ChannelID: cid}
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(parent.ID).
+			Return(parent, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			CreateThreadMessage(uid, cid, parent.ID, content).
+			Return(&model.Message{ID: uuid.NewV3(uuid.Nil, "m2"), UserID: uid, ChannelID: cid, Text: content, ThreadID: optional.UUIDFrom(parent.ID)}, nil).
+			Times(1)
+
+		msg, err := m.CreateReply(parent.ID, uid, content, nil)
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, cid, msg.GetChannelID())
+			assert.EqualValues(t, uid, msg.GetUserID())
+			assert.EqualValues(t, content, msg.GetText())
+			assert.EqualValues(t, optional.UUIDFrom(parent.ID), msg.GetThreadID())
+		}
+	})
+
+	t.Run("success (reply to reply)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		uid := uuid.NewV3(uuid.Nil, "u1")
+		root := uuid.NewV3(uuid.Nil, "m0")
+		parent := &model.Message{ID: uuid.NewV3(uuid.Nil, "m1"), UserID: uuid.NewV3(uuid.Nil, "u2"), ChannelID: cid, ThreadID: optional.UUIDFrom(root)}
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(parent.ID).
+			Return(parent, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			CreateThreadMessage(uid, cid, root, content).
+			Return(&model.Message{ID: uuid.NewV3(uuid.Nil, "m2"), UserID: uid, ChannelID: cid, Text: content, ThreadID: optional.UUIDFrom(root)}, nil).
+			Times(1)
+
+		msg, err := m.CreateReply(parent.ID, uid, content, nil)
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, optional.UUIDFrom(root), msg.GetThreadID())
+		}
+	})
+
+	t.Run("success (with actions)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		uid := uuid.NewV3(uuid.Nil, "u1")
+		actions := model.MessageActions{{ID: "ok", Type: model.MessageActionButton, Label: "OK"}}
+		parent := &model.Message{ID: uuid.NewV3(uuid.Nil, "m1"), UserID: uuid.NewV3(uuid.Nil, "u2"), ChannelID: cid}
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(parent.ID).
+			Return(parent, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			CreateMessageWithActions(uid, cid, optional.UUIDFrom(parent.ID), content, actions).
+			Return(&model.Message{ID: uuid.NewV3(uuid.Nil, "m2"), UserID: uid, ChannelID: cid, Text: content, ThreadID: optional.UUIDFrom(parent.ID)}, nil).
+			Times(1)
+
+		msg, err := m.CreateReply(parent.ID, uid, content, actions)
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, optional.UUIDFrom(parent.ID), msg.GetThreadID())
+		}
+	})
+}
+
 func TestManager_Edit(t *testing.T) {
 	t.Parallel()
 	const newContent = "new message"
@@ -230,7 +385,7 @@ func TestManager_Edit(t *testing.T) {
 			Return(nil, repository.ErrNotFound).
 			Times(1)
 
-		err := m.Edit(id, newContent)
+		err := m.Edit(id, newContent, nil)
 		assert.EqualError(t, err, ErrNotFound.Error())
 	})
 
@@ -249,7 +404,7 @@ func TestManager_Edit(t *testing.T) {
 		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
 		tree.EXPECT().IsArchivedChannel(cid).Return(true).Times(1)
 
-		err := m.Edit(id, newContent)
+		err := m.Edit(id, newContent, nil)
 		assert.EqualError(t, err, ErrChannelArchived.Error())
 	})
 
@@ -273,7 +428,60 @@ func TestManager_Edit(t *testing.T) {
 			Return(nil).
 			Times(1)
 
-		err := m.Edit(id, newContent)
+		err := m.Edit(id, newContent, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("success (with actions)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		id := uuid.NewV3(uuid.Nil, "m1")
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		actions := model.MessageActions{{ID: "ok", Type: model.MessageActionButton, Label: "OK"}}
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(id).
+			Return(&model.Message{ID: id, ChannelID: cid}, nil).
+			Times(2)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			UpdateMessageWithActions(id, newContent, actions).
+			Return(nil).
+			Times(1)
+
+		err := m.Edit(id, newContent, actions)
+		assert.NoError(t, err)
+
+		// キャッシュが破棄されていること
+		_, err = m.Get(id)
+		assert.NoError(t, err)
+	})
+
+	t.Run("success (remove actions)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		id := uuid.NewV3(uuid.Nil, "m1")
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(id).
+			Return(&model.Message{ID: id, ChannelID: cid}, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			UpdateMessageWithActions(id, newContent, model.MessageActions{}).
+			Return(nil).
+			Times(1)
+
+		err := m.Edit(id, newContent, model.MessageActions{})
 		assert.NoError(t, err)
 	})
 }
@@ -341,6 +549,55 @@ func TestManager_Delete(t *testing.T) {
 	})
 }
 
+func TestManager_Hide(t *testing.T) {
+	t.Parallel()
+
+	t.Run("message not found", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, _, repo, _ := setupM(ctrl)
+
+		id := uuid.NewV3(uuid.Nil, "m1")
+		repo.MockMessageRepository.
+			EXPECT().
+			HideMessage(id).
+			Return(repository.ErrNotFound).
+			Times(1)
+
+		err := m.Hide(id)
+		assert.EqualError(t, err, ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, _, repo, _ := setupM(ctrl)
+
+		id := uuid.NewV3(uuid.Nil, "m1")
+		repo.MockMessageRepository.
+			EXPECT().
+			HideMessage(id).
+			Return(nil).
+			Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(id).
+			Return(&model.Message{ID: id, Text: "secret", Hidden: true}, nil).
+			Times(1)
+
+		if assert.NoError(t, m.Hide(id)) {
+			msg, err := m.Get(id)
+			if assert.NoError(t, err) {
+				assert.True(t, msg.IsHidden())
+				b, err := msg.MarshalJSON()
+				if assert.NoError(t, err) {
+					assert.NotContains(t, string(b), "secret")
+				}
+			}
+		}
+	})
+}
+
 func TestManager_AddStamps(t *testing.T) {
 	t.Parallel()
 
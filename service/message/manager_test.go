@@ -11,13 +11,17 @@ type Repo struct {
 	*mock_repository.MockChannelRepository
 	*mock_repository.MockMessageRepository
 	*mock_repository.MockPinRepository
+	*mock_repository.MockScheduledMessageRepository
+	*mock_repository.MockUserRepository
 	testUtils.EmptyTestRepository
 }
 
 func NewMockRepo(ctrl *gomock.Controller) *Repo {
 	return &Repo{
-		MockChannelRepository: mock_repository.NewMockChannelRepository(ctrl),
-		MockMessageRepository: mock_repository.NewMockMessageRepository(ctrl),
-		MockPinRepository:     mock_repository.NewMockPinRepository(ctrl),
+		MockChannelRepository:          mock_repository.NewMockChannelRepository(ctrl),
+		MockMessageRepository:          mock_repository.NewMockMessageRepository(ctrl),
+		MockPinRepository:              mock_repository.NewMockPinRepository(ctrl),
+		MockScheduledMessageRepository: mock_repository.NewMockScheduledMessageRepository(ctrl),
+		MockUserRepository:             mock_repository.NewMockUserRepository(ctrl),
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/gofrs/uuid"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 type Message interface {
@@ -14,10 +15,15 @@ type Message interface {
 	GetUserID() uuid.UUID
 	GetChannelID() uuid.UUID
 	GetText() string
+	GetThreadID() optional.UUID
 	GetCreatedAt() time.Time
 	GetUpdatedAt() time.Time
 	GetStamps() []model.MessageStamp
 	GetPin() *model.Pin
+	// GetActions BOTにより付与されたアクションを取得します
+	GetActions() model.MessageActions
+	// IsHidden モデレーターにより非表示にされているかどうか
+	IsHidden() bool
 
 	json.Marshaler
 }
@@ -45,6 +45,12 @@ func (m *message) GetText() string {
 	return m.Model.Text
 }
 
+func (m *message) GetThreadID() optional.UUID {
+	m.RLock()
+	defer m.RUnlock()
+	return m.Model.ThreadID
+}
+
 func (m *message) GetCreatedAt() time.Time {
 	m.RLock()
 	defer m.RUnlock()
@@ -124,6 +130,18 @@ func (m *message) GetPin() *model.Pin {
 	return m.Model.Pin
 }
 
+func (m *message) GetActions() model.MessageActions {
+	m.RLock()
+	defer m.RUnlock()
+	return messageActions(m.Model)
+}
+
+func (m *message) IsHidden() bool {
+	m.RLock()
+	defer m.RUnlock()
+	return m.Model.Hidden
+}
+
 func (m *message) MarshalJSON() ([]byte, error) {
 	type obj struct {
 		ID        uuid.UUID            `json:"id"`
@@ -134,7 +152,9 @@ func (m *message) MarshalJSON() ([]byte, error) {
 		UpdatedAt time.Time            `json:"updatedAt"`
 		Pinned    bool                 `json:"pinned"`
 		Stamps    []model.MessageStamp `json:"stamps"`
-		ThreadID  optional.UUID        `json:"threadId"` // TODO
+		ThreadID  optional.UUID        `json:"threadId"`
+		Hidden    bool                 `json:"hidden"`
+		Actions   model.MessageActions `json:"actions,omitempty"`
 	}
 	stamps := m.GetStamps()
 	m.RLock()
@@ -142,13 +162,33 @@ func (m *message) MarshalJSON() ([]byte, error) {
 		ID:        m.Model.ID,
 		UserID:    m.Model.UserID,
 		ChannelID: m.Model.ChannelID,
-		Content:   m.Model.Text,
+		Content:   VisibleText(m.Model),
 		CreatedAt: m.Model.CreatedAt,
 		UpdatedAt: m.Model.UpdatedAt,
 		Pinned:    m.Model.Pin != nil,
 		Stamps:    stamps,
-		ThreadID:  optional.UUID{},
+		ThreadID:  m.Model.ThreadID,
+		Hidden:    m.Model.Hidden,
+		Actions:   messageActions(m.Model),
 	}
 	m.RUnlock()
 	return jsonIter.ConfigFastest.Marshal(v)
 }
+
+// VisibleText 非表示にされたメッセージの本文を伏せて返します
+func VisibleText(m *model.Message) string {
+	if m.Hidden {
+		return ""
+	}
+	return m.Text
+}
+
+// messageActions メッセージのアクションを返します
+//
+// 非表示にされたメッセージのアクションは返しません。
+func messageActions(m *model.Message) model.MessageActions {
+	if m.Hidden || m.Attachment == nil {
+		return nil
+	}
+	return m.Attachment.Actions
+}
@@ -0,0 +1,189 @@
+package message
+
+import (
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/rbac"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+)
+
+const (
+	schedulerInterval  = 10 * time.Second
+	schedulerBatchSize = 100
+	// schedulerMaxAttempts 送信を試行する最大回数
+	schedulerMaxAttempts = 5
+	// schedulerRetryBackoff 1回目の再試行までの待ち時間 再試行の度に2倍になる
+	schedulerRetryBackoff = time.Minute
+	// schedulerClaimTimeout 送信中のままこの時間が経過した予約投稿メッセージは、送信処理が中断されたとみなす
+	schedulerClaimTimeout = 5 * time.Minute
+)
+
+var (
+	errScheduledMessageUserInactive = errors.New("the user is not active")
+	errScheduledMessageForbidden    = errors.New("you are not permitted to post messages")
+	errScheduledMessageInaccessible = errors.New("the channel is not accessible")
+	errScheduledMessageTooManyTries = errors.New("failed to send the message too many times")
+)
+
+// Scheduler 予約投稿メッセージの送信ワーカー
+type Scheduler struct {
+	repo repository.Repository
+	mm   Manager
+	cm   channel.Manager
+	rbac rbac.RBAC
+	l    *zap.Logger
+
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// NewScheduler 予約投稿メッセージの送信ワーカーを生成します
+func NewScheduler(repo repository.Repository, mm Manager, cm channel.Manager, rbac rbac.RBAC, logger *zap.Logger) *Scheduler {
+	return &Scheduler{
+		repo:    repo,
+		mm:      mm,
+		cm:      cm,
+		rbac:    rbac,
+		l:       logger.Named("message_scheduler"),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Start 送信ワーカーを開始します
+func (s *Scheduler) Start() {
+	go func() {
+		defer close(s.stopped)
+		ticker := time.NewTicker(schedulerInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				s.sendDue(now)
+			case <-s.done:
+				return
+			}
+		}
+	}()
+	s.l.Info("message scheduler started")
+}
+
+// Shutdown 送信ワーカーを停止します
+func (s *Scheduler) Shutdown() {
+	close(s.done)
+	<-s.stopped
+}
+
+// sendDue 予約日時を過ぎたメッセージを送信します
+func (s *Scheduler) sendDue(now time.Time) {
+	// 送信処理中にプロセスが停止したメッセージを回収する
+	if n, err := s.repo.ReleaseStaleScheduledMessages(now.Add(-schedulerClaimTimeout)); err != nil {
+		s.l.Error("failed to ReleaseStaleScheduledMessages", zap.Error(err))
+	} else if n > 0 {
+		s.l.Warn("released stale scheduled messages", zap.Int64("count", n))
+	}
+
+	sms, err := s.repo.GetDueScheduledMessages(now, schedulerBatchSize)
+	if err != nil {
+		s.l.Error("failed to GetDueScheduledMessages", zap.Error(err))
+		return
+	}
+	for _, sm := range sms {
+		s.send(sm, now)
+	}
+}
+
+func (s *Scheduler) send(sm *model.ScheduledMessage, now time.Time) {
+	logger := s.l.With(zap.Stringer("scheduledMessageID", sm.ID))
+
+	// 二重送信を防ぐため、送信前に送信中状態にする
+	ok, err := s.repo.ClaimScheduledMessage(sm.ID, now)
+	if err != nil {
+		logger.Error("failed to ClaimScheduledMessage", zap.Error(err))
+		return
+	}
+	if !ok {
+		return // 他のワーカーが送信中、或いは更新・削除された
+	}
+	if sm.Attempts >= schedulerMaxAttempts {
+		s.fail(sm, errScheduledMessageTooManyTries)
+		return
+	}
+
+	if err := s.checkPostable(sm); err != nil {
+		if errors.Is(err, errScheduledMessageUserInactive) || errors.Is(err, errScheduledMessageForbidden) || errors.Is(err, errScheduledMessageInaccessible) {
+			s.fail(sm, err)
+			return
+		}
+		logger.Error("failed to check scheduled message permission", zap.Error(err))
+		s.release(sm, now)
+		return
+	}
+
+	if _, err := s.mm.Create(sm.ChannelID, sm.UserID, sm.Text, nil); err != nil {
+		if errors.Is(err, ErrChannelArchived) {
+			// アーカイブされたチャンネルには送信できないので、失敗として記録する
+			s.fail(sm, ErrChannelArchived)
+			return
+		}
+		logger.Error("failed to send scheduled message", zap.Error(err))
+		s.release(sm, now)
+		return
+	}
+	// 削除に失敗した場合は送信中状態のまま残り、schedulerClaimTimeout後に回収されて再送信される
+	if err := s.repo.DeleteScheduledMessage(sm.ID); err != nil && err != repository.ErrNotFound {
+		logger.Error("failed to DeleteScheduledMessage", zap.Error(err))
+	}
+}
+
+// checkPostable 送信時点で予約者がチャンネルにメッセージを投稿できるかどうかを確認します
+func (s *Scheduler) checkPostable(sm *model.ScheduledMessage) error {
+	user, err := s.repo.GetUser(sm.UserID, false)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return errScheduledMessageUserInactive
+		}
+		return err
+	}
+	if !user.IsActive() {
+		return errScheduledMessageUserInactive
+	}
+	if !s.rbac.IsGranted(user.GetRole(), permission.PostMessage) {
+		return errScheduledMessageForbidden
+	}
+	ok, err := s.cm.IsChannelAccessibleToUser(sm.UserID, sm.ChannelID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errScheduledMessageInaccessible
+	}
+	return nil
+}
+
+// fail 予約投稿メッセージを送信失敗状態にします
+func (s *Scheduler) fail(sm *model.ScheduledMessage, reason error) {
+	if err := s.repo.SetScheduledMessageError(sm.ID, reason.Error()); err != nil {
+		s.l.Error("failed to SetScheduledMessageError", zap.Error(err), zap.Stringer("scheduledMessageID", sm.ID))
+	}
+}
+
+// release 予約投稿メッセージを送信待ち状態に戻し、間隔を空けて再試行します
+//
+// 再試行回数が上限に達した場合は送信失敗状態にします
+func (s *Scheduler) release(sm *model.ScheduledMessage, now time.Time) {
+	if sm.Attempts+1 >= schedulerMaxAttempts {
+		s.fail(sm, errScheduledMessageTooManyTries)
+		return
+	}
+	retryAt := now.Add(schedulerRetryBackoff << sm.Attempts)
+	if err := s.repo.ReleaseScheduledMessage(sm.ID, retryAt); err != nil {
+		s.l.Error("failed to ReleaseScheduledMessage", zap.Error(err), zap.Stringer("scheduledMessageID", sm.ID))
+	}
+}
@@ -0,0 +1,215 @@
+package message
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+	"github.com/traPtitech/traQ/service/rbac/role"
+)
+
+// testRBAC role.Userにのみメッセージ投稿権限を与えるRBAC
+type testRBAC struct{}
+
+func (testRBAC) Reload() error { return nil }
+func (testRBAC) IsGranted(r string, p permission.Permission) bool {
+	return r == role.User && p == permission.PostMessage
+}
+func (testRBAC) IsAllGranted([]string, permission.Permission) bool    { return false }
+func (testRBAC) IsAnyGranted([]string, permission.Permission) bool    { return false }
+func (testRBAC) GetGrantedPermissions(string) []permission.Permission { return nil }
+
+func TestScheduler_sendDue(t *testing.T) {
+	t.Parallel()
+	const content = "content"
+
+	newScheduledMessage := func() *model.ScheduledMessage {
+		return &model.ScheduledMessage{
+			ID:        uuid.NewV3(uuid.Nil, "s1"),
+			UserID:    uuid.NewV3(uuid.Nil, "u1"),
+			ChannelID: uuid.NewV3(uuid.Nil, "c1"),
+			Text:      content,
+		}
+	}
+	activeUser := func(sm *model.ScheduledMessage, r string) *model.User {
+		return &model.User{ID: sm.UserID, Status: model.UserAccountStatusActive, Role: r}
+	}
+	setup := func(t *testing.T, now time.Time, sm *model.ScheduledMessage) (*Scheduler, *mock_channel.MockManager, *Repo, *mock_channel.MockTree) {
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+		repo.MockScheduledMessageRepository.
+			EXPECT().
+			ReleaseStaleScheduledMessages(now.Add(-schedulerClaimTimeout)).
+			Return(int64(0), nil).
+			Times(1)
+		repo.MockScheduledMessageRepository.
+			EXPECT().
+			GetDueScheduledMessages(now, schedulerBatchSize).
+			Return([]*model.ScheduledMessage{sm}, nil).
+			Times(1)
+		return NewScheduler(repo, m, cm, testRBAC{}, zap.NewNop()), cm, repo, tree
+	}
+
+	t.Run("already claimed", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		sm := newScheduledMessage()
+		s, _, repo, _ := setup(t, now, sm)
+
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(sm.ID, now).Return(false, nil).Times(1)
+
+		s.sendDue(now)
+	})
+
+	t.Run("user inactive", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		sm := newScheduledMessage()
+		s, _, repo, _ := setup(t, now, sm)
+
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(sm.ID, now).Return(true, nil).Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(sm.UserID, false).Return(&model.User{ID: sm.UserID, Status: model.UserAccountStatusDeactivated, Role: role.User}, nil).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().SetScheduledMessageError(sm.ID, errScheduledMessageUserInactive.Error()).Return(nil).Times(1)
+
+		s.sendDue(now)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		sm := newScheduledMessage()
+		s, _, repo, _ := setup(t, now, sm)
+
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(sm.ID, now).Return(true, nil).Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(sm.UserID, false).Return(activeUser(sm, role.Read), nil).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().SetScheduledMessageError(sm.ID, errScheduledMessageForbidden.Error()).Return(nil).Times(1)
+
+		s.sendDue(now)
+	})
+
+	t.Run("channel inaccessible", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		sm := newScheduledMessage()
+		s, cm, repo, _ := setup(t, now, sm)
+
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(sm.ID, now).Return(true, nil).Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(sm.UserID, false).Return(activeUser(sm, role.User), nil).Times(1)
+		cm.EXPECT().IsChannelAccessibleToUser(sm.UserID, sm.ChannelID).Return(false, nil).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().SetScheduledMessageError(sm.ID, errScheduledMessageInaccessible.Error()).Return(nil).Times(1)
+
+		s.sendDue(now)
+	})
+
+	t.Run("channel archived", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		sm := newScheduledMessage()
+		s, cm, repo, tree := setup(t, now, sm)
+
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(sm.ID, now).Return(true, nil).Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(sm.UserID, false).Return(activeUser(sm, role.User), nil).Times(1)
+		cm.EXPECT().IsChannelAccessibleToUser(sm.UserID, sm.ChannelID).Return(true, nil).Times(1)
+		cm.EXPECT().IsPublicChannel(sm.ChannelID).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(sm.ChannelID).Return(true).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().SetScheduledMessageError(sm.ID, ErrChannelArchived.Error()).Return(nil).Times(1)
+
+		s.sendDue(now)
+	})
+
+	t.Run("create failed", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		sm := newScheduledMessage()
+		s, cm, repo, tree := setup(t, now, sm)
+
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(sm.ID, now).Return(true, nil).Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(sm.UserID, false).Return(activeUser(sm, role.User), nil).Times(1)
+		cm.EXPECT().IsChannelAccessibleToUser(sm.UserID, sm.ChannelID).Return(true, nil).Times(1)
+		cm.EXPECT().IsPublicChannel(sm.ChannelID).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(sm.ChannelID).Return(false).Times(1)
+		repo.MockMessageRepository.EXPECT().CreateMessage(sm.UserID, sm.ChannelID, content).Return(nil, errors.New("db error")).Times(1)
+		// 送信待ちに戻して間隔を空けて再試行する
+		repo.MockScheduledMessageRepository.EXPECT().ReleaseScheduledMessage(sm.ID, now.Add(schedulerRetryBackoff)).Return(nil).Times(1)
+
+		s.sendDue(now)
+	})
+
+	t.Run("create failed (backoff)", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		sm := newScheduledMessage()
+		sm.Attempts = 2
+		s, cm, repo, tree := setup(t, now, sm)
+
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(sm.ID, now).Return(true, nil).Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(sm.UserID, false).Return(activeUser(sm, role.User), nil).Times(1)
+		cm.EXPECT().IsChannelAccessibleToUser(sm.UserID, sm.ChannelID).Return(true, nil).Times(1)
+		cm.EXPECT().IsPublicChannel(sm.ChannelID).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(sm.ChannelID).Return(false).Times(1)
+		repo.MockMessageRepository.EXPECT().CreateMessage(sm.UserID, sm.ChannelID, content).Return(nil, errors.New("db error")).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().ReleaseScheduledMessage(sm.ID, now.Add(4*schedulerRetryBackoff)).Return(nil).Times(1)
+
+		s.sendDue(now)
+	})
+
+	t.Run("create failed (last attempt)", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		sm := newScheduledMessage()
+		sm.Attempts = schedulerMaxAttempts - 1
+		s, cm, repo, tree := setup(t, now, sm)
+
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(sm.ID, now).Return(true, nil).Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(sm.UserID, false).Return(activeUser(sm, role.User), nil).Times(1)
+		cm.EXPECT().IsChannelAccessibleToUser(sm.UserID, sm.ChannelID).Return(true, nil).Times(1)
+		cm.EXPECT().IsPublicChannel(sm.ChannelID).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(sm.ChannelID).Return(false).Times(1)
+		repo.MockMessageRepository.EXPECT().CreateMessage(sm.UserID, sm.ChannelID, content).Return(nil, errors.New("db error")).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().SetScheduledMessageError(sm.ID, errScheduledMessageTooManyTries.Error()).Return(nil).Times(1)
+
+		s.sendDue(now)
+	})
+
+	t.Run("too many attempts", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		sm := newScheduledMessage()
+		sm.Attempts = schedulerMaxAttempts
+		s, _, repo, _ := setup(t, now, sm)
+
+		// 回収されたメッセージも試行回数の上限を超えたら送信しない
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(sm.ID, now).Return(true, nil).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().SetScheduledMessageError(sm.ID, errScheduledMessageTooManyTries.Error()).Return(nil).Times(1)
+
+		s.sendDue(now)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		sm := newScheduledMessage()
+		s, cm, repo, tree := setup(t, now, sm)
+
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(sm.ID, now).Return(true, nil).Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(sm.UserID, false).Return(activeUser(sm, role.User), nil).Times(1)
+		cm.EXPECT().IsChannelAccessibleToUser(sm.UserID, sm.ChannelID).Return(true, nil).Times(1)
+		cm.EXPECT().IsPublicChannel(sm.ChannelID).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(sm.ChannelID).Return(false).Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			CreateMessage(sm.UserID, sm.ChannelID, content).
+			Return(&model.Message{ID: uuid.NewV3(uuid.Nil, "m1"), UserID: sm.UserID, ChannelID: sm.ChannelID, Text: content}, nil).
+			Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().DeleteScheduledMessage(sm.ID).Return(nil).Times(1)
+
+		s.sendDue(now)
+	})
+}
@@ -60,6 +60,10 @@ func (m *timelineMessage) GetText() string {
 	return m.Model.Text
 }
 
+func (m *timelineMessage) GetThreadID() optional.UUID {
+	return m.Model.ThreadID
+}
+
 func (m *timelineMessage) GetCreatedAt() time.Time {
 	return m.Model.CreatedAt
 }
@@ -76,6 +80,14 @@ func (m *timelineMessage) GetPin() *model.Pin {
 	return m.Model.Pin
 }
 
+func (m *timelineMessage) GetActions() model.MessageActions {
+	return messageActions(m.Model)
+}
+
+func (m *timelineMessage) IsHidden() bool {
+	return m.Model.Hidden
+}
+
 func (m *timelineMessage) MarshalJSON() ([]byte, error) {
 	type object struct {
 		ID        uuid.UUID `json:"id"`
@@ -84,12 +96,14 @@ func (m *timelineMessage) MarshalJSON() ([]byte, error) {
 		Content   string    `json:"content"`
 		CreatedAt time.Time `json:"createdAt"`
 		UpdatedAt time.Time `json:"updatedAt"`
+		Hidden    bool      `json:"hidden"`
 	}
 	type objectWithPreload struct {
 		object
 		Pinned   bool                 `json:"pinned"`
 		Stamps   []model.MessageStamp `json:"stamps"`
-		ThreadID optional.UUID        `json:"threadId"` // TODO
+		ThreadID optional.UUID        `json:"threadId"`
+		Actions  model.MessageActions `json:"actions,omitempty"`
 	}
 	var v interface{}
 	if m.preloaded {
@@ -98,22 +112,25 @@ func (m *timelineMessage) MarshalJSON() ([]byte, error) {
 				ID:        m.Model.ID,
 				UserID:    m.Model.UserID,
 				ChannelID: m.Model.ChannelID,
-				Content:   m.Model.Text,
+				Content:   VisibleText(m.Model),
 				CreatedAt: m.Model.CreatedAt,
 				UpdatedAt: m.Model.UpdatedAt,
+				Hidden:    m.Model.Hidden,
 			},
 			Pinned:   m.Model.Pin != nil,
 			Stamps:   m.Model.Stamps,
-			ThreadID: optional.UUID{},
+			ThreadID: m.Model.ThreadID,
+			Actions:  messageActions(m.Model),
 		}
 	} else {
 		v = &object{
 			ID:        m.Model.ID,
 			UserID:    m.Model.UserID,
 			ChannelID: m.Model.ChannelID,
-			Content:   m.Model.Text,
+			Content:   VisibleText(m.Model),
 			CreatedAt: m.Model.CreatedAt,
 			UpdatedAt: m.Model.UpdatedAt,
+			Hidden:    m.Model.Hidden,
 		}
 	}
 	return jsonIter.ConfigFastest.Marshal(v)
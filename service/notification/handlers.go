@@ -15,6 +15,7 @@ import (
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/fcm"
+	"github.com/traPtitech/traQ/service/rbac/role"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/service/ws"
 	"github.com/traPtitech/traQ/utils/message"
@@ -27,6 +28,8 @@ var handlerMap = map[string]eventHandler{
 	event.MessageCreated:            messageCreatedHandler,
 	event.MessageUpdated:            messageUpdatedHandler,
 	event.MessageDeleted:            messageDeletedHandler,
+	event.MessageHidden:             messageUpdatedHandler,
+	event.MessageReported:           messageReportedHandler,
 	event.MessagePinned:             messagePinnedHandler,
 	event.MessageUnpinned:           messageUnpinnedHandler,
 	event.MessageStamped:            messageStampedHandler,
@@ -94,12 +97,19 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 	}
 	wsEventType := "MESSAGE_CREATED"
 	wsPayloadNotCited := map[string]interface{}{
-		"id":        m.ID,
-		"is_citing": false,
+		"id":             m.ID,
+		"is_citing":      false,
+		"is_keyword_hit": false,
 	}
 	wsPayloadCited := map[string]interface{}{
-		"id":        m.ID,
-		"is_citing": true,
+		"id":             m.ID,
+		"is_citing":      true,
+		"is_keyword_hit": false,
+	}
+	wsPayloadKeywordHit := map[string]interface{}{
+		"id":             m.ID,
+		"is_citing":      false,
+		"is_keyword_hit": true,
 	}
 
 	viewers := set.UUID{}       // バックグラウンドを含む対象チャンネル閲覧中のユーザー
@@ -107,6 +117,7 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 	markedUsers := set.UUID{}   // チャンネル未読管理ユーザー
 	noticeable := set.UUID{}    // noticeableな未読追加対象のユーザー
 	citedUsers := set.UUID{}    // メッセージで引用されたメッセージを投稿したユーザー
+	keywordUsers := set.UUID{}  // 通知キーワードに一致したユーザー
 	dmMembers := set.UUID{}     // isDMの場合 DMのメンバー
 
 	// メッセージボディ作成
@@ -145,6 +156,7 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 		noticeable.Add(users...)
 
 	case isDM: // DM
+		// 非公開チャンネルはDMのみ。スレッドのフォロワー(スレッドへの投稿者)はDMのメンバーに限られ、メンバー全員に通知するため別途追加しない
 		users, err := ns.repo.GetUserIDs(q.CMemberOf(chID))
 		if err != nil {
 			logger.Error("failed to GetPrivateChannelMemberIDs", zap.Error(err), zap.Stringer("channelId", m.ChannelID)) // 失敗
@@ -230,6 +242,53 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 				notifiedUsers.Add(uid)
 			}
 		}
+		// スレッドのフォロワーへの通知
+		if m.ThreadID.Valid {
+			followers, err := ns.repo.GetThreadFollowerIDs(m.ThreadID.UUID)
+			if err != nil {
+				// 取得に失敗した場合はフォロワーへの通知をスキップ
+				logger.Error("failed to GetThreadFollowerIDs", zap.Error(err), zap.Stringer("threadId", m.ThreadID.UUID)) // 失敗
+			} else {
+				for _, uid := range followers {
+					user, err := ns.repo.GetUser(uid, false)
+					if err != nil {
+						logger.Error("failed to GetUser", zap.Error(err), zap.Stringer("userId", uid)) // 失敗
+						continue
+					}
+					// 凍結ユーザー / Botの除外
+					if !user.IsActive() || user.IsBot() {
+						continue
+					}
+
+					notifiedUsers.Add(uid)
+					markedUsers.Add(uid)
+					noticeable.Add(uid)
+				}
+			}
+		}
+		// 通知キーワードに一致したユーザーへの通知
+		// 全ユーザーを走査しないように、チャンネルを未読管理レベルで購読しているユーザーのみを対象とする
+		keywords, err := ns.repo.GetAllNotificationKeywords()
+		if err != nil {
+			// 取得に失敗した場合はキーワード通知をスキップ
+			logger.Error("failed to GetAllNotificationKeywords", zap.Error(err)) // 失敗
+		} else {
+			for _, uid := range mark {
+				// 既に通知対象のユーザー / 投稿者の除外
+				if notifiedUsers.Contains(uid) || uid == m.UserID {
+					continue
+				}
+				kws, ok := keywords[uid]
+				if !ok || len(message.MatchKeywords(parsed.PlainText, kws)) == 0 {
+					continue
+				}
+
+				notifiedUsers.Add(uid)
+				markedUsers.Add(uid)
+				noticeable.Add(uid)
+				keywordUsers.Add(uid)
+			}
+		}
 	}
 
 	// チャンネル閲覧者取得
@@ -253,26 +312,46 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 	// WS送信
 	var targetFuncNotCited ws.TargetFunc
 	var targetFuncCited ws.TargetFunc
+	var targetFuncKeywordHit ws.TargetFunc
 	if isDM {
 		targetFuncNotCited = ws.TargetUserSets(dmMembers)
 		targetFuncCited = ws.TargetNone()
+		targetFuncKeywordHit = ws.TargetNone()
 	} else {
 		targetFuncNotCited = ws.And(
 			ws.Or(
 				ws.TargetUserSets(markedUsers, viewers),
 				ws.TargetTimelineStreamingEnabled(),
 			),
-			ws.Not(ws.TargetUserSets(citedUsers)),
+			ws.Not(ws.TargetUserSets(citedUsers, keywordUsers)),
 		)
 		targetFuncCited = ws.TargetUserSets(citedUsers)
+		targetFuncKeywordHit = ws.And(
+			ws.TargetUserSets(keywordUsers),
+			ws.Not(ws.TargetUserSets(citedUsers)),
+		)
 	}
 	go ns.ws.WriteMessage(wsEventType, wsPayloadNotCited, targetFuncNotCited)
 	go ns.ws.WriteMessage(wsEventType, wsPayloadCited, targetFuncCited)
+	go ns.ws.WriteMessage(wsEventType, wsPayloadKeywordHit, targetFuncKeywordHit)
 
 	// FCM送信
 	targets := notifiedUsers.Clone()
 	targets.Remove(m.UserID)
+	removeDNDUsers(ns, targets, forceNotify, time.Now())
+	keywordTargets := set.UUID{}
+	for uid := range keywordUsers {
+		if targets.Contains(uid) {
+			targets.Remove(uid)
+			keywordTargets.Add(uid)
+		}
+	}
 	ns.fcm.Send(targets, fcmPayload, true)
+	if len(keywordTargets) > 0 {
+		keywordPayload := *fcmPayload
+		keywordPayload.KeywordHit = true
+		ns.fcm.Send(keywordTargets, &keywordPayload, true)
+	}
 }
 
 func messageUpdatedHandler(ns *Service, ev hub.Message) {
@@ -297,6 +376,22 @@ func messageUpdatedHandler(ns *Service, ev hub.Message) {
 	go ns.ws.WriteMessage(wsEventType, wsPayload, targetFunc)
 }
 
+func messageReportedHandler(ns *Service, ev hub.Message) {
+	// 管理者に通知
+	admins, err := ns.repo.GetUserIDs(repository.UsersQuery{}.Active().NotBot().RoleOf(role.Admin))
+	if err != nil {
+		ns.logger.Error("failed to GetUserIDs", zap.Error(err))
+		return
+	}
+	if len(admins) == 0 {
+		return
+	}
+	go ns.ws.WriteMessage("MESSAGE_REPORTED", map[string]interface{}{
+		"id":         ev.Fields["report_id"].(uuid.UUID),
+		"message_id": ev.Fields["message_id"].(uuid.UUID),
+	}, ws.TargetUsers(admins...))
+}
+
 func messageDeletedHandler(ns *Service, ev hub.Message) {
 	cid := ev.Fields["message"].(*model.Message).ChannelID
 	wsEventType := "MESSAGE_DELETED"
@@ -682,6 +777,29 @@ func channelHandler(ns *Service, ev hub.Message, eventType string) {
 	}
 }
 
+// removeDNDUsers おやすみモード中のユーザーをプッシュ通知の対象から外します
+//
+// 強制通知チャンネルの場合、強制通知チャンネルもミュートする設定のユーザーのみを外します
+func removeDNDUsers(ns *Service, targets set.UUID, forceNotify bool, now time.Time) {
+	if len(targets) == 0 {
+		return
+	}
+	settings, err := ns.repo.GetDNDUserSettings()
+	if err != nil {
+		ns.logger.Error("failed to GetDNDUserSettings", zap.Error(err)) // 失敗した場合はそのまま通知する
+		return
+	}
+	for _, us := range settings {
+		if !targets.Contains(us.UserID) || !us.IsDNDActive(now) {
+			continue
+		}
+		if forceNotify && !us.DNDMuteForceChannels {
+			continue
+		}
+		targets.Remove(us.UserID)
+	}
+}
+
 func channelViewerMulticast(ns *Service, cid uuid.UUID, wsEventType string, wsPayload interface{}) {
 	go ns.ws.WriteMessage(wsEventType, wsPayload, ws.TargetChannelViewers(cid))
 }
@@ -13,6 +13,8 @@ const (
 	ReportMessage = Permission("report_message")
 	// GetMessageReports メッセージ通報取得権限
 	GetMessageReports = Permission("get_message_reports")
+	// HandleMessageReports メッセージ通報対応権限
+	HandleMessageReports = Permission("handle_message_reports")
 	// CreateMessagePin ピン留め作成権限
 	CreateMessagePin = Permission("create_message_pin")
 	// DeleteMessagePin ピン留め削除権限
@@ -85,6 +85,7 @@ var List = []Permission{
 	DeleteMessage,
 	ReportMessage,
 	GetMessageReports,
+	HandleMessageReports,
 
 	GetChannelSubscription,
 	EditChannelSubscription,
@@ -0,0 +1,283 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/lang/cjk"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/highlight/highlighter/html"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/message"
+)
+
+const (
+	bleveChannelsFacet = "channels"
+	bleveAuthorsFacet  = "authors"
+	bleveMonthsFacet   = "months"
+	// bleveMonthsFacetSize 投稿月の集計で返す最大項目数 全ての月を返すよう十分大きくする
+	bleveMonthsFacetSize = 1200
+	bleveMonthLayout     = "2006-01"
+)
+
+// BleveEngineConfig 組み込み検索エンジン設定
+type BleveEngineConfig struct {
+	// Enabled 組み込み検索エンジンを使用するかどうか
+	Enabled bool
+	// Path インデックスの保存先ディレクトリ 空の場合はメモリ上に保持します
+	Path string
+}
+
+// bleveEngine search.Engine 実装
+type bleveEngine struct {
+	index bleve.Index
+	mm    message.Manager
+	cm    channel.Manager
+	repo  repository.Repository
+	l     *zap.Logger
+	done  chan<- struct{}
+}
+
+// bleveMessageDoc bleveに入るメッセージの情報
+type bleveMessageDoc struct {
+	UserID         string    `json:"userId"`
+	ChannelID      string    `json:"channelId"`
+	IsPublic       bool      `json:"isPublic"`
+	Bot            bool      `json:"bot"`
+	Text           string    `json:"text"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+	CreatedMonth   string    `json:"createdMonth"` // 集計用 投稿月(UTC) 2006-01
+	To             []string  `json:"to"`
+	Citation       []string  `json:"citation"`
+	HasURL         bool      `json:"hasURL"`
+	HasAttachments bool      `json:"hasAttachments"`
+	HasImage       bool      `json:"hasImage"`
+	HasVideo       bool      `json:"hasVideo"`
+	HasAudio       bool      `json:"hasAudio"`
+}
+
+// newBleveMapping bleveMessageDoc に対応するマッピングを生成します
+func newBleveMapping() mapping.IndexMapping {
+	keywordField := bleve.NewTextFieldMapping()
+	keywordField.Analyzer = keyword.Name
+	keywordField.Store = false
+	keywordField.IncludeInAll = false
+
+	booleanField := bleve.NewBooleanFieldMapping()
+	booleanField.Store = false
+	booleanField.IncludeInAll = false
+
+	dateField := bleve.NewDateTimeFieldMapping()
+	dateField.Store = false
+	dateField.IncludeInAll = false
+
+	textField := bleve.NewTextFieldMapping()
+	textField.Analyzer = cjk.AnalyzerName
+	textField.Store = true // ハイライトに必要
+
+	doc := bleve.NewDocumentStaticMapping()
+	for _, f := range []string{"userId", "channelId", "createdMonth", "to", "citation"} {
+		doc.AddFieldMappingsAt(f, keywordField)
+	}
+	for _, f := range []string{"isPublic", "bot", "hasURL", "hasAttachments", "hasImage", "hasVideo", "hasAudio"} {
+		doc.AddFieldMappingsAt(f, booleanField)
+	}
+	for _, f := range []string{"createdAt", "updatedAt"} {
+		doc.AddFieldMappingsAt(f, dateField)
+	}
+	doc.AddFieldMappingsAt("text", textField)
+
+	im := bleve.NewIndexMapping()
+	im.DefaultMapping = doc
+	im.DefaultAnalyzer = cjk.AnalyzerName
+	return im
+}
+
+// openBleveIndex インデックスを開きます 存在しない場合は作成します
+func openBleveIndex(path string) (bleve.Index, error) {
+	if len(path) == 0 {
+		return bleve.NewMemOnly(newBleveMapping())
+	}
+	if _, err := os.Stat(path); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		return bleve.New(path, newBleveMapping())
+	}
+	return bleve.Open(path)
+}
+
+// NewBleveEngine 組み込み検索エンジンを生成します
+func NewBleveEngine(mm message.Manager, cm channel.Manager, repo repository.Repository, logger *zap.Logger, config BleveEngineConfig) (Engine, error) {
+	index, err := openBleveIndex(config.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init search engine: %w", err)
+	}
+
+	done := make(chan struct{})
+	engine := &bleveEngine{
+		index: index,
+		mm:    mm,
+		cm:    cm,
+		repo:  repo,
+		l:     logger.Named("search"),
+		done:  done,
+	}
+
+	go engine.syncLoop(done)
+
+	return engine, nil
+}
+
+func (e *bleveEngine) Do(q *Query) (Result, error) {
+	e.l.Debug("do search", zap.Reflect("q", q))
+
+	req, err := newBleveSearchRequest(q)
+	if err != nil {
+		return nil, err
+	}
+	sr, err := e.index.Search(req)
+	if err != nil {
+		return nil, err
+	}
+
+	e.l.Debug("search result", zap.Reflect("hits", sr.Hits))
+	return e.bindBleveResult(sr, q.GetSortKey(), req.Size)
+}
+
+// newBleveSearchRequest 検索クエリからbleveの検索リクエストを生成します
+func newBleveSearchRequest(q *Query) (*bleve.SearchRequest, error) {
+	var musts []query.Query
+
+	if q.Word.Valid {
+		mq := bleve.NewMatchQuery(q.Word.String)
+		mq.SetField("text")
+		mq.SetOperator(query.MatchQueryOperatorAnd)
+		musts = append(musts, mq)
+	}
+
+	if q.After.Valid || q.Before.Valid {
+		// ゼロ値は範囲の指定なしとして扱われる
+		inclusive := false
+		rq := bleve.NewDateRangeInclusiveQuery(q.After.ValueOrZero(), q.Before.ValueOrZero(), &inclusive, &inclusive)
+		rq.SetField("createdAt")
+		musts = append(musts, rq)
+	}
+
+	// チャンネル指定があるときはそのチャンネルを検索
+	// そうでないときはPublicチャンネルを検索
+	if q.In.Valid {
+		musts = append(musts, bleveTermQuery("channelId", q.In.UUID))
+	} else {
+		musts = append(musts, bleveBoolQuery("isPublic", true))
+	}
+
+	if q.To.Valid {
+		musts = append(musts, bleveTermQuery("to", q.To.UUID))
+	}
+
+	if q.From.Valid {
+		musts = append(musts, bleveTermQuery("userId", q.From.UUID))
+	}
+
+	if q.Citation.Valid {
+		musts = append(musts, bleveTermQuery("citation", q.Citation.UUID))
+	}
+
+	if q.Bot.Valid {
+		musts = append(musts, bleveBoolQuery("bot", q.Bot.Bool))
+	}
+
+	if q.HasURL.Valid {
+		musts = append(musts, bleveBoolQuery("hasURL", q.HasURL.Bool))
+	}
+
+	if q.HasAttachments.Valid {
+		musts = append(musts, bleveBoolQuery("hasAttachments", q.HasAttachments.Bool))
+	}
+
+	if q.HasImage.Valid {
+		musts = append(musts, bleveBoolQuery("hasImage", q.HasImage.Bool))
+	}
+	if q.HasVideo.Valid {
+		musts = append(musts, bleveBoolQuery("hasVideo", q.HasVideo.Bool))
+	}
+	if q.HasAudio.Valid {
+		musts = append(musts, bleveBoolQuery("hasAudio", q.HasAudio.Bool))
+	}
+
+	limit, offset := 20, 0
+	if q.Limit.Valid {
+		limit = int(q.Limit.Int64)
+	}
+	if q.Offset.Valid {
+		offset = int(q.Offset.Int64)
+	}
+
+	// NOTE: 現状`sort.Key`はそのままbleveのソートキーとして使える前提
+	sort := q.GetSortKey()
+	sortKey := sort.Key
+	if sort.Desc {
+		sortKey = "-" + sortKey
+	}
+
+	req := bleve.NewSearchRequestOptions(bleve.NewConjunctionQuery(musts...), limit, offset, false)
+	req.SortBy([]string{sortKey, "_id"}) // `_id`はSearchAfterで同時刻のメッセージを取りこぼさないためのタイブレーカー
+	if q.Cursor.Valid {
+		values, err := q.cursorValues()
+		if err != nil {
+			return nil, err
+		}
+		after := make([]string, len(values))
+		for i, v := range values {
+			s, ok := v.(string)
+			if !ok {
+				return nil, errInvalidCursor
+			}
+			after[i] = s
+		}
+		if len(after) != len(req.Sort) {
+			return nil, errInvalidCursor
+		}
+		req.SearchAfter = after
+	}
+	req.AddFacet(bleveChannelsFacet, bleve.NewFacetRequest("channelId", facetSize))
+	req.AddFacet(bleveAuthorsFacet, bleve.NewFacetRequest("userId", facetSize))
+	req.AddFacet(bleveMonthsFacet, bleve.NewFacetRequest("createdMonth", bleveMonthsFacetSize))
+	if q.Word.Valid {
+		req.Highlight = bleve.NewHighlightWithStyle(html.Name)
+		req.Highlight.AddField("text")
+	}
+	return req, nil
+}
+
+func (e *bleveEngine) Available() bool {
+	return true
+}
+
+func (e *bleveEngine) Close() error {
+	e.done <- struct{}{}
+	return e.index.Close()
+}
+
+func bleveTermQuery(field string, id uuid.UUID) query.Query {
+	q := bleve.NewTermQuery(id.String())
+	q.SetField(field)
+	return q
+}
+
+func bleveBoolQuery(field string, v bool) query.Query {
+	q := bleve.NewBoolFieldQuery(v)
+	q.SetField(field)
+	return q
+}
@@ -0,0 +1,99 @@
+package search
+
+import (
+	"sort"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search"
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/service/message"
+)
+
+// bleveResult search.Result 実装
+type bleveResult struct {
+	totalHits  int64
+	messages   []message.Message
+	highlights map[uuid.UUID][]string
+	facets     *Facets
+	nextCursor string
+}
+
+func (e *bleveEngine) bindBleveResult(sr *bleve.SearchResult, sortKey Sort, limit int) (Result, error) {
+	r := &bleveResult{
+		totalHits:  int64(sr.Total),
+		messages:   make([]message.Message, 0, len(sr.Hits)),
+		highlights: make(map[uuid.UUID][]string, len(sr.Hits)),
+		facets:     bindBleveFacets(sr.Facets),
+	}
+
+	for _, hit := range sr.Hits {
+		// NOTE: N+1 の可能性
+		m, err := e.mm.Get(uuid.Must(uuid.FromString(hit.ID)))
+		if err != nil {
+			return nil, err
+		}
+		// 非表示化ではupdated_atが更新されずインデックスに残るので、ここで非表示のメッセージを除外する
+		if m.IsHidden() {
+			continue
+		}
+		r.messages = append(r.messages, m)
+
+		if fragments, ok := hit.Fragments["text"]; ok {
+			r.highlights[m.GetID()] = fragments
+		}
+	}
+
+	// 取得件数分ヒットした場合は続きがある可能性がある
+	if hits := sr.Hits; len(hits) > 0 && len(hits) == limit {
+		last := hits[len(hits)-1].Sort
+		values := make([]interface{}, len(last))
+		for i, v := range last {
+			values[i] = v
+		}
+		r.nextCursor = encodeCursor(sortKey, values)
+	}
+
+	return r, nil
+}
+
+func bindBleveFacets(facets search.FacetResults) *Facets {
+	f := &Facets{
+		Channels: bindBleveFacetBuckets(facets[bleveChannelsFacet]),
+		Authors:  bindBleveFacetBuckets(facets[bleveAuthorsFacet]),
+		Months:   bindBleveFacetBuckets(facets[bleveMonthsFacet]),
+	}
+	sort.Slice(f.Months, func(i, j int) bool { return f.Months[i].Key < f.Months[j].Key })
+	return f
+}
+
+func bindBleveFacetBuckets(fr *search.FacetResult) []*FacetBucket {
+	buckets := make([]*FacetBucket, 0)
+	if fr == nil {
+		return buckets
+	}
+	for _, t := range fr.Terms.Terms() {
+		buckets = append(buckets, &FacetBucket{Key: t.Term, Count: int64(t.Count)})
+	}
+	return buckets
+}
+
+func (r *bleveResult) TotalHits() int64 {
+	return r.totalHits
+}
+
+func (r *bleveResult) Hits() []message.Message {
+	return r.messages
+}
+
+func (r *bleveResult) Highlights() map[uuid.UUID][]string {
+	return r.highlights
+}
+
+func (r *bleveResult) Facets() *Facets {
+	return r.facets
+}
+
+func (r *bleveResult) NextCursor() string {
+	return r.nextCursor
+}
@@ -0,0 +1,195 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+// bleveLastSyncedKey 最後に同期したメッセージのupdatedAtを保存するインデックス内部のキー
+var bleveLastSyncedKey = []byte("lastSynced")
+
+// convertMessage メッセージをbleveへ入れる型に変換する
+func (e *bleveEngine) convertMessage(m *model.Message, parseResult *message.ParseResult, userCache userCache) (*bleveMessageDoc, error) {
+	isBot, err := userCache.isBot(e.repo, m.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	attr := getAttributes(e.repo, e.l, m, parseResult)
+
+	return &bleveMessageDoc{
+		UserID:         m.UserID.String(),
+		ChannelID:      m.ChannelID.String(),
+		IsPublic:       e.cm.IsPublicChannel(m.ChannelID),
+		Bot:            isBot,
+		Text:           m.Text,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+		CreatedMonth:   m.CreatedAt.UTC().Format(bleveMonthLayout),
+		To:             uuidsToStrings(attr.To),
+		Citation:       uuidsToStrings(attr.Citation),
+		HasURL:         attr.HasURL,
+		HasAttachments: attr.HasAttachments,
+		HasImage:       attr.HasImage,
+		HasVideo:       attr.HasVideo,
+		HasAudio:       attr.HasAudio,
+	}, nil
+}
+
+func uuidsToStrings(ids []uuid.UUID) []string {
+	res := make([]string, len(ids))
+	for i, id := range ids {
+		res[i] = id.String()
+	}
+	return res
+}
+
+func (e *bleveEngine) syncLoop(done <-chan struct{}) {
+	t := time.NewTicker(syncInterval)
+	defer t.Stop()
+loop:
+	for {
+		err := e.sync()
+		if err != nil {
+			e.l.Error(err.Error(), zap.Error(err))
+		}
+
+		select {
+		case <-t.C:
+		case <-done:
+			break loop
+		}
+	}
+}
+
+// sync メッセージを repository.MessageRepository から読み取り、bleveへindexします
+func (e *bleveEngine) sync() error {
+	e.l.Debug("syncing messages with bleve")
+
+	lastSynced, err := e.lastSynced()
+	if err != nil {
+		return err
+	}
+
+	var userCache userCache
+	lastInsert := lastSynced
+	for {
+		messages, more, err := e.repo.GetUpdatedMessagesAfter(lastInsert, syncMessageBulk)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			break
+		}
+		lastInsert = messages[len(messages)-1].UpdatedAt
+
+		// NOTE: index時にBotかどうかを確認するN+1問題へのworkaround
+		if userCache == nil && more {
+			// 新規メッセージが2ページ以上の時のみデータが入ったキャッシュを作成
+			userCache, err = newUserCache(e.repo, e.l)
+			if err != nil {
+				return err
+			}
+		}
+
+		// bleveのIndexは常にドキュメント全体を置き換えるので、新規作成と更新を区別しない
+		batch := e.index.NewBatch()
+		for _, v := range messages {
+			if v.Hidden {
+				// 非表示のメッセージは検索できないようにインデックスから削除する
+				batch.Delete(v.ID.String())
+				continue
+			}
+			doc, err := e.convertMessage(v, message.Parse(v.Text), userCache)
+			if err != nil {
+				return err
+			}
+			if err := batch.Index(v.ID.String(), doc); err != nil {
+				return err
+			}
+		}
+		if err := setLastSynced(batch, lastInsert); err != nil {
+			return err
+		}
+		if err := e.index.Batch(batch); err != nil {
+			return err
+		}
+
+		e.l.Info(fmt.Sprintf("indexed %v message(s) to index, last insert %v", len(messages), lastInsert))
+
+		if !more {
+			break
+		}
+	}
+
+	lastDelete := lastSynced
+	for {
+		messages, more, err := e.repo.GetDeletedMessagesAfter(lastDelete, syncMessageBulk)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			break
+		}
+		if !messages[len(messages)-1].DeletedAt.Valid {
+			return errors.New("expected DeletedAt to exist, but found nil")
+		}
+		lastDelete = messages[len(messages)-1].DeletedAt.Time
+
+		batch := e.index.NewBatch()
+		count := 0
+		for _, v := range messages {
+			if v.CreatedAt.After(lastSynced) {
+				continue
+			}
+			count++
+			batch.Delete(v.ID.String())
+		}
+		if count > 0 {
+			if err := e.index.Batch(batch); err != nil {
+				return err
+			}
+			e.l.Info(fmt.Sprintf("deleted %v message(s) from index, last delete %v", count, lastDelete))
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return nil
+}
+
+// lastSynced インデックスに同期済みの、updatedAtが一番新しいメッセージの値を取得します
+func (e *bleveEngine) lastSynced() (time.Time, error) {
+	b, err := e.index.GetInternal(bleveLastSyncedKey)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var t time.Time
+	if len(b) == 0 {
+		return t, nil
+	}
+	if err := t.UnmarshalBinary(b); err != nil {
+		return time.Time{}, err
+	}
+	return t, nil
+}
+
+// setLastSynced 最後に同期したメッセージのupdatedAtをバッチに追加します
+func setLastSynced(batch *bleve.Batch, t time.Time) error {
+	b, err := t.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	batch.SetInternal(bleveLastSyncedKey, b)
+	return nil
+}
@@ -0,0 +1,168 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestNewBleveSearchRequest(t *testing.T) {
+	t.Parallel()
+
+	index, err := bleve.NewMemOnly(newBleveMapping())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = index.Close() })
+
+	ch := uuid.NewV3(uuid.Nil, "c")
+	user := uuid.NewV3(uuid.Nil, "u")
+	other := uuid.NewV3(uuid.Nil, "o")
+	now := time.Now()
+
+	docs := map[string]*bleveMessageDoc{
+		"a": {UserID: user.String(), ChannelID: ch.String(), IsPublic: true, Text: "こんにちは世界 hello world", CreatedAt: now.Add(-2 * time.Hour), UpdatedAt: now, To: []string{other.String()}, HasImage: true},
+		"b": {UserID: other.String(), ChannelID: ch.String(), IsPublic: true, Bot: true, Text: "hello there", CreatedAt: now.Add(-1 * time.Hour), UpdatedAt: now.Add(-1 * time.Hour)},
+		"c": {UserID: user.String(), ChannelID: uuid.Nil.String(), IsPublic: false, Text: "hello", CreatedAt: now, UpdatedAt: now},
+	}
+	for id, doc := range docs {
+		doc.CreatedMonth = doc.CreatedAt.UTC().Format(bleveMonthLayout)
+		require.NoError(t, index.Index(id, doc))
+	}
+
+	tests := []struct {
+		name string
+		q    *Query
+		want []string
+	}{
+		{"word", &Query{Word: optional.StringFrom("hello")}, []string{"b", "a"}},
+		{"word (cjk)", &Query{Word: optional.StringFrom("世界")}, []string{"a"}},
+		{"word (and)", &Query{Word: optional.StringFrom("hello world")}, []string{"a"}},
+		{"in", &Query{In: optional.UUIDFrom(uuid.Nil)}, []string{"c"}},
+		{"from", &Query{From: optional.UUIDFrom(user)}, []string{"a"}},
+		{"to", &Query{To: optional.UUIDFrom(other)}, []string{"a"}},
+		{"bot", &Query{Bot: optional.BoolFrom(true)}, []string{"b"}},
+		{"hasImage", &Query{HasImage: optional.BoolFrom(true)}, []string{"a"}},
+		{"before", &Query{Before: optional.TimeFrom(now.Add(-90 * time.Minute))}, []string{"a"}},
+		{"after", &Query{After: optional.TimeFrom(now.Add(-90 * time.Minute))}, []string{"b"}},
+		{"sort", &Query{Sort: optional.StringFrom("-createdAt")}, []string{"a", "b"}},
+		{"limit and offset", &Query{Limit: optional.IntFrom(1), Offset: optional.IntFrom(1)}, []string{"a"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req, err := newBleveSearchRequest(tt.q)
+			require.NoError(t, err)
+			sr, err := index.Search(req)
+			require.NoError(t, err)
+			ids := make([]string, len(sr.Hits))
+			for i, hit := range sr.Hits {
+				ids[i] = hit.ID
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+
+	t.Run("facets and highlights", func(t *testing.T) {
+		t.Parallel()
+		req, err := newBleveSearchRequest(&Query{Word: optional.StringFrom("hello")})
+		require.NoError(t, err)
+		sr, err := index.Search(req)
+		require.NoError(t, err)
+
+		f := bindBleveFacets(sr.Facets)
+		assert.ElementsMatch(t, []*FacetBucket{{Key: ch.String(), Count: 2}}, f.Channels)
+		assert.ElementsMatch(t, []*FacetBucket{{Key: user.String(), Count: 1}, {Key: other.String(), Count: 1}}, f.Authors)
+		var months int64
+		for _, b := range f.Months {
+			months += b.Count
+		}
+		assert.EqualValues(t, 2, months)
+
+		for _, hit := range sr.Hits {
+			if assert.NotEmpty(t, hit.Fragments["text"]) {
+				assert.Contains(t, hit.Fragments["text"][0], highlightPreTag+"hello"+highlightPostTag)
+			}
+		}
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		t.Parallel()
+		var (
+			ids    []string
+			cursor optional.String
+		)
+		for i := 0; i < 3; i++ {
+			q := &Query{Limit: optional.IntFrom(1), Cursor: cursor}
+			require.NoError(t, q.Validate())
+			req, err := newBleveSearchRequest(q)
+			require.NoError(t, err)
+			sr, err := index.Search(req)
+			require.NoError(t, err)
+			for _, hit := range sr.Hits {
+				ids = append(ids, hit.ID)
+			}
+			if len(sr.Hits) < 1 {
+				break
+			}
+			values := make([]interface{}, len(sr.Hits[0].Sort))
+			for i, v := range sr.Hits[0].Sort {
+				values[i] = v
+			}
+			cursor = optional.StringFrom(encodeCursor(q.GetSortKey(), values))
+		}
+		assert.Equal(t, []string{"b", "a"}, ids)
+	})
+}
+
+type fakeMessage struct {
+	message.Message
+	id     uuid.UUID
+	hidden bool
+}
+
+func (m *fakeMessage) GetID() uuid.UUID { return m.id }
+func (m *fakeMessage) IsHidden() bool   { return m.hidden }
+
+type fakeMM struct {
+	message.Manager
+	messages map[uuid.UUID]*fakeMessage
+}
+
+func (mm *fakeMM) Get(id uuid.UUID) (message.Message, error) {
+	return mm.messages[id], nil
+}
+
+func TestBleveEngine_bindBleveResult(t *testing.T) {
+	t.Parallel()
+
+	visible := uuid.NewV3(uuid.Nil, "visible")
+	hidden := uuid.NewV3(uuid.Nil, "hidden")
+	e := &bleveEngine{mm: &fakeMM{messages: map[uuid.UUID]*fakeMessage{
+		visible: {id: visible},
+		hidden:  {id: hidden, hidden: true},
+	}}}
+
+	sr := &bleve.SearchResult{
+		Total: 2,
+		Hits: search.DocumentMatchCollection{
+			{ID: visible.String(), Fragments: search.FieldFragmentMap{"text": {"visible"}}},
+			{ID: hidden.String(), Fragments: search.FieldFragmentMap{"text": {"hidden"}}},
+		},
+	}
+	r, err := e.bindBleveResult(sr, Sort{Key: "createdAt", Desc: true}, 10)
+	require.NoError(t, err)
+
+	// 非表示のメッセージはヒットしない
+	if assert.Len(t, r.Hits(), 1) {
+		assert.Equal(t, visible, r.Hits()[0].GetID())
+	}
+	assert.NotContains(t, r.Highlights(), hidden)
+}
@@ -0,0 +1,58 @@
+package search
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// cursor 検索結果のページングカーソル
+//
+// クライアントには中身を隠蔽したbase64url文字列として渡します。
+type cursor struct {
+	// Sort カーソルを発行した検索のソート順
+	Sort string `json:"s"`
+	// Values 最後にヒットしたメッセージのソートキーの値 検索エンジンによって異なる
+	Values []interface{} `json:"v"`
+}
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+func (s Sort) String() string {
+	if s.Desc {
+		return "-" + s.Key
+	}
+	return s.Key
+}
+
+// encodeCursor ソートキーの値からカーソル文字列を生成します
+func encodeCursor(sort Sort, values []interface{}) string {
+	b, err := json.Marshal(&cursor{Sort: sort.String(), Values: values})
+	if err != nil {
+		panic(err) // 起こらない
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor カーソル文字列からソートキーの値を取り出します
+//
+// カーソルが不正な場合や、sortがカーソル発行時と異なる場合はエラーを返します。
+func decodeCursor(s string, sort Sort) ([]interface{}, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	var c cursor
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&c); err != nil || len(c.Values) == 0 {
+		return nil, errInvalidCursor
+	}
+	if c.Sort != sort.String() {
+		return nil, fmt.Errorf("cursor was issued for sort %s", c.Sort)
+	}
+	return c.Values, nil
+}
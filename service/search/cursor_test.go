@@ -0,0 +1,68 @@
+package search
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+
+	sort := Sort{Key: createdAtSortKey, Desc: true}
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+		c := encodeCursor(sort, []interface{}{int64(1656633600000), "id"})
+		values, err := decodeCursor(c, sort)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{json.Number("1656633600000"), "id"}, values)
+	})
+
+	t.Run("different sort", func(t *testing.T) {
+		t.Parallel()
+		c := encodeCursor(sort, []interface{}{"a"})
+		_, err := decodeCursor(c, Sort{Key: updatedAtSortKey, Desc: true})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		_, err := decodeCursor("!!!", sort)
+		assert.Error(t, err)
+		_, err = decodeCursor("e30", sort) // {}
+		assert.Error(t, err)
+	})
+}
+
+func TestQuery_Validate(t *testing.T) {
+	t.Parallel()
+
+	cursor := encodeCursor(Sort{Key: createdAtSortKey, Desc: true}, []interface{}{"a"})
+	tests := []struct {
+		name    string
+		q       Query
+		wantErr bool
+	}{
+		{"empty", Query{}, false},
+		{"offset", Query{Offset: optional.IntFrom(9900)}, false},
+		{"too large offset", Query{Offset: optional.IntFrom(9901)}, true},
+		{"cursor", Query{Cursor: optional.StringFrom(cursor)}, false},
+		{"cursor with offset", Query{Cursor: optional.StringFrom(cursor), Offset: optional.IntFrom(20)}, true},
+		{"cursor with different sort", Query{Cursor: optional.StringFrom(cursor), Sort: optional.StringFrom("updatedAt")}, true},
+		{"invalid cursor", Query{Cursor: optional.StringFrom("invalid")}, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.q.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
 
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/utils/optional"
@@ -41,6 +42,7 @@ type Query struct {
 	HasAudio       optional.Bool   `query:"hasAudio"`       // 添付ファイル（音声ファイル）
 	Limit          optional.Int    `query:"limit"`          // 取得件数
 	Offset         optional.Int    `query:"offset"`         // 取得Offset
+	Cursor         optional.String `query:"cursor"`         // 取得カーソル 前回の検索結果のNextCursor Offsetとは併用不可
 	Sort           optional.String `query:"sort"`           // 並び順 /[-\+]?key/
 }
 
@@ -49,11 +51,21 @@ func (q Query) Validate() error {
 		vd.Field(&q.Limit, vd.Min(1), vd.Max(100)),
 		// Cannot page through more than 10k hits with From and Size
 		// https://www.elastic.co/guide/en/elasticsearch/reference/current/paginate-search-results.html
-		vd.Field(&q.Offset, vd.Min(0), vd.Max(9900)),
+		// それ以上はCursorを使う
+		vd.Field(&q.Offset, vd.Min(0), vd.Max(9900), vd.When(q.Cursor.Valid, vd.Empty.Error("cannot be used with cursor"))),
 		vd.Field(&q.Sort, vd.Match(allowedSortKeysRegExp)),
+		vd.Field(&q.Cursor, vd.When(q.Cursor.Valid, vd.By(func(interface{}) error {
+			_, err := q.cursorValues()
+			return err
+		}))),
 	)
 }
 
+// cursorValues Cursorからソートキーの値を取り出します
+func (q Query) cursorValues() ([]interface{}, error) {
+	return decodeCursor(q.Cursor.String, q.GetSortKey())
+}
+
 // Sort ソート情報
 type Sort struct {
 	Key  string // 何によってソートするか
@@ -81,8 +93,36 @@ type Result interface {
 	TotalHits() int64
 	// Hits createdAtで降順にソートされた、ヒットしたメッセージ
 	Hits() []message.Message
+	// Highlights ヒットしたメッセージのIDと、本文のうち検索ワードに一致した部分を<mark>タグで囲んだ断片のマップ
+	//
+	// 断片はHTMLエスケープされています。検索ワードが無い場合や非表示のメッセージは含まれません。
+	Highlights() map[uuid.UUID][]string
+	// Facets ヒットしたメッセージ全体の集計結果
+	Facets() *Facets
+	// NextCursor 続きを取得するためのカーソル 続きが無い場合は空文字列
+	NextCursor() string
+}
+
+// Facets 検索結果の集計
+type Facets struct {
+	Channels []*FacetBucket `json:"channels"` // チャンネルIDごとの件数 件数の降順
+	Authors  []*FacetBucket `json:"authors"`  // 投稿者IDごとの件数 件数の降順
+	Months   []*FacetBucket `json:"months"`   // 投稿月(UTC)ごとの件数 キーは"2006-01"形式 月の昇順
 }
 
+// FacetBucket 集計の項目
+type FacetBucket struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+}
+
+const (
+	highlightPreTag  = "<mark>"
+	highlightPostTag = "</mark>"
+	// facetSize チャンネル・投稿者の集計で返す最大項目数
+	facetSize = 20
+)
+
 const createdAtSortKey = "createdAt" // 作成日時の新しい順
 const updatedAtSortKey = "updatedAt" // 更新日時の新しい順
 
@@ -2,7 +2,9 @@ package search
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -21,6 +23,20 @@ const (
 	esDateFormat      = "2006-01-02T15:04:05.000000000Z"
 )
 
+// esMessageIndexVersion メッセージindexのバージョン mappingの`_meta.version`に記録します
+//
+//	1: `id`フィールドを追加
+const esMessageIndexVersion = 1
+
+// esMigrationPollInterval indexのマイグレーションタスクの完了を確認する間隔
+const esMigrationPollInterval = 10 * time.Second
+
+const (
+	esChannelsAggregation = "channels"
+	esAuthorsAggregation  = "authors"
+	esMonthsAggregation   = "months"
+)
+
 func getIndexName(index string) string {
 	return esIndexPrefix + index
 }
@@ -39,11 +55,13 @@ type esEngine struct {
 	repo   repository.Repository
 	l      *zap.Logger
 	done   chan<- struct{}
+	// idSortable 全てのドキュメントに`id`フィールドがあり、タイブレーカーに使えるかどうか
+	idSortable atomic.Bool
 }
 
 // esMessageDoc Elasticsearchに入るメッセージの情報
 type esMessageDoc struct {
-	ID             uuid.UUID   `json:"-"`
+	ID             uuid.UUID   `json:"id"`
 	UserID         uuid.UUID   `json:"userId"`
 	ChannelID      uuid.UUID   `json:"channelId"`
 	IsPublic       bool        `json:"isPublic"`
@@ -77,7 +95,13 @@ type m map[string]interface{}
 // esMapping Elasticsearchに入るメッセージの情報
 // esMessageDoc と同じにする
 var esMapping = m{
+	"_meta": m{
+		"version": esMessageIndexVersion,
+	},
 	"properties": m{
+		"id": m{
+			"type": "keyword",
+		},
 		"userId": m{
 			"type": "keyword",
 		},
@@ -176,6 +200,7 @@ func NewESEngine(mm message.Manager, cm channel.Manager, repo repository.Reposit
 	}
 
 	// index確認
+	idSortable := true
 	if exists, err := client.IndexExists(getIndexName(esMessageIndex)).Do(context.Background()); err != nil {
 		return nil, fmt.Errorf("failed to init search engine: %w", err)
 	} else if !exists {
@@ -190,6 +215,10 @@ func NewESEngine(mm message.Manager, cm channel.Manager, repo repository.Reposit
 		if !r1.Acknowledged {
 			return nil, fmt.Errorf("failed to init search engine: index not acknowledged")
 		}
+	} else if version, err := getESMessageIndexVersion(client); err != nil {
+		return nil, fmt.Errorf("failed to init search engine: %w", err)
+	} else if version < esMessageIndexVersion {
+		idSortable = false
 	}
 
 	done := make(chan struct{})
@@ -201,12 +230,126 @@ func NewESEngine(mm message.Manager, cm channel.Manager, repo repository.Reposit
 		l:      logger.Named("search"),
 		done:   done,
 	}
+	engine.idSortable.Store(idSortable)
+
+	if !idSortable {
+		if err := putESMessageIDMapping(client); err != nil {
+			return nil, fmt.Errorf("failed to init search engine: %w", err)
+		}
+		go engine.migrateMessageID()
+	}
 
 	go engine.syncLoop(done)
 
 	return engine, nil
 }
 
+// getESMessageIndexVersion メッセージindexのmappingに記録されたバージョンを返します 記録されていない場合は0を返します
+func getESMessageIndexVersion(client *elastic.Client) (int, error) {
+	res, err := client.GetMapping().Index(getIndexName(esMessageIndex)).Do(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	index, _ := res[getIndexName(esMessageIndex)].(map[string]interface{})
+	mappings, _ := index["mappings"].(map[string]interface{})
+	meta, _ := mappings["_meta"].(map[string]interface{})
+	version, _ := meta["version"].(float64)
+	return int(version), nil
+}
+
+// putESMessageIDMapping `id`フィールドが無い既存のindexにフィールドを追加します
+func putESMessageIDMapping(client *elastic.Client) error {
+	r, err := client.PutMapping().Index(getIndexName(esMessageIndex)).BodyJson(m{
+		"properties": m{
+			"id": esMapping["properties"].(m)["id"],
+		},
+	}).Do(context.Background())
+	if err != nil {
+		return err
+	}
+	if !r.Acknowledged {
+		return errors.New("mapping not acknowledged")
+	}
+	return nil
+}
+
+// migrateMessageID 既存のドキュメントに`id`フィールドの値を埋め、完了したらindexのバージョンを記録します
+//
+// 値の埋め込みはesのタスクとして非同期に行います。完了するまでタイブレーカーには`_id`を使います。
+// 失敗した場合はバージョンを記録せず、次回の起動時にやり直します。
+func (e *esEngine) migrateMessageID() {
+	ctx := context.Background()
+	missing := elastic.NewBoolQuery().MustNot(elastic.NewExistsQuery("id"))
+	for {
+		count, err := e.client.Count(getIndexName(esMessageIndex)).Query(missing).Do(ctx)
+		if err != nil {
+			e.l.Error("failed to count messages without id field", zap.Error(err))
+			return
+		}
+		if count == 0 {
+			break
+		}
+
+		task, err := e.client.UpdateByQuery(getIndexName(esMessageIndex)).
+			Query(missing).
+			Script(elastic.NewScript("ctx._source.id = ctx._id")).
+			ProceedOnVersionConflict().
+			DoAsync(ctx)
+		if err != nil {
+			e.l.Error("failed to start filling message id field", zap.Error(err))
+			return
+		}
+		e.l.Info("started filling message id field on index", zap.String("taskId", task.TaskId), zap.Int64("count", count))
+		if err := e.waitESTask(ctx, task.TaskId); err != nil {
+			e.l.Error("failed to fill message id field", zap.Error(err), zap.String("taskId", task.TaskId))
+			return
+		}
+		// 同時に更新されたドキュメントはスキップされるので、残りが無くなるまで繰り返す
+	}
+
+	r, err := e.client.PutMapping().Index(getIndexName(esMessageIndex)).BodyJson(m{
+		"_meta": esMapping["_meta"],
+	}).Do(ctx)
+	if err != nil {
+		e.l.Error("failed to record message index version", zap.Error(err))
+		return
+	}
+	if !r.Acknowledged {
+		e.l.Error("failed to record message index version: mapping not acknowledged")
+		return
+	}
+	e.idSortable.Store(true)
+	e.l.Info("finished filling message id field on index")
+}
+
+// waitESTask esのタスクが完了するまで待ちます
+func (e *esEngine) waitESTask(ctx context.Context, taskID string) error {
+	for {
+		res, err := e.client.TasksGetTask().TaskId(taskID).Do(ctx)
+		if err != nil {
+			return err
+		}
+		if res.Completed {
+			if res.Error != nil {
+				return fmt.Errorf("%s: %s", res.Error.Type, res.Error.Reason)
+			}
+			return nil
+		}
+		time.Sleep(esMigrationPollInterval)
+	}
+}
+
+// tiebreaker search_afterで同時刻のメッセージを取りこぼさないためのタイブレーカーとするフィールド
+//
+// `_id`はfielddataが必要なため、全てのドキュメントに`id`がある場合はそちらを使います。
+// 値は同じなので、どちらで発行したカーソルも使えます。
+func (e *esEngine) tiebreaker() string {
+	if e.idSortable.Load() {
+		return "id"
+	}
+	return "_id"
+}
+
 func (e *esEngine) Do(q *Query) (Result, error) {
 	e.l.Debug("do search", zap.Reflect("q", q))
 
@@ -284,19 +427,42 @@ func (e *esEngine) Do(q *Query) (Result, error) {
 	// NOTE: 現状`sort.Key`はそのままesのソートキーとして使える前提
 	sort := q.GetSortKey()
 
-	sr, err := e.client.Search().
+	ss := e.client.Search().
 		Index(getIndexName(esMessageIndex)).
 		Query(elastic.NewBoolQuery().Must(musts...)).
 		Sort(sort.Key, !sort.Desc).
+		Sort(e.tiebreaker(), !sort.Desc).
 		Size(limit).
 		From(offset).
-		Do(context.Background())
+		Aggregation(esChannelsAggregation, elastic.NewTermsAggregation().Field("channelId").Size(facetSize)).
+		Aggregation(esAuthorsAggregation, elastic.NewTermsAggregation().Field("userId").Size(facetSize)).
+		Aggregation(esMonthsAggregation, elastic.NewDateHistogramAggregation().
+			Field("createdAt").
+			CalendarInterval("month").
+			Format("yyyy-MM").
+			MinDocCount(1))
+	if q.Cursor.Valid {
+		values, err := q.cursorValues()
+		if err != nil {
+			return nil, err
+		}
+		ss = ss.SearchAfter(values...)
+	}
+	if q.Word.Valid {
+		ss = ss.Highlight(elastic.NewHighlight().
+			Field("text").
+			PreTags(highlightPreTag).
+			PostTags(highlightPostTag).
+			Encoder("html"))
+	}
+
+	sr, err := ss.Do(context.Background())
 	if err != nil {
 		return nil, err
 	}
 
 	e.l.Debug("search result", zap.Reflect("hits", sr.Hits))
-	return e.bindESResult(sr)
+	return e.bindESResult(sr, sort, limit)
 }
 
 func (e *esEngine) Available() bool {
@@ -1,6 +1,8 @@
 package search
 
 import (
+	"fmt"
+
 	"github.com/gofrs/uuid"
 	"github.com/olivere/elastic/v7"
 
@@ -9,14 +11,19 @@ import (
 
 // esResult search.Result 実装
 type esResult struct {
-	totalHits int64
-	messages  []message.Message
+	totalHits  int64
+	messages   []message.Message
+	highlights map[uuid.UUID][]string
+	facets     *Facets
+	nextCursor string
 }
 
-func (e *esEngine) bindESResult(sr *elastic.SearchResult) (Result, error) {
+func (e *esEngine) bindESResult(sr *elastic.SearchResult, sort Sort, limit int) (Result, error) {
 	r := &esResult{
-		totalHits: sr.TotalHits(),
-		messages:  make([]message.Message, 0, len(sr.Hits.Hits)),
+		totalHits:  sr.TotalHits(),
+		messages:   make([]message.Message, 0, len(sr.Hits.Hits)),
+		highlights: make(map[uuid.UUID][]string, len(sr.Hits.Hits)),
+		facets:     bindESFacets(sr.Aggregations),
 	}
 
 	for _, hit := range sr.Hits.Hits {
@@ -25,12 +32,52 @@ func (e *esEngine) bindESResult(sr *elastic.SearchResult) (Result, error) {
 		if err != nil {
 			return nil, err
 		}
+		// 非表示化ではupdated_atが更新されずインデックスに残るので、ここで非表示のメッセージを除外する
+		if m.IsHidden() {
+			continue
+		}
 		r.messages = append(r.messages, m)
+
+		if fragments, ok := hit.Highlight["text"]; ok {
+			r.highlights[m.GetID()] = fragments
+		}
+	}
+
+	// 取得件数分ヒットした場合は続きがある可能性がある
+	if hits := sr.Hits.Hits; len(hits) > 0 && len(hits) == limit {
+		r.nextCursor = encodeCursor(sort, hits[len(hits)-1].Sort)
 	}
 
 	return r, nil
 }
 
+func bindESFacets(aggs elastic.Aggregations) *Facets {
+	f := &Facets{
+		Channels: []*FacetBucket{},
+		Authors:  []*FacetBucket{},
+		Months:   []*FacetBucket{},
+	}
+	if items, ok := aggs.Terms(esChannelsAggregation); ok {
+		for _, b := range items.Buckets {
+			f.Channels = append(f.Channels, &FacetBucket{Key: fmt.Sprint(b.Key), Count: b.DocCount})
+		}
+	}
+	if items, ok := aggs.Terms(esAuthorsAggregation); ok {
+		for _, b := range items.Buckets {
+			f.Authors = append(f.Authors, &FacetBucket{Key: fmt.Sprint(b.Key), Count: b.DocCount})
+		}
+	}
+	if items, ok := aggs.DateHistogram(esMonthsAggregation); ok {
+		for _, b := range items.Buckets {
+			if b.KeyAsString == nil {
+				continue
+			}
+			f.Months = append(f.Months, &FacetBucket{Key: *b.KeyAsString, Count: b.DocCount})
+		}
+	}
+	return f
+}
+
 func (e *esResult) TotalHits() int64 {
 	return e.totalHits
 }
@@ -38,3 +85,15 @@ func (e *esResult) TotalHits() int64 {
 func (e *esResult) Hits() []message.Message {
 	return e.messages
 }
+
+func (e *esResult) Highlights() map[uuid.UUID][]string {
+	return e.highlights
+}
+
+func (e *esResult) Facets() *Facets {
+	return e.facets
+}
+
+func (e *esResult) NextCursor() string {
+	return e.nextCursor
+}
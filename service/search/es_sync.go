@@ -37,19 +37,15 @@ type userCache map[uuid.UUID]bool
 
 // convertMessageCreated 新規メッセージをesへ入れる型に変換する
 func (e *esEngine) convertMessageCreated(m *model.Message, parseResult *message.ParseResult, userCache userCache) (*esMessageDoc, error) {
-	var isBot, ok bool
-	if isBot, ok = userCache[m.UserID]; !ok {
-		// 新規ユーザー or キャッシュが存在しない
-		user, err := e.repo.GetUser(m.UserID, false)
-		if err != nil {
-			return nil, err
-		}
-		isBot = user.IsBot()
+	isBot, err := userCache.isBot(e.repo, m.UserID)
+	if err != nil {
+		return nil, err
 	}
 
-	attr := e.getAttributes(m, parseResult)
+	attr := getAttributes(e.repo, e.l, m, parseResult)
 
 	return &esMessageDoc{
+		ID:             m.ID,
 		UserID:         m.UserID,
 		ChannelID:      m.ChannelID,
 		IsPublic:       e.cm.IsPublicChannel(m.ChannelID),
@@ -69,7 +65,7 @@ func (e *esEngine) convertMessageCreated(m *model.Message, parseResult *message.
 
 // convertMessageUpdated 既存メッセージの更新情報をesへ入れる型に変換する
 func (e *esEngine) convertMessageUpdated(m *model.Message, parseResult *message.ParseResult) *esMessageDocUpdate {
-	attr := e.getAttributes(m, parseResult)
+	attr := getAttributes(e.repo, e.l, m, parseResult)
 	// Updateする項目のみ
 	return &esMessageDocUpdate{
 		Text:           m.Text,
@@ -83,7 +79,8 @@ func (e *esEngine) convertMessageUpdated(m *model.Message, parseResult *message.
 	}
 }
 
-func (e *esEngine) getAttributes(m *model.Message, parseResult *message.ParseResult) *attributes {
+// getAttributes メッセージの検索用の属性を抽出する
+func getAttributes(repo repository.Repository, l *zap.Logger, m *model.Message, parseResult *message.ParseResult) *attributes {
 	attr := &attributes{}
 
 	attr.To = append(parseResult.Mentions, parseResult.GroupMentions...)
@@ -92,9 +89,9 @@ func (e *esEngine) getAttributes(m *model.Message, parseResult *message.ParseRes
 	attr.HasAttachments = len(parseResult.Attachments) != 0
 
 	for _, attachmentID := range parseResult.Attachments {
-		meta, err := e.repo.GetFileMeta(attachmentID)
+		meta, err := repo.GetFileMeta(attachmentID)
 		if err != nil {
-			e.l.Warn(err.Error(), zap.Error(err))
+			l.Warn(err.Error(), zap.Error(err))
 			continue
 		}
 		if strings.HasPrefix(meta.Mime, "image/") {
@@ -127,12 +124,12 @@ loop:
 	}
 }
 
-func (e *esEngine) newUserCache() (userCache, error) {
-	users, err := e.repo.GetUsers(repository.UsersQuery{})
+func newUserCache(repo repository.Repository, l *zap.Logger) (userCache, error) {
+	users, err := repo.GetUsers(repository.UsersQuery{})
 	if err != nil {
 		return nil, err
 	}
-	e.l.Debug("making user cache of size", zap.Int("size", len(users)))
+	l.Debug("making user cache of size", zap.Int("size", len(users)))
 
 	cache := make(map[uuid.UUID]bool, len(users))
 	for _, u := range users {
@@ -141,6 +138,19 @@ func (e *esEngine) newUserCache() (userCache, error) {
 	return cache, nil
 }
 
+// isBot 指定したユーザーがbotかどうかを返す キャッシュに無い場合はDBから取得する
+func (c userCache) isBot(repo repository.Repository, userID uuid.UUID) (bool, error) {
+	if isBot, ok := c[userID]; ok {
+		return isBot, nil
+	}
+	// 新規ユーザー or キャッシュが存在しない
+	user, err := repo.GetUser(userID, false)
+	if err != nil {
+		return false, err
+	}
+	return user.IsBot(), nil
+}
+
 // sync メッセージを repository.MessageRepository から読み取り、esへindexします
 func (e *esEngine) sync() error {
 	e.l.Debug("syncing messages with es")
@@ -166,7 +176,7 @@ func (e *esEngine) sync() error {
 		// ユーザーキャッシュサービスができたら書き換えても良い
 		if userCache == nil && more {
 			// 新規メッセージが2ページ以上の時のみデータが入ったキャッシュを作成
-			userCache, err = e.newUserCache()
+			userCache, err = newUserCache(e.repo, e.l)
 			if err != nil {
 				return err
 			}
@@ -175,6 +185,14 @@ func (e *esEngine) sync() error {
 		bulk := e.client.Bulk().Index(getIndexName(esMessageIndex))
 		for _, v := range messages {
 			var bulkReq elastic.BulkableRequest
+			if v.Hidden {
+				// 非表示のメッセージは検索できないようにインデックスから削除する
+				if v.CreatedAt.After(lastSynced) {
+					continue
+				}
+				bulk.Add(elastic.NewBulkDeleteRequest().Id(v.ID.String()))
+				continue
+			}
 			if v.CreatedAt.After(lastSynced) {
 				doc, err := e.convertMessageCreated(v, message.Parse(v.Text), userCache)
 				if err != nil {
@@ -190,6 +208,12 @@ func (e *esEngine) sync() error {
 			}
 			bulk.Add(bulkReq)
 		}
+		if bulk.NumberOfActions() == 0 {
+			if more {
+				continue
+			}
+			break
+		}
 		res, err := bulk.Do(context.Background())
 		if err != nil {
 			return err
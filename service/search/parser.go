@@ -0,0 +1,229 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// インライン検索クエリの演算子
+const (
+	operatorFrom   = "from"   // from:@user 投稿者
+	operatorTo     = "to"     // to:@user メンション先
+	operatorIn     = "in"     // in:#channel/path 投稿チャンネル
+	operatorHas    = "has"    // has:(url|attachment|image|video|audio) 添付物
+	operatorIs     = "is"     // is:bot 投稿者の種類
+	operatorBefore = "before" // before:2006-01-02 以前(投稿日時)
+	operatorAfter  = "after"  // after:2006-01-02 以降(投稿日時)
+)
+
+const queryDateLayout = "2006-01-02"
+
+// QueryParseError インライン検索クエリの演算子の解析エラー
+type QueryParseError struct {
+	// Operator 演算子名
+	Operator string `json:"operator"`
+	// Value 演算子に与えられた値
+	Value string `json:"value"`
+	// Reason エラーの理由
+	Reason string `json:"reason"`
+}
+
+func (e *QueryParseError) Error() string {
+	return fmt.Sprintf("%s:%s: %s", e.Operator, e.Value, e.Reason)
+}
+
+// QueryParseErrors インライン検索クエリの解析エラーの配列
+type QueryParseErrors []*QueryParseError
+
+func (e QueryParseErrors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return "invalid search query: " + strings.Join(s, ", ")
+}
+
+// ParseQuery `from:@alice in:#general has:image before:2024-01-01 foo`形式のインライン検索クエリを解析し、qに反映します
+//
+// 演算子として解釈されなかった部分は検索ワードとしてq.Wordの後ろに追加されます。
+// 演算子の値はダブルクォートで囲むことができます(`in:"#a b"`)。
+// ユーザー名はrepo、チャンネルパスはtreeから解決します。日付はUTCとして解釈します。
+// 未知の演算子や解析に失敗した演算子があった場合、QueryParseErrorsを返します。
+// DBによるエラーを返すことがあります。
+func ParseQuery(s string, repo repository.UserRepository, tree channel.Tree, q *Query) error {
+	var (
+		words []string
+		errs  QueryParseErrors
+	)
+	for _, token := range tokenizeQuery(s) {
+		op, value, ok := strings.Cut(token, ":")
+		if !ok || !looksLikeQueryOperator(op, value) {
+			words = append(words, token)
+			continue
+		}
+		value = unquoteQueryValue(value)
+		if !isQueryOperator(op) {
+			errs = append(errs, &QueryParseError{Operator: op, Value: value, Reason: "unknown operator"})
+			continue
+		}
+
+		reason, err := applyQueryOperator(op, value, repo, tree, q)
+		if err != nil {
+			return err
+		}
+		if len(reason) > 0 {
+			errs = append(errs, &QueryParseError{Operator: op, Value: value, Reason: reason})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	if len(words) > 0 {
+		if q.Word.Valid && len(q.Word.String) > 0 {
+			words = append([]string{q.Word.String}, words...)
+		}
+		q.Word = optional.StringFrom(strings.Join(words, " "))
+	}
+	return nil
+}
+
+// looksLikeQueryOperator `op:value`が演算子として書かれたものかどうか
+//
+// opが英字のみからなる場合に演算子とみなします。`12:30`や`https://...`は検索ワードとして扱います
+func looksLikeQueryOperator(op, value string) bool {
+	if len(op) == 0 || strings.HasPrefix(value, "//") {
+		return false
+	}
+	for _, r := range op {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// unquoteQueryValue ダブルクォートで囲まれた演算子の値からダブルクォートを取り除きます
+func unquoteQueryValue(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+func isQueryOperator(op string) bool {
+	switch op {
+	case operatorFrom, operatorTo, operatorIn, operatorHas, operatorIs, operatorBefore, operatorAfter:
+		return true
+	default:
+		return false
+	}
+}
+
+// applyQueryOperator 演算子をqに反映します 値が不正な場合はその理由を返します
+func applyQueryOperator(op, value string, repo repository.UserRepository, tree channel.Tree, q *Query) (string, error) {
+	if len(value) == 0 {
+		return "value is required", nil
+	}
+
+	switch op {
+	case operatorFrom, operatorTo:
+		user, err := repo.GetUserByName(strings.TrimPrefix(value, "@"), false)
+		if err != nil {
+			if err == repository.ErrNotFound {
+				return "user not found", nil
+			}
+			return "", err
+		}
+		if op == operatorFrom {
+			q.From = optional.UUIDFrom(user.GetID())
+		} else {
+			q.To = optional.UUIDFrom(user.GetID())
+		}
+
+	case operatorIn:
+		id := tree.GetChannelIDFromPath(strings.TrimPrefix(value, "#"))
+		if id.IsNil() {
+			return "channel not found", nil
+		}
+		q.In = optional.UUIDFrom(id)
+
+	case operatorHas:
+		switch value {
+		case "url":
+			q.HasURL = optional.BoolFrom(true)
+		case "attachment", "attachments":
+			q.HasAttachments = optional.BoolFrom(true)
+		case "image":
+			q.HasImage = optional.BoolFrom(true)
+		case "video":
+			q.HasVideo = optional.BoolFrom(true)
+		case "audio":
+			q.HasAudio = optional.BoolFrom(true)
+		default:
+			return "must be one of url, attachment, image, video, audio", nil
+		}
+
+	case operatorIs:
+		switch value {
+		case "bot":
+			q.Bot = optional.BoolFrom(true)
+		case "human", "user":
+			q.Bot = optional.BoolFrom(false)
+		default:
+			return "must be one of bot, user", nil
+		}
+
+	case operatorBefore, operatorAfter:
+		t, err := parseQueryDate(value)
+		if err != nil {
+			return "must be a date in YYYY-MM-DD or RFC3339 format", nil
+		}
+		if op == operatorBefore {
+			q.Before = optional.TimeFrom(t)
+		} else {
+			q.After = optional.TimeFrom(t)
+		}
+	}
+	return "", nil
+}
+
+func parseQueryDate(value string) (time.Time, error) {
+	if t, err := time.Parse(queryDateLayout, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// tokenizeQuery クエリ文字列を空白で分割します ダブルクォートで囲まれた部分は分割しません
+func tokenizeQuery(s string) []string {
+	var (
+		tokens  []string
+		current strings.Builder
+		quoted  bool
+	)
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
@@ -0,0 +1,115 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestTokenizeQuery(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"from:@alice", `"foo bar"`, "baz"}, tokenizeQuery(`  from:@alice  "foo bar"	baz `))
+	assert.Empty(t, tokenizeQuery("   "))
+}
+
+func TestParseQuery(t *testing.T) {
+	t.Parallel()
+
+	alice := &model.User{ID: uuid.NewV3(uuid.Nil, "alice"), Name: "alice"}
+	general := uuid.NewV3(uuid.Nil, "general")
+	spaced := uuid.NewV3(uuid.Nil, "a b")
+
+	setup := func(t *testing.T) (*mock_repository.MockUserRepository, *mock_channel.MockTree) {
+		t.Helper()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockUserRepository(ctrl)
+		repo.EXPECT().GetUserByName("alice", false).Return(alice, nil).AnyTimes()
+		repo.EXPECT().GetUserByName(gomock.Any(), false).Return(nil, repository.ErrNotFound).AnyTimes()
+		tree := mock_channel.NewMockTree(ctrl)
+		tree.EXPECT().GetChannelIDFromPath("general").Return(general).AnyTimes()
+		tree.EXPECT().GetChannelIDFromPath("a b").Return(spaced).AnyTimes()
+		tree.EXPECT().GetChannelIDFromPath(gomock.Any()).Return(uuid.Nil).AnyTimes()
+		return repo, tree
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		repo, tree := setup(t)
+
+		q := Query{Word: optional.StringFrom("hello")}
+		require.NoError(t, ParseQuery(`from:@alice to:alice in:#general has:image is:bot before:2024-01-01 after:2023-01-01T09:00:00+09:00 "foo bar" http://example.com`, repo, tree, &q))
+
+		assert.Equal(t, optional.StringFrom(`hello "foo bar" http://example.com`), q.Word)
+		assert.Equal(t, optional.UUIDFrom(alice.ID), q.From)
+		assert.Equal(t, optional.UUIDFrom(alice.ID), q.To)
+		assert.Equal(t, optional.UUIDFrom(general), q.In)
+		assert.Equal(t, optional.BoolFrom(true), q.HasImage)
+		assert.Equal(t, optional.BoolFrom(true), q.Bot)
+		assert.True(t, q.Before.Time.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+		assert.True(t, q.After.Time.Equal(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("operators only", func(t *testing.T) {
+		t.Parallel()
+		repo, tree := setup(t)
+
+		var q Query
+		require.NoError(t, ParseQuery("has:url", repo, tree, &q))
+		assert.False(t, q.Word.Valid)
+		assert.Equal(t, optional.BoolFrom(true), q.HasURL)
+	})
+
+	t.Run("quoted values", func(t *testing.T) {
+		t.Parallel()
+		repo, tree := setup(t)
+
+		var q Query
+		require.NoError(t, ParseQuery(`from:"@alice" in:"#a b" 12:30`, repo, tree, &q))
+		assert.Equal(t, optional.StringFrom("12:30"), q.Word)
+		assert.Equal(t, optional.UUIDFrom(alice.ID), q.From)
+		assert.Equal(t, optional.UUIDFrom(spaced), q.In)
+	})
+
+	t.Run("unknown operator", func(t *testing.T) {
+		t.Parallel()
+		repo, tree := setup(t)
+
+		var q Query
+		err := ParseQuery(`foo:bar has:url`, repo, tree, &q)
+		var errs QueryParseErrors
+		if assert.ErrorAs(t, err, &errs) {
+			assert.Equal(t, QueryParseErrors{
+				{Operator: "foo", Value: "bar", Reason: "unknown operator"},
+			}, errs)
+		}
+	})
+
+	t.Run("invalid operators", func(t *testing.T) {
+		t.Parallel()
+		repo, tree := setup(t)
+
+		var q Query
+		err := ParseQuery("from:@bob in:#unknown has:gif before:yesterday is: foo", repo, tree, &q)
+		var errs QueryParseErrors
+		if assert.ErrorAs(t, err, &errs) {
+			assert.Equal(t, QueryParseErrors{
+				{Operator: "from", Value: "@bob", Reason: "user not found"},
+				{Operator: "in", Value: "#unknown", Reason: "channel not found"},
+				{Operator: "has", Value: "gif", Reason: "must be one of url, attachment, image, video, audio"},
+				{Operator: "before", Value: "yesterday", Reason: "must be a date in YYYY-MM-DD or RFC3339 format"},
+				{Operator: "is", Value: "", Reason: "value is required"},
+			}, errs)
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	botWS "github.com/traPtitech/traQ/service/bot/ws"
 	"github.com/traPtitech/traQ/service/channel"
 	"github.com/traPtitech/traQ/service/counter"
+	"github.com/traPtitech/traQ/service/digest"
 	"github.com/traPtitech/traQ/service/exevent"
 	"github.com/traPtitech/traQ/service/fcm"
 	"github.com/traPtitech/traQ/service/file"
@@ -15,6 +16,7 @@ import (
 	"github.com/traPtitech/traQ/service/rbac"
 	"github.com/traPtitech/traQ/service/search"
 	"github.com/traPtitech/traQ/service/viewer"
+	"github.com/traPtitech/traQ/service/webhook"
 	"github.com/traPtitech/traQ/service/webrtcv3"
 	"github.com/traPtitech/traQ/service/ws"
 )
@@ -26,16 +28,19 @@ type Services struct {
 	UnreadMessageCounter counter.UnreadMessageCounter
 	MessageCounter       counter.MessageCounter
 	ChannelCounter       counter.ChannelCounter
+	EmailDigest          *digest.Notifier
 	StampThrottler       *exevent.StampThrottler
 	FCM                  fcm.Client
 	FileManager          file.Manager
 	Imaging              imaging.Processor
 	MessageManager       message.Manager
+	MessageScheduler     *message.Scheduler
 	Notification         *notification.Service
 	OGP                  ogp.Service
 	RBAC                 rbac.RBAC
 	Search               search.Engine
 	ViewerManager        *viewer.Manager
+	OutgoingWebhook      *webhook.OutgoingDispatcher
 	WebRTCv3             *webrtcv3.Manager
 	WS                   *ws.Streamer
 	BotWS                *botWS.Streamer
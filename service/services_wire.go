@@ -14,16 +14,19 @@ var ProviderSet = wire.NewSet(wire.FieldsOf(new(*Services),
 	"UnreadMessageCounter",
 	"MessageCounter",
 	"ChannelCounter",
+	"EmailDigest",
 	"StampThrottler",
 	"FCM",
 	"FileManager",
 	"Imaging",
 	"MessageManager",
+	"MessageScheduler",
 	"Notification",
 	"OGP",
 	"RBAC",
 	"Search",
 	"ViewerManager",
+	"OutgoingWebhook",
 	"WebRTCv3",
 	"WS",
 	"BotWS",
@@ -0,0 +1,100 @@
+package adapter
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	jsonIter "github.com/json-iterator/go"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+var json = jsonIter.ConfigFastest
+
+var (
+	// ErrUnsupportedEvent 対応していないイベント
+	ErrUnsupportedEvent = errors.New("unsupported event")
+	// ErrInvalidSignature 署名が不正
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrInvalidPayload ペイロードが不正
+	ErrInvalidPayload = errors.New("invalid payload")
+)
+
+// Adapter 外部サービスのWebhookリクエストをtraQのメッセージに変換するアダプター
+type Adapter interface {
+	// Verify リクエストの署名をsecretで検証します
+	//
+	// 署名が不正な場合はErrInvalidSignatureを返します
+	Verify(header http.Header, body []byte, secret string) error
+	// Render リクエストをメッセージ本文に変換します
+	//
+	// 対応していないイベントの場合はErrUnsupportedEventを、
+	// ペイロードが不正な場合はErrInvalidPayloadを返します
+	Render(header http.Header, body []byte) (model.WebhookEventType, string, error)
+}
+
+// maxCommits pushイベントで表示する最大コミット数
+const maxCommits = 5
+
+func link(label, url string) string {
+	if len(url) == 0 {
+		return label
+	}
+	return "[" + label + "](" + url + ")"
+}
+
+// firstLine コミットメッセージの1行目を返します
+func firstLine(s string) string {
+	l, _, _ := strings.Cut(s, "\n")
+	return strings.TrimSpace(l)
+}
+
+func shortHash(s string) string {
+	if len(s) > 7 {
+		return s[:7]
+	}
+	return s
+}
+
+// quote 本文を引用形式に変換します
+func quote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) == 0 {
+		return ""
+	}
+	return "\n> " + strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\n", "\n> ")
+}
+
+type commit struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	URL     string `json:"url"`
+	Author  struct {
+		Name string `json:"name"`
+	} `json:"author"`
+}
+
+// renderCommits pushイベントのコミット一覧を描画します
+func renderCommits(b *strings.Builder, commits []commit, total int) {
+	for i, c := range commits {
+		if i >= maxCommits {
+			break
+		}
+		b.WriteString("\n- " + link("`"+shortHash(c.ID)+"`", c.URL) + " " + firstLine(c.Message))
+		if len(c.Author.Name) > 0 {
+			b.WriteString(" - " + c.Author.Name)
+		}
+	}
+	if total > maxCommits {
+		b.WriteString("\n- ...and more")
+	}
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return "1 " + word
+	}
+	return strconv.Itoa(n) + " " + word + "s"
+}
@@ -0,0 +1,207 @@
+package adapter
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/hmac"
+)
+
+const (
+	headerGitHubEvent     = "X-GitHub-Event"
+	headerGitHubSignature = "X-Hub-Signature-256"
+)
+
+// GitHub GitHubのWebhookアダプター
+//
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads
+type GitHub struct{}
+
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+type githubRepository struct {
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+}
+
+type githubPushPayload struct {
+	Ref        string           `json:"ref"`
+	Deleted    bool             `json:"deleted"`
+	Compare    string           `json:"compare"`
+	Commits    []commit         `json:"commits"`
+	Repository githubRepository `json:"repository"`
+	Sender     githubUser       `json:"sender"`
+}
+
+type githubPullRequestPayload struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Title   string `json:"title"`
+		Body    string `json:"body"`
+		HTMLURL string `json:"html_url"`
+		Merged  bool   `json:"merged"`
+	} `json:"pull_request"`
+	Repository githubRepository `json:"repository"`
+	Sender     githubUser       `json:"sender"`
+}
+
+type githubIssuePayload struct {
+	Action string `json:"action"`
+	Issue  struct {
+		Number  int    `json:"number"`
+		Title   string `json:"title"`
+		Body    string `json:"body"`
+		HTMLURL string `json:"html_url"`
+	} `json:"issue"`
+	Repository githubRepository `json:"repository"`
+	Sender     githubUser       `json:"sender"`
+}
+
+type githubReleasePayload struct {
+	Action  string `json:"action"`
+	Release struct {
+		TagName    string `json:"tag_name"`
+		Name       string `json:"name"`
+		Body       string `json:"body"`
+		HTMLURL    string `json:"html_url"`
+		Prerelease bool   `json:"prerelease"`
+	} `json:"release"`
+	Repository githubRepository `json:"repository"`
+	Sender     githubUser       `json:"sender"`
+}
+
+// Verify implements Adapter interface.
+//
+// X-Hub-Signature-256ヘッダーのHMAC-SHA256署名を検証します
+func (GitHub) Verify(header http.Header, body []byte, secret string) error {
+	sig := header.Get(headerGitHubSignature)
+	if !strings.HasPrefix(sig, "sha256=") {
+		return ErrInvalidSignature
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(sig, "sha256="))
+	if err != nil || subtle.ConstantTimeCompare(hmac.SHA256(body, secret), b) != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Render implements Adapter interface.
+func (GitHub) Render(header http.Header, body []byte) (model.WebhookEventType, string, error) {
+	switch header.Get(headerGitHubEvent) {
+	case "push":
+		var p githubPushPayload
+		if err := json.Unmarshal(body, &p); err != nil {
+			return "", "", ErrInvalidPayload
+		}
+		return model.WebhookEventPush, p.render(), nil
+	case "pull_request":
+		var p githubPullRequestPayload
+		if err := json.Unmarshal(body, &p); err != nil {
+			return "", "", ErrInvalidPayload
+		}
+		text, ok := p.render()
+		if !ok {
+			return "", "", ErrUnsupportedEvent
+		}
+		return model.WebhookEventPullRequest, text, nil
+	case "issues":
+		var p githubIssuePayload
+		if err := json.Unmarshal(body, &p); err != nil {
+			return "", "", ErrInvalidPayload
+		}
+		text, ok := p.render()
+		if !ok {
+			return "", "", ErrUnsupportedEvent
+		}
+		return model.WebhookEventIssue, text, nil
+	case "release":
+		var p githubReleasePayload
+		if err := json.Unmarshal(body, &p); err != nil {
+			return "", "", ErrInvalidPayload
+		}
+		text, ok := p.render()
+		if !ok {
+			return "", "", ErrUnsupportedEvent
+		}
+		return model.WebhookEventRelease, text, nil
+	default:
+		return "", "", ErrUnsupportedEvent
+	}
+}
+
+func (r githubRepository) link() string {
+	return link(r.FullName, r.HTMLURL)
+}
+
+func (p *githubPushPayload) render() string {
+	branch := strings.TrimPrefix(strings.TrimPrefix(p.Ref, "refs/heads/"), "refs/tags/")
+	var b strings.Builder
+	if p.Deleted {
+		fmt.Fprintf(&b, "%s deleted `%s` in %s", p.Sender.Login, branch, p.Repository.link())
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%s pushed %s to `%s` in %s", p.Sender.Login, link(plural(len(p.Commits), "commit"), p.Compare), branch, p.Repository.link())
+	renderCommits(&b, p.Commits, len(p.Commits))
+	return b.String()
+}
+
+func (p *githubPullRequestPayload) render() (string, bool) {
+	var action string
+	switch p.Action {
+	case "opened", "reopened":
+		action = p.Action
+	case "ready_for_review":
+		action = "marked as ready for review"
+	case "closed":
+		action = "closed"
+		if p.PullRequest.Merged {
+			action = "merged"
+		}
+	default:
+		return "", false
+	}
+	text := fmt.Sprintf("%s %s pull request %s in %s",
+		p.Sender.Login, action, link("#"+strconv.Itoa(p.Number)+" "+p.PullRequest.Title, p.PullRequest.HTMLURL), p.Repository.link())
+	if p.Action == "opened" {
+		text += quote(p.PullRequest.Body)
+	}
+	return text, true
+}
+
+func (p *githubIssuePayload) render() (string, bool) {
+	switch p.Action {
+	case "opened", "closed", "reopened":
+	default:
+		return "", false
+	}
+	text := fmt.Sprintf("%s %s issue %s in %s",
+		p.Sender.Login, p.Action, link("#"+strconv.Itoa(p.Issue.Number)+" "+p.Issue.Title, p.Issue.HTMLURL), p.Repository.link())
+	if p.Action == "opened" {
+		text += quote(p.Issue.Body)
+	}
+	return text, true
+}
+
+func (p *githubReleasePayload) render() (string, bool) {
+	if p.Action != "published" {
+		return "", false
+	}
+	name := p.Release.Name
+	if len(name) == 0 {
+		name = p.Release.TagName
+	}
+	kind := "release"
+	if p.Release.Prerelease {
+		kind = "pre-release"
+	}
+	text := fmt.Sprintf("%s published %s %s in %s", p.Sender.Login, kind, link(name, p.Release.HTMLURL), p.Repository.link())
+	return text + quote(p.Release.Body), true
+}
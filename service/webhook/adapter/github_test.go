@@ -0,0 +1,145 @@
+package adapter
+
+import (
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/hmac"
+)
+
+func githubHeader(event string) http.Header {
+	h := http.Header{}
+	h.Set(headerGitHubEvent, event)
+	return h
+}
+
+func TestGitHub_Verify(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"zen":"test"}`)
+	secret := "secret"
+	valid := "sha256=" + hex.EncodeToString(hmac.SHA256(body, secret))
+
+	tests := []struct {
+		name string
+		sig  string
+		ok   bool
+	}{
+		{"valid", valid, true},
+		{"missing", "", false},
+		{"no prefix", hex.EncodeToString(hmac.SHA256(body, secret)), false},
+		{"not hex", "sha256=zzzz", false},
+		{"wrong", "sha256=" + hex.EncodeToString(hmac.SHA256(body, "wrong")), false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			h := http.Header{}
+			if len(tt.sig) > 0 {
+				h.Set(headerGitHubSignature, tt.sig)
+			}
+			err := GitHub{}.Verify(h, body, secret)
+			if tt.ok {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidSignature)
+			}
+		})
+	}
+}
+
+func TestGitHub_Render(t *testing.T) {
+	t.Parallel()
+
+	const repo = `"repository":{"full_name":"traPtitech/traQ","html_url":"https://github.com/traPtitech/traQ"},"sender":{"login":"octocat"}`
+
+	tests := []struct {
+		name      string
+		event     string
+		body      string
+		eventType model.WebhookEventType
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "push",
+			event:     "push",
+			body:      `{"ref":"refs/heads/master","compare":"https://github.com/c","commits":[{"id":"0123456789abcdef","message":"fix bug\n\ndetail","url":"https://github.com/c/1","author":{"name":"Octo"}}],` + repo + `}`,
+			eventType: model.WebhookEventPush,
+			want:      "octocat pushed [1 commit](https://github.com/c) to `master` in [traPtitech/traQ](https://github.com/traPtitech/traQ)\n- [`0123456`](https://github.com/c/1) fix bug - Octo",
+		},
+		{
+			name:      "push deleted",
+			event:     "push",
+			body:      `{"ref":"refs/heads/feat","deleted":true,` + repo + `}`,
+			eventType: model.WebhookEventPush,
+			want:      "octocat deleted `feat` in [traPtitech/traQ](https://github.com/traPtitech/traQ)",
+		},
+		{
+			name:      "pull request opened",
+			event:     "pull_request",
+			body:      `{"action":"opened","number":1,"pull_request":{"title":"Add feature","body":"desc","html_url":"https://github.com/pr/1"},` + repo + `}`,
+			eventType: model.WebhookEventPullRequest,
+			want:      "octocat opened pull request [#1 Add feature](https://github.com/pr/1) in [traPtitech/traQ](https://github.com/traPtitech/traQ)\n> desc",
+		},
+		{
+			name:      "pull request merged",
+			event:     "pull_request",
+			body:      `{"action":"closed","number":1,"pull_request":{"title":"Add feature","html_url":"https://github.com/pr/1","merged":true},` + repo + `}`,
+			eventType: model.WebhookEventPullRequest,
+			want:      "octocat merged pull request [#1 Add feature](https://github.com/pr/1) in [traPtitech/traQ](https://github.com/traPtitech/traQ)",
+		},
+		{
+			name:    "pull request synchronize",
+			event:   "pull_request",
+			body:    `{"action":"synchronize",` + repo + `}`,
+			wantErr: ErrUnsupportedEvent,
+		},
+		{
+			name:      "issue closed",
+			event:     "issues",
+			body:      `{"action":"closed","issue":{"number":2,"title":"Bug","html_url":"https://github.com/i/2"},` + repo + `}`,
+			eventType: model.WebhookEventIssue,
+			want:      "octocat closed issue [#2 Bug](https://github.com/i/2) in [traPtitech/traQ](https://github.com/traPtitech/traQ)",
+		},
+		{
+			name:      "release published",
+			event:     "release",
+			body:      `{"action":"published","release":{"tag_name":"v1.0.0","html_url":"https://github.com/r/1","body":"notes"},` + repo + `}`,
+			eventType: model.WebhookEventRelease,
+			want:      "octocat published release [v1.0.0](https://github.com/r/1) in [traPtitech/traQ](https://github.com/traPtitech/traQ)\n> notes",
+		},
+		{
+			name:    "ping",
+			event:   "ping",
+			body:    `{"zen":"test"}`,
+			wantErr: ErrUnsupportedEvent,
+		},
+		{
+			name:    "invalid payload",
+			event:   "push",
+			body:    `{"ref":`,
+			wantErr: ErrInvalidPayload,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			eventType, text, err := GitHub{}.Render(githubHeader(tt.event), []byte(tt.body))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.eventType, eventType)
+				assert.Equal(t, tt.want, text)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package adapter
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+const (
+	headerGitLabEvent = "X-Gitlab-Event"
+	headerGitLabToken = "X-Gitlab-Token"
+
+	// gitlabZeroSHA ブランチ削除時のafter
+	gitlabZeroSHA = "0000000000000000000000000000000000000000"
+)
+
+// GitLab GitLabのWebhookアダプター
+//
+// https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html
+type GitLab struct{}
+
+type gitlabUser struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
+
+type gitlabProject struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebURL            string `json:"web_url"`
+}
+
+type gitlabPushPayload struct {
+	Ref               string        `json:"ref"`
+	After             string        `json:"after"`
+	UserUsername      string        `json:"user_username"`
+	Commits           []commit      `json:"commits"`
+	TotalCommitsCount int           `json:"total_commits_count"`
+	Project           gitlabProject `json:"project"`
+}
+
+type gitlabMergeRequestPayload struct {
+	User             gitlabUser    `json:"user"`
+	Project          gitlabProject `json:"project"`
+	ObjectAttributes struct {
+		IID         int    `json:"iid"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		URL         string `json:"url"`
+		Action      string `json:"action"`
+	} `json:"object_attributes"`
+}
+
+type gitlabIssuePayload struct {
+	User             gitlabUser    `json:"user"`
+	Project          gitlabProject `json:"project"`
+	ObjectAttributes struct {
+		IID         int    `json:"iid"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		URL         string `json:"url"`
+		Action      string `json:"action"`
+	} `json:"object_attributes"`
+}
+
+type gitlabReleasePayload struct {
+	Action      string        `json:"action"`
+	Name        string        `json:"name"`
+	Tag         string        `json:"tag"`
+	Description string        `json:"description"`
+	URL         string        `json:"url"`
+	Project     gitlabProject `json:"project"`
+}
+
+// Verify implements Adapter interface.
+//
+// X-Gitlab-Tokenヘッダーがsecretと一致するかを検証します
+func (GitLab) Verify(header http.Header, _ []byte, secret string) error {
+	token := header.Get(headerGitLabToken)
+	if len(token) == 0 || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Render implements Adapter interface.
+func (GitLab) Render(header http.Header, body []byte) (model.WebhookEventType, string, error) {
+	switch header.Get(headerGitLabEvent) {
+	case "Push Hook":
+		var p gitlabPushPayload
+		if err := json.Unmarshal(body, &p); err != nil {
+			return "", "", ErrInvalidPayload
+		}
+		return model.WebhookEventPush, p.render(), nil
+	case "Merge Request Hook":
+		var p gitlabMergeRequestPayload
+		if err := json.Unmarshal(body, &p); err != nil {
+			return "", "", ErrInvalidPayload
+		}
+		text, ok := p.render()
+		if !ok {
+			return "", "", ErrUnsupportedEvent
+		}
+		return model.WebhookEventPullRequest, text, nil
+	case "Issue Hook":
+		var p gitlabIssuePayload
+		if err := json.Unmarshal(body, &p); err != nil {
+			return "", "", ErrInvalidPayload
+		}
+		text, ok := p.render()
+		if !ok {
+			return "", "", ErrUnsupportedEvent
+		}
+		return model.WebhookEventIssue, text, nil
+	case "Release Hook":
+		var p gitlabReleasePayload
+		if err := json.Unmarshal(body, &p); err != nil {
+			return "", "", ErrInvalidPayload
+		}
+		text, ok := p.render()
+		if !ok {
+			return "", "", ErrUnsupportedEvent
+		}
+		return model.WebhookEventRelease, text, nil
+	default:
+		return "", "", ErrUnsupportedEvent
+	}
+}
+
+func (p gitlabProject) link() string {
+	return link(p.PathWithNamespace, p.WebURL)
+}
+
+// gitlabAction GitLabのアクション名を過去形に変換します
+func gitlabAction(action string) (string, bool) {
+	switch action {
+	case "open":
+		return "opened", true
+	case "close":
+		return "closed", true
+	case "reopen":
+		return "reopened", true
+	case "merge":
+		return "merged", true
+	default:
+		return "", false
+	}
+}
+
+func (p *gitlabPushPayload) render() string {
+	branch := strings.TrimPrefix(strings.TrimPrefix(p.Ref, "refs/heads/"), "refs/tags/")
+	var b strings.Builder
+	if p.After == gitlabZeroSHA {
+		fmt.Fprintf(&b, "%s deleted `%s` in %s", p.UserUsername, branch, p.Project.link())
+		return b.String()
+	}
+	total := p.TotalCommitsCount
+	if total < len(p.Commits) {
+		total = len(p.Commits)
+	}
+	fmt.Fprintf(&b, "%s pushed %s to `%s` in %s", p.UserUsername, plural(total, "commit"), branch, p.Project.link())
+	renderCommits(&b, p.Commits, total)
+	return b.String()
+}
+
+func (p *gitlabMergeRequestPayload) render() (string, bool) {
+	action, ok := gitlabAction(p.ObjectAttributes.Action)
+	if !ok {
+		return "", false
+	}
+	a := p.ObjectAttributes
+	text := fmt.Sprintf("%s %s merge request %s in %s",
+		p.User.Username, action, link("!"+strconv.Itoa(a.IID)+" "+a.Title, a.URL), p.Project.link())
+	if a.Action == "open" {
+		text += quote(a.Description)
+	}
+	return text, true
+}
+
+func (p *gitlabIssuePayload) render() (string, bool) {
+	action, ok := gitlabAction(p.ObjectAttributes.Action)
+	if !ok || action == "merged" {
+		return "", false
+	}
+	a := p.ObjectAttributes
+	text := fmt.Sprintf("%s %s issue %s in %s",
+		p.User.Username, action, link("#"+strconv.Itoa(a.IID)+" "+a.Title, a.URL), p.Project.link())
+	if a.Action == "open" {
+		text += quote(a.Description)
+	}
+	return text, true
+}
+
+func (p *gitlabReleasePayload) render() (string, bool) {
+	if p.Action != "create" {
+		return "", false
+	}
+	name := p.Name
+	if len(name) == 0 {
+		name = p.Tag
+	}
+	text := fmt.Sprintf("published release %s in %s", link(name, p.URL), p.Project.link())
+	return text + quote(p.Description), true
+}
@@ -0,0 +1,105 @@
+package adapter
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+func gitlabHeader(event string) http.Header {
+	h := http.Header{}
+	h.Set(headerGitLabEvent, event)
+	return h
+}
+
+func TestGitLab_Verify(t *testing.T) {
+	t.Parallel()
+
+	h := http.Header{}
+	assert.ErrorIs(t, GitLab{}.Verify(h, nil, "secret"), ErrInvalidSignature)
+	h.Set(headerGitLabToken, "wrong")
+	assert.ErrorIs(t, GitLab{}.Verify(h, nil, "secret"), ErrInvalidSignature)
+	h.Set(headerGitLabToken, "secret")
+	assert.NoError(t, GitLab{}.Verify(h, nil, "secret"))
+}
+
+func TestGitLab_Render(t *testing.T) {
+	t.Parallel()
+
+	const project = `"project":{"path_with_namespace":"group/app","web_url":"https://gitlab.com/group/app"}`
+
+	tests := []struct {
+		name      string
+		event     string
+		body      string
+		eventType model.WebhookEventType
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "push",
+			event:     "Push Hook",
+			body:      `{"ref":"refs/heads/main","after":"abc","user_username":"alice","total_commits_count":7,"commits":[{"id":"0123456789","message":"a","url":"u1"},{"id":"1","message":"b","url":"u2"},{"id":"2","message":"c","url":"u3"},{"id":"3","message":"d","url":"u4"},{"id":"4","message":"e","url":"u5"},{"id":"5","message":"f","url":"u6"}],` + project + `}`,
+			eventType: model.WebhookEventPush,
+			want:      "alice pushed 7 commits to `main` in [group/app](https://gitlab.com/group/app)\n- [`0123456`](u1) a\n- [`1`](u2) b\n- [`2`](u3) c\n- [`3`](u4) d\n- [`4`](u5) e\n- ...and more",
+		},
+		{
+			name:      "push deleted",
+			event:     "Push Hook",
+			body:      `{"ref":"refs/heads/feat","after":"` + gitlabZeroSHA + `","user_username":"alice",` + project + `}`,
+			eventType: model.WebhookEventPush,
+			want:      "alice deleted `feat` in [group/app](https://gitlab.com/group/app)",
+		},
+		{
+			name:      "merge request merged",
+			event:     "Merge Request Hook",
+			body:      `{"user":{"username":"alice"},"object_attributes":{"iid":3,"title":"MR","url":"https://gitlab.com/mr/3","action":"merge"},` + project + `}`,
+			eventType: model.WebhookEventPullRequest,
+			want:      "alice merged merge request [!3 MR](https://gitlab.com/mr/3) in [group/app](https://gitlab.com/group/app)",
+		},
+		{
+			name:    "merge request update",
+			event:   "Merge Request Hook",
+			body:    `{"object_attributes":{"action":"update"}}`,
+			wantErr: ErrUnsupportedEvent,
+		},
+		{
+			name:      "issue opened",
+			event:     "Issue Hook",
+			body:      `{"user":{"username":"alice"},"object_attributes":{"iid":4,"title":"Bug","description":"line1\nline2","url":"https://gitlab.com/i/4","action":"open"},` + project + `}`,
+			eventType: model.WebhookEventIssue,
+			want:      "alice opened issue [#4 Bug](https://gitlab.com/i/4) in [group/app](https://gitlab.com/group/app)\n> line1\n> line2",
+		},
+		{
+			name:      "release created",
+			event:     "Release Hook",
+			body:      `{"action":"create","name":"v2","tag":"v2.0.0","url":"https://gitlab.com/r/v2",` + project + `}`,
+			eventType: model.WebhookEventRelease,
+			want:      "published release [v2](https://gitlab.com/r/v2) in [group/app](https://gitlab.com/group/app)",
+		},
+		{
+			name:    "unknown",
+			event:   "Pipeline Hook",
+			body:    `{}`,
+			wantErr: ErrUnsupportedEvent,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			eventType, text, err := GitLab{}.Render(gitlabHeader(tt.event), []byte(tt.body))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.eventType, eventType)
+				assert.Equal(t, tt.want, text)
+			}
+		})
+	}
+}
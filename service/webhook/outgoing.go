@@ -0,0 +1,353 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid"
+	jsonIter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/lthibault/jitterbug/v2"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/utils/hmac"
+	mutil "github.com/traPtitech/traQ/utils/message"
+)
+
+const (
+	headerTRAQWebhookID        = "X-TRAQ-WEBHOOK-ID"
+	headerTRAQWebhookRequestID = "X-TRAQ-WEBHOOK-REQUEST-ID"
+	headerTRAQWebhookTimestamp = "X-TRAQ-WEBHOOK-TIMESTAMP"
+	headerTRAQWebhookSignature = "X-TRAQ-WEBHOOK-SIGNATURE"
+	headerUserAgent            = "User-Agent"
+	ua                         = "traQ_Outgoing_Webhook/1.0"
+
+	resultOK           = "ok"
+	resultNG           = "ng"
+	resultNetworkError = "ne"
+
+	// maxResponseBodySize 返信として読み込むレスポンスボディの最大バイト数
+	maxResponseBodySize = 1 << 16
+	// maxReplyLength 返信メッセージの最大文字数
+	maxReplyLength = 10000
+	// logPurgeBefore 配送ログの保存期間
+	logPurgeBefore = 7 * 24 * time.Hour
+)
+
+var (
+	json = jsonIter.ConfigFastest
+
+	errInvalidReply = errors.New("invalid reply: response body must be utf-8 text or json with text field")
+	errReplyTooLong = errors.New("invalid reply: text must be less than or equal to 10000 characters")
+)
+
+// OutgoingDispatcher 送信Webhookの配送ワーカー
+//
+// 送信対象チャンネルに投稿されたメッセージのうち、トリガーワードに一致したものを送信先URLにPOSTします
+type OutgoingDispatcher struct {
+	repo   repository.Repository
+	cm     channel.Manager
+	mm     message.Manager
+	hub    *hub.Hub
+	client http.Client
+	l      *zap.Logger
+
+	sub        hub.Subscription
+	wg         sync.WaitGroup
+	logPurger  *jitterbug.Ticker
+	done       chan struct{}
+	hubDone    chan struct{}
+	purgerDone chan struct{}
+}
+
+// NewOutgoingDispatcher 送信Webhookの配送ワーカーを生成します
+func NewOutgoingDispatcher(repo repository.Repository, cm channel.Manager, mm message.Manager, hub *hub.Hub, logger *zap.Logger) *OutgoingDispatcher {
+	return &OutgoingDispatcher{
+		repo: repo,
+		cm:   cm,
+		mm:   mm,
+		hub:  hub,
+		client: http.Client{
+			Jar:     nil,
+			Timeout: 5 * time.Second,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		l:          logger.Named("outgoing_webhook"),
+		done:       make(chan struct{}),
+		hubDone:    make(chan struct{}),
+		purgerDone: make(chan struct{}),
+	}
+}
+
+// Start 配送ワーカーを開始します
+func (d *OutgoingDispatcher) Start() {
+	d.sub = d.hub.Subscribe(100, event.MessageCreated)
+	go func() {
+		defer close(d.hubDone)
+		for ev := range d.sub.Receiver {
+			d.wg.Add(1)
+			go func(ev hub.Message) {
+				defer d.wg.Done()
+				d.onMessageCreated(ev.Fields["message"].(*model.Message), ev.Fields["parse_result"].(*mutil.ParseResult))
+			}(ev)
+		}
+		d.wg.Wait()
+	}()
+
+	// 配送ログの定期的消去
+	d.logPurger = jitterbug.New(time.Hour*24, &jitterbug.Uniform{
+		Min: time.Hour * 23,
+	})
+	go func() {
+		defer close(d.purgerDone)
+		for {
+			select {
+			case _, ok := <-d.logPurger.C:
+				if !ok {
+					return
+				}
+				if err := d.repo.PurgeOutgoingWebhookLogs(time.Now().Add(-logPurgeBefore)); err != nil {
+					d.l.Error("an error occurred while puring old outgoing webhook logs", zap.Error(err))
+				}
+			case <-d.done:
+				return
+			}
+		}
+	}()
+
+	d.l.Info("outgoing webhook dispatcher started")
+}
+
+// Shutdown 配送ワーカーを停止します
+//
+// 配送中のリクエストが完了するまで待機します
+func (d *OutgoingDispatcher) Shutdown() {
+	d.hub.Unsubscribe(d.sub)
+	d.logPurger.Stop()
+	close(d.done)
+	<-d.hubDone
+	<-d.purgerDone
+}
+
+// outgoingPayload 送信先URLにPOSTするリクエストボディ
+type outgoingPayload struct {
+	WebhookID   uuid.UUID              `json:"webhookId"`
+	EventTime   time.Time              `json:"eventTime"`
+	TriggerWord string                 `json:"triggerWord"`
+	Message     outgoingPayloadMessage `json:"message"`
+	Channel     outgoingPayloadChannel `json:"channel"`
+	User        outgoingPayloadUser    `json:"user"`
+}
+
+type outgoingPayloadMessage struct {
+	ID        uuid.UUID `json:"id"`
+	ChannelID uuid.UUID `json:"channelId"`
+	UserID    uuid.UUID `json:"userId"`
+	Text      string    `json:"text"`
+	PlainText string    `json:"plainText"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type outgoingPayloadChannel struct {
+	ID   uuid.UUID `json:"id"`
+	Path string    `json:"path"`
+}
+
+type outgoingPayloadUser struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	DisplayName string    `json:"displayName"`
+}
+
+// outgoingReply 送信先からのJSON形式のレスポンス
+type outgoingReply struct {
+	Text string `json:"text"`
+}
+
+func (d *OutgoingDispatcher) onMessageCreated(m *model.Message, parsed *mutil.ParseResult) {
+	if !d.cm.IsPublicChannel(m.ChannelID) {
+		return
+	}
+	webhooks, err := d.repo.GetOutgoingWebhooksByChannelID(m.ChannelID)
+	if err != nil {
+		d.l.Error("failed to GetOutgoingWebhooksByChannelID", zap.Error(err), zap.Stringer("channelID", m.ChannelID))
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	user, err := d.repo.GetUser(m.UserID, false)
+	if err != nil {
+		d.l.Error("failed to GetUser", zap.Error(err), zap.Stringer("userID", m.UserID))
+		return
+	}
+	// BOT・Webhookの投稿には反応しない (返信による無限ループを防ぐ)
+	if user.IsBot() {
+		return
+	}
+
+	now := time.Now()
+	for _, w := range webhooks {
+		word, ok := w.MatchTriggerWord(parsed.PlainText)
+		if !ok {
+			continue
+		}
+		payload := outgoingPayload{
+			WebhookID:   w.WebhookID,
+			EventTime:   now,
+			TriggerWord: word,
+			Message: outgoingPayloadMessage{
+				ID:        m.ID,
+				ChannelID: m.ChannelID,
+				UserID:    m.UserID,
+				Text:      m.Text,
+				PlainText: parsed.PlainText,
+				CreatedAt: m.CreatedAt,
+			},
+			Channel: outgoingPayloadChannel{
+				ID:   m.ChannelID,
+				Path: d.cm.PublicChannelTree().GetChannelPath(m.ChannelID),
+			},
+			User: outgoingPayloadUser{
+				ID:          user.GetID(),
+				Name:        user.GetName(),
+				DisplayName: user.GetDisplayName(),
+			},
+		}
+		d.send(w, m, &payload)
+	}
+}
+
+func (d *OutgoingDispatcher) send(w *model.OutgoingWebhook, m *model.Message, payload *outgoingPayload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		d.l.Error("failed to marshal outgoing webhook payload", zap.Error(err))
+		return
+	}
+
+	reqID := uuid.Must(uuid.NewV4())
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		d.l.Error("failed to create outgoing webhook request", zap.Error(err), zap.Stringer("webhookID", w.WebhookID))
+		return
+	}
+	req.Header.Set(headerUserAgent, ua)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	req.Header.Set(headerTRAQWebhookID, w.WebhookID.String())
+	req.Header.Set(headerTRAQWebhookRequestID, reqID.String())
+
+	start := time.Now()
+	req.Header.Set(headerTRAQWebhookTimestamp, strconv.FormatInt(start.Unix(), 10))
+	req.Header.Set(headerTRAQWebhookSignature, signPayload(w.SigningSecret, start, body))
+	res, err := d.client.Do(req)
+	latency := time.Since(start)
+
+	log := &model.OutgoingWebhookLog{
+		RequestID: reqID,
+		WebhookID: w.WebhookID,
+		MessageID: m.ID,
+		Body:      string(body),
+		Latency:   latency.Nanoseconds(),
+		DateTime:  start,
+	}
+	defer func() {
+		if err := d.repo.WriteOutgoingWebhookLog(log); err != nil {
+			d.l.Error("failed to WriteOutgoingWebhookLog", zap.Error(err), zap.Stringer("webhookID", w.WebhookID))
+		}
+	}()
+
+	if err != nil {
+		log.Result = resultNetworkError
+		log.Error = err.Error()
+		log.Code = -1
+		return
+	}
+	defer res.Body.Close()
+
+	log.Code = res.StatusCode
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		log.Result = resultNG
+		return
+	}
+	log.Result = resultOK
+
+	if !w.Reply || w.Webhook == nil {
+		return
+	}
+	text, err := readReply(res)
+	if err != nil {
+		log.Error = err.Error()
+		return
+	}
+	if len(text) == 0 {
+		return
+	}
+	if _, err := d.mm.CreateReply(m.ID, w.Webhook.BotUserID, text, nil); err != nil {
+		log.Error = "failed to post reply: " + err.Error()
+		if err != message.ErrChannelArchived && err != message.ErrNotFound {
+			d.l.Error("failed to CreateReply", zap.Error(err), zap.Stringer("webhookID", w.WebhookID))
+		}
+	}
+}
+
+// readReply レスポンスから返信メッセージの本文を読み取ります
+//
+// Content-Typeがapplication/jsonの場合は`text`フィールドを、それ以外の場合はボディ全体を本文とします
+func readReply(res *http.Response) (string, error) {
+	b, err := io.ReadAll(io.LimitReader(res.Body, maxResponseBodySize))
+	if err != nil {
+		return "", err
+	}
+
+	var text string
+	if mt, _, _ := mime.ParseMediaType(res.Header.Get(echo.HeaderContentType)); mt == echo.MIMEApplicationJSON {
+		if len(bytes.TrimSpace(b)) == 0 {
+			return "", nil
+		}
+		var r outgoingReply
+		if err := json.Unmarshal(b, &r); err != nil {
+			return "", errInvalidReply
+		}
+		text = r.Text
+	} else {
+		if !utf8.Valid(b) {
+			return "", errInvalidReply
+		}
+		text = string(b)
+	}
+
+	text = strings.TrimSpace(text)
+	if utf8.RuneCountInString(text) > maxReplyLength {
+		return "", errReplyTooLong
+	}
+	return text, nil
+}
+
+// signPayload リクエストボディの署名を計算します
+//
+// 署名は`sha256=`に続けて、"{UNIXタイムスタンプ(秒)}.{リクエストボディ}"のHMAC-SHA256を16進数表記したものです。
+func signPayload(secret string, timestamp time.Time, body []byte) string {
+	data := make([]byte, 0, len(body)+20)
+	data = strconv.AppendInt(data, timestamp.Unix(), 10)
+	data = append(data, '.')
+	data = append(data, body...)
+	return "sha256=" + hex.EncodeToString(hmac.SHA256(data, secret))
+}
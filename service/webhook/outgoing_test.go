@@ -0,0 +1,207 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/testUtils"
+	mutil "github.com/traPtitech/traQ/utils/message"
+)
+
+type Repo struct {
+	*mock_repository.MockUserRepository
+	*mock_repository.MockOutgoingWebhookRepository
+	testUtils.EmptyTestRepository
+}
+
+// fakeMM CreateReplyの呼び出しを記録するmessage.Manager
+type fakeMM struct {
+	message.Manager
+
+	mu      sync.Mutex
+	replies []string
+}
+
+func (mm *fakeMM) CreateReply(_, _ uuid.UUID, content string, _ model.MessageActions) (message.Message, error) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	mm.replies = append(mm.replies, content)
+	return nil, nil
+}
+
+func setup(t *testing.T) (*OutgoingDispatcher, *Repo, *fakeMM) {
+	ctrl := gomock.NewController(t)
+	repo := &Repo{
+		MockUserRepository:            mock_repository.NewMockUserRepository(ctrl),
+		MockOutgoingWebhookRepository: mock_repository.NewMockOutgoingWebhookRepository(ctrl),
+	}
+	cm := mock_channel.NewMockManager(ctrl)
+	tree := mock_channel.NewMockTree(ctrl)
+	cm.EXPECT().IsPublicChannel(gomock.Any()).Return(true).AnyTimes()
+	cm.EXPECT().PublicChannelTree().Return(tree).AnyTimes()
+	tree.EXPECT().GetChannelPath(gomock.Any()).Return("/a/b").AnyTimes()
+	mm := &fakeMM{}
+	return NewOutgoingDispatcher(repo, cm, mm, nil, zap.NewNop()), repo, mm
+}
+
+func TestOutgoingDispatcher_onMessageCreated(t *testing.T) {
+	t.Parallel()
+
+	user := &model.User{ID: uuid.NewV3(uuid.Nil, "u"), Name: "user"}
+	bot := &model.User{ID: uuid.NewV3(uuid.Nil, "b"), Name: "BOT_user", Bot: true}
+	m := &model.Message{
+		ID:        uuid.NewV3(uuid.Nil, "m"),
+		ChannelID: uuid.NewV3(uuid.Nil, "c"),
+		UserID:    user.ID,
+		Text:      "!deploy now",
+	}
+	parsed := &mutil.ParseResult{PlainText: "!deploy now"}
+
+	t.Run("matched and replied", func(t *testing.T) {
+		t.Parallel()
+		d, repo, mm := setup(t)
+
+		const secret = "secret"
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			timestamp, _ := strconv.ParseInt(r.Header.Get(headerTRAQWebhookTimestamp), 10, 64)
+			assert.Equal(t, signPayload(secret, time.Unix(timestamp, 0), body), r.Header.Get(headerTRAQWebhookSignature))
+			assert.Contains(t, string(body), `"triggerWord":"!deploy"`)
+			assert.Contains(t, string(body), `"path":"/a/b"`)
+			w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			_, _ = w.Write([]byte(`{"text":"deployed"}`))
+		}))
+		defer ts.Close()
+
+		ow := &model.OutgoingWebhook{
+			WebhookID:     uuid.NewV3(uuid.Nil, "w"),
+			URL:           ts.URL,
+			TriggerWords:  model.OutgoingWebhookTriggerWords{"!deploy"},
+			SigningSecret: secret,
+			Reply:         true,
+			Webhook:       &model.WebhookBot{BotUserID: bot.ID},
+		}
+		repo.MockOutgoingWebhookRepository.EXPECT().GetOutgoingWebhooksByChannelID(m.ChannelID).Return([]*model.OutgoingWebhook{ow}, nil).Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(user.ID, false).Return(user, nil).Times(1)
+		repo.MockOutgoingWebhookRepository.EXPECT().WriteOutgoingWebhookLog(gomock.Any()).DoAndReturn(func(log *model.OutgoingWebhookLog) error {
+			assert.Equal(t, resultOK, log.Result)
+			assert.Equal(t, http.StatusOK, log.Code)
+			assert.Equal(t, m.ID, log.MessageID)
+			assert.Empty(t, log.Error)
+			return nil
+		}).Times(1)
+
+		d.onMessageCreated(m, parsed)
+		assert.Equal(t, []string{"deployed"}, mm.replies)
+	})
+
+	t.Run("not matched", func(t *testing.T) {
+		t.Parallel()
+		d, repo, _ := setup(t)
+
+		ow := &model.OutgoingWebhook{
+			WebhookID:    uuid.NewV3(uuid.Nil, "w"),
+			URL:          "http://example.invalid",
+			TriggerWords: model.OutgoingWebhookTriggerWords{"!build"},
+		}
+		repo.MockOutgoingWebhookRepository.EXPECT().GetOutgoingWebhooksByChannelID(m.ChannelID).Return([]*model.OutgoingWebhook{ow}, nil).Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(user.ID, false).Return(user, nil).Times(1)
+
+		d.onMessageCreated(m, parsed)
+	})
+
+	t.Run("posted by bot", func(t *testing.T) {
+		t.Parallel()
+		d, repo, _ := setup(t)
+
+		bm := *m
+		bm.UserID = bot.ID
+		ow := &model.OutgoingWebhook{WebhookID: uuid.NewV3(uuid.Nil, "w"), URL: "http://example.invalid"}
+		repo.MockOutgoingWebhookRepository.EXPECT().GetOutgoingWebhooksByChannelID(m.ChannelID).Return([]*model.OutgoingWebhook{ow}, nil).Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(bot.ID, false).Return(bot, nil).Times(1)
+
+		d.onMessageCreated(&bm, parsed)
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		t.Parallel()
+		d, repo, mm := setup(t)
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("error"))
+		}))
+		defer ts.Close()
+
+		ow := &model.OutgoingWebhook{
+			WebhookID: uuid.NewV3(uuid.Nil, "w"),
+			URL:       ts.URL,
+			Reply:     true,
+			Webhook:   &model.WebhookBot{BotUserID: bot.ID},
+		}
+		repo.MockOutgoingWebhookRepository.EXPECT().GetOutgoingWebhooksByChannelID(m.ChannelID).Return([]*model.OutgoingWebhook{ow}, nil).Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(user.ID, false).Return(user, nil).Times(1)
+		repo.MockOutgoingWebhookRepository.EXPECT().WriteOutgoingWebhookLog(gomock.Any()).DoAndReturn(func(log *model.OutgoingWebhookLog) error {
+			assert.Equal(t, resultNG, log.Result)
+			assert.Equal(t, http.StatusInternalServerError, log.Code)
+			return nil
+		}).Times(1)
+
+		d.onMessageCreated(m, parsed)
+		assert.Empty(t, mm.replies)
+	})
+}
+
+func TestReadReply(t *testing.T) {
+	t.Parallel()
+
+	newResponse := func(contentType, body string) *http.Response {
+		rec := httptest.NewRecorder()
+		rec.Header().Set(echo.HeaderContentType, contentType)
+		_, _ = rec.WriteString(body)
+		return rec.Result()
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+		wantErr     error
+	}{
+		{"plain text", echo.MIMETextPlainCharsetUTF8, "  hello\n", "hello", nil},
+		{"json", echo.MIMEApplicationJSONCharsetUTF8, `{"text":"hello"}`, "hello", nil},
+		{"empty json", echo.MIMEApplicationJSON, "", "", nil},
+		{"invalid json", echo.MIMEApplicationJSON, `{"text":`, "", errInvalidReply},
+		{"invalid utf-8", echo.MIMETextPlain, "\xff", "", errInvalidReply},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := readReply(newResponse(tt.contentType, tt.body))
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
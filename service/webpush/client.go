@@ -0,0 +1,227 @@
+package webpush
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	jsonIter "github.com/json-iterator/go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/counter"
+	"github.com/traPtitech/traQ/service/fcm"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+const (
+	workers           = 8
+	queueSize         = 100
+	requestTimeout    = 10 * time.Second
+	messageTTLSeconds = 60 * 60 * 24 * 2 // 2日
+)
+
+var (
+	json = jsonIter.ConfigFastest
+
+	webPushSendCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "traq",
+		Name:      "webpush_send_count_total",
+	}, []string{"result"})
+)
+
+// Config Web Push設定
+type Config struct {
+	// VAPIDPublicKey base64urlでエンコードされたVAPID公開鍵
+	VAPIDPublicKey string
+	// VAPIDPrivateKey base64urlでエンコードされたVAPID秘密鍵
+	VAPIDPrivateKey string
+	// Subject プッシュサービスに通知する連絡先 (mailto:またはhttps:のURL)
+	Subject string
+	// DeviceRateLimit 購読(エンドポイント)ごとの送信レート制限
+	DeviceRateLimit fcm.RateLimit
+}
+
+// Valid Web Pushが有効かどうか
+func (c Config) Valid() bool {
+	return len(c.VAPIDPublicKey) > 0 && len(c.VAPIDPrivateKey) > 0
+}
+
+type message struct {
+	sub     *model.WebPushSubscription
+	payload []byte
+}
+
+type clientImpl struct {
+	repo          repository.Repository
+	logger        *zap.Logger
+	unreadCounter counter.UnreadMessageCounter
+	keys          *vapidKeys
+	subject       string
+	limiter       *fcm.DeviceLimiter
+	client        http.Client
+
+	queue    chan *message
+	closeMu  sync.RWMutex
+	closed   bool
+	workerWg sync.WaitGroup
+}
+
+// NewClient Web Push(VAPID)クライアントを生成します
+//
+// fcm.Clientと同じインターフェイスで、登録されたWeb Push購読情報に通知を送信します
+func NewClient(repo repository.Repository, logger *zap.Logger, unreadCounter counter.UnreadMessageCounter, config Config) (fcm.Client, error) {
+	keys, err := parseVAPIDKeys(config.VAPIDPublicKey, config.VAPIDPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &clientImpl{
+		repo:          repo,
+		logger:        logger.Named("webpush"),
+		unreadCounter: unreadCounter,
+		keys:          keys,
+		subject:       config.Subject,
+		limiter:       fcm.NewDeviceLimiter("webpush", config.DeviceRateLimit),
+		client:        http.Client{Timeout: requestTimeout},
+		queue:         make(chan *message, queueSize),
+	}
+	for i := 0; i < workers; i++ {
+		c.workerWg.Add(1)
+		go c.worker()
+	}
+	return c, nil
+}
+
+func (c *clientImpl) Send(targetUserIDs set.UUID, p *fcm.Payload, withUnreadCount bool) {
+	c.closeMu.RLock()
+	defer c.closeMu.RUnlock()
+	if c.closed {
+		return
+	}
+
+	subsMap, err := c.repo.GetWebPushSubscriptions(targetUserIDs)
+	if err != nil {
+		c.logger.Error("failed to GetWebPushSubscriptions", zap.Error(err), zap.Strings("target_user_ids", targetUserIDs.StringArray()))
+		return
+	}
+
+	for uid, subs := range subsMap {
+		data := map[string]string{
+			"type":  p.Type,
+			"title": p.Title,
+			"body":  p.Body,
+			"path":  p.Path,
+			"tag":   p.Tag,
+			"icon":  p.Icon,
+		}
+		if p.Image.Valid {
+			data["image"] = p.Image.String
+		}
+		if p.KeywordHit {
+			data["keyword_hit"] = "true"
+		}
+		if withUnreadCount {
+			data["unread"] = strconv.Itoa(c.unreadCounter.Get(uid))
+		}
+		payload, err := json.Marshal(data)
+		if err != nil {
+			c.logger.Error("failed to marshal webpush payload", zap.Error(err))
+			return
+		}
+
+		for _, sub := range subs {
+			if !c.limiter.Allow(sub.Endpoint) {
+				continue
+			}
+			c.queue <- &message{sub: sub, payload: payload}
+		}
+	}
+}
+
+func (c *clientImpl) Close() {
+	c.closeMu.Lock()
+	if c.closed {
+		c.closeMu.Unlock()
+		return
+	}
+	c.closed = true
+	close(c.queue)
+	c.closeMu.Unlock()
+
+	c.workerWg.Wait()
+}
+
+func (c *clientImpl) worker() {
+	defer c.workerWg.Done()
+	for m := range c.queue {
+		c.send(m)
+	}
+}
+
+func (c *clientImpl) send(m *message) {
+	logger := c.logger.With(zap.String("endpoint", m.sub.Endpoint))
+
+	uaPublic, authSecret, err := ParseSubscriptionKeys(m.sub.P256dh, m.sub.Auth)
+	if err != nil {
+		// 不正な購読情報は今後も送信できないので削除
+		webPushSendCounter.WithLabelValues("error").Inc()
+		c.deleteSubscription(m.sub.Endpoint)
+		return
+	}
+	body, err := encrypt(m.payload, uaPublic, authSecret)
+	if err != nil {
+		webPushSendCounter.WithLabelValues("error").Inc()
+		logger.Error("failed to encrypt webpush payload", zap.Error(err))
+		return
+	}
+	auth, err := c.keys.authorization(m.sub.Endpoint, c.subject, time.Now())
+	if err != nil {
+		webPushSendCounter.WithLabelValues("error").Inc()
+		logger.Error("failed to create vapid authorization", zap.Error(err))
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, m.sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		webPushSendCounter.WithLabelValues("error").Inc()
+		logger.Error("failed to create webpush request", zap.Error(err))
+		return
+	}
+	req.Header.Set("Authorization", auth)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(messageTTLSeconds))
+	req.Header.Set("Urgency", "high")
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		webPushSendCounter.WithLabelValues("error").Inc()
+		logger.Warn("failed to send webpush", zap.Error(err))
+		return
+	}
+	_ = res.Body.Close()
+
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		webPushSendCounter.WithLabelValues("ok").Inc()
+	case res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone:
+		// 購読が期限切れ・解除されている
+		webPushSendCounter.WithLabelValues("error").Inc()
+		c.deleteSubscription(m.sub.Endpoint)
+	default:
+		webPushSendCounter.WithLabelValues("error").Inc()
+		logger.Warn("webpush: unexpected status code", zap.Int("status", res.StatusCode))
+	}
+}
+
+func (c *clientImpl) deleteSubscription(endpoint string) {
+	if err := c.repo.DeleteWebPushSubscriptions([]string{endpoint}); err != nil {
+		c.logger.Error("failed to DeleteWebPushSubscriptions", zap.Error(err), zap.String("endpoint", endpoint))
+	}
+}
@@ -0,0 +1,281 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/fcm"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+type subscriber struct {
+	private    *ecdsa.PrivateKey
+	public     []byte
+	authSecret []byte
+}
+
+func newSubscriber(t *testing.T) *subscriber {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	authSecret := make([]byte, 16)
+	_, err = io.ReadFull(rand.Reader, authSecret)
+	require.NoError(t, err)
+	return &subscriber{
+		private:    priv,
+		public:     elliptic.Marshal(elliptic.P256(), priv.X, priv.Y),
+		authSecret: authSecret,
+	}
+}
+
+func (s *subscriber) subscription(userID uuid.UUID, endpoint string) *model.WebPushSubscription {
+	return &model.WebPushSubscription{
+		Endpoint: endpoint,
+		UserID:   userID,
+		P256dh:   base64.RawURLEncoding.EncodeToString(s.public),
+		Auth:     base64.RawURLEncoding.EncodeToString(s.authSecret),
+	}
+}
+
+// decrypt ユーザーエージェント側の処理でaes128gcmのボディを復号します
+func (s *subscriber) decrypt(t *testing.T, body []byte) []byte {
+	t.Helper()
+	require.Greater(t, len(body), 21)
+	salt := body[:16]
+	rs := binary.BigEndian.Uint32(body[16:20])
+	idLen := int(body[20])
+	require.EqualValues(t, recordSize, rs)
+	asPublic := body[21 : 21+idLen]
+	ciphertext := body[21+idLen:]
+
+	curve := elliptic.P256()
+	asX, asY := elliptic.Unmarshal(curve, asPublic)
+	require.NotNil(t, asX)
+	sx, _ := curve.ScalarMult(asX, asY, s.private.D.Bytes())
+	ecdhSecret := sx.FillBytes(make([]byte, 32))
+
+	keyInfo := append([]byte("WebPush: info\x00"), s.public...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm, err := hkdfExpand(s.authSecret, ecdhSecret, keyInfo, 32)
+	require.NoError(t, err)
+	cek, err := hkdfExpand(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	require.NoError(t, err)
+	nonce, err := hkdfExpand(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(cek)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x02), plaintext[len(plaintext)-1])
+	return plaintext[:len(plaintext)-1]
+}
+
+type testRepository struct {
+	repository.Repository
+	mu      sync.Mutex
+	subs    map[uuid.UUID][]*model.WebPushSubscription
+	deleted []string
+}
+
+func (r *testRepository) GetWebPushSubscriptions(userIDs set.UUID) (map[uuid.UUID][]*model.WebPushSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := make(map[uuid.UUID][]*model.WebPushSubscription)
+	for id, subs := range r.subs {
+		if userIDs.Contains(id) {
+			res[id] = subs
+		}
+	}
+	return res, nil
+}
+
+func (r *testRepository) DeleteWebPushSubscriptions(endpoints []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deleted = append(r.deleted, endpoints...)
+	return nil
+}
+
+type testCounter struct{}
+
+func (testCounter) Get(uuid.UUID) int                 { return 3 }
+func (testCounter) GetChanges(bool) map[uuid.UUID]int { return nil }
+
+func TestEncrypt(t *testing.T) {
+	t.Parallel()
+
+	s := newSubscriber(t)
+	payload := []byte(`{"title":"test"}`)
+	body, err := encrypt(payload, s.public, s.authSecret)
+	require.NoError(t, err)
+	assert.Equal(t, payload, s.decrypt(t, body))
+
+	_, err = encrypt(make([]byte, maxPayloadSize+1), s.public, s.authSecret)
+	assert.ErrorIs(t, err, errPayloadTooLarge)
+	_, err = encrypt(payload, []byte("invalid"), s.authSecret)
+	assert.ErrorIs(t, err, errInvalidSubscriptionKey)
+}
+
+func TestParseSubscriptionKeys(t *testing.T) {
+	t.Parallel()
+
+	s := newSubscriber(t)
+	sub := s.subscription(uuid.Nil, "")
+
+	ua, as, err := ParseSubscriptionKeys(sub.P256dh, sub.Auth)
+	if assert.NoError(t, err) {
+		assert.Equal(t, s.public, ua)
+		assert.Equal(t, s.authSecret, as)
+	}
+	// パディング付きでも受け付ける
+	_, _, err = ParseSubscriptionKeys(base64.URLEncoding.EncodeToString(s.public), base64.URLEncoding.EncodeToString(s.authSecret))
+	assert.NoError(t, err)
+
+	_, _, err = ParseSubscriptionKeys("invalid", sub.Auth)
+	assert.Error(t, err)
+	_, _, err = ParseSubscriptionKeys(sub.P256dh, base64.RawURLEncoding.EncodeToString([]byte("short")))
+	assert.Error(t, err)
+}
+
+func TestVAPIDKeys(t *testing.T) {
+	t.Parallel()
+
+	pub, priv, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+	keys, err := parseVAPIDKeys(pub, priv)
+	require.NoError(t, err)
+
+	otherPub, _, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+	_, err = parseVAPIDKeys(otherPub, priv)
+	assert.Error(t, err)
+	_, err = parseVAPIDKeys(pub, "invalid")
+	assert.Error(t, err)
+
+	now := time.Now()
+	auth, err := keys.authorization("https://push.example.com/send/abc?x=1", "mailto:admin@example.com", now)
+	require.NoError(t, err)
+	assertVAPIDAuthorization(t, auth, pub, "https://push.example.com", "mailto:admin@example.com")
+}
+
+func assertVAPIDAuthorization(t *testing.T, auth, publicKey, aud, sub string) {
+	t.Helper()
+	require.True(t, strings.HasPrefix(auth, "vapid t="))
+	parts := strings.SplitN(strings.TrimPrefix(auth, "vapid t="), ", k=", 2)
+	require.Len(t, parts, 2)
+	assert.Equal(t, publicKey, parts[1])
+
+	raw, err := decodeBase64(publicKey)
+	require.NoError(t, err)
+	x, y := elliptic.Unmarshal(elliptic.P256(), raw)
+	key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(parts[0], claims, func(token *jwt.Token) (interface{}, error) {
+		return key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}))
+	require.NoError(t, err)
+	assert.Equal(t, aud, claims["aud"])
+	assert.Equal(t, sub, claims["sub"])
+}
+
+func TestClient_Send(t *testing.T) {
+	t.Parallel()
+
+	pub, priv, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+
+	s := newSubscriber(t)
+	var (
+		mu       sync.Mutex
+		received [][]byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		assert.Equal(t, "aes128gcm", r.Header.Get("Content-Encoding"))
+		assert.NotEmpty(t, r.Header.Get("TTL"))
+		assertVAPIDAuthorization(t, r.Header.Get("Authorization"), pub, "http://"+r.Host, "mailto:admin@example.com")
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mu.Lock()
+		received = append(received, body)
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	user1 := uuid.Must(uuid.NewV4())
+	user2 := uuid.Must(uuid.NewV4())
+	repo := &testRepository{subs: map[uuid.UUID][]*model.WebPushSubscription{
+		user1: {s.subscription(user1, srv.URL+"/ok")},
+		user2: {s.subscription(user2, srv.URL+"/gone")},
+	}}
+
+	c, err := NewClient(repo, zap.NewNop(), testCounter{}, Config{
+		VAPIDPublicKey:  pub,
+		VAPIDPrivateKey: priv,
+		Subject:         "mailto:admin@example.com",
+	})
+	require.NoError(t, err)
+
+	c.Send(set.UUIDSetFromArray([]uuid.UUID{user1, user2}), &fcm.Payload{
+		Type:       "new_message",
+		Title:      "#general",
+		Body:       "hello",
+		Path:       "/channels/general",
+		Tag:        "c:general",
+		Icon:       "https://example.com/icon.png",
+		Image:      optional.StringFrom("https://example.com/image.png"),
+		KeywordHit: true,
+	}, true)
+	c.Close()
+
+	if assert.Len(t, received, 1) {
+		var data map[string]string
+		require.NoError(t, json.Unmarshal(s.decrypt(t, received[0]), &data))
+		assert.Equal(t, map[string]string{
+			"type":        "new_message",
+			"title":       "#general",
+			"body":        "hello",
+			"path":        "/channels/general",
+			"tag":         "c:general",
+			"icon":        "https://example.com/icon.png",
+			"image":       "https://example.com/image.png",
+			"unread":      "3",
+			"keyword_hit": "true",
+		}, data)
+	}
+	assert.Equal(t, []string{srv.URL + "/gone"}, repo.deleted)
+
+	// Close後は送信しない
+	c.Send(set.UUIDSetFromArray([]uuid.UUID{user1}), &fcm.Payload{}, false)
+	assert.Len(t, received, 1)
+}
@@ -0,0 +1,127 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// recordSize aes128gcmのレコードサイズ
+	recordSize = 4096
+	// maxPayloadSize 1レコードに収まる平文の最大バイト数 (タグ16バイトとパディング区切り1バイトを除く)
+	maxPayloadSize = recordSize - 16 - 1
+)
+
+var (
+	errInvalidSubscriptionKey = errors.New("invalid subscription key")
+	errPayloadTooLarge        = errors.New("payload too large")
+)
+
+// decodeBase64 base64url(パディングの有無を問わない)をデコードします
+func decodeBase64(s string) ([]byte, error) {
+	for len(s)%4 != 0 {
+		s += "="
+	}
+	return base64.URLEncoding.DecodeString(s)
+}
+
+// ParseSubscriptionKeys 購読情報のp256dh, authを検証してデコードします
+func ParseSubscriptionKeys(p256dh, auth string) (uaPublic []byte, authSecret []byte, err error) {
+	uaPublic, err = decodeBase64(p256dh)
+	if err != nil {
+		return nil, nil, errInvalidSubscriptionKey
+	}
+	if x, _ := elliptic.Unmarshal(elliptic.P256(), uaPublic); x == nil {
+		return nil, nil, errInvalidSubscriptionKey
+	}
+	authSecret, err = decodeBase64(auth)
+	if err != nil || len(authSecret) != 16 {
+		return nil, nil, errInvalidSubscriptionKey
+	}
+	return uaPublic, authSecret, nil
+}
+
+// encrypt RFC 8291に従い、購読者の鍵でpayloadをaes128gcmで暗号化します
+//
+// https://www.rfc-editor.org/rfc/rfc8291
+func encrypt(payload, uaPublic, authSecret []byte) ([]byte, error) {
+	if len(payload) > maxPayloadSize {
+		return nil, errPayloadTooLarge
+	}
+
+	curve := elliptic.P256()
+	uaX, uaY := elliptic.Unmarshal(curve, uaPublic)
+	if uaX == nil {
+		return nil, errInvalidSubscriptionKey
+	}
+
+	// サーバー側の一時鍵
+	asPrivate, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	asPublic := elliptic.Marshal(curve, asPrivate.X, asPrivate.Y)
+
+	sx, _ := curve.ScalarMult(uaX, uaY, asPrivate.D.Bytes())
+	ecdhSecret := sx.FillBytes(make([]byte, 32))
+
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	// IKM = HKDF(auth_secret, ecdh_secret, "WebPush: info" || 0x00 || ua_public || as_public, 32)
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublic...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm, err := hkdfExpand(authSecret, ecdhSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdfExpand(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdfExpand(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// 単一レコードなので区切りは0x02
+	plaintext := append(append(make([]byte, 0, len(payload)+1), payload...), 0x02)
+
+	// ヘッダー: salt(16) || rs(4) || idlen(1) || keyid(as_public)
+	header := make([]byte, 0, 16+4+1+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+func hkdfExpand(salt, secret, info []byte, length int) ([]byte, error) {
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,81 @@
+package webpush
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// vapidTokenExpire VAPIDトークンの有効期間 (最大24時間)
+const vapidTokenExpire = 12 * time.Hour
+
+// vapidKeys VAPIDの鍵ペア
+//
+// https://www.rfc-editor.org/rfc/rfc8292
+type vapidKeys struct {
+	private *ecdsa.PrivateKey
+	// public base64urlでエンコードされた非圧縮形式の公開鍵
+	public string
+}
+
+// parseVAPIDKeys base64urlでエンコードされたVAPIDの鍵ペアをパースします
+func parseVAPIDKeys(publicKey, privateKey string) (*vapidKeys, error) {
+	d, err := decodeBase64(privateKey)
+	if err != nil || len(d) != 32 {
+		return nil, errors.New("invalid vapid private key")
+	}
+
+	curve := elliptic.P256()
+	priv := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(d)}
+	priv.Curve = curve
+	priv.X, priv.Y = curve.ScalarBaseMult(d)
+
+	pub, err := decodeBase64(publicKey)
+	if err != nil {
+		return nil, errors.New("invalid vapid public key")
+	}
+	x, y := elliptic.Unmarshal(curve, pub)
+	if x == nil || x.Cmp(priv.X) != 0 || y.Cmp(priv.Y) != 0 {
+		return nil, errors.New("vapid public key does not match the private key")
+	}
+
+	return &vapidKeys{
+		private: priv,
+		public:  base64.RawURLEncoding.EncodeToString(pub),
+	}, nil
+}
+
+// GenerateVAPIDKeys 新しいVAPIDの鍵ペアを生成し、base64urlでエンコードして返します
+func GenerateVAPIDKeys() (publicKey, privateKey string, err error) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	pub := elliptic.Marshal(elliptic.P256(), priv.X, priv.Y)
+	return base64.RawURLEncoding.EncodeToString(pub), base64.RawURLEncoding.EncodeToString(priv.D.FillBytes(make([]byte, 32))), nil
+}
+
+// authorization プッシュサービスに送信するAuthorizationヘッダーの値を生成します
+func (k *vapidKeys) authorization(endpoint, subject string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": now.Add(vapidTokenExpire).Unix(),
+		"sub": subject,
+	}).SignedString(k.private)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("vapid t=%s, k=%s", token, k.public), nil
+}
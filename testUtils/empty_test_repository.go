@@ -18,10 +18,15 @@ type EmptyTestRepository struct {
 	repository.StarRepository
 	repository.PinRepository
 	repository.DeviceRepository
+	repository.WebPushSubscriptionRepository
+	repository.NotificationKeywordRepository
 	repository.FileRepository
 	repository.WebhookRepository
+	repository.OutgoingWebhookRepository
 	repository.OAuth2Repository
 	repository.BotRepository
+	repository.BotCommandRepository
 	repository.ClipRepository
 	repository.OgpCacheRepository
+	repository.ScheduledMessageRepository
 }
@@ -1305,6 +1305,7 @@ func (repo *TestRepository) CreateWebhook(name, description string, channelID, i
 		Secret:      secret,
 		ChannelID:   channelID,
 		CreatorID:   creatorID,
+		EventTypes:  model.AllWebhookEventTypes(),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -1368,6 +1369,10 @@ func (repo *TestRepository) UpdateWebhook(id uuid.UUID, args repository.UpdateWe
 		wb.Secret = args.Secret.String
 		wb.UpdatedAt = time.Now()
 	}
+	if args.EventTypes != nil {
+		wb.EventTypes = args.EventTypes
+		wb.UpdatedAt = time.Now()
+	}
 	if args.Name.Valid {
 		if len(args.Name.String) == 0 || utf8.RuneCountInString(args.Name.String) > 32 {
 			return repository.ArgError("args.Name", "Name must be non-empty and shorter than 33 characters")
@@ -0,0 +1,22 @@
+package message
+
+import (
+	"strings"
+)
+
+// MatchKeywords textに含まれているキーワードを返します
+//
+// 大文字・小文字は区別しません。含まれていない場合は空配列を返します
+func MatchKeywords(text string, keywords []string) []string {
+	matched := make([]string, 0)
+	if len(text) == 0 {
+		return matched
+	}
+	lower := strings.ToLower(text)
+	for _, k := range keywords {
+		if len(k) > 0 && strings.Contains(lower, strings.ToLower(k)) {
+			matched = append(matched, k)
+		}
+	}
+	return matched
+}
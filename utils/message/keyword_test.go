@@ -0,0 +1,28 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchKeywords(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Text     string
+		Keywords []string
+		Matched  []string
+	}{
+		{Text: "", Keywords: []string{"traQ"}, Matched: []string{}},
+		{Text: "traQの新機能", Keywords: nil, Matched: []string{}},
+		{Text: "traQの新機能", Keywords: []string{"traQ"}, Matched: []string{"traQ"}},
+		{Text: "TRAQの新機能", Keywords: []string{"traq"}, Matched: []string{"traq"}},
+		{Text: "traQの新機能", Keywords: []string{"新機能", "SysAd", "traQ"}, Matched: []string{"新機能", "traQ"}},
+		{Text: "traQの新機能", Keywords: []string{""}, Matched: []string{}},
+		{Text: "[添付ファイル]", Keywords: []string{"sysad"}, Matched: []string{}},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.Matched, MatchKeywords(c.Text, c.Keywords), c.Text)
+	}
+}
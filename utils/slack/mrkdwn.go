@@ -0,0 +1,135 @@
+package slack
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// codeRegex コードブロックとインラインコード
+	codeRegex = regexp.MustCompile("```[\\s\\S]*?```|`[^`\\n]+`")
+	// linkRegex <url|label>, <@U123>, <#C123|general>, <!here> などの特殊記法
+	linkRegex = regexp.MustCompile(`<([^<>\n]+)>`)
+
+	entityReplacer = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+)
+
+// ConvertMrkdwn SlackのmrkdwnをtraQのMarkdownに変換します
+//
+// 太字・斜体・打ち消し線・リンク・メンションを変換します。コード内は変換しません。
+func ConvertMrkdwn(s string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range codeRegex.FindAllStringIndex(s, -1) {
+		b.WriteString(convertMrkdwnText(s[last:loc[0]]))
+		b.WriteString(entityReplacer.Replace(s[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	b.WriteString(convertMrkdwnText(s[last:]))
+	return b.String()
+}
+
+func convertMrkdwnText(s string) string {
+	s = convertEmphasis(s, '*', "**")
+	s = convertEmphasis(s, '_', "*")
+	s = convertEmphasis(s, '~', "~~")
+	s = linkRegex.ReplaceAllStringFunc(s, func(m string) string {
+		return convertLink(m[1 : len(m)-1])
+	})
+	return entityReplacer.Replace(s)
+}
+
+// convertLink <>で囲まれた特殊記法の中身を変換します
+func convertLink(content string) string {
+	target, label, hasLabel := strings.Cut(content, "|")
+	switch {
+	case strings.HasPrefix(target, "@"):
+		// ユーザーメンション SlackのユーザーIDはtraQのユーザーと対応しないため、表示名のみ残す
+		if hasLabel {
+			return "@" + strings.TrimPrefix(label, "@")
+		}
+		return target
+	case strings.HasPrefix(target, "#"):
+		if hasLabel {
+			return "#" + strings.TrimPrefix(label, "#")
+		}
+		return target
+	case strings.HasPrefix(target, "!"):
+		if hasLabel {
+			return label
+		}
+		name, _, _ := strings.Cut(target[1:], "^")
+		return "@" + name
+	default:
+		if hasLabel && len(label) > 0 {
+			return "[" + label + "](" + target + ")"
+		}
+		return target
+	}
+}
+
+// convertEmphasis markerで囲まれた範囲をwrapで囲み直します
+//
+// Slackと同様に、markerの外側が単語の境界で、内側が空白でない場合のみ変換します。
+func convertEmphasis(s string, marker rune, wrap string) string {
+	if !strings.ContainsRune(s, marker) {
+		return s
+	}
+
+	rs := []rune(s)
+	var b strings.Builder
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		if r == '<' {
+			// 特殊記法の中は変換しない
+			if j := indexRune(rs, i+1, '>'); j >= 0 {
+				b.WriteString(string(rs[i : j+1]))
+				i = j
+				continue
+			}
+		}
+		if r == marker && isWordBoundary(rs, i-1) && i+1 < len(rs) && rs[i+1] != marker && !unicode.IsSpace(rs[i+1]) {
+			if j := findClosingMarker(rs, i+1, marker); j >= 0 {
+				b.WriteString(wrap)
+				b.WriteString(string(rs[i+1 : j]))
+				b.WriteString(wrap)
+				i = j
+				continue
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func findClosingMarker(rs []rune, start int, marker rune) int {
+	for k := start + 1; k < len(rs); k++ {
+		switch {
+		case rs[k] == '\n':
+			return -1
+		case rs[k] == marker && !unicode.IsSpace(rs[k-1]) && isWordBoundary(rs, k+1):
+			return k
+		}
+	}
+	return -1
+}
+
+func isWordBoundary(rs []rune, i int) bool {
+	if i < 0 || i >= len(rs) {
+		return true
+	}
+	return !unicode.IsLetter(rs[i]) && !unicode.IsDigit(rs[i])
+}
+
+func indexRune(rs []rune, start int, r rune) int {
+	for i := start; i < len(rs); i++ {
+		if rs[i] == r {
+			return i
+		}
+		if rs[i] == '\n' {
+			return -1
+		}
+	}
+	return -1
+}
@@ -0,0 +1,44 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertMrkdwn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "hello world", "hello world"},
+		{"bold", "this is *bold* text", "this is **bold** text"},
+		{"italic", "this is _italic_ text", "this is *italic* text"},
+		{"strike", "this is ~strike~ text", "this is ~~strike~~ text"},
+		{"adjacent", "*a* *b*", "**a** **b**"},
+		{"nested", "*_both_*", "***both***"},
+		{"not emphasis in word", "snake_case_name and 2*3*4", "snake_case_name and 2*3*4"},
+		{"not emphasis with space", "a * b * c", "a * b * c"},
+		{"unclosed", "*not closed\nnext*", "*not closed\nnext*"},
+		{"link with label", "see <https://example.com|example>", "see [example](https://example.com)"},
+		{"link without label", "see <https://example.com/a_b_c>", "see https://example.com/a_b_c"},
+		{"user mention", "hi <@U123|takashi> and <@U456>", "hi @takashi and @U456"},
+		{"channel", "go to <#C123|general>", "go to #general"},
+		{"special mention", "<!here> <!channel>", "@here @channel"},
+		{"subteam", "<!subteam^S123|@team>", "@team"},
+		{"date", "<!date^1392734382^{date}|Feb 18, 2014>", "Feb 18, 2014"},
+		{"entities", "a &lt; b &amp;&amp; c &gt; d", "a < b && c > d"},
+		{"inline code", "run `*not bold*` now", "run `*not bold*` now"},
+		{"code block", "```\n*x* &lt;\n```\n*y*", "```\n*x* <\n```\n**y**"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ConvertMrkdwn(tt.in))
+		})
+	}
+}
@@ -0,0 +1,360 @@
+package slack
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	jsonIter "github.com/json-iterator/go"
+)
+
+var json = jsonIter.ConfigFastest
+
+// Payload SlackのIncoming Webhookのペイロード
+//
+// https://api.slack.com/messaging/webhooks
+type Payload struct {
+	Text        string        `json:"text"`
+	Username    string        `json:"username"`
+	IconURL     string        `json:"icon_url"`
+	IconEmoji   string        `json:"icon_emoji"`
+	Attachments []*Attachment `json:"attachments"`
+	Blocks      []*Block      `json:"blocks"`
+}
+
+// Attachment Slackのメッセージの添付 (Secondary content)
+type Attachment struct {
+	Fallback   string             `json:"fallback"`
+	Color      string             `json:"color"`
+	Pretext    string             `json:"pretext"`
+	AuthorName string             `json:"author_name"`
+	AuthorLink string             `json:"author_link"`
+	Title      string             `json:"title"`
+	TitleLink  string             `json:"title_link"`
+	Text       string             `json:"text"`
+	Fields     []*AttachmentField `json:"fields"`
+	ImageURL   string             `json:"image_url"`
+	ThumbURL   string             `json:"thumb_url"`
+	Footer     string             `json:"footer"`
+	Blocks     []*Block           `json:"blocks"`
+}
+
+// AttachmentField Attachmentのフィールド
+type AttachmentField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// TextObject Block Kitのテキストオブジェクト
+type TextObject struct {
+	// Type plain_text, mrkdwn
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Block Block Kitのブロック
+//
+// header, section, divider, image, context, actions, rich_textに対応しています
+type Block struct {
+	Type     string          `json:"type"`
+	Text     *TextObject     `json:"text"`
+	Fields   []*TextObject   `json:"fields"`
+	Elements []*BlockElement `json:"elements"`
+	ImageURL string          `json:"image_url"`
+	AltText  string          `json:"alt_text"`
+	Title    *TextObject     `json:"title"`
+}
+
+// BlockElement Block Kitのブロック要素
+type BlockElement struct {
+	Type      string              `json:"type"`
+	Text      ElementText         `json:"text"`
+	URL       string              `json:"url"`
+	ImageURL  string              `json:"image_url"`
+	AltText   string              `json:"alt_text"`
+	Name      string              `json:"name"`
+	UserID    string              `json:"user_id"`
+	ChannelID string              `json:"channel_id"`
+	Range     string              `json:"range"`
+	Style     jsonIter.RawMessage `json:"style"`
+	Elements  []*BlockElement     `json:"elements"`
+}
+
+// ElementText ブロック要素のテキスト
+//
+// 要素の種類によって文字列、もしくはテキストオブジェクトになります
+type ElementText struct {
+	TextObject
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (t *ElementText) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &t.Text)
+	}
+	return json.Unmarshal(data, &t.TextObject)
+}
+
+type richTextStyle struct {
+	Bold   bool `json:"bold"`
+	Italic bool `json:"italic"`
+	Strike bool `json:"strike"`
+	Code   bool `json:"code"`
+}
+
+// ErrEmptyPayload 変換後のメッセージが空
+var ErrEmptyPayload = errors.New("empty payload")
+
+// ParsePayload JSON形式のペイロードをパースします
+func ParsePayload(data []byte) (*Payload, error) {
+	var p Payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Markdown ペイロードをtraQのMarkdownに変換します
+//
+// blocksが存在する場合、textは通知用の代替テキストとして扱われ、本文には含まれません。
+// traQではメッセージ毎にアイコンを変更できないため、icon_url, icon_emojiは無視されます。
+// 変換後のメッセージが空の場合、ErrEmptyPayloadを返します。
+func (p *Payload) Markdown() (string, error) {
+	var lines []string
+	if len(p.Username) > 0 {
+		lines = append(lines, "**"+p.Username+"**")
+	}
+	if len(p.Blocks) > 0 {
+		lines = appendNonEmpty(lines, blocksMarkdown(p.Blocks))
+	} else {
+		lines = appendNonEmpty(lines, ConvertMrkdwn(p.Text))
+	}
+	for _, a := range p.Attachments {
+		if a != nil {
+			lines = appendNonEmpty(lines, a.markdown())
+		}
+	}
+
+	body := strings.Join(lines, "\n")
+	if len(strings.TrimSpace(body)) == 0 || (len(p.Username) > 0 && len(lines) == 1) {
+		return "", ErrEmptyPayload
+	}
+	return body, nil
+}
+
+func (a *Attachment) markdown() string {
+	var quoted []string
+	if len(a.AuthorName) > 0 {
+		quoted = append(quoted, link(a.AuthorName, a.AuthorLink))
+	}
+	if len(a.Title) > 0 {
+		quoted = append(quoted, "**"+link(a.Title, a.TitleLink)+"**")
+	}
+	quoted = appendNonEmpty(quoted, ConvertMrkdwn(a.Text))
+	for _, f := range a.Fields {
+		if f == nil {
+			continue
+		}
+		if len(f.Title) > 0 {
+			quoted = append(quoted, "**"+f.Title+"**")
+		}
+		quoted = appendNonEmpty(quoted, ConvertMrkdwn(f.Value))
+	}
+	if len(a.Blocks) > 0 {
+		quoted = appendNonEmpty(quoted, blocksMarkdown(a.Blocks))
+	}
+	quoted = appendNonEmpty(quoted, a.ImageURL)
+	quoted = appendNonEmpty(quoted, a.Footer)
+	if len(quoted) == 0 {
+		quoted = appendNonEmpty(quoted, a.Fallback)
+	}
+
+	var lines []string
+	lines = appendNonEmpty(lines, ConvertMrkdwn(a.Pretext))
+	if len(quoted) > 0 {
+		lines = append(lines, quote(strings.Join(quoted, "\n")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func blocksMarkdown(blocks []*Block) string {
+	var lines []string
+	for _, b := range blocks {
+		if b != nil {
+			lines = appendNonEmpty(lines, b.markdown())
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (b *Block) markdown() string {
+	switch b.Type {
+	case "header":
+		if t := b.Text.markdown(); len(t) > 0 {
+			return "### " + t
+		}
+	case "section":
+		var lines []string
+		lines = appendNonEmpty(lines, b.Text.markdown())
+		for _, f := range b.Fields {
+			lines = appendNonEmpty(lines, f.markdown())
+		}
+		return strings.Join(lines, "\n")
+	case "divider":
+		return "---"
+	case "image":
+		alt := b.AltText
+		if t := b.Title.markdown(); len(t) > 0 {
+			alt = t
+		}
+		return link(alt, b.ImageURL)
+	case "context", "actions":
+		var parts []string
+		for _, e := range b.Elements {
+			if e != nil {
+				parts = appendNonEmpty(parts, e.markdown())
+			}
+		}
+		return strings.Join(parts, " ")
+	case "rich_text":
+		var lines []string
+		for _, e := range b.Elements {
+			if e != nil {
+				lines = appendNonEmpty(lines, e.richTextMarkdown())
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+	return ""
+}
+
+func (t *TextObject) markdown() string {
+	if t == nil {
+		return ""
+	}
+	if t.Type == "mrkdwn" {
+		return ConvertMrkdwn(t.Text)
+	}
+	return t.Text
+}
+
+// markdown context, actionsブロックの要素を変換します
+func (e *BlockElement) markdown() string {
+	switch e.Type {
+	case "mrkdwn":
+		return ConvertMrkdwn(e.Text.Text)
+	case "plain_text":
+		return e.Text.Text
+	case "image":
+		return link(e.AltText, e.ImageURL)
+	case "button":
+		if len(e.URL) > 0 {
+			return link(e.Text.markdown(), e.URL)
+		}
+	}
+	return ""
+}
+
+// richTextMarkdown rich_textブロックの要素を変換します
+func (e *BlockElement) richTextMarkdown() string {
+	switch e.Type {
+	case "rich_text_section":
+		return e.inlineMarkdown()
+	case "rich_text_preformatted":
+		return "```\n" + e.inlineMarkdown() + "\n```"
+	case "rich_text_quote":
+		return quote(e.inlineMarkdown())
+	case "rich_text_list":
+		var style string
+		_ = json.Unmarshal(e.Style, &style)
+		var lines []string
+		for i, item := range e.Elements {
+			if item == nil {
+				continue
+			}
+			prefix := "- "
+			if style == "ordered" {
+				prefix = strconv.Itoa(i+1) + ". "
+			}
+			lines = append(lines, prefix+item.inlineMarkdown())
+		}
+		return strings.Join(lines, "\n")
+	}
+	return ""
+}
+
+// inlineMarkdown rich_textのセクション内の要素を連結して変換します
+func (e *BlockElement) inlineMarkdown() string {
+	var b strings.Builder
+	for _, c := range e.Elements {
+		if c == nil {
+			continue
+		}
+		switch c.Type {
+		case "text":
+			b.WriteString(c.styledText())
+		case "link":
+			label := c.Text.Text
+			if len(label) == 0 {
+				label = c.URL
+			}
+			b.WriteString(link(label, c.URL))
+		case "emoji":
+			b.WriteString(":" + c.Name + ":")
+		case "user":
+			b.WriteString("@" + c.UserID)
+		case "channel":
+			b.WriteString("#" + c.ChannelID)
+		case "broadcast":
+			b.WriteString("@" + c.Range)
+		}
+	}
+	return b.String()
+}
+
+func (e *BlockElement) styledText() string {
+	text := e.Text.Text
+	if len(strings.TrimSpace(text)) == 0 || len(e.Style) == 0 {
+		return text
+	}
+	var s richTextStyle
+	if err := json.Unmarshal(e.Style, &s); err != nil {
+		return text
+	}
+	if s.Code {
+		return "`" + text + "`"
+	}
+	if s.Strike {
+		text = "~~" + text + "~~"
+	}
+	if s.Italic {
+		text = "*" + text + "*"
+	}
+	if s.Bold {
+		text = "**" + text + "**"
+	}
+	return text
+}
+
+func link(label, url string) string {
+	switch {
+	case len(url) == 0:
+		return label
+	case len(label) == 0:
+		return url
+	default:
+		return "[" + label + "](" + url + ")"
+	}
+}
+
+func quote(s string) string {
+	return "> " + strings.ReplaceAll(s, "\n", "\n> ")
+}
+
+func appendNonEmpty(lines []string, s string) []string {
+	if len(strings.TrimSpace(s)) == 0 {
+		return lines
+	}
+	return append(lines, s)
+}
@@ -0,0 +1,113 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayload_Markdown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		payload string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "text",
+			payload: `{"text":"Build *succeeded*: <https://ci.example.com/1|#1>"}`,
+			want:    "Build **succeeded**: [#1](https://ci.example.com/1)",
+		},
+		{
+			name:    "username",
+			payload: `{"text":"hello","username":"CI","icon_url":"https://example.com/icon.png"}`,
+			want:    "**CI**\nhello",
+		},
+		{
+			name:    "empty",
+			payload: `{"text":"","username":"CI"}`,
+			wantErr: ErrEmptyPayload,
+		},
+		{
+			name: "attachments",
+			payload: `{
+				"text": "Deploy",
+				"attachments": [{
+					"pretext": "pre",
+					"author_name": "bot",
+					"title": "Job",
+					"title_link": "https://example.com/job",
+					"text": "line1\nline2",
+					"fields": [{"title": "Status", "value": "_ok_", "short": true}],
+					"footer": "footer"
+				}, {
+					"fallback": "fallback only"
+				}]
+			}`,
+			want: "Deploy\npre\n> bot\n> **[Job](https://example.com/job)**\n> line1\n> line2\n> **Status**\n> *ok*\n> footer\n> fallback only",
+		},
+		{
+			name: "blocks",
+			payload: `{
+				"text": "fallback text",
+				"blocks": [
+					{"type": "header", "text": {"type": "plain_text", "text": "Alert"}},
+					{"type": "section", "text": {"type": "mrkdwn", "text": "*CPU* high"}, "fields": [{"type": "mrkdwn", "text": "*Host*\nweb1"}]},
+					{"type": "divider"},
+					{"type": "context", "elements": [{"type": "mrkdwn", "text": "by _monitor_"}, {"type": "image", "image_url": "https://example.com/i.png", "alt_text": "icon"}]},
+					{"type": "actions", "elements": [{"type": "button", "text": {"type": "plain_text", "text": "Open"}, "url": "https://example.com"}]},
+					{"type": "image", "image_url": "https://example.com/graph.png", "alt_text": "graph"},
+					{"type": "unknown"}
+				]
+			}`,
+			want: "### Alert\n**CPU** high\n**Host**\nweb1\n---\nby *monitor* [icon](https://example.com/i.png)\n[Open](https://example.com)\n[graph](https://example.com/graph.png)",
+		},
+		{
+			name: "rich text",
+			payload: `{
+				"blocks": [{
+					"type": "rich_text",
+					"elements": [
+						{"type": "rich_text_section", "elements": [
+							{"type": "text", "text": "Hello "},
+							{"type": "text", "text": "world", "style": {"bold": true}},
+							{"type": "emoji", "name": "tada"},
+							{"type": "link", "url": "https://example.com", "text": "link"}
+						]},
+						{"type": "rich_text_list", "style": "ordered", "elements": [
+							{"type": "rich_text_section", "elements": [{"type": "text", "text": "one"}]},
+							{"type": "rich_text_section", "elements": [{"type": "text", "text": "two", "style": {"code": true}}]}
+						]},
+						{"type": "rich_text_preformatted", "elements": [{"type": "text", "text": "code"}]}
+					]
+				}]
+			}`,
+			want: "Hello **world**:tada:[link](https://example.com)\n1. one\n2. `two`\n```\ncode\n```",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := ParsePayload([]byte(tt.payload))
+			require.NoError(t, err)
+			got, err := p.Markdown()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParsePayload(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParsePayload([]byte(`{"text":`))
+	assert.Error(t, err)
+}
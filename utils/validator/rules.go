@@ -128,3 +128,21 @@ var ClipFolderNameRuleRequired = append([]vd.Rule{
 var ClipFolderDescriptionRule = []vd.Rule{
 	vd.RuneLength(0, 1000),
 }
+
+// BotCommandNameRule BOTのスラッシュコマンド名バリデーションルール
+var BotCommandNameRule = []vd.Rule{
+	vd.Match(regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)).Error("must contain [a-zA-Z0-9_-] only"),
+	vd.RuneLength(1, 32),
+}
+
+// BotCommandNameRuleRequired BOTのスラッシュコマンド名バリデーションルール with Required
+var BotCommandNameRuleRequired = append([]vd.Rule{
+	vd.Required,
+}, BotCommandNameRule...)
+
+// MessageActionIDRule メッセージアクションIDバリデーションルール
+var MessageActionIDRule = []vd.Rule{
+	vd.Required,
+	vd.Match(regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)).Error("must contain [a-zA-Z0-9_-] only"),
+	vd.RuneLength(1, 32),
+}